@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,12 +16,16 @@ import (
 	"github.com/go-pkgz/lgr"
 	"github.com/jessevdk/go-flags"
 
+	"github.com/umputun/newscope/pkg/canonical"
 	"github.com/umputun/newscope/pkg/config"
 	"github.com/umputun/newscope/pkg/content"
 	"github.com/umputun/newscope/pkg/feed"
+	"github.com/umputun/newscope/pkg/httpclient"
 	"github.com/umputun/newscope/pkg/llm"
+	"github.com/umputun/newscope/pkg/newsletter"
 	"github.com/umputun/newscope/pkg/repository"
 	"github.com/umputun/newscope/pkg/scheduler"
+	"github.com/umputun/newscope/pkg/websub"
 	"github.com/umputun/newscope/server"
 )
 
@@ -74,7 +79,7 @@ func run(ctx context.Context, opts Opts) error {
 	}
 
 	// setup logging with secrets for redaction
-	setupLog(opts.Debug, opts.NoColor, cfg.LLM.APIKey)
+	setupLog(opts.Debug, opts.NoColor, cfg.LLM.APIKey, cfg.Newsletters.IMAP.Password)
 
 	log.Printf("[INFO] starting newscope version %s", revision)
 
@@ -96,8 +101,25 @@ func run(ctx context.Context, opts Opts) error {
 		return fmt.Errorf("LLM classifier is required - missing endpoint or API key configuration")
 	}
 
+	// outbound transport shared by the parser, extractor, websub subscriber and LLM client
+	transport, err := httpclient.NewTransport(httpclient.Params{
+		Proxy:         cfg.HTTP.Proxy,
+		CAFiles:       cfg.HTTP.CAFiles,
+		InsecureHosts: cfg.HTTP.InsecureHosts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create http transport: %w", err)
+	}
+	if proxyURL, err := httpclient.ParseProxy(cfg.HTTP.Proxy); err == nil {
+		log.Printf("[INFO] outbound requests use proxy %s", proxyURL.Redacted())
+	}
+	if len(cfg.HTTP.InsecureHosts) > 0 {
+		log.Printf("[WARN] TLS certificate verification disabled for %s", strings.Join(cfg.HTTP.InsecureHosts, ", "))
+	}
+
 	// setup feed parser and content extractor
 	feedParser := feed.NewParser(cfg.Server.Timeout, cfg.Extraction.UserAgent)
+	feedParser.SetTransport(transport)
 
 	var contentExtractor *content.HTTPExtractor
 	if cfg.Extraction.Enabled {
@@ -106,10 +128,18 @@ func run(ctx context.Context, opts Opts) error {
 			contentExtractor.SetFallbackURL(cfg.Extraction.FallbackURL)
 		}
 		contentExtractor.SetOptions(cfg.Extraction.MinTextLength, cfg.Extraction.IncludeImages, cfg.Extraction.IncludeLinks)
+		contentExtractor.SetRateLimit(cfg.Extraction.RateLimit)
+		contentExtractor.SetRobots(cfg.Extraction.RespectRobots)
+		contentExtractor.SetTransport(transport)
 	}
 	classifier := llm.NewClassifier(cfg.LLM)
+	classifier.SetTransport(transport)
 	log.Printf("[INFO] LLM classifier enabled with model: %s", cfg.LLM.Model)
 
+	// resolves feed proxies and link shorteners in item links before they are stored
+	linkResolver := canonical.NewResolver(cfg.Server.Timeout, cfg.Extraction.UserAgent)
+	linkResolver.SetTransport(transport)
+
 	// setup and start scheduler
 	// warn if jitter is disabled
 	if cfg.Schedule.RetryJitter == 0 {
@@ -121,21 +151,45 @@ func run(ctx context.Context, opts Opts) error {
 		ItemManager:           repos.Item,
 		ClassificationManager: repos.Classification,
 		SettingManager:        repos.Setting,
+		MuteRuleManager:       repos.MuteRule,
+		JobManager:            repos.Job,
 		Parser:                feedParser,
 		Extractor:             contentExtractor,
 		Classifier:            classifier,
+		LinkResolver:          linkResolver,
 		// configuration
 		UpdateInterval:             cfg.Schedule.UpdateInterval,
+		MinFetchInterval:           cfg.Schedule.MinFetchInterval,
+		MaxFetchInterval:           cfg.Schedule.MaxFetchInterval,
+		MaxFeedErrors:              cfg.Schedule.MaxFeedErrors,
 		MaxWorkers:                 cfg.Schedule.MaxWorkers,
+		MaxExtractions:             cfg.Extraction.MaxConcurrent,
+		MaxClassifications:         cfg.LLM.Classification.MaxConcurrent,
 		PreferenceSummaryThreshold: cfg.LLM.Classification.PreferenceSummaryThreshold,
 		CleanupAge:                 cfg.Schedule.CleanupAge,
 		CleanupMinScore:            cfg.Schedule.CleanupMinScore,
 		CleanupInterval:            cfg.Schedule.CleanupInterval,
+		JobTimeout:                 cfg.Schedule.JobTimeout,
+		MaxProcessAttempts:         cfg.Schedule.MaxProcessAttempts,
 		RetryAttempts:              cfg.Schedule.RetryAttempts,
 		RetryInitialDelay:          cfg.Schedule.RetryInitialDelay,
 		RetryMaxDelay:              cfg.Schedule.RetryMaxDelay,
 		RetryJitter:                cfg.Schedule.RetryJitter,
 	}
+	if cfg.WebSub.Enabled {
+		subscriber := websub.NewSubscriber(cfg.Server.Timeout, cfg.Extraction.UserAgent)
+		subscriber.SetTransport(transport)
+		params.WebSubscriber = subscriber
+		params.WebSubCallbackURL = cfg.Server.BaseURL
+		params.WebSubLease = cfg.WebSub.LeaseDuration
+		params.WebSubSafetyInterval = cfg.WebSub.SafetyInterval
+		log.Printf("[INFO] websub push subscriptions enabled, callback base %s", cfg.Server.BaseURL)
+	}
+	if cfg.Newsletters.Enabled {
+		params.Newsletters = newsletterSources(cfg.Newsletters)
+		params.NewsletterInterval = cfg.Newsletters.Interval
+		params.NewsletterLookback = cfg.Newsletters.Lookback
+	}
 	sched := scheduler.NewScheduler(params)
 	sched.Start(ctx)
 	defer sched.Stop()
@@ -150,6 +204,27 @@ func run(ctx context.Context, opts Opts) error {
 	return nil
 }
 
+// newsletterSources makes the configured newsletter sources, maildir and imap can be used together
+func newsletterSources(cfg config.NewslettersConfig) []scheduler.NewsletterSource {
+	var sources []scheduler.NewsletterSource
+	if cfg.Maildir != "" {
+		sources = append(sources, newsletter.NewMaildir(cfg.Maildir))
+		log.Printf("[INFO] reading newsletters from maildir %s", cfg.Maildir)
+	}
+	if cfg.IMAP.Addr != "" {
+		sources = append(sources, newsletter.NewIMAP(newsletter.IMAPParams{
+			Addr:     cfg.IMAP.Addr,
+			NoTLS:    cfg.IMAP.NoTLS,
+			Username: cfg.IMAP.Username,
+			Password: cfg.IMAP.Password,
+			Folder:   cfg.IMAP.Folder,
+			Timeout:  cfg.IMAP.Timeout,
+		}))
+		log.Printf("[INFO] reading newsletters from imap %s, folder %s", cfg.IMAP.Addr, cfg.IMAP.Folder)
+	}
+	return sources
+}
+
 // setupLog configures the logger
 func setupLog(dbg, noColor bool, secs ...string) {
 	logOpts := []lgr.Option{lgr.Msec, lgr.LevelBraces, lgr.StackTraceOnError}
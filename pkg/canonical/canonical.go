@@ -0,0 +1,298 @@
+// Package canonical normalizes article links, so the same article linked with tracking parameters,
+// through a redirect wrapper, as an AMP page or on a mobile subdomain is recognized as one article.
+// Clean makes a dedupe key which may not be a working URL, links to fetch and show are only unwrapped.
+package canonical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxUnwrap limits nested wrappers followed for a single link
+const maxUnwrap = 5
+
+// trackingParams are query parameters used only for tracking, names ending with "_" match as prefixes
+var trackingParams = []string{
+	"utm_", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid", "igshid", "twclid",
+	"mc_cid", "mc_eid", "_hsenc", "_hsmi", "mkt_tok", "oly_anon_id", "oly_enc_id", "vero_id", "vero_conv",
+	"wt_mc", "wt.mc_id", "ocid", "cmpid", "sr_share", "ref_src", "ref_url", "__twitter_impression",
+}
+
+// queryWrappers are redirect pages with the target link in a query parameter.
+// an empty path matches any path of the host.
+var queryWrappers = []struct{ host, path, param string }{
+	{"www.google.com", "/url", "q"},
+	{"www.google.com", "/url", "url"},
+	{"google.com", "/url", "q"},
+	{"l.facebook.com", "/l.php", "u"},
+	{"lm.facebook.com", "/l.php", "u"},
+	{"out.reddit.com", "", "url"},
+	{"www.youtube.com", "/redirect", "q"},
+	{"t.umblr.com", "/redirect", "z"},
+	{"slack-redir.net", "/link", "url"},
+	{"news.ycombinator.com", "/l", "u"},
+}
+
+// redirectHosts are feed proxies and link shorteners answering with an HTTP redirect to the target
+var redirectHosts = map[string]bool{
+	"feedproxy.google.com": true, "feeds.feedburner.com": true, "feeds.feedblitz.com": true,
+	"t.co": true, "bit.ly": true, "buff.ly": true, "ow.ly": true, "dlvr.it": true, "trib.al": true,
+	"lnkd.in": true, "tinyurl.com": true, "goo.gl": true, "ift.tt": true, "fb.me": true, "wp.me": true,
+	"rebrand.ly": true, "shorturl.at": true,
+}
+
+// Clean returns the canonical form of an http(s) link: lowercase scheme and host, no default port,
+// fragment, tracking parameters, AMP and mobile variants, with query-parameter wrappers unwrapped.
+// the guessed variants make it a dedupe key only, the result is not meant to be fetched or shown.
+// other and malformed links are returned as is.
+func Clean(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return link
+	}
+	u = unwrap(u)
+
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = u.Hostname()
+	}
+	u.Host = strings.TrimSuffix(u.Host, ".")
+	u.Fragment, u.RawFragment = "", ""
+	u.User = nil
+
+	u.Host = stripSubdomain(u.Host)
+	u.Path, u.RawPath = stripAMPPath(u.Path), ""
+	u.RawQuery = cleanQuery(u.RawQuery)
+	u.ForceQuery = false
+	return u.String()
+}
+
+// Unwrap returns the target of an http(s) link through query-parameter wrappers and Google's AMP cache,
+// taken from the link itself. other and malformed links are returned as is.
+func Unwrap(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return link
+	}
+	target := unwrap(u)
+	if target == u {
+		return link
+	}
+	return target.String()
+}
+
+// Prefer returns the canonical link declared by the page of the article, e.g. with
+// <link rel="canonical">, or the link if the page declares no usable one.
+// a canonical pointing to the home page of a site is ignored, some sites declare it for every page.
+func Prefer(link, pageCanonical string) string {
+	u, err := url.Parse(pageCanonical)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return link
+	}
+	if strings.Trim(u.Path, "/") == "" && u.RawQuery == "" {
+		if orig, err := url.Parse(link); err != nil || strings.Trim(orig.Path, "/") != "" {
+			return link
+		}
+	}
+	return pageCanonical
+}
+
+// IsRedirect reports whether the link points to a feed proxy or link shortener, resolved only with a request
+func IsRedirect(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "feeds.feedburner.com" {
+		return strings.HasPrefix(u.Path, "/~r/") // other feedburner links are feeds
+	}
+	return redirectHosts[host]
+}
+
+// Resolver resolves links of feed proxies and link shorteners to the articles they point to
+type Resolver struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewResolver creates a resolver with the request timeout and user agent
+func NewResolver(timeout time.Duration, userAgent string) *Resolver {
+	return &Resolver{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse // redirects are followed by Resolve, one wrapper at a time
+			},
+		},
+		userAgent: userAgent,
+	}
+}
+
+// SetTransport sets the transport used for requests, e.g. one with a proxy or custom root CAs
+func (r *Resolver) SetTransport(t http.RoundTripper) {
+	r.client.Transport = t
+}
+
+// Resolve returns the article the link points to, with wrappers unwrapped as Unwrap does and redirects of
+// feed proxies and link shorteners followed until the link leaves them. a failed request leaves the link
+// as it is at that point. the result is a working link, Clean makes a dedupe key of it.
+func (r *Resolver) Resolve(ctx context.Context, link string) string {
+	link = Unwrap(link)
+	for range maxUnwrap {
+		if !IsRedirect(link) {
+			break
+		}
+		target, err := r.location(ctx, link)
+		if err != nil || target == "" {
+			break
+		}
+		link = Unwrap(target)
+	}
+	return link
+}
+
+// location returns the redirect target of the link, empty if the response is not a redirect
+func (r *Resolver) location(ctx context.Context, link string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", r.userAgent)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request %s: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		return "", nil
+	}
+	loc, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("redirect location of %s: %w", link, err)
+	}
+	return loc.String(), nil
+}
+
+// unwrap returns the target of query-parameter wrappers and Google's AMP cache, u itself if it is not wrapped
+func unwrap(u *url.URL) *url.URL {
+	for range maxUnwrap {
+		target := unwrapQuery(u)
+		if target == nil {
+			break
+		}
+		u = target
+	}
+	if amp := unwrapAMPCache(u); amp != nil {
+		u = amp
+	}
+	return u
+}
+
+// unwrapQuery returns the target of a query-parameter wrapper link, nil if the link is not one
+func unwrapQuery(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	for _, w := range queryWrappers {
+		if w.host != host || (w.path != "" && w.path != u.Path) {
+			continue
+		}
+		target, err := url.Parse(u.Query().Get(w.param))
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			continue
+		}
+		return target
+	}
+	return nil
+}
+
+// unwrapAMPCache returns the original page of a link to Google's AMP cache
+// (www.google.com/amp/s/example.com/page, example-com.cdn.ampproject.org/c/s/example.com/page)
+func unwrapAMPCache(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	var rest string
+	switch {
+	case (host == "www.google.com" || host == "google.com") && strings.HasPrefix(u.Path, "/amp/"):
+		rest = strings.TrimPrefix(u.Path, "/amp/")
+	case strings.HasSuffix(host, ".cdn.ampproject.org") || host == "cdn.ampproject.org":
+		rest = strings.TrimPrefix(strings.TrimPrefix(u.Path, "/v/"), "/c/")
+	default:
+		return nil
+	}
+	scheme := "http"
+	if after, ok := strings.CutPrefix(rest, "s/"); ok {
+		scheme, rest = "https", after
+	}
+	target, err := url.Parse(scheme + "://" + rest)
+	if err != nil || target.Host == "" || !strings.Contains(target.Host, ".") {
+		return nil
+	}
+	target.RawQuery = u.RawQuery
+	return target
+}
+
+// stripSubdomain removes mobile and AMP subdomains, m.example.com and amp.example.com are example.com
+func stripSubdomain(host string) string {
+	for _, prefix := range []string{"m.", "mobile.", "amp."} {
+		if rest, ok := strings.CutPrefix(host, prefix); ok && strings.Contains(rest, ".") {
+			return rest
+		}
+	}
+	return host
+}
+
+// stripAMPPath removes AMP path variants: /story/amp, /amp/story and /story.amp.html are /story and /story.html
+func stripAMPPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/amp"), strings.HasSuffix(path, "/amp/"):
+		path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), "/amp")
+		if path == "" {
+			path = "/"
+		}
+	case strings.HasPrefix(path, "/amp/"):
+		path = strings.TrimPrefix(path, "/amp")
+	case strings.HasSuffix(path, ".amp"):
+		path = strings.TrimSuffix(path, ".amp")
+	case strings.HasSuffix(path, ".amp.html"):
+		path = strings.TrimSuffix(path, ".amp.html") + ".html"
+	}
+	return path
+}
+
+// cleanQuery removes tracking and AMP parameters from the raw query, keeping the order and encoding of the rest
+func cleanQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	parts := strings.Split(rawQuery, "&")
+	kept := parts[:0]
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		name = strings.ToLower(name)
+		if isTracking(name) || name == "amp" || (name == "outputtype" && strings.EqualFold(value, "amp")) {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, "&")
+}
+
+// isTracking reports whether the lowercase parameter name is a tracking parameter
+func isTracking(name string) bool {
+	for _, p := range trackingParams {
+		if strings.HasSuffix(p, "_") && strings.HasPrefix(name, p) || name == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package canonical
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		name, link, want string
+	}{
+		{"clean link kept", "https://example.com/news/story?id=5", "https://example.com/news/story?id=5"},
+		{"tracking parameters", "https://example.com/story?utm_source=rss&id=5&utm_medium=feed&fbclid=abc",
+			"https://example.com/story?id=5"},
+		{"only tracking parameters", "https://example.com/story?utm_source=rss&utm_campaign=x", "https://example.com/story"},
+		{"encoding of kept parameters", "https://example.com/search?q=a%20b&gclid=1", "https://example.com/search?q=a%20b"},
+		{"fragment and default port", "HTTPS://Example.COM:443/story#comments", "https://example.com/story"},
+		{"mobile subdomain", "https://m.example.com/story", "https://example.com/story"},
+		{"mobile subdomain of a short host kept", "https://m.co/story", "https://m.co/story"},
+		{"amp subdomain", "https://amp.example.com/story", "https://example.com/story"},
+		{"amp path suffix", "https://example.com/story/amp/", "https://example.com/story"},
+		{"amp path prefix", "https://example.com/amp/story", "https://example.com/story"},
+		{"amp html", "https://example.com/story.amp.html", "https://example.com/story.html"},
+		{"amp parameter", "https://example.com/story?amp=1&id=2", "https://example.com/story?id=2"},
+		{"google amp cache", "https://www.google.com/amp/s/example.com/story/amp", "https://example.com/story"},
+		{"amp project cache", "https://example-com.cdn.ampproject.org/c/s/example.com/story", "https://example.com/story"},
+		{"query wrapper", "https://www.google.com/url?q=https%3A%2F%2Fexample.com%2Fstory%3Futm_source%3Dg&sa=D",
+			"https://example.com/story"},
+		{"nested wrappers", "https://l.facebook.com/l.php?u=" +
+			"https%3A%2F%2Fout.reddit.com%2Ft3_x%3Furl%3Dhttps%253A%252F%252Fexample.com%252Fstory", "https://example.com/story"},
+		{"wrapper without target kept", "https://www.google.com/url?sa=D", "https://www.google.com/url?sa=D"},
+		{"newsletter link", "mid:1@example.com", "mid:1@example.com"},
+		{"relative link", "/story?utm_source=rss", "/story?utm_source=rss"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Clean(tt.link))
+		})
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	tests := []struct {
+		name, link, want string
+	}{
+		{"plain link kept", "https://m.example.com/story/amp?utm_source=rss#top", "https://m.example.com/story/amp?utm_source=rss#top"},
+		{"query wrapper", "https://www.google.com/url?q=https%3A%2F%2Fexample.com%2Fstory%3Fid%3D1&sa=D",
+			"https://example.com/story?id=1"},
+		{"nested wrappers", "https://l.facebook.com/l.php?u=" +
+			"https%3A%2F%2Fout.reddit.com%2Ft3_x%3Furl%3Dhttps%253A%252F%252Fexample.com%252Fstory", "https://example.com/story"},
+		{"google amp cache", "https://www.google.com/amp/s/example.com/story/amp", "https://example.com/story/amp"},
+		{"wrapper without target kept", "https://www.google.com/url?sa=D", "https://www.google.com/url?sa=D"},
+		{"newsletter link", "mid:1@example.com", "mid:1@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Unwrap(tt.link))
+		})
+	}
+}
+
+func TestPrefer(t *testing.T) {
+	assert.Equal(t, "https://example.com/story", Prefer("https://example.com/story-123?utm_source=rss", "https://example.com/story"))
+	assert.Equal(t, "https://m.example.com/story#top", Prefer("https://m.example.com/story", "https://m.example.com/story#top"))
+	assert.Equal(t, "https://example.com/story?utm_source=rss", Prefer("https://example.com/story?utm_source=rss", ""), "no canonical")
+	assert.Equal(t, "https://example.com/story", Prefer("https://example.com/story", "/story"), "relative canonical")
+	assert.Equal(t, "https://example.com/story", Prefer("https://example.com/story", "https://example.com/"), "home page")
+	assert.Equal(t, "https://example.com/", Prefer("https://example.com/?utm_source=rss", "https://example.com/"))
+}
+
+func TestIsRedirect(t *testing.T) {
+	assert.True(t, IsRedirect("https://t.co/abc"))
+	assert.True(t, IsRedirect("http://feedproxy.google.com/~r/example/~3/abc/story"))
+	assert.True(t, IsRedirect("https://feeds.feedburner.com/~r/example/~3/abc/story"))
+	assert.False(t, IsRedirect("https://feeds.feedburner.com/example"), "feedburner feed")
+	assert.False(t, IsRedirect("https://example.com/story"))
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	article := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer article.Close()
+	var userAgent string
+	shortener := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		switch r.URL.Path {
+		case "/abc":
+			http.Redirect(w, r, "http://t.co/def", http.StatusMovedPermanently)
+		case "/def":
+			http.Redirect(w, r, article.URL+"/story?utm_source=twitter", http.StatusFound)
+		case "/page":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer shortener.Close()
+
+	// send requests for t.co to the test shortener
+	tr := &http.Transport{DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, shortener.Listener.Addr().String())
+	}}
+	r := NewResolver(time.Second, "test-agent")
+	r.SetTransport(tr)
+	ctx := context.Background()
+
+	assert.Equal(t, article.URL+"/story?utm_source=twitter", r.Resolve(ctx, "http://t.co/abc"), "chain of wrappers followed")
+	assert.Equal(t, "test-agent", userAgent)
+	assert.Equal(t, "http://t.co/page", r.Resolve(ctx, "http://t.co/page"), "not a redirect")
+	assert.Equal(t, "http://t.co/missing?utm_source=x", r.Resolve(ctx, "http://t.co/missing?utm_source=x"), "failed request")
+	assert.Equal(t, "https://m.example.com/story/amp", r.Resolve(ctx, "https://m.example.com/story/amp"),
+		"no request for other links, no guessed variants")
+	assert.Equal(t, "https://example.com/story", r.Resolve(ctx, "https://www.google.com/url?q=https%3A%2F%2Fexample.com%2Fstory"))
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/umputun/newscope/pkg/httpclient"
 )
 
 //go:generate go run internal/schema/main.go schema.json
@@ -27,24 +29,36 @@ type Config struct {
 	} `yaml:"database" json:"database" jsonschema:"description=Database configuration"`
 
 	Schedule struct {
-		UpdateInterval    time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
-		MaxWorkers        int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent workers"`
-		CleanupAge        time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
-		CleanupMinScore   float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
-		CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
-		RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
-		RetryInitialDelay time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
-		RetryMaxDelay     time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
-		RetryJitter       float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
+		UpdateInterval     time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
+		MinFetchInterval   time.Duration `yaml:"min_fetch_interval" json:"min_fetch_interval" jsonschema:"default=5m,description=Lower bound for adaptive per-feed fetch interval"`
+		MaxFetchInterval   time.Duration `yaml:"max_fetch_interval" json:"max_fetch_interval" jsonschema:"default=24h,description=Upper bound for adaptive per-feed fetch interval"`
+		MaxFeedErrors      int           `yaml:"max_feed_errors" json:"max_feed_errors" jsonschema:"default=10,description=Consecutive fetch errors before a feed is disabled automatically (0 for the default; negative to never disable)"`
+		MaxWorkers         int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent feed updates"`
+		CleanupAge         time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
+		CleanupMinScore    float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
+		CleanupInterval    time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
+		JobTimeout         time.Duration `yaml:"job_timeout" json:"job_timeout" jsonschema:"default=10m,description=How long an item may be processed before it is returned to the queue"`
+		MaxProcessAttempts int           `yaml:"max_process_attempts" json:"max_process_attempts" jsonschema:"default=5,minimum=1,description=Attempts to process an item before its processing job fails"`
+		RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
+		RetryInitialDelay  time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
+		RetryMaxDelay      time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
+		RetryJitter        float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
 	} `yaml:"schedule" json:"schedule" jsonschema:"description=Scheduler configuration"`
 
 	LLM LLMConfig `yaml:"llm" json:"llm" jsonschema:"description=LLM configuration for article classification"`
 
 	Extraction ExtractionConfig `yaml:"extraction" json:"extraction" jsonschema:"description=Content extraction configuration"`
+
+	WebSub WebSubConfig `yaml:"websub" json:"websub" jsonschema:"description=WebSub push subscriptions for feeds advertising a hub"`
+
+	Newsletters NewslettersConfig `yaml:"newsletters" json:"newsletters" jsonschema:"description=Email newsletters read from a maildir or an IMAP folder"`
+
+	HTTP HTTPConfig `yaml:"http" json:"http" jsonschema:"description=Outbound HTTP settings for feed fetching, content extraction and the LLM client"`
 }
 
 // ClassificationConfig holds classification-specific settings
 type ClassificationConfig struct {
+	MaxConcurrent              int                   `yaml:"max_concurrent" json:"max_concurrent" jsonschema:"default=5,minimum=1,description=Maximum concurrent classification requests"`
 	FeedbackExamples           int                   `yaml:"feedback_examples" json:"feedback_examples" jsonschema:"default=10,description=Number of recent feedback examples to include in prompt"`
 	UseJSONMode                bool                  `yaml:"use_json_mode" json:"use_json_mode" jsonschema:"default=false,description=Use JSON response format (not all models support this)"`
 	PreferenceSummaryThreshold int                   `yaml:"preference_summary_threshold" json:"preference_summary_threshold" jsonschema:"default=10,minimum=5,description=Number of new feedbacks required before updating preference summary"`
@@ -79,14 +93,51 @@ type ExtractionConfig struct {
 	Enabled       bool          `yaml:"enabled" json:"enabled" jsonschema:"default=false,description=Enable content extraction"`
 	Timeout       time.Duration `yaml:"timeout" json:"timeout" jsonschema:"default=30s,description=Extraction timeout per article"`
 	MaxConcurrent int           `yaml:"max_concurrent" json:"max_concurrent" jsonschema:"default=5,description=Maximum concurrent extractions"`
-	RateLimit     time.Duration `yaml:"rate_limit" json:"rate_limit" jsonschema:"default=1s,description=Rate limit between extractions"`
+	RateLimit     time.Duration `yaml:"rate_limit" json:"rate_limit" jsonschema:"default=1s,description=Interval between extractions from the same host after a short burst"`
+	RespectRobots bool          `yaml:"respect_robots" json:"respect_robots" jsonschema:"default=false,description=Skip pages disallowed by the site's robots.txt and use the feed's content instead"`
 	UserAgent     string        `yaml:"user_agent" json:"user_agent" jsonschema:"default=Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36,description=User agent for HTTP requests"`
-	FallbackURL   string        `yaml:"fallback_url" json:"fallback_url" jsonschema:"description=Fallback trafilatura API URL"`
+	FallbackURL   string        `yaml:"fallback_url" json:"fallback_url" jsonschema:"description=URL of an extraction API the fetched page is posted to when the built-in extraction finds too little content"`
 	MinTextLength int           `yaml:"min_text_length" json:"min_text_length" jsonschema:"default=100,description=Minimum text length to consider valid"`
 	IncludeImages bool          `yaml:"include_images" json:"include_images" jsonschema:"default=false,description=Include images in extraction"`
 	IncludeLinks  bool          `yaml:"include_links" json:"include_links" jsonschema:"default=false,description=Include links in extraction"`
 }
 
+// WebSubConfig holds WebSub (PubSubHubbub) push subscription settings.
+// hubs deliver updates to server.base_url, so it has to be reachable from the internet.
+type WebSubConfig struct {
+	Enabled        bool          `yaml:"enabled" json:"enabled" jsonschema:"default=false,description=Subscribe to WebSub hubs advertised by feeds and accept pushed updates"`
+	LeaseDuration  time.Duration `yaml:"lease_duration" json:"lease_duration" jsonschema:"default=240h,description=Subscription lease requested from hubs, renewed before it expires"`
+	SafetyInterval time.Duration `yaml:"safety_interval" json:"safety_interval" jsonschema:"default=12h,description=Polling interval for feeds with an active push subscription"`
+}
+
+// NewslettersConfig holds email newsletter settings. messages are read from a maildir directory,
+// an IMAP folder or both, and each sender gets its own pseudo-feed.
+type NewslettersConfig struct {
+	Enabled  bool          `yaml:"enabled" json:"enabled" jsonschema:"default=false,description=Read email newsletters and classify them as articles"`
+	Interval time.Duration `yaml:"interval" json:"interval" jsonschema:"default=15m,description=How often to check for new newsletters"`
+	Lookback time.Duration `yaml:"lookback" json:"lookback" jsonschema:"default=168h,description=Only messages received within this period are read"`
+	Maildir  string        `yaml:"maildir" json:"maildir" jsonschema:"description=Maildir directory with newsletters (messages are read from new and cur)"`
+	IMAP     IMAPConfig    `yaml:"imap" json:"imap" jsonschema:"description=IMAP folder with newsletters"`
+}
+
+// IMAPConfig holds IMAP server settings for newsletters, messages are read without being marked as seen
+type IMAPConfig struct {
+	Addr     string        `yaml:"addr" json:"addr" jsonschema:"description=IMAP server address as host:port (e.g. imap.example.com:993) or empty to disable IMAP"`
+	NoTLS    bool          `yaml:"no_tls" json:"no_tls" jsonschema:"default=false,description=Connect without TLS"`
+	Username string        `yaml:"username" json:"username" jsonschema:"description=IMAP user name"`
+	Password string        `yaml:"password" json:"password" jsonschema:"description=IMAP password (can use environment variable)"`
+	Folder   string        `yaml:"folder" json:"folder" jsonschema:"default=INBOX,description=IMAP folder with newsletters"`
+	Timeout  time.Duration `yaml:"timeout" json:"timeout" jsonschema:"default=1m,description=IMAP session timeout"`
+}
+
+// HTTPConfig holds outbound HTTP settings shared by feed fetching, content extraction and the LLM client.
+// feeds can override the proxy with their own one.
+type HTTPConfig struct {
+	Proxy         string   `yaml:"proxy" json:"proxy" jsonschema:"description=Proxy URL (http://, https:// or socks5://), direct for no proxy, empty to use HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment"`
+	CAFiles       []string `yaml:"ca_files" json:"ca_files" jsonschema:"description=PEM files with root CAs trusted in addition to the system ones"`
+	InsecureHosts []string `yaml:"insecure_hosts" json:"insecure_hosts" jsonschema:"description=Hosts with TLS certificate verification disabled (*.example.com matches subdomains)"`
+}
+
 // Load reads configuration from a YAML file
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // file path comes from CLI flag
@@ -134,6 +185,15 @@ func Load(path string) (*Config, error) {
 	if cfg.Schedule.UpdateInterval == 0 {
 		cfg.Schedule.UpdateInterval = 1 * time.Minute
 	}
+	if cfg.Schedule.MinFetchInterval == 0 {
+		cfg.Schedule.MinFetchInterval = 5 * time.Minute
+	}
+	if cfg.Schedule.MaxFetchInterval == 0 {
+		cfg.Schedule.MaxFetchInterval = 24 * time.Hour
+	}
+	if cfg.Schedule.MaxFeedErrors == 0 { // negative never disables feeds
+		cfg.Schedule.MaxFeedErrors = 10
+	}
 	if cfg.Schedule.MaxWorkers == 0 {
 		cfg.Schedule.MaxWorkers = 5
 	}
@@ -146,6 +206,12 @@ func Load(path string) (*Config, error) {
 	if cfg.Schedule.CleanupInterval == 0 {
 		cfg.Schedule.CleanupInterval = 24 * time.Hour // daily cleanup
 	}
+	if cfg.Schedule.JobTimeout == 0 {
+		cfg.Schedule.JobTimeout = 10 * time.Minute
+	}
+	if cfg.Schedule.MaxProcessAttempts == 0 {
+		cfg.Schedule.MaxProcessAttempts = 5
+	}
 	if cfg.Schedule.RetryAttempts == 0 {
 		cfg.Schedule.RetryAttempts = 5
 	}
@@ -169,6 +235,9 @@ func Load(path string) (*Config, error) {
 	if cfg.LLM.Timeout == 0 {
 		cfg.LLM.Timeout = 30 * time.Second
 	}
+	if cfg.LLM.Classification.MaxConcurrent == 0 {
+		cfg.LLM.Classification.MaxConcurrent = 5
+	}
 	if cfg.LLM.Classification.FeedbackExamples == 0 {
 		cfg.LLM.Classification.FeedbackExamples = 10
 	}
@@ -193,6 +262,28 @@ func Load(path string) (*Config, error) {
 		cfg.Extraction.MinTextLength = 100
 	}
 
+	// set defaults for websub
+	if cfg.WebSub.LeaseDuration == 0 {
+		cfg.WebSub.LeaseDuration = 240 * time.Hour
+	}
+	if cfg.WebSub.SafetyInterval == 0 {
+		cfg.WebSub.SafetyInterval = 12 * time.Hour
+	}
+
+	// set defaults for newsletters
+	if cfg.Newsletters.Interval == 0 {
+		cfg.Newsletters.Interval = 15 * time.Minute
+	}
+	if cfg.Newsletters.Lookback == 0 {
+		cfg.Newsletters.Lookback = 168 * time.Hour
+	}
+	if cfg.Newsletters.IMAP.Folder == "" {
+		cfg.Newsletters.IMAP.Folder = "INBOX"
+	}
+	if cfg.Newsletters.IMAP.Timeout == 0 {
+		cfg.Newsletters.IMAP.Timeout = time.Minute
+	}
+
 	// validate configuration
 	if err := validate(&cfg); err != nil {
 		return nil, fmt.Errorf("validate config: %w", err)
@@ -234,6 +325,23 @@ func validate(cfg *Config) error {
 		}
 	}
 
+	// validate newsletters config
+	if cfg.Newsletters.Enabled && cfg.Newsletters.Maildir == "" && cfg.Newsletters.IMAP.Addr == "" {
+		return fmt.Errorf("newsletters need maildir or imap.addr")
+	}
+
+	// validate http config
+	if cfg.HTTP.Proxy != "" && cfg.HTTP.Proxy != httpclient.Direct {
+		if _, err := httpclient.ParseProxy(cfg.HTTP.Proxy); err != nil {
+			return fmt.Errorf("http.proxy: %w", err)
+		}
+	}
+
+	// validate schedule config
+	if cfg.Schedule.MinFetchInterval > cfg.Schedule.MaxFetchInterval {
+		return fmt.Errorf("schedule min_fetch_interval must not exceed max_fetch_interval")
+	}
+
 	// validate server config
 	if cfg.Server.Timeout < time.Second {
 		return fmt.Errorf("server timeout must be at least 1 second")
@@ -57,6 +57,66 @@ llm:
 
 		// check LLM classification defaults
 		assert.Equal(t, 10, cfg.LLM.Classification.PreferenceSummaryThreshold)
+		assert.Equal(t, 5, cfg.LLM.Classification.MaxConcurrent)
+
+		// check adaptive interval bounds defaults
+		assert.Equal(t, 5*time.Minute, cfg.Schedule.MinFetchInterval)
+		assert.Equal(t, 24*time.Hour, cfg.Schedule.MaxFetchInterval)
+		assert.Equal(t, 10, cfg.Schedule.MaxFeedErrors)
+		assert.Equal(t, 10*time.Minute, cfg.Schedule.JobTimeout)
+		assert.Equal(t, 5, cfg.Schedule.MaxProcessAttempts)
+
+		// check websub defaults
+		assert.False(t, cfg.WebSub.Enabled)
+		assert.Equal(t, 240*time.Hour, cfg.WebSub.LeaseDuration)
+		assert.Equal(t, 12*time.Hour, cfg.WebSub.SafetyInterval)
+
+		// check newsletters defaults
+		assert.False(t, cfg.Newsletters.Enabled)
+		assert.Equal(t, 15*time.Minute, cfg.Newsletters.Interval)
+		assert.Equal(t, 168*time.Hour, cfg.Newsletters.Lookback)
+		assert.Equal(t, "INBOX", cfg.Newsletters.IMAP.Folder)
+		assert.Equal(t, time.Minute, cfg.Newsletters.IMAP.Timeout)
+	})
+
+	t.Run("min fetch interval above max", func(t *testing.T) {
+		configContent := `
+schedule:
+  min_fetch_interval: 2h
+  max_fetch_interval: 1h
+llm:
+  endpoint: http://localhost:11434/v1
+  api_key: test-api-key
+  model: llama3
+`
+		tmpDir := t.TempDir()
+		configPath := filepath.Join(tmpDir, "test-config.yml")
+		err := os.WriteFile(configPath, []byte(configContent), 0o644)
+		require.NoError(t, err)
+
+		cfg, err := Load(configPath)
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "min_fetch_interval must not exceed max_fetch_interval")
+	})
+
+	t.Run("max feed errors", func(t *testing.T) {
+		for value, want := range map[string]int{"0": 10, "3": 3, "-1": -1} {
+			configContent := `
+schedule:
+  max_feed_errors: ` + value + `
+llm:
+  endpoint: http://localhost:11434/v1
+  api_key: test-api-key
+  model: llama3
+`
+			configPath := filepath.Join(t.TempDir(), "test-config.yml")
+			require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+
+			cfg, err := Load(configPath)
+			require.NoError(t, err, value)
+			assert.Equal(t, want, cfg.Schedule.MaxFeedErrors, value)
+		}
 	})
 
 	t.Run("file not found", func(t *testing.T) {
@@ -282,6 +342,31 @@ func TestConfig_ValidateEdgeCases(t *testing.T) {
 		assert.Contains(t, err.Error(), "extraction timeout must be at least 1 second")
 	})
 
+	t.Run("newsletters without a source", func(t *testing.T) {
+		cfg := &Config{
+			LLM:         LLMConfig{Endpoint: "https://api.openai.com/v1", APIKey: "test-key", Model: "gpt-4"},
+			Newsletters: NewslettersConfig{Enabled: true},
+		}
+		err := validate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "newsletters need maildir or imap.addr")
+	})
+
+	t.Run("invalid http proxy", func(t *testing.T) {
+		cfg := &Config{
+			LLM:  LLMConfig{Endpoint: "https://api.openai.com/v1", APIKey: "test-key", Model: "gpt-4"},
+			HTTP: HTTPConfig{Proxy: "proxy.example.com:3128"},
+		}
+		err := validate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "http.proxy")
+
+		cfg.HTTP.Proxy = "direct"
+		err = validate(cfg)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "http.proxy", "direct is a valid proxy value")
+	})
+
 	t.Run("extraction min text length negative", func(t *testing.T) {
 		cfg := &Config{
 			LLM: LLMConfig{
@@ -43,15 +43,20 @@ func TestVerifyAgainstEmbeddedSchema(t *testing.T) {
 					Model:    "test-model",
 				},
 				Schedule: struct {
-					UpdateInterval    time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
-					MaxWorkers        int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent workers"`
-					CleanupAge        time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
-					CleanupMinScore   float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
-					CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
-					RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
-					RetryInitialDelay time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
-					RetryMaxDelay     time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
-					RetryJitter       float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
+					UpdateInterval     time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
+					MinFetchInterval   time.Duration `yaml:"min_fetch_interval" json:"min_fetch_interval" jsonschema:"default=5m,description=Lower bound for adaptive per-feed fetch interval"`
+					MaxFetchInterval   time.Duration `yaml:"max_fetch_interval" json:"max_fetch_interval" jsonschema:"default=24h,description=Upper bound for adaptive per-feed fetch interval"`
+					MaxFeedErrors      int           `yaml:"max_feed_errors" json:"max_feed_errors" jsonschema:"default=10,description=Consecutive fetch errors before a feed is disabled automatically (0 for the default; negative to never disable)"`
+					MaxWorkers         int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent feed updates"`
+					CleanupAge         time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
+					CleanupMinScore    float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
+					CleanupInterval    time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
+					JobTimeout         time.Duration `yaml:"job_timeout" json:"job_timeout" jsonschema:"default=10m,description=How long an item may be processed before it is returned to the queue"`
+					MaxProcessAttempts int           `yaml:"max_process_attempts" json:"max_process_attempts" jsonschema:"default=5,minimum=1,description=Attempts to process an item before its processing job fails"`
+					RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
+					RetryInitialDelay  time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
+					RetryMaxDelay      time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
+					RetryJitter        float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
 				}{
 					UpdateInterval: 1 * time.Minute,
 					MaxWorkers:     5,
@@ -124,15 +129,20 @@ func TestVerifyAgainstEmbeddedSchema(t *testing.T) {
 					Model:    "test-model",
 				},
 				Schedule: struct {
-					UpdateInterval    time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
-					MaxWorkers        int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent workers"`
-					CleanupAge        time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
-					CleanupMinScore   float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
-					CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
-					RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
-					RetryInitialDelay time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
-					RetryMaxDelay     time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
-					RetryJitter       float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
+					UpdateInterval     time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
+					MinFetchInterval   time.Duration `yaml:"min_fetch_interval" json:"min_fetch_interval" jsonschema:"default=5m,description=Lower bound for adaptive per-feed fetch interval"`
+					MaxFetchInterval   time.Duration `yaml:"max_fetch_interval" json:"max_fetch_interval" jsonschema:"default=24h,description=Upper bound for adaptive per-feed fetch interval"`
+					MaxFeedErrors      int           `yaml:"max_feed_errors" json:"max_feed_errors" jsonschema:"default=10,description=Consecutive fetch errors before a feed is disabled automatically (0 for the default; negative to never disable)"`
+					MaxWorkers         int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent feed updates"`
+					CleanupAge         time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
+					CleanupMinScore    float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
+					CleanupInterval    time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
+					JobTimeout         time.Duration `yaml:"job_timeout" json:"job_timeout" jsonschema:"default=10m,description=How long an item may be processed before it is returned to the queue"`
+					MaxProcessAttempts int           `yaml:"max_process_attempts" json:"max_process_attempts" jsonschema:"default=5,minimum=1,description=Attempts to process an item before its processing job fails"`
+					RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
+					RetryInitialDelay  time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
+					RetryMaxDelay      time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
+					RetryJitter        float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
 				}{
 					UpdateInterval: 1 * time.Minute,
 					MaxWorkers:     5,
@@ -199,15 +209,20 @@ func TestValidateRequiredFields(t *testing.T) {
 					Model:    "test-model",
 				},
 				Schedule: struct {
-					UpdateInterval    time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
-					MaxWorkers        int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent workers"`
-					CleanupAge        time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
-					CleanupMinScore   float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
-					CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
-					RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
-					RetryInitialDelay time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
-					RetryMaxDelay     time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
-					RetryJitter       float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
+					UpdateInterval     time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
+					MinFetchInterval   time.Duration `yaml:"min_fetch_interval" json:"min_fetch_interval" jsonschema:"default=5m,description=Lower bound for adaptive per-feed fetch interval"`
+					MaxFetchInterval   time.Duration `yaml:"max_fetch_interval" json:"max_fetch_interval" jsonschema:"default=24h,description=Upper bound for adaptive per-feed fetch interval"`
+					MaxFeedErrors      int           `yaml:"max_feed_errors" json:"max_feed_errors" jsonschema:"default=10,description=Consecutive fetch errors before a feed is disabled automatically (0 for the default; negative to never disable)"`
+					MaxWorkers         int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent feed updates"`
+					CleanupAge         time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
+					CleanupMinScore    float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
+					CleanupInterval    time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
+					JobTimeout         time.Duration `yaml:"job_timeout" json:"job_timeout" jsonschema:"default=10m,description=How long an item may be processed before it is returned to the queue"`
+					MaxProcessAttempts int           `yaml:"max_process_attempts" json:"max_process_attempts" jsonschema:"default=5,minimum=1,description=Attempts to process an item before its processing job fails"`
+					RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
+					RetryInitialDelay  time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
+					RetryMaxDelay      time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
+					RetryJitter        float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
 				}{
 					UpdateInterval: 1 * time.Minute,
 					MaxWorkers:     5,
@@ -243,15 +258,20 @@ func TestValidateRequiredFields(t *testing.T) {
 					Model:    "test-model",
 				},
 				Schedule: struct {
-					UpdateInterval    time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
-					MaxWorkers        int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent workers"`
-					CleanupAge        time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
-					CleanupMinScore   float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
-					CleanupInterval   time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
-					RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
-					RetryInitialDelay time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
-					RetryMaxDelay     time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
-					RetryJitter       float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
+					UpdateInterval     time.Duration `yaml:"update_interval" json:"update_interval" jsonschema:"default=1m,description=Scheduler run interval"`
+					MinFetchInterval   time.Duration `yaml:"min_fetch_interval" json:"min_fetch_interval" jsonschema:"default=5m,description=Lower bound for adaptive per-feed fetch interval"`
+					MaxFetchInterval   time.Duration `yaml:"max_fetch_interval" json:"max_fetch_interval" jsonschema:"default=24h,description=Upper bound for adaptive per-feed fetch interval"`
+					MaxFeedErrors      int           `yaml:"max_feed_errors" json:"max_feed_errors" jsonschema:"default=10,description=Consecutive fetch errors before a feed is disabled automatically (0 for the default; negative to never disable)"`
+					MaxWorkers         int           `yaml:"max_workers" json:"max_workers" jsonschema:"default=5,description=Maximum concurrent feed updates"`
+					CleanupAge         time.Duration `yaml:"cleanup_age" json:"cleanup_age" jsonschema:"default=168h,description=Maximum age for articles with low scores (default 1 week)"`
+					CleanupMinScore    float64       `yaml:"cleanup_min_score" json:"cleanup_min_score" jsonschema:"default=5.0,description=Minimum score to keep articles regardless of age"`
+					CleanupInterval    time.Duration `yaml:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=24h,description=How often to run cleanup"`
+					JobTimeout         time.Duration `yaml:"job_timeout" json:"job_timeout" jsonschema:"default=10m,description=How long an item may be processed before it is returned to the queue"`
+					MaxProcessAttempts int           `yaml:"max_process_attempts" json:"max_process_attempts" jsonschema:"default=5,minimum=1,description=Attempts to process an item before its processing job fails"`
+					RetryAttempts      int           `yaml:"retry_attempts" json:"retry_attempts" jsonschema:"default=5,description=Number of retry attempts for database operations"`
+					RetryInitialDelay  time.Duration `yaml:"retry_initial_delay" json:"retry_initial_delay" jsonschema:"default=100ms,description=Initial retry delay for database operations"`
+					RetryMaxDelay      time.Duration `yaml:"retry_max_delay" json:"retry_max_delay" jsonschema:"default=5s,description=Maximum retry delay for database operations"`
+					RetryJitter        float64       `yaml:"retry_jitter" json:"retry_jitter" jsonschema:"default=0.3,minimum=0,maximum=1,description=Jitter factor 0-1 to avoid thundering herd"`
 				}{
 					UpdateInterval: 1 * time.Minute,
 					MaxWorkers:     5,
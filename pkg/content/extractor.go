@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,6 +13,9 @@ import (
 	"github.com/go-pkgz/repeater/v2"
 	"github.com/markusmobius/go-trafilatura"
 	"golang.org/x/net/html"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
 )
 
 // clientError represents non-retryable client errors
@@ -27,7 +31,8 @@ func (e *clientError) Error() string {
 	return fmt.Sprintf("client error: %d", e.code)
 }
 
-// HTTPExtractor extracts article content from URLs using trafilatura
+// HTTPExtractor extracts article content from URLs using trafilatura, and the text of PDF documents.
+// requests to the same host are rate limited and, if enabled, checked against the site's robots.txt.
 type HTTPExtractor struct {
 	timeout       time.Duration
 	userAgent     string
@@ -36,6 +41,8 @@ type HTTPExtractor struct {
 	includeImages bool
 	includeLinks  bool
 	client        *http.Client
+	limiter       *hostLimiter
+	robots        *robotsCache // nil if robots.txt is not checked
 }
 
 // ExtractResult contains the result of content extraction
@@ -44,7 +51,10 @@ type ExtractResult struct {
 	RichContent string    // extracted content with simplified HTML formatting
 	Title       string    // article title if available
 	URL         string    // original URL
+	Canonical   string    // canonical URL declared by the page, the URL after redirects if none
+	Image       string    // lead image of the page, e.g. from og:image
 	Date        time.Time // publication date if available
+	Extractor   string    // extractor which produced the content, ExtractorTrafilatura, ExtractorFallback or ExtractorPDF
 }
 
 // NewHTTPExtractor creates a new content extractor
@@ -54,16 +64,39 @@ func NewHTTPExtractor(timeout time.Duration, userAgent string) *HTTPExtractor {
 		userAgent:     userAgent,
 		minTextLength: 100,
 		client: &http.Client{
-			Timeout: timeout,
+			Timeout:       timeout,
+			CheckRedirect: httpclient.CheckAuthRedirect,
 		},
+		limiter: newHostLimiter(0),
 	}
 }
 
-// SetFallbackURL sets the fallback trafilatura API URL
+// SetFallbackURL sets the URL of a remote extraction API, e.g. a self-hosted trafilatura service.
+// pages the built-in extraction gets no or too short content from are posted to it as FallbackRequest
+// and it responds with FallbackResponse.
 func (e *HTTPExtractor) SetFallbackURL(fallbackURL string) {
 	e.fallbackURL = fallbackURL
 }
 
+// SetTransport sets the transport used for requests, e.g. one with a proxy or custom root CAs
+func (e *HTTPExtractor) SetTransport(t http.RoundTripper) {
+	e.client.Transport = t
+}
+
+// SetRateLimit sets the interval between requests to the same host, after a short burst. zero disables
+// the limit, hosts asking to wait with Retry-After are waited for anyway.
+func (e *HTTPExtractor) SetRateLimit(interval time.Duration) {
+	e.limiter.setInterval(interval)
+}
+
+// SetRobots enables checking of robots.txt, pages it disallows fail with ErrDisallowed without being fetched
+func (e *HTTPExtractor) SetRobots(enabled bool) {
+	e.robots = nil
+	if enabled {
+		e.robots = newRobotsCache(e.client, e.userAgent)
+	}
+}
+
 // SetOptions configures extraction options
 func (e *HTTPExtractor) SetOptions(minTextLength int, includeImages, includeLinks bool) {
 	e.minTextLength = minTextLength
@@ -71,8 +104,10 @@ func (e *HTTPExtractor) SetOptions(minTextLength int, includeImages, includeLink
 	e.includeLinks = includeLinks
 }
 
-// Extract retrieves and extracts text content from the given URL
-func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractResult, error) {
+// Extract retrieves and extracts text content from the given URL, auth holds credentials
+// of a private feed the URL belongs to and is empty for public ones. each attempt takes a slot
+// of the context's Slots, if set, once the host's rate limit allows the request.
+func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string, auth domain.FeedAuth) (*ExtractResult, error) {
 	// validate URL
 	if urlStr == "" {
 		return nil, fmt.Errorf("empty URL")
@@ -85,6 +120,9 @@ func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractRes
 	if parsedURL.Scheme == "" || parsedURL.Host == "" {
 		return nil, fmt.Errorf("invalid URL: %s", urlStr)
 	}
+	if e.robots != nil && !e.robots.allowed(ctx, parsedURL) {
+		return nil, fmt.Errorf("%w: %s", ErrDisallowed, urlStr)
+	}
 
 	var result *ExtractResult
 	termErr := &clientError{} // terminal error for client errors
@@ -92,8 +130,17 @@ func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractRes
 	// retry with exponential backoff for network/server errors
 	err = repeater.NewBackoff(3, time.Second, repeater.WithMaxDelay(10*time.Second)).
 		Do(ctx, func() error {
-			// create request with context
-			req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+			if err := e.limiter.wait(ctx, parsedURL.Hostname()); err != nil {
+				return err
+			}
+			release, err := AcquireSlot(ctx)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			// create request with context, the credentials are dropped from redirects to other hosts
+			req, err := http.NewRequestWithContext(httpclient.WithAuth(ctx, auth), http.MethodGet, urlStr, http.NoBody)
 			if err != nil {
 				return fmt.Errorf("create request: %w", err)
 			}
@@ -104,6 +151,9 @@ func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractRes
 			// add browser-like headers with randomization
 			addBrowserHeaders(req)
 
+			// credentials override the headers above
+			httpclient.ApplyAuth(req, auth)
+
 			// fetch content
 			resp, err := e.client.Do(req)
 			if err != nil {
@@ -116,8 +166,17 @@ func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractRes
 			switch {
 			case resp.StatusCode == http.StatusOK:
 				// success, continue to extraction
-			case resp.StatusCode >= 500 || resp.StatusCode == 429:
-				// server errors and rate limiting are retryable
+			case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+				// the next attempt waits as long as the host asked, if it is not too long
+				if delay, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					e.limiter.block(parsedURL.Hostname(), time.Now().Add(delay))
+					if delay > maxBlockWait {
+						return fmt.Errorf("%w: status %d, retry after %v", ErrRateLimited, resp.StatusCode, delay)
+					}
+				}
+				return fmt.Errorf("server error: %d", resp.StatusCode)
+			case resp.StatusCode >= 500:
+				// server errors are retryable
 				return fmt.Errorf("server error: %d", resp.StatusCode)
 			default:
 				// client errors (4xx) are not retryable
@@ -125,71 +184,107 @@ func (e *HTTPExtractor) Extract(ctx context.Context, urlStr string) (*ExtractRes
 				return termErr
 			}
 
-			// check content type - only process HTML/text content
+			// check content type - only process HTML/text content and PDF documents
 			contentType := resp.Header.Get("Content-Type")
+			if isPDF(contentType, resp.Request.URL) {
+				data, err := io.ReadAll(io.LimitReader(resp.Body, pdfMaxSize+1))
+				if err != nil {
+					return fmt.Errorf("read pdf: %w", err)
+				}
+				// documents without text or too large won't change with another attempt
+				if result, err = e.extractPDF(data, resp.Request.URL, urlStr); err != nil {
+					termErr.code = resp.StatusCode
+					termErr.message = fmt.Sprintf("extract pdf: %v", err)
+					return termErr
+				}
+				return nil
+			}
 			if contentType != "" && !strings.Contains(strings.ToLower(contentType), "text/html") &&
 				!strings.Contains(strings.ToLower(contentType), "application/xhtml") &&
 				!strings.Contains(strings.ToLower(contentType), "text/plain") {
-				// non-HTML content (PDF, images, etc) - not retryable
+				// non-HTML content (images, archives, etc) - not retryable
 				termErr.code = resp.StatusCode
 				termErr.message = fmt.Sprintf("unsupported content type: %s", contentType)
 				return termErr
 			}
 
-			// configure trafilatura options
-			opts := trafilatura.Options{
-				EnableFallback:  true,
-				ExcludeComments: true,
-				ExcludeTables:   false,
-				IncludeImages:   e.includeImages,
-				IncludeLinks:    e.includeLinks,
-				Deduplicate:     true,
-				OriginalURL:     parsedURL,
-			}
-
-			// extract content
-			extracted, err := trafilatura.Extract(resp.Body, opts)
+			page, err := io.ReadAll(resp.Body)
 			if err != nil {
-				return fmt.Errorf("extract content: %w", err)
+				return fmt.Errorf("read page: %w", err)
 			}
 
-			if extracted == nil || extracted.ContentText == "" {
-				return fmt.Errorf("no content extracted")
+			// the fallback API gets the page if the built-in extraction finds no content or too little
+			result, err = e.extractTrafilatura(page, resp.Request.URL, urlStr)
+			if err != nil && e.fallbackURL != "" {
+				fallback, fallbackErr := e.extractFallback(ctx, urlStr, resp.Request.URL.String(), page)
+				if fallbackErr != nil {
+					return fmt.Errorf("%w, fallback: %w", err, fallbackErr)
+				}
+				result, err = fallback, nil
 			}
+			return err
+		}, termErr, ErrRateLimited) // stop on client errors and hosts asking to wait too long
+
+	if err != nil {
+		return nil, err
+	}
 
-			// clean up content
-			content := strings.TrimSpace(extracted.ContentText)
+	return result, nil
+}
 
-			// check minimum text length
-			if len(content) < e.minTextLength {
-				// too short content is not retryable
-				return fmt.Errorf("content too short: %d chars", len(content))
-			}
+// extractTrafilatura extracts the article from the page with go-trafilatura,
+// pageURL is the page URL after redirects, urlStr the requested one
+func (e *HTTPExtractor) extractTrafilatura(page []byte, pageURL *url.URL, urlStr string) (*ExtractResult, error) {
+	// configure trafilatura options
+	opts := trafilatura.Options{
+		EnableFallback:  true,
+		ExcludeComments: true,
+		ExcludeTables:   false,
+		IncludeImages:   e.includeImages,
+		IncludeLinks:    e.includeLinks,
+		Deduplicate:     true,
+		OriginalURL:     pageURL, // URL after redirects, relative links are resolved against it
+	}
 
-			// extract rich content with simplified HTML if available
-			richContent := ""
-			if extracted.ContentNode != nil {
-				richContent = extractRichContent(extracted.ContentNode)
-			}
+	// extract content
+	extracted, err := trafilatura.Extract(bytes.NewReader(page), opts)
+	if err != nil {
+		return nil, fmt.Errorf("extract content: %w", err)
+	}
 
-			// build result
-			result = &ExtractResult{
-				Content:     content,
-				RichContent: richContent,
-				Title:       extracted.Metadata.Title,
-				URL:         urlStr,
-			}
+	if extracted == nil || extracted.ContentText == "" {
+		return nil, fmt.Errorf("no content extracted")
+	}
 
-			// use metadata date if available
-			if !extracted.Metadata.Date.IsZero() {
-				result.Date = extracted.Metadata.Date
-			}
+	// clean up content
+	content := strings.TrimSpace(extracted.ContentText)
 
-			return nil
-		}, termErr) // pass termErr to stop on client errors
+	// check minimum text length
+	if len(content) < e.minTextLength {
+		// too short content is not retryable
+		return nil, fmt.Errorf("content too short: %d chars", len(content))
+	}
 
-	if err != nil {
-		return nil, err
+	// extract rich content with simplified HTML if available
+	richContent := ""
+	if extracted.ContentNode != nil {
+		richContent = extractRichContent(extracted.ContentNode)
+	}
+
+	// build result
+	result := &ExtractResult{
+		Content:     content,
+		RichContent: richContent,
+		Title:       extracted.Metadata.Title,
+		URL:         urlStr,
+		Canonical:   extracted.Metadata.URL,
+		Image:       extracted.Metadata.Image,
+		Extractor:   ExtractorTrafilatura,
+	}
+
+	// use metadata date if available
+	if !extracted.Metadata.Date.IsZero() {
+		result.Date = extracted.Metadata.Date
 	}
 
 	return result, nil
@@ -269,6 +364,12 @@ func handleElementNode(node *html.Node, buf *bytes.Buffer) {
 		"br":         "br",
 	}
 
+	// content of these is never article text, e.g. in HTML returned by the fallback API
+	switch node.Data {
+	case "script", "style", "noscript", "template":
+		return
+	}
+
 	outputTag, isAllowed := allowedTags[node.Data]
 
 	if isAllowed {
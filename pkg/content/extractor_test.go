@@ -13,6 +13,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
 )
 
 //go:embed testdata/*
@@ -129,7 +131,7 @@ func TestHTTPExtractor_Extract_RealArticles(t *testing.T) {
 			ctx := context.Background()
 			url := server.URL + "/" + tt.htmlFile
 
-			result, err := extractor.Extract(ctx, url)
+			result, err := extractor.Extract(ctx, url, domain.FeedAuth{})
 			require.NoError(t, err)
 			require.NotNil(t, result)
 			require.NotEmpty(t, result.Content)
@@ -173,7 +175,7 @@ func TestHTTPExtractor_Extract_Timeout(t *testing.T) {
 	extractor := NewHTTPExtractor(100*time.Millisecond, "Newscope/1.0")
 
 	ctx := context.Background()
-	_, err := extractor.Extract(ctx, server.URL)
+	_, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "context deadline exceeded")
 }
@@ -202,7 +204,7 @@ func TestHTTPExtractor_Extract_InvalidURL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			_, err := extractor.Extract(ctx, tt.url)
+			_, err := extractor.Extract(ctx, tt.url, domain.FeedAuth{})
 			require.Error(t, err)
 		})
 	}
@@ -227,7 +229,7 @@ func TestHTTPExtractor_Extract_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	_, err := extractor.Extract(ctx, server.URL)
+	_, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "context canceled")
 }
@@ -296,7 +298,7 @@ func TestHTTPExtractor_Extract_ErrorCases(t *testing.T) {
 				url = tt.url
 			}
 
-			_, err := extractor.Extract(ctx, url)
+			_, err := extractor.Extract(ctx, url, domain.FeedAuth{})
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedErr)
 		})
@@ -323,7 +325,7 @@ func TestHTTPExtractor_Extract_LargeContent(t *testing.T) {
 	extractor := NewHTTPExtractor(30*time.Second, "Newscope/1.0")
 
 	ctx := context.Background()
-	result, err := extractor.Extract(ctx, server.URL)
+	result, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	require.NotEmpty(t, result.Content)
@@ -366,7 +368,7 @@ func TestHTTPExtractor_Extract_Encoding(t *testing.T) {
 			extractor.SetOptions(10, false, false) // set lower min text length for test
 
 			ctx := context.Background()
-			result, err := extractor.Extract(ctx, server.URL)
+			result, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 			require.NoError(t, err)
 			assert.Contains(t, result.Content, tt.expected)
 		})
@@ -456,7 +458,7 @@ func TestHTTPExtractor_Extract_EmptyParagraphHandling(t *testing.T) {
 			extractor.SetOptions(10, false, false) // set lower min text length for test
 
 			ctx := context.Background()
-			result, err := extractor.Extract(ctx, server.URL)
+			result, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 			require.NoError(t, err)
 			require.NotNil(t, result)
 
@@ -496,7 +498,7 @@ func BenchmarkHTTPExtractor_Extract(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := extractor.Extract(ctx, server.URL)
+		_, err := extractor.Extract(ctx, server.URL, domain.FeedAuth{})
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -555,7 +557,7 @@ func TestHTTPExtractor_UserAgent(t *testing.T) {
 	extractor := NewHTTPExtractor(5*time.Second, expectedUA)
 	extractor.SetOptions(50, false, false) // lower min length for test
 
-	_, err := extractor.Extract(context.Background(), server.URL)
+	_, err := extractor.Extract(context.Background(), server.URL, domain.FeedAuth{})
 	require.NoError(t, err)
 
 	assert.Equal(t, expectedUA, capturedUserAgent, "Extractor should send the configured user agent")
@@ -571,6 +573,83 @@ func TestHTTPExtractor_UserAgent(t *testing.T) {
 	assert.NotEmpty(t, capturedHeaders.Get("Sec-Fetch-Mode"))
 }
 
+func TestHTTPExtractor_Extract_Auth(t *testing.T) {
+	var captured http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r.Header.Clone()
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><article><h1>Private</h1><p>This private article has enough text ` +
+			`to pass the minimum length check of the extractor used in this test.</p></article></body></html>`))
+	}))
+	defer server.Close()
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/1.0")
+	extractor.SetOptions(50, false, false)
+
+	auth := domain.FeedAuth{Token: "secret-token", Headers: map[string]string{"Private-Token": "abc", "Accept-Language": "de"},
+		Cookies: "session=xyz"}
+	_, err := extractor.Extract(context.Background(), server.URL, auth)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer secret-token", captured.Get("Authorization"))
+	assert.Equal(t, "abc", captured.Get("Private-Token"))
+	assert.Equal(t, "de", captured.Get("Accept-Language"), "custom header overrides browser header")
+	assert.Equal(t, "session=xyz", captured.Get("Cookie"))
+
+	_, err = extractor.Extract(context.Background(), server.URL, domain.FeedAuth{Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", captured.Get("Authorization"))
+	assert.Empty(t, captured.Get("Cookie"))
+
+	t.Run("credentials dropped on redirect to another host", func(t *testing.T) {
+		// the article server is reached as localhost, a host other than 127.0.0.1 of the redirecting one
+		other := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+		redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "abc", r.Header.Get("Private-Token"))
+			http.Redirect(w, r, other+"/article", http.StatusFound)
+		}))
+		defer redirect.Close()
+
+		_, err := extractor.Extract(context.Background(), redirect.URL, auth)
+		require.NoError(t, err)
+		assert.Empty(t, captured.Get("Authorization"))
+		assert.Empty(t, captured.Get("Private-Token"))
+		assert.Empty(t, captured.Get("Cookie"))
+	})
+}
+
+func TestHTTPExtractor_Extract_Canonical(t *testing.T) {
+	const body = `<p>This article has enough text to pass the minimum length check of the extractor used in this test.</p>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/tracked":
+			http.Redirect(w, r, "/story?utm_source=rss", http.StatusFound)
+		case "/story":
+			_, _ = w.Write([]byte(`<html><head><link rel="canonical" href="/news/story">` +
+				`<meta property="og:image" content="/img/lead.jpg"></head><body><article>` +
+				body + `</article></body></html>`))
+		default:
+			_, _ = w.Write([]byte(`<html><body><article>` + body + `</article></body></html>`))
+		}
+	}))
+	defer server.Close()
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/1.0")
+	extractor.SetOptions(50, false, false)
+
+	result, err := extractor.Extract(context.Background(), server.URL+"/tracked", domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/tracked", result.URL)
+	assert.Equal(t, server.URL+"/news/story", result.Canonical, "relative canonical resolved against the final URL")
+	assert.Equal(t, server.URL+"/img/lead.jpg", result.Image)
+
+	result, err = extractor.Extract(context.Background(), server.URL+"/plain", domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/plain", result.Canonical, "URL used without canonical")
+	assert.Empty(t, result.Image)
+}
+
 func TestHTTPExtractor_Extract_NonHTMLContent(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -579,10 +658,10 @@ func TestHTTPExtractor_Extract_NonHTMLContent(t *testing.T) {
 		expectError string
 	}{
 		{
-			name:        "PDF content",
+			name:        "broken PDF content",
 			contentType: "application/pdf",
 			statusCode:  http.StatusOK,
-			expectError: "unsupported content type: application/pdf",
+			expectError: "extract pdf: not a pdf document",
 		},
 		{
 			name:        "image content",
@@ -620,7 +699,7 @@ func TestHTTPExtractor_Extract_NonHTMLContent(t *testing.T) {
 			defer server.Close()
 
 			extractor := NewHTTPExtractor(5*time.Second, "TestBot/1.0")
-			_, err := extractor.Extract(context.Background(), server.URL)
+			_, err := extractor.Extract(context.Background(), server.URL, domain.FeedAuth{})
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectError)
@@ -0,0 +1,107 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// extractors recorded in ExtractResult.Extractor
+const (
+	ExtractorTrafilatura = "trafilatura" // built-in go-trafilatura
+	ExtractorFallback    = "fallback"    // remote extraction API set with SetFallbackURL
+)
+
+const fallbackMaxResponseSize = 10 * 1024 * 1024 // responses of the fallback API above this size are cut
+
+// FallbackRequest is the JSON body posted to the fallback extraction API. the page is fetched by the extractor,
+// with credentials and rate limits applied, so the service only extracts the article from the HTML.
+type FallbackRequest struct {
+	URL           string `json:"url"`  // page URL after redirects, relative links are resolved against it
+	HTML          string `json:"html"` // raw HTML of the page
+	IncludeImages bool   `json:"include_images"`
+	IncludeLinks  bool   `json:"include_links"`
+}
+
+// FallbackResponse is the JSON body the fallback extraction API responds with, status 200 on success.
+// only text is required, rich content is built from html if set.
+type FallbackResponse struct {
+	Text  string `json:"text"`            // article as plain text
+	HTML  string `json:"html,omitempty"`  // article with HTML formatting, simplified to the tags the extractor keeps
+	Title string `json:"title,omitempty"` // article title
+	URL   string `json:"url,omitempty"`   // canonical URL of the article
+	Image string `json:"image,omitempty"` // lead image URL
+	Date  string `json:"date,omitempty"`  // publication date, RFC 3339 or YYYY-MM-DD
+}
+
+// extractFallback posts the page to the fallback extraction API and returns its result,
+// pageURL is the page URL after redirects, urlStr the requested one
+func (e *HTTPExtractor) extractFallback(ctx context.Context, urlStr, pageURL string, page []byte) (*ExtractResult, error) {
+	body, err := json.Marshal(FallbackRequest{URL: pageURL, HTML: string(page), IncludeImages: e.includeImages,
+		IncludeLinks: e.includeLinks})
+	if err != nil {
+		return nil, fmt.Errorf("marshal fallback request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.fallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create fallback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", e.userAgent)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post to fallback: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fallback status: %d", resp.StatusCode)
+	}
+
+	var fr FallbackResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, fallbackMaxResponseSize)).Decode(&fr); err != nil {
+		return nil, fmt.Errorf("decode fallback response: %w", err)
+	}
+
+	text := strings.TrimSpace(fr.Text)
+	if text == "" {
+		return nil, fmt.Errorf("no content extracted by fallback")
+	}
+	if len(text) < e.minTextLength {
+		return nil, fmt.Errorf("fallback content too short: %d chars", len(text))
+	}
+
+	result := &ExtractResult{
+		Content:   text,
+		Title:     fr.Title,
+		URL:       urlStr,
+		Canonical: fr.URL,
+		Image:     fr.Image,
+		Extractor: ExtractorFallback,
+	}
+	if result.Canonical == "" {
+		result.Canonical = pageURL
+	}
+	if fr.HTML != "" {
+		// the service's formatting is reduced to the same tags as the built-in extraction
+		if node, err := html.Parse(strings.NewReader(fr.HTML)); err == nil {
+			result.RichContent = extractRichContent(node)
+		}
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, fr.Date); err == nil {
+			result.Date = date
+			break
+		}
+	}
+	return result, nil
+}
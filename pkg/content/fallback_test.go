@@ -0,0 +1,105 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestHTTPExtractor_Extract_Fallback(t *testing.T) {
+	const page = `<html><head><title>Short</title></head><body><p>Too short.</p></body></html>`
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(page))
+	}))
+	defer site.Close()
+
+	var received FallbackRequest
+	fallbackStatus := http.StatusOK
+	fallbackResp := FallbackResponse{
+		Text:  "The full article text extracted by the fallback service, long enough to pass the minimum length check.",
+		HTML:  `<div><h2>Heading</h2><p>The full article text.</p><script>alert(1)</script></div>`,
+		Title: "Article Title",
+		Image: "https://example.com/lead.png",
+		Date:  "2025-03-14",
+	}
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Newscope/2.0", r.Header.Get("User-Agent"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(fallbackStatus)
+		_ = json.NewEncoder(w).Encode(fallbackResp)
+	}))
+	defer fallback.Close()
+
+	t.Run("no fallback configured", func(t *testing.T) {
+		extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+		extractor.SetOptions(50, false, false)
+		_, err := extractor.Extract(context.Background(), site.URL+"/article", domain.FeedAuth{})
+		require.Error(t, err)
+	})
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+	extractor.SetOptions(50, true, false)
+	extractor.SetFallbackURL(fallback.URL)
+
+	t.Run("too little content", func(t *testing.T) {
+		result, err := extractor.Extract(context.Background(), site.URL+"/article", domain.FeedAuth{})
+		require.NoError(t, err)
+		assert.Equal(t, site.URL+"/article", received.URL)
+		assert.Equal(t, page, received.HTML)
+		assert.True(t, received.IncludeImages)
+		assert.False(t, received.IncludeLinks)
+
+		assert.Equal(t, ExtractorFallback, result.Extractor)
+		assert.Equal(t, fallbackResp.Text, result.Content)
+		assert.Equal(t, "Article Title", result.Title)
+		assert.Equal(t, site.URL+"/article", result.URL)
+		assert.Equal(t, site.URL+"/article", result.Canonical, "page URL without a canonical one")
+		assert.Equal(t, "https://example.com/lead.png", result.Image)
+		assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), result.Date)
+		assert.Contains(t, result.RichContent, "<h4>Heading </h4>", "headers downgraded as in the built-in extraction")
+		assert.NotContains(t, result.RichContent, "alert")
+	})
+
+	t.Run("fallback fails", func(t *testing.T) {
+		fallbackStatus = http.StatusBadGateway
+		defer func() { fallbackStatus = http.StatusOK }()
+		_, err := extractor.Extract(context.Background(), site.URL+"/article", domain.FeedAuth{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallback status: 502")
+	})
+
+	t.Run("fallback content too short", func(t *testing.T) {
+		text := fallbackResp.Text
+		fallbackResp.Text = "Short."
+		defer func() { fallbackResp.Text = text }()
+		_, err := extractor.Extract(context.Background(), site.URL+"/article", domain.FeedAuth{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallback content too short")
+	})
+
+	t.Run("enough content", func(t *testing.T) {
+		received = FallbackRequest{}
+		article := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><body><article><h1>Article</h1><p>This article has enough text ` +
+				`to pass the minimum length check of the extractor used in this test.</p></article></body></html>`))
+		}))
+		defer article.Close()
+
+		result, err := extractor.Extract(context.Background(), article.URL, domain.FeedAuth{})
+		require.NoError(t, err)
+		assert.Equal(t, ExtractorTrafilatura, result.Extractor)
+		assert.Empty(t, received.URL, "fallback not called")
+	})
+}
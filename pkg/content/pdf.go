@@ -0,0 +1,705 @@
+package content
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"mime"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExtractorPDF is recorded in ExtractResult.Extractor for text extracted from PDF documents
+const ExtractorPDF = "pdf"
+
+const (
+	pdfMaxSize      = 20 * 1024 * 1024 // PDFs above this size are not extracted
+	pdfMaxPages     = 50               // text is extracted from the first pages only
+	pdfMaxDecoded   = 64 * 1024 * 1024 // limit of decompressed stream data of a document, against zip bombs
+	pdfMaxFormDepth = 5                // nesting of form XObjects text is extracted from
+	pdfMaxRefDepth  = 32               // chain of references followed to an object
+)
+
+var pdfObjectRe = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// isPDF reports whether the response is a PDF document, by its content type or, for generic binary
+// content, by the extension of the URL
+func isPDF(contentType string, pageURL *url.URL) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/pdf", "application/x-pdf":
+		return true
+	case "application/octet-stream", "binary/octet-stream":
+		return strings.HasSuffix(strings.ToLower(pageURL.Path), ".pdf")
+	}
+	return false
+}
+
+// extractPDF extracts the text of a PDF document, up to pdfMaxPages pages.
+// paragraphs become <p> and lines set notably larger than the body text <h4>.
+// pageURL is the page URL after redirects, urlStr the requested one.
+func (e *HTTPExtractor) extractPDF(data []byte, pageURL *url.URL, urlStr string) (result *ExtractResult, err error) {
+	// the reader runs on untrusted documents, a bug in it fails the extraction instead of the worker
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("broken pdf: %v", r)
+		}
+	}()
+
+	doc, err := parsePDF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	paragraphs := doc.text()
+	title := doc.title()
+	var text, rich strings.Builder
+	bodySize := pdfBodySize(paragraphs)
+	for _, p := range paragraphs {
+		if text.Len() > 0 {
+			text.WriteString("\n\n")
+		}
+		text.WriteString(p.text)
+		tag := "p"
+		if p.size > bodySize*1.2 && len(p.text) < 200 {
+			tag = "h4"
+			if title == "" {
+				title = p.text // documents often have no title set, the first heading is used then
+			}
+		}
+		fmt.Fprintf(&rich, "<%s>%s</%s>", tag, html.EscapeString(p.text), tag)
+	}
+
+	if text.Len() == 0 {
+		return nil, fmt.Errorf("no text in pdf, scanned documents are not supported")
+	}
+	if text.Len() < e.minTextLength {
+		return nil, fmt.Errorf("pdf text too short: %d chars", text.Len())
+	}
+
+	return &ExtractResult{
+		Content:     text.String(),
+		RichContent: rich.String(),
+		Title:       title,
+		URL:         urlStr,
+		Canonical:   pageURL.String(),
+		Extractor:   ExtractorPDF,
+	}, nil
+}
+
+// pdfDoc is a parsed PDF document, objects are found by scanning the file rather than by the
+// cross-reference table, which also reads documents with a broken one. the reader covers only the text
+// layer of unencrypted documents: flate streams, object streams, simple fonts and composite fonts
+// with a ToUnicode CMap. anything else, e.g. other filters, yields no text or an error.
+type pdfDoc struct {
+	objects map[int]any // by object number, later definitions of incremental updates win
+	trailer pdfDict     // trailer and cross-reference stream dictionaries merged, later ones win
+	decoded int         // bytes decompressed so far
+	fonts   map[pdfRef]*pdfFont
+}
+
+// parsePDF reads objects of the document, including ones packed in object streams
+func parsePDF(data []byte) (*pdfDoc, error) {
+	if len(data) > pdfMaxSize {
+		return nil, fmt.Errorf("pdf too large: over %d MB", pdfMaxSize/(1024*1024))
+	}
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a pdf document")
+	}
+
+	doc := &pdfDoc{objects: map[int]any{}, trailer: pdfDict{}, fonts: map[pdfRef]*pdfFont{}}
+	l := &pdfLexer{data: data}
+	streamEnd := 0 // end of the last stream, matches in its data are not objects
+	for _, m := range pdfObjectRe.FindAllSubmatchIndex(data, -1) {
+		if m[0] < streamEnd || (m[0] > 0 && !isPDFDelim(data[m[0]-1])) {
+			continue // inside a stream or digits of a longer word
+		}
+		num, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		l.pos = m[1]
+		obj, err := l.object()
+		if err != nil {
+			continue
+		}
+		if dict, ok := obj.(pdfDict); ok {
+			if stream, ok := doc.readStream(l, dict); ok {
+				obj, streamEnd = stream, l.pos
+				if dict["Type"] == pdfName("XRef") {
+					doc.mergeTrailer(dict)
+				}
+			}
+		}
+		doc.objects[num] = obj
+	}
+
+	for i := 0; ; {
+		j := bytes.Index(data[i:], []byte("trailer"))
+		if j < 0 {
+			break
+		}
+		i += j + len("trailer")
+		l.pos = i
+		if obj, err := l.object(); err == nil {
+			if dict, ok := obj.(pdfDict); ok {
+				doc.mergeTrailer(dict)
+			}
+		}
+	}
+
+	if _, ok := doc.trailer["Encrypt"]; ok {
+		return nil, fmt.Errorf("encrypted pdf not supported")
+	}
+	doc.readObjectStreams()
+	return doc, nil
+}
+
+// mergeTrailer adds keys of a trailer dictionary, later ones replace earlier ones
+func (d *pdfDoc) mergeTrailer(dict pdfDict) {
+	for k, v := range dict {
+		d.trailer[k] = v
+	}
+}
+
+// readStream reads the data of a stream following its dictionary, false if the dictionary is not a stream
+func (d *pdfDoc) readStream(l *pdfLexer, dict pdfDict) (pdfStream, bool) {
+	save := l.pos
+	if tok, err := l.token(); err != nil || tok != pdfKeyword("stream") {
+		l.pos = save
+		return pdfStream{}, false
+	}
+	// the data starts after the end of line following the keyword
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+	start := l.pos
+
+	// the length is trusted if endstream follows it, otherwise endstream is searched for
+	if length, ok := pdfNumber(dict["Length"]); ok && length >= 0 && start+int(length) <= len(l.data) {
+		end := start + int(length)
+		rest := bytes.TrimLeft(l.data[end:min(end+32, len(l.data))], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			l.pos = end
+			return pdfStream{dict: dict, data: l.data[start:end]}, true
+		}
+	}
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end < 0 {
+		end = len(l.data) - start
+	}
+	l.pos = start + end
+	return pdfStream{dict: dict, data: bytes.TrimRight(l.data[start:start+end], "\r\n")}, true
+}
+
+// readObjectStreams adds objects packed in object streams, objects defined directly are kept
+func (d *pdfDoc) readObjectStreams() {
+	for _, obj := range d.objects {
+		stream, ok := obj.(pdfStream)
+		if !ok || stream.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := d.decode(stream)
+		if err != nil {
+			continue
+		}
+		n, _ := pdfNumber(d.resolve(stream.dict["N"]))
+		first, _ := pdfNumber(d.resolve(stream.dict["First"]))
+
+		// the header holds pairs of object number and offset relative to First
+		l := &pdfLexer{data: data}
+		type entry struct{ num, offset int }
+		entries := make([]entry, 0, int(min(n, 10000)))
+		for i := 0; i < int(n) && i < 10000; i++ {
+			num, err1 := l.token()
+			offset, err2 := l.token()
+			numV, ok1 := num.(float64)
+			offV, ok2 := offset.(float64)
+			if err1 != nil || err2 != nil || !ok1 || !ok2 {
+				break
+			}
+			entries = append(entries, entry{num: int(numV), offset: int(offV)})
+		}
+		for _, e := range entries {
+			if _, ok := d.objects[e.num]; ok {
+				continue
+			}
+			if l.pos = int(first) + e.offset; l.pos < 0 || l.pos >= len(data) {
+				continue
+			}
+			if obj, err := l.object(); err == nil {
+				d.objects[e.num] = obj
+			}
+		}
+	}
+}
+
+// resolve follows references to the object they point to, nil for missing objects
+func (d *pdfDoc) resolve(obj any) any {
+	for i := 0; i < pdfMaxRefDepth; i++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+		obj = d.objects[ref.num]
+	}
+	return nil
+}
+
+// decode returns the decoded data of a stream. only FlateDecode is supported, as used for
+// content streams and object streams, other filters return an error.
+func (d *pdfDoc) decode(s pdfStream) ([]byte, error) {
+	var filters []any
+	switch f := d.resolve(s.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{f}
+	case pdfArray:
+		filters = f
+	}
+
+	data := s.data
+	for _, f := range filters {
+		if name := d.resolve(f); name != pdfName("FlateDecode") && name != pdfName("Fl") {
+			return nil, fmt.Errorf("unsupported filter %v", name)
+		}
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("flate stream: %w", err)
+		}
+		// broken streams are used up to the error, as other readers do
+		out, err := io.ReadAll(io.LimitReader(r, int64(pdfMaxDecoded-d.decoded+1)))
+		if err != nil && len(out) == 0 {
+			return nil, fmt.Errorf("flate stream: %w", err)
+		}
+		if d.decoded += len(out); d.decoded > pdfMaxDecoded {
+			return nil, fmt.Errorf("decompressed pdf data over %d MB", pdfMaxDecoded/(1024*1024))
+		}
+		data = out
+	}
+	return data, nil
+}
+
+// title returns the title of the document information dictionary, empty if not set
+func (d *pdfDoc) title() string {
+	info, _ := d.resolve(d.trailer["Info"]).(pdfDict)
+	s, _ := d.resolve(info["Title"]).(pdfString)
+	return strings.Join(strings.Fields(pdfTextString(s)), " ")
+}
+
+// pdfPage is a page dictionary with the resources it inherits
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages returns up to pdfMaxPages pages in document order from the page tree
+func (d *pdfDoc) pages() []pdfPage {
+	var pages []pdfPage
+	visited := map[pdfRef]bool{}
+	var walk func(node any, resources pdfDict, depth int)
+	walk = func(node any, resources pdfDict, depth int) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict, ok := d.resolve(node).(pdfDict)
+		if !ok || depth > pdfMaxNesting || len(pages) >= pdfMaxPages {
+			return
+		}
+		if res, ok := d.resolve(dict["Resources"]).(pdfDict); ok {
+			resources = res
+		}
+		kids, ok := d.resolve(dict["Kids"]).(pdfArray)
+		if !ok {
+			pages = append(pages, pdfPage{dict: dict, resources: resources})
+			return
+		}
+		for _, kid := range kids {
+			walk(kid, resources, depth+1)
+		}
+	}
+	if root, ok := d.resolve(d.trailer["Root"]).(pdfDict); ok {
+		walk(root["Pages"], nil, 0)
+	}
+	return pages
+}
+
+// text extracts paragraphs of all pages, a page always ends a paragraph
+func (d *pdfDoc) text() []pdfParagraph {
+	w := &pdfTextWriter{}
+	for _, page := range d.pages() {
+		var content []byte
+		contents := d.resolve(page.dict["Contents"])
+		if stream, ok := contents.(pdfStream); ok {
+			contents = pdfArray{stream}
+		}
+		arr, _ := contents.(pdfArray)
+		for _, item := range arr {
+			stream, ok := d.resolve(item).(pdfStream)
+			if !ok {
+				continue
+			}
+			data, err := d.decode(stream)
+			if err != nil {
+				continue
+			}
+			content = append(content, data...)
+			content = append(content, '\n') // streams of a page may split anywhere between tokens
+		}
+		p := &pdfInterpreter{doc: d, w: w, ctm: pdfIdentity}
+		p.run(content, page.resources, 0)
+		w.endParagraph()
+	}
+	return w.paragraphs
+}
+
+// font returns the font of the resources by name, nil if missing
+func (d *pdfDoc) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts, _ := d.resolve(resources["Font"]).(pdfDict)
+	ref, isRef := fonts[string(name)].(pdfRef)
+	if f, ok := d.fonts[ref]; isRef && ok {
+		return f
+	}
+	dict, ok := d.resolve(fonts[string(name)]).(pdfDict)
+	if !ok {
+		return nil
+	}
+	f := newPDFFont(d, dict)
+	if isRef {
+		d.fonts[ref] = f
+	}
+	return f
+}
+
+// pdfMatrix is a transformation matrix [a b c d e f]
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n, i.e. m applied first
+func (m pdfMatrix) mul(n pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		m[0]*n[0] + m[1]*n[2], m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2], m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4], m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// pdfTextState is the text state of the graphics state
+type pdfTextState struct {
+	font        *pdfFont
+	size        float64
+	charSpacing float64
+	wordSpacing float64
+	scale       float64 // horizontal scaling, 1 for 100%
+	leading     float64
+}
+
+// pdfInterpreter runs a content stream and writes the text it shows
+type pdfInterpreter struct {
+	doc   *pdfDoc
+	w     *pdfTextWriter
+	ctm   pdfMatrix
+	state pdfTextState
+	tm    pdfMatrix // text matrix
+	tlm   pdfMatrix // text line matrix
+	stack []pdfGraphicsState
+}
+
+// pdfGraphicsState is saved by q and restored by Q
+type pdfGraphicsState struct {
+	ctm   pdfMatrix
+	state pdfTextState
+}
+
+// run interprets the content with the resources, form XObjects are run nested up to pdfMaxFormDepth
+func (p *pdfInterpreter) run(content []byte, resources pdfDict, depth int) {
+	if p.state.scale == 0 {
+		p.state.scale = 1
+	}
+	l := &pdfLexer{data: content}
+	var operands []any
+	for {
+		obj, err := l.object()
+		if err != nil {
+			return
+		}
+		op, ok := obj.(pdfKeyword)
+		if !ok || op == "]" || op == ">>" {
+			operands = append(operands, obj)
+			continue
+		}
+		p.do(op, operands, resources, depth)
+		if op == "ID" {
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+// do runs the operator with its operands
+func (p *pdfInterpreter) do(op pdfKeyword, operands []any, resources pdfDict, depth int) {
+	num := func(i int) float64 {
+		if i >= len(operands) {
+			return 0
+		}
+		v, _ := pdfNumber(operands[i])
+		return v
+	}
+	matrix := func() pdfMatrix {
+		return pdfMatrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+	}
+	last := func() any {
+		if len(operands) == 0 {
+			return nil
+		}
+		return operands[len(operands)-1]
+	}
+
+	switch op {
+	case "q":
+		p.stack = append(p.stack, pdfGraphicsState{ctm: p.ctm, state: p.state})
+	case "Q":
+		if n := len(p.stack); n > 0 {
+			p.ctm, p.state = p.stack[n-1].ctm, p.stack[n-1].state
+			p.stack = p.stack[:n-1]
+		}
+	case "cm":
+		p.ctm = matrix().mul(p.ctm)
+	case "BT":
+		p.tm, p.tlm = pdfIdentity, pdfIdentity
+	case "Tf":
+		if len(operands) < 2 {
+			return
+		}
+		if name, ok := operands[0].(pdfName); ok {
+			p.state.font = p.doc.font(resources, name)
+		}
+		p.state.size = num(1)
+	case "Tc":
+		p.state.charSpacing = num(0)
+	case "Tw":
+		p.state.wordSpacing = num(0)
+	case "Tz":
+		p.state.scale = num(0) / 100
+	case "TL":
+		p.state.leading = num(0)
+	case "Td":
+		p.moveLine(num(0), num(1))
+	case "TD":
+		p.state.leading = -num(1)
+		p.moveLine(num(0), num(1))
+	case "Tm":
+		p.tm, p.tlm = matrix(), matrix()
+	case "T*":
+		p.moveLine(0, -p.state.leading)
+	case "Tj":
+		p.show(last())
+	case "'":
+		p.moveLine(0, -p.state.leading)
+		p.show(last())
+	case "\"":
+		p.state.wordSpacing, p.state.charSpacing = num(0), num(1)
+		p.moveLine(0, -p.state.leading)
+		p.show(last())
+	case "TJ":
+		arr, _ := last().(pdfArray)
+		for _, item := range arr {
+			if adjust, ok := pdfNumber(item); ok {
+				p.advance(-adjust / pdfWidthsDivisor * p.state.size * p.state.scale)
+				continue
+			}
+			p.show(item)
+		}
+	case "Do":
+		name, _ := last().(pdfName)
+		p.form(name, resources, depth)
+	}
+}
+
+// moveLine starts a new line offset from the start of the current one
+func (p *pdfInterpreter) moveLine(tx, ty float64) {
+	p.tlm = pdfMatrix{1, 0, 0, 1, tx, ty}.mul(p.tlm)
+	p.tm = p.tlm
+}
+
+// advance moves the text position along the line by tx in text space
+func (p *pdfInterpreter) advance(tx float64) {
+	p.tm = pdfMatrix{1, 0, 0, 1, tx, 0}.mul(p.tm)
+}
+
+// show writes the text of a shown string at the current position and advances past it
+func (p *pdfInterpreter) show(s any) {
+	str, ok := s.(pdfString)
+	if !ok || p.state.font == nil {
+		return
+	}
+	trm := p.tm.mul(p.ctm)
+	size := math.Abs(p.state.size) * math.Hypot(trm[2], trm[3])
+
+	var text strings.Builder
+	for _, g := range p.state.font.glyphs(str) {
+		text.WriteString(g.text)
+		tx := g.width/pdfWidthsDivisor*p.state.size + p.state.charSpacing
+		if g.space {
+			tx += p.state.wordSpacing
+		}
+		p.advance(tx * p.state.scale)
+	}
+	end := p.tm.mul(p.ctm)
+	p.w.write(text.String(), trm[4], trm[5], end[4], size)
+}
+
+// form runs the form XObject of the resources by name with its own resources and matrix
+func (p *pdfInterpreter) form(name pdfName, resources pdfDict, depth int) {
+	if depth >= pdfMaxFormDepth {
+		return
+	}
+	xobjects, _ := p.doc.resolve(resources["XObject"]).(pdfDict)
+	stream, ok := p.doc.resolve(xobjects[string(name)]).(pdfStream)
+	if !ok || p.doc.resolve(stream.dict["Subtype"]) != pdfName("Form") {
+		return
+	}
+	data, err := p.doc.decode(stream)
+	if err != nil {
+		return
+	}
+	if res, ok := p.doc.resolve(stream.dict["Resources"]).(pdfDict); ok {
+		resources = res
+	}
+
+	saved := pdfGraphicsState{ctm: p.ctm, state: p.state}
+	savedTM, savedTLM := p.tm, p.tlm
+	if m, ok := p.doc.resolve(stream.dict["Matrix"]).(pdfArray); ok && len(m) == 6 {
+		var fm pdfMatrix
+		for i := range fm {
+			fm[i], _ = pdfNumber(p.doc.resolve(m[i]))
+		}
+		p.ctm = fm.mul(p.ctm)
+	}
+	p.run(data, resources, depth+1)
+	p.ctm, p.state, p.tm, p.tlm = saved.ctm, saved.state, savedTM, savedTLM
+}
+
+// pdfParagraph is a paragraph of extracted text with the font size of its first line
+type pdfParagraph struct {
+	text string
+	size float64
+}
+
+// pdfTextWriter joins shown strings to lines and lines to paragraphs by their positions on the page
+type pdfTextWriter struct {
+	paragraphs []pdfParagraph
+	line       strings.Builder // current line
+	para       strings.Builder // lines of the current paragraph before the current one
+	paraSize   float64
+	started    bool
+	lastY      float64
+	lastEndX   float64
+	lastSize   float64
+}
+
+// write adds the text shown from x to endX at the baseline y with the font size, all in device space
+func (w *pdfTextWriter) write(text string, x, y, endX, size float64) {
+	text = strings.Map(func(r rune) rune {
+		if r == unicode.ReplacementChar || (unicode.IsControl(r) && r != '\t') {
+			return -1
+		}
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	if strings.TrimSpace(text) == "" && !w.started {
+		return
+	}
+
+	if w.started {
+		gap := math.Abs(y - w.lastY)
+		lineHeight := math.Max(size, w.lastSize)
+		switch {
+		case gap > lineHeight*1.6, gap > size*0.5 && math.Abs(size-w.lastSize) > w.lastSize*0.2:
+			// wide spacing or a change of the font size on a new line, e.g. a heading
+			w.endParagraph()
+		case gap > lineHeight*0.5 || x < w.lastEndX-lineHeight*4:
+			w.endLine()
+		case x > w.lastEndX+size*0.15:
+			w.line.WriteByte(' ') // word gap made by positioning instead of a space
+		}
+	}
+	if !w.started {
+		w.paraSize = size
+	}
+	w.line.WriteString(text)
+	w.started, w.lastY, w.lastEndX, w.lastSize = true, y, endX, size
+}
+
+// endLine adds the current line to the paragraph. a word broken with a hyphen at the line end is joined,
+// without the hyphen if the next line goes on in lowercase, e.g. "experi-" and "ments", and with it
+// otherwise, e.g. "pre-" and "COVID".
+func (w *pdfTextWriter) endLine() {
+	line := strings.Join(strings.Fields(w.line.String()), " ")
+	w.line.Reset()
+	if line == "" {
+		return
+	}
+	prev := w.para.String()
+	before, _ := utf8.DecodeLastRuneInString(strings.TrimSuffix(prev, "-"))
+	hyphenated := strings.HasSuffix(prev, "-") && unicode.IsLetter(before)
+	switch {
+	case prev == "":
+	case hyphenated && unicode.IsLower([]rune(line)[0]):
+		w.para.Reset()
+		w.para.WriteString(strings.TrimSuffix(prev, "-"))
+	case hyphenated:
+	default:
+		w.para.WriteByte(' ')
+	}
+	w.para.WriteString(line)
+}
+
+// endParagraph ends the current paragraph, the next text starts a new one
+func (w *pdfTextWriter) endParagraph() {
+	w.endLine()
+	if text := w.para.String(); text != "" {
+		w.paragraphs = append(w.paragraphs, pdfParagraph{text: text, size: w.paraSize})
+	}
+	w.para.Reset()
+	w.started = false
+}
+
+// pdfBodySize returns the font size most of the text is set in
+func pdfBodySize(paragraphs []pdfParagraph) float64 {
+	chars := map[float64]int{}
+	for _, p := range paragraphs {
+		chars[math.Round(p.size*10)/10] += len(p.text)
+	}
+	sizes := make([]float64, 0, len(chars))
+	for size := range chars {
+		sizes = append(sizes, size)
+	}
+	slices.Sort(sizes)
+	body := 0.0
+	for _, size := range sizes {
+		if chars[size] > chars[body] {
+			body = size
+		}
+	}
+	return body
+}
@@ -0,0 +1,302 @@
+package content
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	pdfDefaultWidth  = 500   // glyph width assumed for fonts without widths, e.g. the standard 14 fonts
+	pdfMaxCMapRange  = 65536 // codes of a CMap range above this count are ignored
+	pdfSpaceCode     = 32    // single-byte code the word spacing applies to
+	pdfWidthsDivisor = 1000  // glyph widths are in thousandths of the text space unit
+)
+
+// pdfFont decodes character codes of strings shown with a font to text and gives their widths
+type pdfFont struct {
+	codeLen      int               // bytes per character code, 2 for composite fonts
+	toUnicode    map[uint32]string // from the ToUnicode CMap, preferred if set
+	encoding     [256]string       // text of single-byte codes, from the base encoding and its differences
+	widths       map[uint32]float64
+	defaultWidth float64
+}
+
+// newPDFFont reads the font dictionary, unknown or broken parts fall back to
+// the WinAnsi encoding and the default width
+func newPDFFont(doc *pdfDoc, dict pdfDict) *pdfFont {
+	f := &pdfFont{codeLen: 1, widths: map[uint32]float64{}, defaultWidth: pdfDefaultWidth}
+
+	composite := doc.resolve(dict["Subtype"]) == pdfName("Type0")
+	if composite {
+		f.codeLen = 2
+		f.defaultWidth = pdfWidthsDivisor
+		if descendants, ok := doc.resolve(dict["DescendantFonts"]).(pdfArray); ok && len(descendants) > 0 {
+			if cid, ok := doc.resolve(descendants[0]).(pdfDict); ok {
+				f.readCIDWidths(doc, cid)
+			}
+		}
+	} else {
+		f.readEncoding(doc, doc.resolve(dict["Encoding"]))
+		f.readWidths(doc, dict)
+	}
+
+	if stream, ok := doc.resolve(dict["ToUnicode"]).(pdfStream); ok {
+		if data, err := doc.decode(stream); err == nil {
+			f.toUnicode, f.codeLen = parseToUnicode(data, f.codeLen)
+		}
+	}
+	return f
+}
+
+// readEncoding sets the text of single-byte codes from the WinAnsi encoding and the differences of
+// an encoding dictionary, e.g. << /BaseEncoding /WinAnsiEncoding /Differences [32 /space 128 /Euro] >>
+func (f *pdfFont) readEncoding(doc *pdfDoc, enc any) {
+	var differences pdfArray
+	if dict, ok := enc.(pdfDict); ok {
+		differences, _ = doc.resolve(dict["Differences"]).(pdfArray)
+	}
+
+	for code := range f.encoding {
+		if code >= 32 {
+			f.encoding[code] = string(charmap.Windows1252.DecodeByte(byte(code)))
+		}
+	}
+
+	code := 0
+	for _, item := range differences {
+		switch v := doc.resolve(item).(type) {
+		case float64:
+			code = int(v)
+		case pdfName:
+			if code >= 0 && code < len(f.encoding) {
+				if text, ok := glyphText(string(v)); ok {
+					f.encoding[code] = text
+				}
+			}
+			code++
+		}
+	}
+}
+
+// readWidths reads widths of a simple font, Widths array starting at FirstChar
+func (f *pdfFont) readWidths(doc *pdfDoc, dict pdfDict) {
+	first, _ := pdfNumber(doc.resolve(dict["FirstChar"]))
+	widths, _ := doc.resolve(dict["Widths"]).(pdfArray)
+	for i, w := range widths {
+		if w, ok := pdfNumber(doc.resolve(w)); ok {
+			f.widths[uint32(int(first)+i)] = w //nolint:gosec // codes are bytes
+		}
+	}
+}
+
+// readCIDWidths reads widths of a composite font's descendant, DW and the W array
+// of "first [w1 w2 ...]" and "first last w" entries
+func (f *pdfFont) readCIDWidths(doc *pdfDoc, cid pdfDict) {
+	if dw, ok := pdfNumber(doc.resolve(cid["DW"])); ok {
+		f.defaultWidth = dw
+	}
+	w, _ := doc.resolve(cid["W"]).(pdfArray)
+	for i := 0; i+1 < len(w); {
+		first, ok := pdfNumber(doc.resolve(w[i]))
+		if !ok {
+			return
+		}
+		if list, ok := doc.resolve(w[i+1]).(pdfArray); ok {
+			for j, width := range list {
+				if width, ok := pdfNumber(doc.resolve(width)); ok {
+					f.widths[uint32(int(first)+j)] = width //nolint:gosec // CIDs are positive
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last, _ := pdfNumber(doc.resolve(w[i+1]))
+		width, _ := pdfNumber(doc.resolve(w[i+2]))
+		for c := first; c <= last && c-first < pdfMaxCMapRange; c++ {
+			f.widths[uint32(c)] = width
+		}
+		i += 3
+	}
+}
+
+// pdfGlyph is a character code of a shown string with its text
+type pdfGlyph struct {
+	code  uint32
+	text  string
+	width float64 // in thousandths of the text space unit
+	space bool    // single-byte code 32, the word spacing applies
+}
+
+// glyphs splits the shown string into character codes and decodes them
+func (f *pdfFont) glyphs(s pdfString) []pdfGlyph {
+	result := make([]pdfGlyph, 0, len(s)/f.codeLen)
+	for i := 0; i+f.codeLen <= len(s); i += f.codeLen {
+		var code uint32
+		for j := 0; j < f.codeLen; j++ {
+			code = code<<8 | uint32(s[i+j])
+		}
+		g := pdfGlyph{code: code, width: f.defaultWidth, space: f.codeLen == 1 && code == pdfSpaceCode}
+		if w, ok := f.widths[code]; ok {
+			g.width = w
+		}
+		switch text, ok := f.toUnicode[code]; {
+		case ok:
+			g.text = text
+		case f.codeLen == 1:
+			g.text = f.encoding[code]
+		}
+		result = append(result, g)
+	}
+	return result
+}
+
+// parseToUnicode reads the bfchar and bfrange mappings of a ToUnicode CMap, the code length
+// is taken from the codespace range and stays codeLen if the CMap has none
+func parseToUnicode(data []byte, codeLen int) (map[uint32]string, int) {
+	result := map[uint32]string{}
+	l := &pdfLexer{data: data}
+	var operands []any
+	for {
+		obj, err := l.object()
+		if err != nil {
+			return result, codeLen
+		}
+		kw, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch kw {
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if lo, ok := operands[0].(pdfString); ok && len(lo) > 0 && len(lo) <= 4 {
+					codeLen = len(lo)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfString)
+				dst, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 {
+					result[pdfCode(src)] = utf16Text(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(pdfString)
+				hi, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 || pdfCode(hi) < pdfCode(lo) || pdfCode(hi)-pdfCode(lo) >= pdfMaxCMapRange {
+					continue
+				}
+				start, end := pdfCode(lo), pdfCode(hi)
+				switch dst := operands[i+2].(type) {
+				case pdfString:
+					// consecutive codes map to consecutive texts, the last character is incremented
+					base := []rune(utf16Text(dst))
+					if len(base) == 0 {
+						continue
+					}
+					for c := start; c <= end; c++ {
+						text := append([]rune{}, base...)
+						text[len(text)-1] += rune(c - start) //nolint:gosec // range is limited above
+						result[c] = string(text)
+					}
+				case pdfArray:
+					for j, item := range dst {
+						if s, ok := item.(pdfString); ok && start+uint32(j) <= end { //nolint:gosec // array of the range
+							result[start+uint32(j)] = utf16Text(s) //nolint:gosec // array of the range
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// pdfCode returns the big-endian value of a character code
+func pdfCode(s pdfString) uint32 {
+	var code uint32
+	for i := 0; i < len(s) && i < 4; i++ {
+		code = code<<8 | uint32(s[i])
+	}
+	return code
+}
+
+// utf16Text decodes a UTF-16BE string of a CMap
+func utf16Text(s pdfString) string {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// pdfTextString decodes a text string of the document, e.g. the title,
+// UTF-16BE with a byte order mark or PDFDocEncoding, close to Latin-1
+func pdfTextString(s pdfString) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		return utf16Text(s[2:])
+	}
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+// glyphNames maps glyph names of encoding differences other than single letters and digits to text
+var glyphNames = map[string]string{
+	"space": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#", "dollar": "$", "percent": "%",
+	"ampersand": "&", "quotesingle": "'", "quoteright": "’", "quoteleft": "‘", "parenleft": "(",
+	"parenright": ")", "asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "period": ".", "slash": "/",
+	"colon": ":", "semicolon": ";", "less": "<", "equal": "=", "greater": ">", "question": "?", "at": "@",
+	"bracketleft": "[", "backslash": "\\", "bracketright": "]", "asciicircum": "^", "underscore": "_",
+	"grave": "`", "braceleft": "{", "bar": "|", "braceright": "}", "asciitilde": "~",
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4", "five": "5", "six": "6", "seven": "7",
+	"eight": "8", "nine": "9", "quotedblleft": "“", "quotedblright": "”", "quotesinglbase": "‚",
+	"quotedblbase": "„", "endash": "–", "emdash": "—", "bullet": "•", "ellipsis": "…",
+	"fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl", "dagger": "†", "daggerdbl": "‡",
+	"copyright": "©", "registered": "®", "trademark": "™", "degree": "°",
+	"section": "§", "paragraph": "¶", "minus": "−", "multiply": "×", "divide": "÷",
+	"periodcentered": "·", "guillemotleft": "«", "guillemotright": "»", "sterling": "£",
+	"Euro": "€", "yen": "¥", "cent": "¢", "nbspace": " ", "dotlessi": "ı",
+	"germandbls": "ß", "eacute": "é", "egrave": "è", "ecircumflex": "ê",
+	"aacute": "á", "agrave": "à", "acircumflex": "â", "adieresis": "ä",
+	"odieresis": "ö", "udieresis": "ü", "Adieresis": "Ä", "Odieresis": "Ö",
+	"Udieresis": "Ü", "ccedilla": "ç", "ntilde": "ñ", "oacute": "ó", "uacute": "ú",
+	"iacute": "í", "Eacute": "É",
+}
+
+// glyphText returns the text of a glyph name: single letters, names of the table,
+// and uniXXXX or uXXXX[XX] code points. false for unknown names, e.g. g42 of subset fonts.
+func glyphText(name string) (string, bool) {
+	name, _, _ = strings.Cut(name, ".") // variants, e.g. a.sc
+	if len(name) == 1 {
+		return name, true
+	}
+	if text, ok := glyphNames[name]; ok {
+		return text, true
+	}
+	hex := ""
+	switch {
+	case strings.HasPrefix(name, "uni") && len(name) == 7:
+		hex = name[3:]
+	case strings.HasPrefix(name, "u") && len(name) >= 5 && len(name) <= 7:
+		hex = name[1:]
+	default:
+		return "", false
+	}
+	r, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", false
+	}
+	return string(rune(r)), true //nolint:gosec // parsed as 32 bits
+}
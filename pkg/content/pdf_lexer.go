@@ -0,0 +1,310 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// objects of a PDF file, numbers are float64, booleans bool and null nil
+type (
+	pdfName    string         // name object, e.g. /Type, stored without the slash
+	pdfString  string         // literal or hex string, raw bytes
+	pdfKeyword string         // bare word, an operator in content streams
+	pdfArray   []any          // array object
+	pdfDict    map[string]any // dictionary object, keyed by name without the slash
+)
+
+// pdfRef is an indirect reference, e.g. "12 0 R"
+type pdfRef struct {
+	num, gen int
+}
+
+// pdfStream is a stream object with its raw, still encoded data
+type pdfStream struct {
+	dict pdfDict
+	data []byte
+}
+
+// errPDFEnd is returned by the lexer at the end of the data
+var errPDFEnd = errors.New("end of data")
+
+const pdfMaxNesting = 64 // depth of nested arrays and dictionaries above which parsing fails
+
+// pdfLexer reads objects of PDF syntax, used for the file, content streams and CMaps
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+// isPDFSpace reports whether the byte is PDF whitespace
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+// isPDFDelim reports whether the byte ends a name, number or keyword
+func isPDFDelim(c byte) bool {
+	return isPDFSpace(c) || bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+// skipSpace moves past whitespace and comments
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// object reads the next object, references "N G R" are combined into pdfRef.
+// keywords are returned as pdfKeyword, closing delimiters as pdfKeyword "]" and ">>".
+func (l *pdfLexer) object() (any, error) {
+	return l.objectDepth(0)
+}
+
+func (l *pdfLexer) objectDepth(depth int) (any, error) {
+	if depth > pdfMaxNesting {
+		return nil, fmt.Errorf("objects nested too deep at %d", l.pos)
+	}
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case pdfKeyword("["):
+		arr := pdfArray{}
+		for {
+			obj, err := l.objectDepth(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if obj == pdfKeyword("]") {
+				return arr, nil
+			}
+			arr = append(arr, obj)
+		}
+	case pdfKeyword("<<"):
+		dict := pdfDict{}
+		for {
+			key, err := l.objectDepth(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if key == pdfKeyword(">>") {
+				return dict, nil
+			}
+			name, ok := key.(pdfName)
+			if !ok {
+				continue // broken dictionary, skip to the next name
+			}
+			value, err := l.objectDepth(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if value == pdfKeyword(">>") {
+				return dict, nil
+			}
+			dict[string(name)] = value
+		}
+	}
+
+	// a number may start a reference "N G R"
+	num, ok := tok.(float64)
+	if !ok || num != float64(int(num)) || num < 0 {
+		return tok, nil
+	}
+	save := l.pos
+	if gen, err := l.token(); err == nil {
+		if g, ok := gen.(float64); ok && g == float64(int(g)) {
+			if r, err := l.token(); err == nil && r == pdfKeyword("R") {
+				return pdfRef{num: int(num), gen: int(g)}, nil
+			}
+		}
+	}
+	l.pos = save
+	return tok, nil
+}
+
+// token reads the next token: a number, name, string, keyword or delimiter
+func (l *pdfLexer) token() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errPDFEnd
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		l.pos++
+		return pdfName(l.name()), nil
+	case c == '(':
+		l.pos++
+		return l.literalString(), nil
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return pdfKeyword("<<"), nil
+	case c == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+		l.pos += 2
+		return pdfKeyword(">>"), nil
+	case c == '<':
+		l.pos++
+		return l.hexString(), nil
+	case c == '[' || c == ']' || c == '{' || c == '}' || c == ')' || c == '>':
+		l.pos++
+		return pdfKeyword(c), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		if num, err := strconv.ParseFloat(word, 64); err == nil {
+			return num, nil
+		}
+	}
+	return pdfKeyword(word), nil
+}
+
+// name reads a name after the slash, #xx escapes are decoded
+func (l *pdfLexer) name() string {
+	var buf []byte
+	for l.pos < len(l.data) && !isPDFDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				buf = append(buf, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		buf = append(buf, c)
+		l.pos++
+	}
+	return string(buf)
+}
+
+// literalString reads a string after the opening parenthesis, with balanced parentheses and escapes
+func (l *pdfLexer) literalString() pdfString {
+	var buf []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return pdfString(buf)
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return pdfString(buf)
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue // line continuation
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v) //nolint:gosec // octal escapes above 255 wrap as in other readers
+				}
+			}
+		}
+		buf = append(buf, c)
+	}
+	return pdfString(buf)
+}
+
+// hexString reads a string after the opening angle bracket, an odd digit count is padded with zero
+func (l *pdfLexer) hexString() pdfString {
+	var buf []byte
+	var hi byte
+	odd := false
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		var v byte
+		switch {
+		case c == '>':
+			if odd {
+				buf = append(buf, hi<<4)
+			}
+			return pdfString(buf)
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		case c >= 'a' && c <= 'f':
+			v = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			continue // whitespace and garbage
+		}
+		if odd {
+			buf = append(buf, hi<<4|v)
+		} else {
+			hi = v
+		}
+		odd = !odd
+	}
+	return pdfString(buf)
+}
+
+// skipInlineImage moves past the data of an inline image, right after its ID operator,
+// to the EI operator ending it
+func (l *pdfLexer) skipInlineImage() {
+	for i := l.pos + 1; i+1 < len(l.data); i++ {
+		if l.data[i] == 'E' && l.data[i+1] == 'I' && isPDFSpace(l.data[i-1]) &&
+			(i+2 == len(l.data) || isPDFDelim(l.data[i+2])) {
+			l.pos = i + 2
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+// pdfNumber returns the number of an object, false if it is not a number
+func pdfNumber(obj any) (float64, bool) {
+	num, ok := obj.(float64)
+	return num, ok
+}
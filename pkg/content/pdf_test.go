@@ -0,0 +1,291 @@
+package content
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// testToUnicode maps two-byte codes of the composite test font to ASCII
+const testToUnicode = `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+2 beginbfchar
+<0020> <0020>
+<0001> <00660069>
+endbfchar
+2 beginbfrange
+<0041> <005A> <0041>
+<0061> <007A> [<0061> <0062> <0063> <0064> <0065> <0066> <0067> <0068> <0069> <006A> <006B> <006C> <006D> <006E> <006F>
+<0070> <0071> <0072> <0073> <0074> <0075> <0076> <0077> <0078> <0079> <007A>]
+endbfrange
+endcmap
+end end`
+
+// flateStream makes a stream object with the data compressed with flate
+func flateStream(t testing.TB, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", buf.Len(), buf.String())
+}
+
+// buildPDF makes a document of pages with the content streams, compressed with flate. the fonts F1
+// (Helvetica) and F2 (composite with the testToUnicode CMap) and the form Fm1 are inherited from
+// the page tree. trailer holds extra keys of the trailer dictionary.
+func buildPDF(t testing.TB, trailer string, pages ...string) []byte {
+	t.Helper()
+	flate := func(s string) string { return flateStream(t, s) }
+
+	const firstPage = 9
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /Resources << /Font << /F1 3 0 R /F2 4 0 R >> "+
+			"/XObject << /Fm1 8 0 R >> >> >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding " +
+			"<< /BaseEncoding /WinAnsiEncoding /Differences [1 /fi /uni00E9] >> >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /Test /Encoding /Identity-H /ToUnicode 5 0 R /DescendantFonts [6 0 R] >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(testToUnicode), testToUnicode),
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Test /DW 500 /W [32 [250]] >>",
+		"<< /Title (Research \\(Draft\\)) >>",
+		strings.Replace(flate("BT /F1 8 Tf 72 40 Td (Text of the form footer.) Tj ET"), "<<",
+			"<< /Type /XObject /Subtype /Form /BBox [0 0 612 792] /Matrix [1 0 0 1 0 0]", 1),
+	}
+	for i, content := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents %d 0 R >>", firstPage+2*i+1),
+			flate(content))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 7 0 R %s >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, trailer, xref)
+	return buf.Bytes()
+}
+
+// testPDFPages are the pages of the main test document: text of a simple font with kerning, hyphenated lines
+// and paragraphs on the first page, a composite font and a form XObject on the second one
+var testPDFPages = []string{`BT /F1 18 Tf 72 720 Td (Findings of the Study) Tj ET
+BT /F1 10 Tf 12 TL 72 690 Td [(The)-250(quick brown fox jumps over the lazy dog in our experi-)] TJ
+T* [(ments, w)20(ith \001ve caf\002s back to pre-)] TJ
+T* (COVID levels.) Tj
+0 -30 Td (Second paragraph <b> after a wider gap.) Tj ET`,
+	`q 1 0 0 1 0 0 cm BT /F2 10 Tf 72 720 Td <0048006500790020000100720073007400200070006100670065> Tj
+14 TL <0054007700690063006500200074006800650020006E006F0072006D0061006C> ' ET Q
+/Fm1 Do`}
+
+// objectStreamPDF makes a document with the page tree and the font packed in an object stream,
+// without a cross-reference table
+func objectStreamPDF(t testing.TB) []byte {
+	t.Helper()
+	packed := []string{"<< /Type /Pages /Kids [5 0 R] /Count 1 /Resources << /Font << /F1 3 0 R >> >> >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"}
+	header := fmt.Sprintf("2 0 3 %d ", len(packed[0])+1)
+	objStm := strings.Replace(flateStream(t, header+packed[0]+"\n"+packed[1]), "<<",
+		fmt.Sprintf("<< /Type /ObjStm /N 2 /First %d", len(header)), 1)
+	content := "BT /F1 10 Tf 1 0 0 1 72 720 Tm (Inline image data is skipped,) Tj ET\n" +
+		"BI /W 4 /H 1 /BPC 8 /CS /G ID \x00(junk) Tj\xff EI\n" +
+		"BT /F1 10 Tf 72 720 Td 0 -12 TD 80 Tz 0.5 Tc (lines are moved with TD,) Tj (shown with a quote) ' " +
+		"1 0 (and a double quote.) \" ET"
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	for i, obj := range []string{"<< /Type /Catalog /Pages 2 0 R >>", "", "", objStm,
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>", flateStream(t, content), "<< /Title <FEFF004F0053> >>"} {
+		if obj != "" {
+			fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+		}
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R /Info 7 0 R >>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func TestHTTPExtractor_Extract_PDF(t *testing.T) {
+	doc := buildPDF(t, "", testPDFPages...)
+
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(doc)
+	}))
+	defer server.Close()
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+	extractor.SetOptions(50, false, false)
+
+	contentType = "application/pdf"
+	result, err := extractor.Extract(context.Background(), server.URL+"/paper", domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, ExtractorPDF, result.Extractor)
+	assert.Equal(t, "Research (Draft)", result.Title)
+	assert.Equal(t, server.URL+"/paper", result.Canonical)
+	assert.Equal(t, "Findings of the Study\n\n"+
+		"The quick brown fox jumps over the lazy dog in our experiments, with five cafés back to pre-COVID levels.\n\n"+
+		"Second paragraph <b> after a wider gap.\n\n"+
+		"Hey first page Twice the normal\n\n"+
+		"Text of the form footer.", result.Content)
+	assert.Equal(t, "<h4>Findings of the Study</h4>"+
+		"<p>The quick brown fox jumps over the lazy dog in our experiments, with five cafés back to pre-COVID levels.</p>"+
+		"<p>Second paragraph &lt;b&gt; after a wider gap.</p>"+
+		"<p>Hey first page Twice the normal</p>"+
+		"<p>Text of the form footer.</p>", result.RichContent)
+
+	t.Run("binary content with pdf extension", func(t *testing.T) {
+		contentType = "application/octet-stream"
+		result, err := extractor.Extract(context.Background(), server.URL+"/paper.PDF", domain.FeedAuth{})
+		require.NoError(t, err)
+		assert.Equal(t, ExtractorPDF, result.Extractor)
+
+		_, err = extractor.Extract(context.Background(), server.URL+"/paper.bin", domain.FeedAuth{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported content type")
+	})
+}
+
+func TestHTTPExtractor_Extract_PDFErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         []byte
+		expectError string
+	}{
+		{name: "no text", doc: buildPDF(t, "", "0 0 m 100 100 l S"), expectError: "extract pdf: no text in pdf"},
+		{name: "too short", doc: buildPDF(t, "", "BT /F1 10 Tf 72 720 Td (Cover page.) Tj ET"),
+			expectError: "extract pdf: pdf text too short"},
+		{name: "encrypted", doc: buildPDF(t, "/Encrypt << /Filter /Standard >>", "BT ET"),
+			expectError: "extract pdf: encrypted pdf not supported"},
+		{name: "too large", doc: append([]byte("%PDF-1.4\n"), make([]byte, pdfMaxSize)...),
+			expectError: "extract pdf: pdf too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/pdf")
+				_, _ = w.Write(tt.doc)
+			}))
+			defer server.Close()
+
+			extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+			extractor.SetOptions(50, false, false)
+			_, err := extractor.Extract(context.Background(), server.URL, domain.FeedAuth{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectError)
+			assert.Equal(t, 1, requests, "not retried")
+		})
+	}
+}
+
+func TestPDFDoc_PageLimit(t *testing.T) {
+	pages := make([]string, pdfMaxPages+1)
+	for i := range pages {
+		pages[i] = fmt.Sprintf("BT /F1 10 Tf 72 720 Td (Page %d) Tj ET", i+1)
+	}
+	doc, err := parsePDF(buildPDF(t, "", pages...))
+	require.NoError(t, err)
+
+	paragraphs := doc.text()
+	require.Len(t, paragraphs, pdfMaxPages)
+	assert.Equal(t, "Page 1", paragraphs[0].text)
+	assert.Equal(t, fmt.Sprintf("Page %d", pdfMaxPages), paragraphs[pdfMaxPages-1].text)
+}
+
+func TestPDFDoc_ObjectStream(t *testing.T) {
+	doc, err := parsePDF(objectStreamPDF(t))
+	require.NoError(t, err)
+	assert.Equal(t, "OS", doc.title(), "UTF-16 title")
+	paragraphs := doc.text()
+	require.Len(t, paragraphs, 1)
+	assert.Equal(t, "Inline image data is skipped, lines are moved with TD, shown with a quote and a double quote.",
+		paragraphs[0].text)
+}
+
+func TestPDFLexer(t *testing.T) {
+	l := &pdfLexer{data: []byte(`<< /Type /Page /Na#6de (a\(b\)\n\101) <48 65 6C6C6F> /Kids [1 0 R 2.5 -3] ` +
+		`/Flag true /None null % comment
+/Odd <414> >> Tj`)}
+	obj, err := l.object()
+	require.NoError(t, err)
+	assert.Equal(t, pdfDict{
+		"Type": pdfName("Page"),
+		"Name": pdfString("a(b)\nA"),
+		"Kids": pdfArray{pdfRef{num: 1}, 2.5, -3.0},
+		"Flag": true,
+		"None": nil,
+		"Odd":  pdfString("A@"),
+	}, obj, "string keys are skipped")
+
+	obj, err = l.object()
+	require.NoError(t, err)
+	assert.Equal(t, pdfKeyword("Tj"), obj)
+	_, err = l.object()
+	assert.ErrorIs(t, err, errPDFEnd)
+}
+
+func TestParseToUnicode(t *testing.T) {
+	cmap, codeLen := parseToUnicode([]byte(testToUnicode), 1)
+	assert.Equal(t, 2, codeLen)
+	assert.Equal(t, "fi", cmap[0x0001])
+	assert.Equal(t, " ", cmap[0x0020])
+	assert.Equal(t, "Q", cmap[0x0051])
+	assert.Equal(t, "q", cmap[0x0071])
+	assert.Len(t, cmap, 2+26+26)
+
+	for name, expected := range map[string]string{"a": "a", "quoteright": "’", "uni00E9": "é", "u1F600": "😀",
+		"a.sc": "a", "g42": ""} {
+		text, _ := glyphText(name)
+		assert.Equal(t, expected, text, name)
+	}
+}
+
+func FuzzParsePDF(f *testing.F) {
+	f.Add(buildPDF(f, "", testPDFPages...))
+	f.Add(buildPDF(f, "", "0 0 m 100 100 l S"))
+	f.Add(buildPDF(f, "/Encrypt << /Filter /Standard >>", "BT ET"))
+	f.Add(objectStreamPDF(f))
+	f.Add([]byte(testToUnicode))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		doc, err := parsePDF(data)
+		if err != nil {
+			return
+		}
+		_ = doc.title()
+		for _, p := range doc.text() {
+			assert.NotEmpty(t, p.text)
+		}
+	})
+}
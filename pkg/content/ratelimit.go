@@ -0,0 +1,144 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by Extract if the host asked to wait longer than maxBlockWait before the next request
+var ErrRateLimited = errors.New("rate limited by host")
+
+const (
+	hostBurst          = 3           // requests a host gets at once after idling, before they are spaced by the interval
+	hostLimiterMaxSize = 1000        // number of tracked hosts above which idle hosts are dropped
+	maxBlockWait       = time.Minute // longest wait for a blocked host, requests fail with ErrRateLimited instead
+)
+
+// hostLimiter is a per-host token bucket, each host gets a token every interval and holds up to hostBurst
+// of them. it is kept as the theoretical arrival time of the next request (GCRA), which needs no timer per host.
+// a host which asked to slow down, e.g. with Retry-After, is blocked until the requested time.
+// zero interval doesn't limit requests, blocked hosts are still waited for.
+type hostLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	tat      map[string]time.Time // theoretical arrival time of the next request to the host
+	blocked  map[string]time.Time // hosts blocked until the time
+}
+
+// newHostLimiter makes a limiter giving each host a token every interval
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, tat: map[string]time.Time{}, blocked: map[string]time.Time{}}
+}
+
+// setInterval changes the interval between tokens, it applies to the following requests
+func (l *hostLimiter) setInterval(interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.interval = interval
+}
+
+// wait takes a token of the host and blocks until it is available. returns ErrRateLimited without
+// waiting if the host is blocked for longer than maxBlockWait, and the context's error if it is canceled
+// while waiting, the token is not given back then.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	host = strings.ToLower(host)
+
+	l.mu.Lock()
+	now := time.Now()
+	if len(l.tat) > hostLimiterMaxSize {
+		for h, t := range l.tat {
+			if t.Before(now) {
+				delete(l.tat, h)
+				delete(l.blocked, h)
+			}
+		}
+	}
+	if until := l.blocked[host]; until.After(now.Add(maxBlockWait)) {
+		l.mu.Unlock()
+		return fmt.Errorf("%w until %s", ErrRateLimited, until.Format(time.RFC3339))
+	}
+	tat := l.tat[host]
+	if tat.Before(now) {
+		tat = now
+	}
+	allowAt := tat.Add(-time.Duration(hostBurst-1) * l.interval)
+	l.tat[host] = tat.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(allowAt)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// block holds requests to the host until the time, without a burst afterwards
+func (l *hostLimiter) block(host string, until time.Time) {
+	host = strings.ToLower(host)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.blocked[host]) {
+		l.blocked[host] = until
+	}
+	if tat := until.Add(time.Duration(hostBurst-1) * l.interval); tat.After(l.tat[host]) {
+		l.tat[host] = tat
+	}
+}
+
+// Slots limits the number of requests made at once, e.g. a pool shared with other work of the caller
+type Slots interface {
+	Acquire(ctx context.Context) error
+	Release()
+}
+
+type slotsKey struct{}
+
+// WithSlots returns a context making Extract take one of the slots for each request and processing
+// of its response. waits for a rate limited host and between retries don't hold a slot.
+func WithSlots(ctx context.Context, slots Slots) context.Context {
+	return context.WithValue(ctx, slotsKey{}, slots)
+}
+
+// AcquireSlot takes one of the slots set with WithSlots and returns the function releasing it.
+// a context without slots doesn't limit the caller.
+func AcquireSlot(ctx context.Context) (release func(), err error) {
+	slots, ok := ctx.Value(slotsKey{}).(Slots)
+	if !ok {
+		return func() {}, nil
+	}
+	if err := slots.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return slots.Release, nil
+}
+
+// retryAfter parses the Retry-After header, either delay seconds or an HTTP date.
+// returns false if the header is missing or invalid.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+	at, err := http.ParseTime(header)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
@@ -0,0 +1,144 @@
+package content
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestHostLimiter(t *testing.T) {
+	l := newHostLimiter(100 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range hostBurst {
+		require.NoError(t, l.wait(ctx, "example.com"))
+	}
+	require.NoError(t, l.wait(ctx, "other.com"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "burst not delayed")
+
+	require.NoError(t, l.wait(ctx, "example.com"))
+	require.NoError(t, l.wait(ctx, "EXAMPLE.com"))
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond, "requests after the burst spaced")
+
+	t.Run("blocked host", func(t *testing.T) {
+		l := newHostLimiter(0)
+		l.block("example.com", time.Now().Add(100*time.Millisecond))
+		start := time.Now()
+		require.NoError(t, l.wait(ctx, "example.com"))
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "waited for the block")
+		require.NoError(t, l.wait(ctx, "other.com"))
+
+		l.block("example.com", time.Now().Add(time.Hour))
+		err := l.wait(ctx, "example.com")
+		require.ErrorIs(t, err, ErrRateLimited, "too long to wait")
+	})
+
+	t.Run("canceled wait", func(t *testing.T) {
+		l := newHostLimiter(time.Hour)
+		for range hostBurst {
+			require.NoError(t, l.wait(ctx, "example.com"))
+		}
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.wait(ctx, "example.com"), context.DeadlineExceeded)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		delay  time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-5", 0, true},
+		{"Wed, 06 Mar 2024 10:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 06 Mar 2024 09:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			delay, ok := retryAfter(tt.header, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.delay, delay)
+		})
+	}
+}
+
+func TestHTTPExtractor_Extract_RetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	retryAfterHeader := "1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", retryAfterHeader)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><article><h1>Article</h1><p>This article has enough text ` +
+			`to pass the minimum length check of the extractor used in this test.</p></article></body></html>`))
+	}))
+	defer server.Close()
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+	extractor.SetOptions(50, false, false)
+
+	slots := &countingSlots{}
+	start := time.Now()
+	_, err := extractor.Extract(WithSlots(context.Background(), slots), server.URL, domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "retried after the requested delay")
+	assert.Equal(t, int32(2), slots.acquired.Load(), "slot taken for each request")
+	assert.Zero(t, slots.held.Load(), "slot not held while waiting")
+
+	t.Run("too long to wait", func(t *testing.T) {
+		requests.Store(0)
+		retryAfterHeader = "3600"
+		extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+		_, err := extractor.Extract(context.Background(), server.URL, domain.FeedAuth{})
+		require.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(1), requests.Load(), "not retried")
+
+		_, err = extractor.Extract(context.Background(), server.URL+"/other", domain.FeedAuth{})
+		require.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(1), requests.Load(), "blocked host not requested")
+	})
+}
+
+func TestAcquireSlot(t *testing.T) {
+	release, err := AcquireSlot(context.Background())
+	require.NoError(t, err)
+	release() // no slots, no limit
+
+	slots := &countingSlots{}
+	release, err = AcquireSlot(WithSlots(context.Background(), slots))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), slots.held.Load())
+	release()
+	assert.Zero(t, slots.held.Load())
+}
+
+// countingSlots counts slots taken and held, it never blocks
+type countingSlots struct {
+	acquired, held atomic.Int32
+}
+
+func (s *countingSlots) Acquire(context.Context) error {
+	s.acquired.Add(1)
+	s.held.Add(1)
+	return nil
+}
+
+func (s *countingSlots) Release() { s.held.Add(-1) }
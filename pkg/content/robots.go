@@ -0,0 +1,194 @@
+package content
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowed is returned by Extract for pages the site's robots.txt doesn't allow to fetch
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+const (
+	robotsTTL      = 24 * time.Hour // how long rules of a site are cached
+	robotsErrorTTL = time.Hour      // how long a site is treated as allowing everything after its robots.txt failed to load
+	robotsMaxSize  = 512 * 1024     // robots.txt content above this size is ignored, as RFC 9309 allows
+)
+
+// robotsCache loads and caches robots.txt rules per site, keyed by scheme and host
+type robotsCache struct {
+	client    *http.Client
+	userAgent string
+	mu        sync.Mutex
+	sites     map[string]robotsEntry
+}
+
+// robotsEntry is the cached rules of a site
+type robotsEntry struct {
+	rules   *robotsRules
+	expires time.Time
+}
+
+// newRobotsCache makes a cache loading robots.txt with the client, rules are picked for the user agent
+func newRobotsCache(client *http.Client, userAgent string) *robotsCache {
+	return &robotsCache{client: client, userAgent: userAgent, sites: map[string]robotsEntry{}}
+}
+
+// allowed reports whether the site's robots.txt allows fetching the URL. a robots.txt which is missing
+// or fails to load allows everything.
+func (c *robotsCache) allowed(ctx context.Context, u *url.URL) bool {
+	site := strings.ToLower(u.Scheme + "://" + u.Host)
+
+	c.mu.Lock()
+	entry, ok := c.sites[site]
+	c.mu.Unlock()
+	if !ok || time.Now().After(entry.expires) {
+		rules, err := c.load(ctx, site)
+		entry = robotsEntry{rules: rules, expires: time.Now().Add(robotsTTL)}
+		if err != nil {
+			if ctx.Err() != nil {
+				return true // not cached, the page request fails the same way
+			}
+			entry.expires = time.Now().Add(robotsErrorTTL)
+		}
+		c.mu.Lock()
+		c.sites[site] = entry
+		c.mu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return entry.rules.allowed(path)
+}
+
+// load fetches and parses robots.txt of the site. returns rules allowing everything if the site has none.
+func (c *robotsCache) load(ctx context.Context, site string) (*robotsRules, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", http.NoBody)
+	if err != nil {
+		return &robotsRules{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &robotsRules{}, fmt.Errorf("fetch robots.txt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			return &robotsRules{}, nil // no robots.txt, everything allowed
+		}
+		return &robotsRules{}, fmt.Errorf("fetch robots.txt: status %d", resp.StatusCode)
+	}
+	return parseRobots(io.LimitReader(resp.Body, robotsMaxSize), c.userAgent), nil
+}
+
+// robotsRules is the allow and disallow rules of a robots.txt group applying to the user agent
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// robotsRule is an allow or disallow line, the pattern matches path prefixes with * and $ wildcards
+type robotsRule struct {
+	allow   bool
+	length  int // length of the pattern, the longest matching rule wins
+	pattern *regexp.Regexp
+}
+
+// allowed reports whether the path, with the query if any, may be fetched. the most specific,
+// i.e. the longest, matching rule decides and allow wins a tie. paths no rule matches are allowed.
+func (r *robotsRules) allowed(path string) bool {
+	allow, length := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > length || (rule.length == length && rule.allow) {
+			allow, length = rule.allow, rule.length
+		}
+	}
+	return allow
+}
+
+// parseRobots parses robots.txt and returns the rules of groups for the user agent's product token,
+// e.g. "Newscope" of "Newscope/2.0", or of the * groups if no group names it
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	token := productToken(userAgent)
+	var own, common []robotsRule
+	ownGroup := false   // a group names the user agent, * groups are ignored then
+	var agents []string // user agents of the current group
+	inRules := false    // a rule line was seen after the group's user-agent lines
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				agents, inRules = nil, false // a new group starts
+			}
+			agent := productToken(value)
+			ownGroup = ownGroup || agent == token
+			agents = append(agents, agent)
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // empty disallow allows everything, which is the default anyway
+			}
+			rule := robotsRule{allow: key == "allow", length: len(value), pattern: robotsPattern(value)}
+			for _, agent := range agents {
+				switch agent {
+				case token:
+					own = append(own, rule)
+				case "*":
+					common = append(common, rule)
+				}
+			}
+		}
+	}
+
+	if ownGroup {
+		return &robotsRules{rules: own}
+	}
+	return &robotsRules{rules: common}
+}
+
+// productToken returns the lowercased name of a user agent without version and comments,
+// e.g. "newscope" for "Newscope/2.0 (+https://example.com)"
+func productToken(userAgent string) string {
+	token := strings.ToLower(strings.TrimSpace(userAgent))
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	return token
+}
+
+// robotsPattern compiles a path pattern of robots.txt, * matches any characters and a trailing $ the end of the path
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
@@ -0,0 +1,130 @@
+package content
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := `# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/public
+Disallow: /*.pdf$
+Disallow: /search?
+
+User-agent: BadBot
+User-agent: Other
+Disallow: /
+
+User-agent: Newscope
+Disallow: /no-newscope/
+`
+	tests := []struct {
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{"Mozilla/5.0 (X11)", "/", true},
+		{"Mozilla/5.0 (X11)", "/private/page", false},
+		{"Mozilla/5.0 (X11)", "/private/public/page", true},
+		{"Mozilla/5.0 (X11)", "/docs/report.pdf", false},
+		{"Mozilla/5.0 (X11)", "/docs/report.pdf?download=1", true},
+		{"Mozilla/5.0 (X11)", "/search?q=go", false},
+		{"Mozilla/5.0 (X11)", "/search", true},
+		{"badbot/1.0", "/anything", false},
+		{"other", "/", false},
+		{"Newscope/2.0", "/private/page", true}, // own group replaces the * group
+		{"Newscope/2.0", "/no-newscope/page", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userAgent+" "+tt.path, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(robots), tt.userAgent)
+			assert.Equal(t, tt.allowed, rules.allowed(tt.path))
+		})
+	}
+
+	t.Run("empty disallow allows everything", func(t *testing.T) {
+		rules := parseRobots(strings.NewReader("User-agent: *\nDisallow:\n"), "Newscope/2.0")
+		assert.True(t, rules.allowed("/page"))
+	})
+
+	t.Run("allow wins a tie", func(t *testing.T) {
+		rules := parseRobots(strings.NewReader("User-agent: *\nDisallow: /page\nAllow: /page\n"), "Newscope/2.0")
+		assert.True(t, rules.allowed("/page"))
+	})
+}
+
+func TestRobotsCache(t *testing.T) {
+	var robotsRequests atomic.Int32
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		robotsRequests.Add(1)
+		assert.Equal(t, "/robots.txt", r.URL.Path)
+		assert.Equal(t, "Newscope/2.0", r.Header.Get("User-Agent"))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	parse := func(path string) *url.URL {
+		u, err := url.Parse(server.URL + path)
+		require.NoError(t, err)
+		return u
+	}
+
+	cache := newRobotsCache(server.Client(), "Newscope/2.0")
+	assert.True(t, cache.allowed(ctx, parse("/article")))
+	assert.False(t, cache.allowed(ctx, parse("/private/article")))
+	assert.Equal(t, int32(1), robotsRequests.Load(), "rules cached per site")
+
+	for _, code := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		status = code
+		cache = newRobotsCache(server.Client(), "Newscope/2.0")
+		assert.True(t, cache.allowed(ctx, parse("/private/article")), "robots.txt with status %d allows everything", code)
+	}
+}
+
+func TestHTTPExtractor_Extract_Robots(t *testing.T) {
+	var pageRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /members/\n"))
+			return
+		}
+		pageRequests.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><article><h1>Article</h1><p>This article has enough text ` +
+			`to pass the minimum length check of the extractor used in this test.</p></article></body></html>`))
+	}))
+	defer server.Close()
+
+	extractor := NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+	extractor.SetOptions(50, false, false)
+
+	// robots.txt isn't checked by default
+	_, err := extractor.Extract(context.Background(), server.URL+"/members/article", domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), pageRequests.Load())
+
+	extractor.SetRobots(true)
+	_, err = extractor.Extract(context.Background(), server.URL+"/members/article", domain.FeedAuth{})
+	require.ErrorIs(t, err, ErrDisallowed)
+	assert.Equal(t, int32(1), pageRequests.Load(), "disallowed page not requested")
+
+	_, err = extractor.Extract(context.Background(), server.URL+"/news/article", domain.FeedAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), pageRequests.Load())
+}
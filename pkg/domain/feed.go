@@ -1,18 +1,279 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeedType is the kind of source a feed's items are read from
+type FeedType string
+
+// feed types
+const (
+	FeedTypeRSS        FeedType = "rss"        // RSS, Atom or JSON feed
+	FeedTypeHTML       FeedType = "html"       // web page without a feed, scraped with CSS selectors
+	FeedTypeSitemap    FeedType = "sitemap"    // sitemap, sitemap index or Google News sitemap
+	FeedTypeNewsletter FeedType = "newsletter" // pseudo-feed of an email newsletter sender, never fetched
+)
+
+// ErrFeedGone is returned by the parser when the feed URL responds 410 Gone
+var ErrFeedGone = errors.New("feed is gone, server responded 410")
+
+// ErrWebSubBusy is returned for content pushed by a hub while too many pushes wait to be stored
+var ErrWebSubBusy = errors.New("too many websub pushes waiting")
+
+// FeedEventType is the kind of a change in a feed's life made automatically
+type FeedEventType string
+
+// feed event types
+const (
+	FeedEventMoved FeedEventType = "moved" // URL updated after a permanent redirect
+	FeedEventGone  FeedEventType = "gone"  // feed disabled after a 410 Gone response
+)
+
+// FeedEvent records a change made to a feed automatically, shown in the feed's history
+type FeedEvent struct {
+	ID        int64
+	FeedID    int64
+	Type      FeedEventType
+	Message   string
+	CreatedAt time.Time
+}
+
+// StatusError is returned when a feed responds with an unexpected HTTP status
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// FeedFetch records a single fetch attempt of a feed, kept in the feed's fetch log
+type FeedFetch struct {
+	ID         int64
+	FeedID     int64
+	FetchedAt  time.Time
+	StatusCode int           // HTTP status, zero if there was no response, e.g. on network errors
+	Duration   time.Duration // time to fetch and parse the feed
+	Bytes      int64         // body size, zero for not modified responses
+	Items      int           // items in the feed
+	NewItems   int           // items stored as new
+	Error      string        // empty for successful fetches
+}
+
+// OK reports whether the fetch succeeded
+func (f FeedFetch) OK() bool {
+	return f.Error == ""
+}
+
+// ScrapeSelectors are CSS selectors turning a web page into feed items.
+// Item matches each item container, the other selectors are relative to it.
+type ScrapeSelectors struct {
+	Item    string `json:"item"`
+	Title   string `json:"title"`
+	Link    string `json:"link,omitempty"`    // first link in the item if empty
+	Date    string `json:"date,omitempty"`    // items have no date if empty
+	Summary string `json:"summary,omitempty"` // items have no description if empty
+}
+
+// FeedAuth holds credentials of a private feed, sent with the feed fetch and with
+// article extraction for links on the feed's host. all fields are optional.
+type FeedAuth struct {
+	Username string            `json:"username,omitempty"` // basic auth, sent if set
+	Password string            `json:"password,omitempty"`
+	Token    string            `json:"token,omitempty"`   // sent as "Authorization: Bearer <token>"
+	Headers  map[string]string `json:"headers,omitempty"` // extra request headers, e.g. PRIVATE-TOKEN
+	Cookies  string            `json:"cookies,omitempty"` // Cookie header value, e.g. "session=abc; lang=en"
+}
+
+// SecretMask replaces secret values wherever credentials are shown
+const SecretMask = "********"
+
+// IsZero reports whether no credentials are set
+func (a FeedAuth) IsZero() bool {
+	return a.Username == "" && a.Password == "" && a.Token == "" && len(a.Headers) == 0 && a.Cookies == ""
+}
+
+// HeaderNames returns names of the extra headers, sorted
+func (a FeedAuth) HeaderNames() []string {
+	names := make([]string, 0, len(a.Headers))
+	for name := range a.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String describes the credentials with secrets masked, safe to log and show
+func (a FeedAuth) String() string {
+	var parts []string
+	if a.Username != "" || a.Password != "" {
+		parts = append(parts, fmt.Sprintf("basic %s:%s", a.Username, SecretMask))
+	}
+	if a.Token != "" {
+		parts = append(parts, "bearer "+SecretMask)
+	}
+	for _, name := range a.HeaderNames() {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, SecretMask))
+	}
+	if a.Cookies != "" {
+		parts = append(parts, "cookies "+SecretMask)
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
 
 // Feed represents a news feed source
 type Feed struct {
-	ID            int64
-	URL           string
-	Title         string
-	Description   string
-	LastFetched   *time.Time
-	NextFetch     *time.Time
-	FetchInterval time.Duration
-	ErrorCount    int
-	LastError     string
-	Enabled       bool
-	CreatedAt     time.Time
+	ID               int64
+	Type             FeedType // empty is the same as FeedTypeRSS
+	URL              string
+	Title            string
+	Description      string
+	Folder           string // slash-separated folder path, e.g. "Tech/Go", empty for top level
+	LastFetched      *time.Time
+	NextFetch        *time.Time
+	FetchInterval    time.Duration
+	AdaptiveInterval time.Duration // derived from publishing frequency, zero if not known yet
+	ErrorCount       int
+	LastError        string
+	Enabled          bool
+	DisabledReason   string // set when the feed was disabled automatically, e.g. after repeated errors
+	CreatedAt        time.Time
+	LastNewItem      *time.Time      // last fetch that found new items, nil if none did
+	Selectors        ScrapeSelectors // used for FeedTypeHTML only
+	Auth             FeedAuth        // credentials of a private feed, empty for public ones
+	Proxy            string          // proxy URL for fetching the feed, "direct" to bypass the configured proxy, empty for the configured one
+	MovedTo          string          // permanent redirect seen on the last fetch, the URL changes when the next fetch confirms it
+
+	// conditional fetch state
+	ETag         string // ETag of the last full response
+	LastModified string // Last-Modified of the last full response
+	LastSize     int64  // body size of the last full response
+	BytesSaved   int64  // total bytes not downloaded thanks to 304 responses
+
+	// websub push subscription state
+	HubURL        string     // hub advertised by the feed, empty if none
+	WebSubTopic   string     // topic (self URL) subscribed at the hub
+	WebSubSecret  string     // secret used by the hub to sign pushed content, empty if not subscribed
+	WebSubExpires *time.Time // lease expiration confirmed by the hub, nil while pending
+}
+
+// IsScraped reports whether the feed is a web page scraped with CSS selectors
+func (f *Feed) IsScraped() bool {
+	return f.Type == FeedTypeHTML
+}
+
+// IsSitemap reports whether the feed reads new URLs from a sitemap
+func (f *Feed) IsSitemap() bool {
+	return f.Type == FeedTypeSitemap
+}
+
+// IsNewsletter reports whether the feed collects email newsletters of a sender
+func (f *Feed) IsNewsletter() bool {
+	return f.Type == FeedTypeNewsletter
+}
+
+// InFolder reports whether the feed is in the folder or one of its subfolders
+func (f *Feed) InFolder(folder string) bool {
+	return f.Folder == folder || strings.HasPrefix(f.Folder, folder+"/")
+}
+
+// CleanFolder normalizes a user-entered folder path, trimming spaces around
+// its parts and dropping empty ones, e.g. " Tech / Go/" becomes "Tech/Go"
+func CleanFolder(folder string) string {
+	parts := make([]string, 0, strings.Count(folder, "/")+1)
+	for _, part := range strings.Split(folder, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// IsBroken reports whether the feed is failing or was disabled because of failures
+func (f *Feed) IsBroken() bool {
+	return f.ErrorCount > 0 || f.DisabledReason != ""
+}
+
+// WebSubActive reports whether the hub confirmed the push subscription and its lease is not expired
+func (f *Feed) WebSubActive(now time.Time) bool {
+	return f.WebSubSecret != "" && f.WebSubExpires != nil && f.WebSubExpires.After(now)
+}
+
+// WebSubPending reports whether a push subscription was requested but not confirmed by the hub yet
+func (f *Feed) WebSubPending() bool {
+	return f.WebSubSecret != "" && f.WebSubExpires == nil
+}
+
+// EffectiveInterval returns the interval used to schedule the next fetch. the adaptive interval
+// is used once known, but never shorter than the configured one, which only feeds publishing
+// less often stretch.
+func (f *Feed) EffectiveInterval() time.Duration {
+	return max(f.AdaptiveInterval, f.FetchInterval)
+}
+
+// FeedCandidate is a feed found by autodiscovery for a website URL
+type FeedCandidate struct {
+	URL       string
+	Title     string
+	ItemCount int
+}
+
+// FeedPreview is a sample of a feed's latest items classified with the current preferences,
+// made before subscribing. Items without classification were not part of the classified sample.
+type FeedPreview struct {
+	URL   string
+	Title string
+	Items []ClassifiedItem
+}
+
+// ClassifiedCount returns the number of classified items in the preview
+func (p *FeedPreview) ClassifiedCount() int {
+	count := 0
+	for _, item := range p.Items {
+		if item.Classification != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// AverageScore returns the average relevance score of classified items, 0 if none classified
+func (p *FeedPreview) AverageScore() float64 {
+	count, total := 0, 0.0
+	for _, item := range p.Items {
+		if item.Classification != nil {
+			count++
+			total += item.Classification.Score
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// ShareAbove returns the share (0-1) of classified items with score at or above minScore,
+// 0 if none classified
+func (p *FeedPreview) ShareAbove(minScore float64) float64 {
+	count, above := 0, 0
+	for _, item := range p.Items {
+		if item.Classification != nil {
+			count++
+			if item.Classification.Score >= minScore {
+				above++
+			}
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(above) / float64(count)
 }
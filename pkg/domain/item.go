@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // TopicWithScore represents a topic with its statistics
 type TopicWithScore struct {
@@ -11,25 +14,66 @@ type TopicWithScore struct {
 
 // Item represents a core news article/item
 type Item struct {
-	ID          int64
-	FeedID      int64
-	GUID        string
-	Title       string
-	Link        string
-	Description string
-	Content     string
-	Author      string
-	Published   time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID            int64
+	FeedID        int64
+	GUID          string
+	Title         string
+	Link          string
+	CanonicalLink string // dedupe key made of the link by canonical.Clean, may not be a working URL, empty if not known
+	ResolvedLink  string // link with redirect wrappers resolved, the page's declared canonical after extraction, empty if not known
+	Description   string
+	Content       string
+	Author        string
+	Published     time.Time
+	Image         string      // lead image URL, empty if none
+	Enclosures    []Enclosure // media files attached by the feed, e.g. podcast episodes
+	Categories    []string    // categories assigned by the feed
+	MutedBy       string      // description of the mute rule the item matched, empty if not muted
+	ProcessError  string      // error processing was given up with, set only for items of failed jobs
+	FailedAt      *time.Time  // when processing was given up, set only for items of failed jobs
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Enclosure is a media file attached to an item
+type Enclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`   // MIME type, e.g. audio/mpeg
+	Length int64  `json:"length,omitempty"` // size in bytes, zero if unknown
+}
+
+// IsAudio reports whether the enclosure is an audio file, e.g. a podcast episode
+func (e Enclosure) IsAudio() bool {
+	return strings.HasPrefix(strings.ToLower(e.Type), "audio/")
+}
+
+// IsNewsletter reports whether the item is an email newsletter. newsletters are linked by
+// their Message-ID as a mid: URL (RFC 2392) and carry the whole content, there is nothing to extract.
+func (i *Item) IsNewsletter() bool {
+	return strings.HasPrefix(i.Link, "mid:")
+}
+
+// DisplayLink returns the resolved link of the item if known, the original link otherwise.
+// it is the link the article is extracted from and shown with.
+func (i *Item) DisplayLink() string {
+	if i.ResolvedLink != "" {
+		return i.ResolvedLink
+	}
+	return i.Link
 }
 
 // ExtractedContent represents extracted article content
 type ExtractedContent struct {
-	PlainText   string
-	RichHTML    string
-	ExtractedAt time.Time
-	Error       string
+	PlainText     string
+	RichHTML      string
+	Title         string // article title, stored only for items without a title
+	ExtractedAt   time.Time
+	Error         string
+	CanonicalLink string // dedupe key of the page's canonical link, empty to keep the stored one
+	ResolvedLink  string // canonical link declared by the page, empty to keep the stored one
+	Image         string // lead image of the page, stored only for items without an image
+	Fingerprint   uint64 // SimHash of the title and text to find near-duplicates, zero if the text is too short
+	Extractor     string // extractor which produced the content, e.g. trafilatura, fallback or pdf, empty if not extracted from the page
 }
 
 // Classification represents LLM classification results
@@ -65,6 +109,16 @@ type ClassifiedItem struct {
 	Extraction     *ExtractedContent
 	Classification *Classification
 	UserFeedback   *Feedback
+	Duplicates     []Duplicate // near-duplicates of the item in other feeds, not shown as articles
+}
+
+// Duplicate is a near-duplicate of an article in another feed, e.g. the same wire story
+// with another headline. duplicates are linked to the article they repeat and not classified.
+type Duplicate struct {
+	ItemID   int64
+	Link     string
+	FeedName string
+	FeedURL  string
 }
 
 // GetRelevanceScore returns the relevance score or 0 if not classified.
@@ -146,6 +200,7 @@ type ItemFilter struct {
 	MinScore       float64
 	Topic          string
 	FeedName       string
+	Folder         string // feeds of the folder and its subfolders
 	SortBy         string
 	Limit          int
 	Offset         int
@@ -158,6 +213,7 @@ type ArticlesRequest struct {
 	MinScore      float64
 	Topic         string
 	FeedName      string
+	Folder        string
 	SortBy        string
 	Limit         int
 	Page          int
@@ -191,6 +247,15 @@ type ParsedFeed struct {
 	Description string
 	Link        string
 	Items       []ParsedItem
+	HubURL      string // WebSub hub advertised by the feed, empty if none
+	SelfURL     string // canonical feed URL advertised by the feed (rel="self"), empty if none
+	MovedTo     string // final URL if the feed was reached only through permanent redirects (301, 308), empty otherwise
+
+	// http caching details of the fetch
+	NotModified  bool   // server responded 304, feed unchanged since the last fetch
+	ETag         string // ETag header of the response
+	LastModified string // Last-Modified header of the response
+	Size         int64  // body size in bytes, zero for not modified responses
 }
 
 // ParsedItem represents an item parsed from RSS/Atom (before database storage)
@@ -203,4 +268,8 @@ type ParsedItem struct {
 	Content     string // content from RSS feed (if available)
 	Author      string
 	Published   time.Time
+	Undated     bool        // the source has no date, Published is the fetch time
+	Image       string      // lead image: media:thumbnail, itunes:image or the first image of the content
+	Enclosures  []Enclosure // enclosures with their type and length
+	Categories  []string
 }
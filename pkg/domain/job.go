@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// JobState is the state of an item's processing job in the queue
+type JobState string
+
+// job states, a job moves from pending to done or failed and back to pending if it is retried
+const (
+	JobPending     JobState = "pending"     // waiting for a worker, retries wait until their run time
+	JobExtracting  JobState = "extracting"  // claimed by a worker, the content is being extracted
+	JobClassifying JobState = "classifying" // claimed by a worker, the item is being classified
+	JobDone        JobState = "done"        // item processed, muted or stored as a duplicate
+	JobFailed      JobState = "failed"      // given up after all attempts
+)
+
+// job priorities, jobs with a higher priority are claimed first
+const (
+	PriorityNormal = 0  // items of scheduled feed updates, websub pushes and newsletters
+	PriorityManual = 10 // items of user actions, e.g. an immediate feed update or a retry of a failed item
+)
+
+// ProcessJob is a queued extraction and classification of an item
+type ProcessJob struct {
+	ID          int64
+	ItemID      int64
+	State       JobState
+	Priority    int
+	Attempts    int        // times the job was claimed, including the current claim
+	Error       string     // error of the last failed attempt
+	RunAfter    time.Time  // the job isn't claimed before this time, retries are delayed with it
+	LockedUntil *time.Time // visibility timeout of a claimed job, it can be claimed again once expired
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// QueueStats counts jobs of the processing queue by state
+type QueueStats struct {
+	Pending    int // waiting for a worker, including delayed retries
+	InProgress int // being extracted or classified
+	Done       int
+	Failed     int
+}
+
+// StageStats is the load of a step of the processing pipeline
+type StageStats struct {
+	Waiting int // claimed items waiting for a free slot of the stage, or for their host's rate limit
+	Running int
+	Limit   int // max items processed by the stage at once
+}
+
+// PipelineStats is the load of the processing pipeline by stage
+type PipelineStats struct {
+	Extraction     StageStats
+	Classification StageStats
+}
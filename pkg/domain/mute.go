@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MuteKind is what a mute rule matches
+type MuteKind string
+
+// mute rule kinds
+const (
+	MuteKeyword MuteKind = "keyword" // word or phrase in the title or description, case-insensitive
+	MuteRegex   MuteKind = "regex"   // regular expression on the title or description
+	MuteAuthor  MuteKind = "author"  // author name, case-insensitive
+	MuteDomain  MuteKind = "domain"  // host of the article link, subdomains included
+	MuteTopic   MuteKind = "topic"   // category assigned by the feed, case-insensitive
+)
+
+// MuteKinds lists all mute rule kinds, in the order they are offered
+var MuteKinds = []MuteKind{MuteKeyword, MuteRegex, MuteAuthor, MuteDomain, MuteTopic}
+
+// MuteRule is a user-defined rule muting matching items before extraction and classification.
+// muted items are stored, but never extracted, classified or shown with the other articles.
+type MuteRule struct {
+	ID        int64
+	Kind      MuteKind
+	Pattern   string
+	FeedID    int64  // limits the rule to items of the feed, zero for all feeds
+	FeedName  string // title of the feed, set when rules are listed
+	CreatedAt time.Time
+}
+
+// Validate checks the rule has a known kind and a usable pattern
+func (r *MuteRule) Validate() error {
+	if strings.TrimSpace(r.Pattern) == "" {
+		return errors.New("pattern is required")
+	}
+	switch r.Kind {
+	case MuteKeyword, MuteAuthor, MuteDomain, MuteTopic:
+	case MuteRegex:
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown mute rule kind %q", r.Kind)
+	}
+	return nil
+}
+
+// String describes the rule, e.g. `keyword "sponsored"` or `topic "sports" in Daily News`.
+// the description is stored with muted items, so it stays meaningful after the rule is deleted.
+func (r *MuteRule) String() string {
+	s := fmt.Sprintf("%s %q", r.Kind, r.Pattern)
+	switch {
+	case r.FeedName != "":
+		s += " in " + r.FeedName
+	case r.FeedID != 0:
+		s += fmt.Sprintf(" in feed %d", r.FeedID)
+	}
+	return s
+}
@@ -0,0 +1,156 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+const (
+	maxDiscoveryPageSize = 5 * 1024 * 1024 // max size of a page searched for feed links
+	discoveryConcurrency = 4               // max parallel candidate checks
+)
+
+// wellKnownFeedPaths are probed on the site root in addition to links found on the page
+var wellKnownFeedPaths = []string{"/feed", "/rss", "/rss.xml", "/feed.xml", "/atom.xml", "/index.xml"}
+
+// Discover finds feeds for the given URL. If the URL is a feed itself, it is the only candidate.
+// Otherwise, the page is searched for <link rel="alternate"> feed links and well-known feed paths
+// are probed. Each candidate is fetched and parsed, only working feeds are returned, in the order
+// of page links first.
+func (p *Parser) Discover(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+	resp, err := p.fetch(ctx, &domain.Feed{URL: pageURL})
+	if err != nil {
+		return nil, fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDiscoveryPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("read page: %w", err)
+	}
+
+	// the URL may already point to a feed
+	if feed, err := gofeed.NewParser().Parse(bytes.NewReader(body)); err == nil {
+		return []domain.FeedCandidate{{URL: pageURL, Title: feed.Title, ItemCount: len(feed.Items)}}, nil
+	}
+
+	base := resp.Request.URL // final URL after redirects
+	links, err := feedLinks(body, base)
+	if err != nil {
+		return nil, fmt.Errorf("parse page: %w", err)
+	}
+
+	// collect unique candidate URLs, page links first, then well-known paths
+	var urls []string
+	titles := map[string]string{}
+	for _, l := range append(links, wellKnownLinks(base)...) {
+		if _, seen := titles[l.URL]; seen {
+			continue
+		}
+		titles[l.URL] = l.Title
+		urls = append(urls, l.URL)
+	}
+
+	// check candidates in parallel, keeping only those which parse as feeds
+	found := make([]*domain.FeedCandidate, len(urls)) // each goroutine writes its own slot
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(discoveryConcurrency)
+	for i, u := range urls {
+		g.Go(func() error {
+			feed, err := p.Parse(gctx, u)
+			if err != nil {
+				return nil // not a feed, skip
+			}
+			title := feed.Title
+			if title == "" {
+				title = titles[u]
+			}
+			found[i] = &domain.FeedCandidate{URL: u, Title: title, ItemCount: len(feed.Items)}
+			return nil
+		})
+	}
+	_ = g.Wait() // candidate errors are not propagated
+
+	var res []domain.FeedCandidate
+	for _, c := range found {
+		if c != nil {
+			res = append(res, *c)
+		}
+	}
+	return res, nil
+}
+
+// feedLink is a feed URL found on a page, with the title of the link
+type feedLink struct {
+	URL   string
+	Title string
+}
+
+// feedLinks returns absolute URLs of RSS and Atom <link rel="alternate"> elements of an HTML page,
+// resolved against <base href> if set, or against the page URL
+func feedLinks(page []byte, pageURL *url.URL) ([]feedLink, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+
+	base := pageURL
+	var links []feedLink
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			attrs := map[string]string{}
+			for _, a := range n.Attr {
+				attrs[strings.ToLower(a.Key)] = strings.TrimSpace(a.Val)
+			}
+			switch {
+			case n.Data == "base" && attrs["href"] != "":
+				if u, err := pageURL.Parse(attrs["href"]); err == nil {
+					base = u
+				}
+			case n.Data == "link" && isFeedLink(attrs["rel"], attrs["type"]) && attrs["href"] != "":
+				if u, err := base.Parse(attrs["href"]); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+					links = append(links, feedLink{URL: u.String(), Title: attrs["title"]})
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return links, nil
+}
+
+// isFeedLink checks if rel and type attributes of a <link> element describe an RSS or Atom feed
+func isFeedLink(rel, typ string) bool {
+	isAlternate := false
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == "alternate" {
+			isAlternate = true
+			break
+		}
+	}
+	typ = strings.ToLower(strings.TrimSpace(strings.Split(typ, ";")[0]))
+	return isAlternate && (typ == "application/rss+xml" || typ == "application/atom+xml")
+}
+
+// wellKnownLinks returns well-known feed URLs on the site root of the given page
+func wellKnownLinks(pageURL *url.URL) []feedLink {
+	res := make([]feedLink, 0, len(wellKnownFeedPaths))
+	for _, path := range wellKnownFeedPaths {
+		u := url.URL{Scheme: pageURL.Scheme, Host: pageURL.Host, Path: path}
+		res = append(res, feedLink{URL: u.String()})
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestParser_Discover(t *testing.T) {
+	rss := func(title string, items int) string {
+		var sb strings.Builder
+		sb.WriteString(`<?xml version="1.0"?><rss version="2.0"><channel><title>` + title + `</title>`)
+		for i := range items {
+			fmt.Fprintf(&sb, `<item><title>item %d</title><link>https://example.com/%d</link></item>`, i, i)
+		}
+		sb.WriteString(`</channel></rss>`)
+		return sb.String()
+	}
+	atom := `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Atom Feed</title>
+		<entry><title>a</title><id>1</id></entry></feed>`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>Blog</title>
+			<link rel="alternate" type="application/rss+xml" title="Main RSS" href="/posts/rss">
+			<link rel="Alternate" type="application/atom+xml; charset=utf-8" href="atom">
+			<link rel="alternate" type="application/rss+xml" title="Broken" href="/broken.xml">
+			<link rel="alternate" type="text/html" href="/other">
+			<link rel="stylesheet" href="/style.css">
+			</head><body>hello</body></html>`)
+	})
+	mux.HandleFunc("/posts/rss", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, rss("Blog Posts", 3)) })
+	mux.HandleFunc("/atom", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, atom) })
+	mux.HandleFunc("/broken.xml", func(w http.ResponseWriter, r *http.Request) { http.Error(w, "gone", http.StatusGone) })
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "<html>not a feed</html>") })
+	mux.HandleFunc("/rss.xml", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, rss("Site RSS", 2)) })
+	mux.HandleFunc("/feed.xml", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, rss("", 1)) })
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	parser := NewParser(5*time.Second, "test-agent")
+
+	t.Run("html page with links and well-known paths", func(t *testing.T) {
+		candidates, err := parser.Discover(context.Background(), srv.URL+"/")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.FeedCandidate{
+			{URL: srv.URL + "/posts/rss", Title: "Blog Posts", ItemCount: 3},
+			{URL: srv.URL + "/atom", Title: "Atom Feed", ItemCount: 1},
+			{URL: srv.URL + "/rss.xml", Title: "Site RSS", ItemCount: 2},
+			{URL: srv.URL + "/feed.xml", Title: "", ItemCount: 1},
+		}, candidates)
+	})
+
+	t.Run("url is a feed itself", func(t *testing.T) {
+		candidates, err := parser.Discover(context.Background(), srv.URL+"/posts/rss")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.FeedCandidate{{URL: srv.URL + "/posts/rss", Title: "Blog Posts", ItemCount: 3}}, candidates)
+	})
+
+	t.Run("page fetch error", func(t *testing.T) {
+		_, err := parser.Discover(context.Background(), srv.URL+"/broken.xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fetch page")
+	})
+
+	t.Run("base href is respected", func(t *testing.T) {
+		u, err := url.Parse(srv.URL + "/blog/post")
+		require.NoError(t, err)
+		links, err := feedLinks([]byte(`<html><head><base href="/sub/">
+			<link rel="alternate" type="application/rss+xml" href="x.xml" title="X">
+			<link rel="alternate" type="application/rss+xml" href="javascript:alert(1)"></head></html>`), u)
+		require.NoError(t, err)
+		assert.Equal(t, []feedLink{{URL: srv.URL + "/sub/x.xml", Title: "X"}}, links)
+	})
+}
+
+func TestIsFeedLink(t *testing.T) {
+	tests := []struct {
+		rel, typ string
+		want     bool
+	}{
+		{"alternate", "application/rss+xml", true},
+		{"alternate", "application/atom+xml", true},
+		{"ALTERNATE", "Application/RSS+XML", true},
+		{"alternate home", "application/rss+xml; charset=utf-8", true},
+		{"alternate", "text/html", false},
+		{"stylesheet", "application/rss+xml", false},
+		{"", "application/rss+xml", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel+"|"+tt.typ, func(t *testing.T) {
+			assert.Equal(t, tt.want, isFeedLink(tt.rel, tt.typ))
+		})
+	}
+}
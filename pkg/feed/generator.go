@@ -3,6 +3,7 @@ package feed
 import (
 	"encoding/xml"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -84,69 +85,55 @@ func (g *Generator) convertToRSSItem(item domain.ClassifiedItem) *RSSItem {
 		desc += "\n\n" + item.Description
 	}
 
-	return &RSSItem{
+	rssItem := &RSSItem{
 		Title:       fmt.Sprintf("[%.1f] %s", item.GetRelevanceScore(), item.Title),
-		Link:        item.Link,
+		Link:        item.DisplayLink(),
 		GUID:        item.GUID,
 		Description: desc,
 		Author:      item.Author,
 		PubDate:     item.Published.Format(time.RFC1123Z),
 		Categories:  topics,
 	}
-}
-
-// GenerateOPML creates an OPML file with feed subscriptions
-func (g *Generator) GenerateOPML(feeds []domain.Feed) (string, error) {
-	type outline struct {
-		XMLName xml.Name `xml:"outline"`
-		Text    string   `xml:"text,attr"`
-		Title   string   `xml:"title,attr"`
-		Type    string   `xml:"type,attr"`
-		XMLUrl  string   `xml:"xmlUrl,attr"`
-		HTMLUrl string   `xml:"htmlUrl,attr,omitempty"`
-	}
-
-	type body struct {
-		XMLName  xml.Name  `xml:"body"`
-		Outlines []outline `xml:"outline"`
-	}
 
-	type head struct {
-		XMLName     xml.Name `xml:"head"`
-		Title       string   `xml:"title"`
-		DateCreated string   `xml:"dateCreated"`
-	}
-
-	type opml struct {
-		XMLName xml.Name `xml:"opml"`
-		Version string   `xml:"version,attr"`
-		Head    head     `xml:"head"`
-		Body    body     `xml:"body"`
+	// RSS 2.0 allows a single enclosure per item, the first one is kept
+	if len(item.Enclosures) > 0 {
+		enc := item.Enclosures[0]
+		rssItem.Enclosure = &RSSEnclosure{URL: enc.URL, Type: enc.Type, Length: enc.Length}
 	}
+	return rssItem
+}
 
+// GenerateOPML creates an OPML file with feed subscriptions.
+// feeds with a folder are nested into folder outlines, the fetch interval is kept
+// in the fetchInterval attribute so an export can be imported back without losses.
+func (g *Generator) GenerateOPML(feeds []domain.Feed) (string, error) {
 	// convert feeds to OPML outlines
-	outlines := make([]outline, 0, len(feeds))
+	var outlines []OPMLOutline
 	for _, feed := range feeds {
-		if !feed.Enabled {
-			continue
+		if !feed.Enabled || feed.IsScraped() || feed.IsSitemap() || feed.IsNewsletter() {
+			continue // web pages, sitemaps and newsletters are not feeds other readers could subscribe to
 		}
-		outlines = append(outlines, outline{
+		outline := OPMLOutline{
 			Text:    feed.Title,
 			Title:   feed.Title,
 			Type:    "rss",
 			XMLUrl:  feed.URL,
 			HTMLUrl: feed.URL, // could be improved if we track the website URL separately
-		})
+		}
+		if feed.FetchInterval > 0 {
+			outline.Interval = strconv.Itoa(int(feed.FetchInterval.Minutes()))
+		}
+		outlines = addToFolder(outlines, splitFolder(feed.Folder), outline)
 	}
 
 	// create OPML structure
-	doc := opml{
+	doc := OPML{
 		Version: "2.0",
-		Head: head{
+		Head: OPMLHead{
 			Title:       "Newscope Feed Subscriptions",
 			DateCreated: time.Now().Format(time.RFC1123Z),
 		},
-		Body: body{
+		Body: OPMLBody{
 			Outlines: outlines,
 		},
 	}
@@ -159,3 +146,30 @@ func (g *Generator) GenerateOPML(feeds []domain.Feed) (string, error) {
 
 	return xml.Header + string(output), nil
 }
+
+// addToFolder appends outline to the folder at path, creating missing folder outlines on the way
+func addToFolder(outlines []OPMLOutline, path []string, outline OPMLOutline) []OPMLOutline {
+	if len(path) == 0 {
+		return append(outlines, outline)
+	}
+	for i := range outlines {
+		if outlines[i].XMLUrl == "" && outlines[i].Text == path[0] {
+			outlines[i].Outlines = addToFolder(outlines[i].Outlines, path[1:], outline)
+			return outlines
+		}
+	}
+	folder := OPMLOutline{Text: path[0], Title: path[0]}
+	folder.Outlines = addToFolder(nil, path[1:], outline)
+	return append(outlines, folder)
+}
+
+// splitFolder splits a slash-separated folder path into its non-empty parts
+func splitFolder(folder string) []string {
+	var res []string
+	for _, part := range strings.Split(folder, "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+	return res
+}
@@ -135,6 +135,18 @@ func TestGenerator_convertToRSSItem(t *testing.T) {
 	assert.Contains(t, rssItem.Description, "Score: 9.0/10 - Very relevant")
 	assert.Contains(t, rssItem.Description, "Topics: Tech, AI")
 	assert.Contains(t, rssItem.Description, "Article description")
+
+	assert.Nil(t, rssItem.Enclosure)
+
+	item.CanonicalLink = "https://example.com/news/article"
+	assert.Equal(t, "https://example.com/article", generator.convertToRSSItem(item).Link, "dedupe key not used as link")
+	item.ResolvedLink = "https://example.com/news/article"
+	assert.Equal(t, "https://example.com/news/article", generator.convertToRSSItem(item).Link, "resolved link preferred")
+
+	item.Enclosures = []domain.Enclosure{{URL: "https://cdn.example.com/ep1.mp3", Type: "audio/mpeg", Length: 1024},
+		{URL: "https://cdn.example.com/ep1.ogg", Type: "audio/ogg"}}
+	assert.Equal(t, &RSSEnclosure{URL: "https://cdn.example.com/ep1.mp3", Type: "audio/mpeg", Length: 1024},
+		generator.convertToRSSItem(item).Enclosure)
 }
 
 func TestGenerator_GenerateOPML(t *testing.T) {
@@ -159,6 +171,20 @@ func TestGenerator_GenerateOPML(t *testing.T) {
 			URL:     "https://disabled.com/feed",
 			Enabled: false,
 		},
+		{
+			ID:      4,
+			Type:    domain.FeedTypeHTML,
+			Title:   "Scraped Page",
+			URL:     "https://scraped.com/news",
+			Enabled: true,
+		},
+		{
+			ID:      5,
+			Type:    domain.FeedTypeNewsletter,
+			Title:   "Weekly Letter",
+			URL:     "mailto:weekly@example.com",
+			Enabled: true,
+		},
 	}
 
 	opml, err := generator.GenerateOPML(feeds)
@@ -182,6 +208,10 @@ func TestGenerator_GenerateOPML(t *testing.T) {
 	// check disabled feed is not included
 	assert.NotContains(t, opml, "Disabled Feed")
 	assert.NotContains(t, opml, "disabled.com")
+
+	// check web page and newsletter feeds are not included
+	assert.NotContains(t, opml, "scraped.com")
+	assert.NotContains(t, opml, "mailto:")
 }
 
 func TestRSSXMLStructure(t *testing.T) {
@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// OPMLEntry represents a subscription found in an OPML document
+type OPMLEntry struct {
+	Title    string // outline title, falls back to outline text
+	URL      string // xmlUrl attribute as is, not validated
+	Folder   string // slash-separated path of enclosing folder outlines
+	Interval string // raw fetchInterval attribute in minutes, empty if not set
+}
+
+// ParseOPML reads an OPML document and returns all subscriptions in document order.
+// nested folder outlines are flattened into the entry's folder path.
+func ParseOPML(r io.Reader) ([]OPMLEntry, error) {
+	var doc OPML
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charset.NewReaderLabel
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode OPML: %w", err)
+	}
+
+	var entries []OPMLEntry
+	var walk func(outlines []OPMLOutline, path []string)
+	walk = func(outlines []OPMLOutline, path []string) {
+		for _, o := range outlines {
+			name := strings.TrimSpace(o.Title)
+			if name == "" {
+				name = strings.TrimSpace(o.Text)
+			}
+
+			if o.XMLUrl == "" {
+				// an outline without feed URL is a folder, nameless folders don't add a level
+				sub := path
+				if name != "" {
+					sub = append(append([]string{}, path...), strings.ReplaceAll(name, "/", "-"))
+				}
+				walk(o.Outlines, sub)
+				continue
+			}
+
+			entries = append(entries, OPMLEntry{
+				Title:    name,
+				URL:      strings.TrimSpace(o.XMLUrl),
+				Folder:   strings.Join(path, "/"),
+				Interval: strings.TrimSpace(o.Interval),
+			})
+			walk(o.Outlines, path) // feed outlines normally have no children, but don't lose them
+		}
+	}
+	walk(doc.Body.Outlines, nil)
+
+	return entries, nil
+}
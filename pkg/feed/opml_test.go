@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestParseOPML(t *testing.T) {
+	tests := []struct {
+		name    string
+		opml    string
+		want    []OPMLEntry
+		wantErr bool
+	}{
+		{
+			name: "flat list",
+			opml: `<?xml version="1.0"?>
+<opml version="1.0"><head><title>subs</title></head><body>
+  <outline text="Feed 1" type="rss" xmlUrl="https://example.com/1.xml"/>
+  <outline text="text only" title="Feed 2" type="rss" xmlUrl=" https://example.com/2.xml "/>
+</body></opml>`,
+			want: []OPMLEntry{
+				{Title: "Feed 1", URL: "https://example.com/1.xml"},
+				{Title: "Feed 2", URL: "https://example.com/2.xml"},
+			},
+		},
+		{
+			name: "nested folders and intervals",
+			opml: `<?xml version="1.0"?>
+<opml version="2.0"><head><title>subs</title></head><body>
+  <outline text="Tech">
+    <outline text="Go">
+      <outline text="Go Blog" xmlUrl="https://go.dev/blog/feed.atom" fetchInterval="120"/>
+    </outline>
+    <outline text="HN" xmlUrl="https://news.ycombinator.com/rss" fetchInterval="15"/>
+  </outline>
+  <outline text="">
+    <outline text="Unnamed folder child" xmlUrl="https://example.com/u.xml"/>
+  </outline>
+  <outline text="just a note"/>
+</body></opml>`,
+			want: []OPMLEntry{
+				{Title: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech/Go", Interval: "120"},
+				{Title: "HN", URL: "https://news.ycombinator.com/rss", Folder: "Tech", Interval: "15"},
+				{Title: "Unnamed folder child", URL: "https://example.com/u.xml"},
+			},
+		},
+		{
+			name: "non-utf8 encoding",
+			opml: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<opml version=\"1.0\"><body>" +
+				"<outline text=\"Caf\xe9\" xmlUrl=\"https://example.com/cafe.xml\"/></body></opml>",
+			want: []OPMLEntry{{Title: "Café", URL: "https://example.com/cafe.xml"}},
+		},
+		{
+			name: "empty body",
+			opml: `<opml version="2.0"><head/><body/></opml>`,
+			want: nil,
+		},
+		{
+			name:    "not opml",
+			opml:    `<rss version="2.0"><channel/></rss>`,
+			wantErr: true,
+		},
+		{
+			name:    "broken xml",
+			opml:    `<opml><body><outline`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseOPML(strings.NewReader(tt.opml))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entries)
+		})
+	}
+}
+
+func TestOPML_RoundTrip(t *testing.T) {
+	feeds := []domain.Feed{
+		{Title: "Top", URL: "https://example.com/top.xml", FetchInterval: 30 * time.Minute, Enabled: true},
+		{Title: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech/Go", FetchInterval: 2 * time.Hour, Enabled: true},
+		{Title: "HN", URL: "https://news.ycombinator.com/rss", Folder: "Tech", Enabled: true},
+		{Title: "Rust", URL: "https://blog.rust-lang.org/feed.xml", Folder: "Tech/Rust", FetchInterval: time.Hour, Enabled: true},
+	}
+
+	opml, err := NewGenerator("https://example.com").GenerateOPML(feeds)
+	require.NoError(t, err)
+
+	// single Tech folder holding both sub-folders
+	assert.Equal(t, 1, strings.Count(opml, `text="Tech"`))
+
+	entries, err := ParseOPML(strings.NewReader(opml))
+	require.NoError(t, err)
+	assert.Equal(t, []OPMLEntry{
+		{Title: "Top", URL: "https://example.com/top.xml", Interval: "30"},
+		{Title: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech/Go", Interval: "120"},
+		{Title: "HN", URL: "https://news.ycombinator.com/rss", Folder: "Tech"},
+		{Title: "Rust", URL: "https://blog.rust-lang.org/feed.xml", Folder: "Tech/Rust", Interval: "60"},
+	}, entries)
+}
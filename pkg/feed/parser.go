@@ -1,18 +1,23 @@
 package feed
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
 
 	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
 )
 
-// Parser parses RSS/Atom feeds
+// Parser parses RSS/Atom feeds and scrapes web pages without feeds
 type Parser struct {
 	client    *http.Client
 	userAgent string
@@ -22,7 +27,8 @@ type Parser struct {
 func NewParser(timeout time.Duration, userAgent string) *Parser {
 	return &Parser{
 		client: &http.Client{
-			Timeout: timeout,
+			Timeout:       timeout,
+			CheckRedirect: httpclient.CheckAuthRedirect,
 			Transport: &http.Transport{
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
@@ -33,28 +39,96 @@ func NewParser(timeout time.Duration, userAgent string) *Parser {
 	}
 }
 
+// SetTransport sets the transport used for requests, e.g. one with a proxy or custom root CAs
+func (p *Parser) SetTransport(t http.RoundTripper) {
+	p.client.Transport = t
+}
+
 // Parse fetches and parses a feed from the given URL
 func (p *Parser) Parse(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	return p.Fetch(ctx, &domain.Feed{URL: url})
+}
+
+// Fetch fetches and parses the given feed, web page feeds are scraped with their selectors
+// and sitemap feeds read from sitemaps. If the feed has validators from a previous fetch
+// (ETag, Last-Modified), the request is made conditional and a 304 response is returned
+// as a ParsedFeed with NotModified set and no items. A feed reached only through permanent
+// redirects and parsed successfully or not modified has MovedTo set, a 410 response returns domain.ErrFeedGone.
+func (p *Parser) Fetch(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 	// fetch feed content
-	body, err := p.fetch(ctx, url)
+	resp, err := p.fetch(ctx, f)
 	if err != nil {
 		return nil, fmt.Errorf("fetch feed: %w", err)
 	}
-	defer body.Close()
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return &domain.ParsedFeed{NotModified: true, ETag: f.ETag, LastModified: f.LastModified, MovedTo: movedTo(resp)}, nil
+	}
+
+	// parse feed or scrape the page, counting the bytes read to know what a 304 saves next time
+	body := &countingReader{r: resp.Body}
+	var result *domain.ParsedFeed
+	conditional := true
+	switch {
+	case f.IsScraped():
+		result, err = scrape(body, resp.Request.URL, resp.Header.Get("Content-Type"), f.Selectors)
+	case f.IsSitemap():
+		var index bool
+		result, index, err = p.sitemap(ctx, body, f)
+		conditional = !index // an unchanged index doesn't mean unchanged child sitemaps
+	default:
+		result, err = p.parse(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if conditional {
+		result.ETag = resp.Header.Get("ETag")
+		result.LastModified = resp.Header.Get("Last-Modified")
+	}
+	result.Size = body.n
+	result.MovedTo = movedTo(resp)
+	return result, nil
+}
 
-	// parse feed
+// movedTo returns the final URL of the response if it was reached only through permanent redirects,
+// empty if there were no redirects or some of them were temporary
+func movedTo(resp *http.Response) string {
+	if resp.Request == nil || resp.Request.Response == nil {
+		return ""
+	}
+	for r := resp.Request; r.Response != nil; r = r.Response.Request {
+		if code := r.Response.StatusCode; code != http.StatusMovedPermanently && code != http.StatusPermanentRedirect {
+			return ""
+		}
+	}
+	return resp.Request.URL.String()
+}
+
+// ParseContent parses feed content received without fetching, e.g. pushed by a WebSub hub
+func (p *Parser) ParseContent(content []byte) (*domain.ParsedFeed, error) {
+	return p.parse(bytes.NewReader(content))
+}
+
+// parse parses RSS/Atom/JSON feed from the reader and converts it to our types
+func (p *Parser) parse(r io.Reader) (*domain.ParsedFeed, error) {
 	parser := gofeed.NewParser()
-	feed, err := parser.Parse(body)
+	parser.AtomTranslator = &hubAtomTranslator{}
+	feed, err := parser.Parse(r)
 	if err != nil {
 		return nil, fmt.Errorf("parse feed: %w", err)
 	}
 
 	// convert to our types
+	hub, self := hubLinks(feed)
 	result := &domain.ParsedFeed{
 		Title:       feed.Title,
 		Description: feed.Description,
 		Link:        feed.Link,
 		Items:       make([]domain.ParsedItem, 0, len(feed.Items)),
+		HubURL:      hub,
+		SelfURL:     self,
 	}
 
 	for _, item := range feed.Items {
@@ -63,6 +137,9 @@ func (p *Parser) Parse(ctx context.Context, url string) (*domain.ParsedFeed, err
 			Link:        item.Link,
 			Description: item.Description,
 			Content:     item.Content,
+			Image:       itemImage(item),
+			Enclosures:  enclosures(item.Enclosures),
+			Categories:  item.Categories,
 		}
 
 		// set GUID
@@ -92,9 +169,54 @@ func (p *Parser) Parse(ctx context.Context, url string) (*domain.ParsedFeed, err
 	return result, nil
 }
 
-// fetch retrieves content from a URL
-func (p *Parser) fetch(ctx context.Context, url string) (io.ReadCloser, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+// itemImage returns the lead image of the item: media:thumbnail, also nested in media:group, or the image
+// found by gofeed (itunes:image, image media:content or enclosure, the first image of the content).
+// relative image URLs are skipped.
+func itemImage(item *gofeed.Item) string {
+	var candidates []string
+	if media, ok := item.Extensions["media"]; ok {
+		for _, thumb := range media["thumbnail"] {
+			candidates = append(candidates, thumb.Attrs["url"])
+		}
+		for _, group := range media["group"] {
+			for _, thumb := range group.Children["thumbnail"] {
+				candidates = append(candidates, thumb.Attrs["url"])
+			}
+		}
+	}
+	if item.Image != nil {
+		candidates = append(candidates, item.Image.URL)
+	}
+	for _, c := range candidates {
+		if u, err := url.Parse(c); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// enclosures converts enclosures of the item, skipping ones without a URL. missing or invalid length is zero.
+func enclosures(encs []*gofeed.Enclosure) []domain.Enclosure {
+	var res []domain.Enclosure
+	for _, e := range encs {
+		if e == nil || e.URL == "" {
+			continue
+		}
+		length, err := strconv.ParseInt(strings.TrimSpace(e.Length), 10, 64)
+		if err != nil || length < 0 {
+			length = 0
+		}
+		res = append(res, domain.Enclosure{URL: e.URL, Type: e.Type, Length: length})
+	}
+	return res
+}
+
+// fetch retrieves feed content, returns response with either 200 or 304 status
+func (p *Parser) fetch(ctx context.Context, f *domain.Feed) (*http.Response, error) {
+	// the feed's proxy is picked by transports from httpclient.NewTransport,
+	// the credentials are dropped from redirects to other hosts
+	ctx = httpclient.WithAuth(httpclient.WithProxy(ctx, f.Proxy), f.Auth)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -104,15 +226,42 @@ func (p *Parser) fetch(ctx context.Context, url string) (io.ReadCloser, error) {
 	// add browser-like headers
 	addBrowserHeaders(req)
 
+	// credentials of a private feed override the headers above
+	httpclient.ApplyAuth(req, f.Auth)
+
+	// make request conditional if we have validators from the previous fetch
+	if f.ETag != "" {
+		req.Header.Set("If-None-Match", f.ETag)
+	}
+	if f.LastModified != "" {
+		req.Header.Set("If-Modified-Since", f.LastModified)
+	}
+
 	resp, err := p.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("fetch URL: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode == http.StatusGone {
+		_ = resp.Body.Close()
+		return nil, domain.ErrFeedGone
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotModified {
 		_ = resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, &domain.StatusError{StatusCode: resp.StatusCode}
 	}
 
-	return resp.Body, nil
+	return resp, nil
+}
+
+// countingReader counts bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
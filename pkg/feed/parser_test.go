@@ -4,11 +4,15 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
 )
 
 func TestParser_Parse(t *testing.T) {
@@ -108,6 +112,75 @@ func TestParser_Parse_AtomFeed(t *testing.T) {
 	assert.Equal(t, "John Doe", item.Author)
 }
 
+func TestParser_ParseContent_Media(t *testing.T) {
+	parser := NewParser(5*time.Second, "TestAgent/1.0")
+
+	t.Run("rss", func(t *testing.T) {
+		feed, err := parser.ParseContent([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+	<title>Podcast</title>
+	<item>
+		<title>Episode 1</title>
+		<link>http://example.com/ep1</link>
+		<category>Tech</category>
+		<category>Interviews</category>
+		<itunes:image href="https://example.com/ep1.jpg"/>
+		<enclosure url="https://cdn.example.com/ep1.mp3" type="audio/mpeg" length="12345678"/>
+	</item>
+	<item>
+		<title>News</title>
+		<link>http://example.com/news</link>
+		<media:thumbnail url="https://example.com/thumb.jpg" width="120"/>
+		<description><![CDATA[<img src="https://example.com/inline.jpg"> text]]></description>
+		<enclosure url="https://example.com/clip.mp4" type="video/mp4" length="unknown"/>
+	</item>
+	<item>
+		<title>Relative image</title>
+		<link>http://example.com/rel</link>
+		<description><![CDATA[<img src="/inline.jpg"> text]]></description>
+	</item>
+</channel>
+</rss>`))
+		require.NoError(t, err)
+		require.Len(t, feed.Items, 3)
+
+		assert.Equal(t, "https://example.com/ep1.jpg", feed.Items[0].Image)
+		assert.Equal(t, []domain.Enclosure{{URL: "https://cdn.example.com/ep1.mp3", Type: "audio/mpeg", Length: 12345678}},
+			feed.Items[0].Enclosures)
+		assert.Equal(t, []string{"Tech", "Interviews"}, feed.Items[0].Categories)
+
+		assert.Equal(t, "https://example.com/thumb.jpg", feed.Items[1].Image, "media:thumbnail preferred over content image")
+		assert.Equal(t, []domain.Enclosure{{URL: "https://example.com/clip.mp4", Type: "video/mp4"}}, feed.Items[1].Enclosures)
+
+		assert.Empty(t, feed.Items[2].Image, "relative image skipped")
+		assert.Empty(t, feed.Items[2].Enclosures)
+		assert.Empty(t, feed.Items[2].Categories)
+	})
+
+	t.Run("atom with media group", func(t *testing.T) {
+		feed, err := parser.ParseContent([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+	<title>Channel</title>
+	<entry>
+		<title>Video</title>
+		<id>yt:video:1</id>
+		<link href="https://www.youtube.com/watch?v=1"/>
+		<link rel="enclosure" href="https://example.com/audio.m4a" type="audio/mp4" length="42"/>
+		<category term="Science"/>
+		<media:group>
+			<media:thumbnail url="https://i.ytimg.com/vi/1/hqdefault.jpg" width="480" height="360"/>
+		</media:group>
+	</entry>
+</feed>`))
+		require.NoError(t, err)
+		require.Len(t, feed.Items, 1)
+		assert.Equal(t, "https://i.ytimg.com/vi/1/hqdefault.jpg", feed.Items[0].Image)
+		assert.Equal(t, []domain.Enclosure{{URL: "https://example.com/audio.m4a", Type: "audio/mp4", Length: 42}}, feed.Items[0].Enclosures)
+		assert.Equal(t, []string{"Science"}, feed.Items[0].Categories)
+	})
+}
+
 func TestParser_Parse_Errors(t *testing.T) {
 	t.Run("HTTP error", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -119,6 +192,9 @@ func TestParser_Parse_Errors(t *testing.T) {
 		_, err := parser.Parse(context.Background(), server.URL)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unexpected status code: 500")
+		var statusErr *domain.StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
 	})
 
 	t.Run("Invalid XML", func(t *testing.T) {
@@ -211,3 +287,202 @@ func TestParser_UserAgent(t *testing.T) {
 	assert.NotEmpty(t, capturedHeaders.Get("Accept-Language"))
 	assert.Equal(t, "keep-alive", capturedHeaders.Get("Connection"))
 }
+
+func TestParser_Fetch_Conditional(t *testing.T) {
+	rssContent := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+<channel>
+	<title>Test Feed</title>
+	<item><title>Article</title><link>http://example.com/a</link></item>
+</channel>
+</rss>`
+	const etag = `"v1"`
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+	var gotIfNoneMatch, gotIfModifiedSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotIfNoneMatch = r.Header.Get("If-None-Match")
+		gotIfModifiedSince = r.Header.Get("If-Modified-Since")
+		if gotIfNoneMatch == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(rssContent))
+	}))
+	defer server.Close()
+
+	parser := NewParser(5*time.Second, "TestAgent/1.0")
+
+	t.Run("first fetch returns validators and size", func(t *testing.T) {
+		feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL})
+		require.NoError(t, err)
+		assert.Empty(t, gotIfNoneMatch)
+		assert.Empty(t, gotIfModifiedSince)
+		assert.False(t, feed.NotModified)
+		assert.Equal(t, etag, feed.ETag)
+		assert.Equal(t, lastModified, feed.LastModified)
+		assert.Equal(t, int64(len(rssContent)), feed.Size)
+		assert.Len(t, feed.Items, 1)
+	})
+
+	t.Run("conditional fetch not modified", func(t *testing.T) {
+		feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL, ETag: etag, LastModified: lastModified})
+		require.NoError(t, err)
+		assert.Equal(t, etag, gotIfNoneMatch)
+		assert.Equal(t, lastModified, gotIfModifiedSince)
+		assert.True(t, feed.NotModified)
+		assert.Empty(t, feed.Items)
+		assert.Zero(t, feed.Size)
+		assert.Equal(t, etag, feed.ETag, "validators preserved")
+	})
+
+	t.Run("stale validators get full response", func(t *testing.T) {
+		feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL, ETag: `"v0"`})
+		require.NoError(t, err)
+		assert.False(t, feed.NotModified)
+		assert.Equal(t, etag, feed.ETag)
+		assert.Len(t, feed.Items, 1)
+	})
+}
+
+func TestParser_Fetch_Auth(t *testing.T) {
+	rssContent := `<rss version="2.0"><channel><title>Private</title><item><title>A</title><link>http://example.com/a</link></item></channel></rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "abc", r.Header.Get("Private-Token"))
+		assert.Equal(t, "session=1", r.Header.Get("Cookie"))
+		_, _ = w.Write([]byte(rssContent))
+	}))
+	defer server.Close()
+
+	parser := NewParser(5*time.Second, "TestAgent/1.0")
+
+	_, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+
+	auth := domain.FeedAuth{Username: "user", Password: "pass", Headers: map[string]string{"Private-Token": "abc"}, Cookies: "session=1"}
+	feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL, Auth: auth})
+	require.NoError(t, err)
+	assert.Len(t, feed.Items, 1)
+
+	t.Run("credentials dropped on redirect to another host", func(t *testing.T) {
+		var captured http.Header
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = r.Header.Clone()
+			_, _ = w.Write([]byte(rssContent))
+		}))
+		defer other.Close()
+		// the second server is reached as localhost, a host other than 127.0.0.1 of the feed
+		otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+		mux := http.NewServeMux()
+		mux.Handle("/away.xml", http.RedirectHandler(otherURL+"/feed.xml", http.StatusFound))
+		mux.Handle("/same.xml", http.RedirectHandler(other.URL+"/feed.xml", http.StatusFound))
+		feedServer := httptest.NewServer(mux)
+		defer feedServer.Close()
+
+		_, err := parser.Fetch(context.Background(), &domain.Feed{URL: feedServer.URL + "/away.xml", Auth: auth})
+		require.NoError(t, err)
+		assert.Empty(t, captured.Get("Authorization"))
+		assert.Empty(t, captured.Get("Private-Token"))
+		assert.Empty(t, captured.Get("Cookie"))
+
+		_, err = parser.Fetch(context.Background(), &domain.Feed{URL: feedServer.URL + "/same.xml", Auth: auth})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", captured.Get("Private-Token"), "kept on the same host")
+	})
+}
+
+func TestParser_Fetch_Redirects(t *testing.T) {
+	rssContent := `<rss version="2.0"><channel><title>Moved</title><item><title>A</title></item></channel></rss>`
+	mux := http.NewServeMux()
+	mux.HandleFunc("/new.xml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(rssContent))
+	})
+	mux.Handle("/old.xml", http.RedirectHandler("/older.xml", http.StatusMovedPermanently))
+	mux.Handle("/older.xml", http.RedirectHandler("/new.xml", http.StatusPermanentRedirect))
+	mux.Handle("/temp.xml", http.RedirectHandler("/new.xml", http.StatusFound))
+	mux.Handle("/mixed.xml", http.RedirectHandler("/temp.xml", http.StatusMovedPermanently))
+	mux.Handle("/broken.xml", http.RedirectHandler("/missing.xml", http.StatusMovedPermanently))
+	mux.HandleFunc("/gone.xml", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusGone) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	parser := NewParser(5*time.Second, "TestAgent/1.0")
+
+	tests := []struct {
+		path, movedTo string
+	}{
+		{path: "/new.xml", movedTo: ""},
+		{path: "/old.xml", movedTo: server.URL + "/new.xml"},
+		{path: "/temp.xml", movedTo: ""},
+		{path: "/mixed.xml", movedTo: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL + tt.path})
+			require.NoError(t, err)
+			assert.Equal(t, "Moved", feed.Title)
+			assert.Equal(t, tt.movedTo, feed.MovedTo)
+		})
+	}
+
+	t.Run("not modified after permanent redirects", func(t *testing.T) {
+		f := &domain.Feed{URL: server.URL + "/old.xml"}
+		feed, err := parser.Fetch(context.Background(), f)
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/new.xml", feed.MovedTo)
+		require.Equal(t, `"v1"`, feed.ETag)
+
+		f.ETag = feed.ETag
+		feed, err = parser.Fetch(context.Background(), f)
+		require.NoError(t, err)
+		assert.True(t, feed.NotModified)
+		assert.Equal(t, server.URL+"/new.xml", feed.MovedTo, "the redirect is seen on 304 responses too")
+
+		f.URL = server.URL + "/temp.xml"
+		feed, err = parser.Fetch(context.Background(), f)
+		require.NoError(t, err)
+		assert.True(t, feed.NotModified)
+		assert.Empty(t, feed.MovedTo)
+	})
+
+	t.Run("redirect to a broken feed", func(t *testing.T) {
+		_, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL + "/broken.xml"})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrFeedGone)
+	})
+
+	t.Run("gone", func(t *testing.T) {
+		_, err := parser.Fetch(context.Background(), &domain.Feed{URL: server.URL + "/gone.xml"})
+		require.ErrorIs(t, err, domain.ErrFeedGone)
+	})
+}
+
+func TestParser_Fetch_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String()) // plain http proxy gets the absolute URL
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Proxied</title></channel></rss>`))
+	}))
+	defer proxy.Close()
+
+	transport, err := httpclient.NewTransport(httpclient.Params{Proxy: httpclient.Direct})
+	require.NoError(t, err)
+	parser := NewParser(5*time.Second, "TestAgent/1.0")
+	parser.SetTransport(transport)
+
+	feed, err := parser.Fetch(context.Background(), &domain.Feed{URL: "http://feeds.example.com/rss", Proxy: proxy.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "Proxied", feed.Title)
+	assert.Equal(t, []string{"http://feeds.example.com/rss"}, proxied)
+}
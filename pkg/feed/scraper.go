@@ -0,0 +1,175 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/araddon/dateparse"
+	"golang.org/x/net/html/charset"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// maxScrapedItems limits the number of items taken from a scraped page
+const maxScrapedItems = 100
+
+// Scrape fetches a web page and extracts items with the given selectors without storing anything,
+// used to try selectors before a web page feed is saved
+func (p *Parser) Scrape(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	if err := ValidateSelectors(sel); err != nil {
+		return nil, err
+	}
+	return p.Fetch(ctx, &domain.Feed{Type: domain.FeedTypeHTML, URL: pageURL, Selectors: sel})
+}
+
+// ValidateSelectors checks that item and title selectors are set and all selectors are valid CSS
+func ValidateSelectors(sel domain.ScrapeSelectors) error {
+	if strings.TrimSpace(sel.Item) == "" {
+		return errors.New("item selector is required")
+	}
+	if strings.TrimSpace(sel.Title) == "" {
+		return errors.New("title selector is required")
+	}
+
+	selectors := []struct{ name, value string }{
+		{"item", sel.Item}, {"title", sel.Title}, {"link", sel.Link}, {"date", sel.Date}, {"summary", sel.Summary},
+	}
+	for _, s := range selectors {
+		if s.value == "" {
+			continue
+		}
+		if _, err := cascadia.Compile(s.value); err != nil {
+			return fmt.Errorf("invalid %s selector %q: %w", s.name, s.value, err)
+		}
+	}
+	return nil
+}
+
+// scrape extracts feed items from a web page. base is the page URL used to resolve relative links,
+// contentType is the response header used to detect the page charset.
+// a page without matching items is an error, most likely the site layout changed.
+func scrape(r io.Reader, base *url.URL, contentType string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	if err := ValidateSelectors(sel); err != nil {
+		return nil, err
+	}
+
+	utf8Reader, err := charset.NewReader(r, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("detect page charset: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(utf8Reader)
+	if err != nil {
+		return nil, fmt.Errorf("parse page: %w", err)
+	}
+
+	result := &domain.ParsedFeed{
+		Title: collapseSpaces(doc.Find("title").First().Text()),
+		Link:  base.String(),
+	}
+	if desc, ok := doc.Find(`meta[name="description"]`).First().Attr("content"); ok {
+		result.Description = collapseSpaces(desc)
+	}
+
+	// links are relative to <base href> if the page has one
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			base = u
+		}
+	}
+
+	seen := map[string]bool{}
+	doc.Find(sel.Item).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		item, ok := scrapeItem(s, base, sel)
+		if ok && !seen[item.GUID] {
+			seen[item.GUID] = true
+			result.Items = append(result.Items, item)
+		}
+		return len(result.Items) < maxScrapedItems
+	})
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("no items found with selector %q", sel.Item)
+	}
+	return result, nil
+}
+
+// scrapeItem extracts a single item from its container. The link is taken from the link selector,
+// or the title if it is a link, or the first link in the container. Items without both title
+// and link are skipped.
+func scrapeItem(s *goquery.Selection, base *url.URL, sel domain.ScrapeSelectors) (domain.ParsedItem, bool) {
+	titleEl := s.Find(sel.Title).First()
+
+	var linkEl *goquery.Selection
+	switch {
+	case sel.Link != "":
+		linkEl = s.Find(sel.Link).First()
+		if !linkEl.Is("[href]") {
+			linkEl = linkEl.Find("a[href]").First()
+		}
+	case titleEl.Is("a[href]"):
+		linkEl = titleEl
+	case s.Is("a[href]"):
+		linkEl = s
+	default:
+		linkEl = s.Find("a[href]").First()
+	}
+
+	var link string
+	if href, ok := linkEl.Attr("href"); ok {
+		if u, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			link = u.String()
+		}
+	}
+
+	title := collapseSpaces(titleEl.Text())
+	if title == "" {
+		title = collapseSpaces(linkEl.Text())
+	}
+	if title == "" && link == "" {
+		return domain.ParsedItem{}, false
+	}
+
+	item := domain.ParsedItem{Title: title, Link: link, GUID: link}
+	if item.GUID == "" {
+		item.GUID = base.String() + "#" + title
+	}
+	if sel.Summary != "" {
+		item.Description = collapseSpaces(s.Find(sel.Summary).First().Text())
+	}
+	if sel.Date != "" {
+		item.Published = scrapeDate(s.Find(sel.Date).First())
+	}
+	return item, true
+}
+
+// scrapeDate parses the date of an element, preferring machine-readable datetime and content
+// attributes over the text. returns zero time if the date can't be parsed.
+func scrapeDate(el *goquery.Selection) time.Time {
+	value := el.AttrOr("datetime", "")
+	if value == "" {
+		value = el.AttrOr("content", "")
+	}
+	if value == "" {
+		value = collapseSpaces(el.Text())
+	}
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := dateparse.ParseAny(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// collapseSpaces trims the text and replaces runs of whitespace with a single space
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
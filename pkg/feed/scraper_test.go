@@ -0,0 +1,142 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+const scrapedPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>  Example   News </title>
+	<meta name="description" content="Latest news from example">
+</head>
+<body>
+	<div class="post">
+		<h2><a href="/posts/first">First   post</a></h2>
+		<time datetime="2024-03-01T10:00:00Z">March 1</time>
+		<p class="summary">Summary of the first post</p>
+	</div>
+	<div class="post">
+		<h2>Second post</h2>
+		<span class="date">2024-03-02 08:30</span>
+		<a class="more" href="https://other.example.com/second">Read more</a>
+	</div>
+	<div class="post">
+		<h2><a href="/posts/first">First post again</a></h2>
+	</div>
+	<div class="post"><h2></h2></div>
+</body>
+</html>`
+
+func TestParser_Scrape(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(scrapedPage))
+	}))
+	defer ts.Close()
+	p := NewParser(5*time.Second, "test-agent")
+
+	t.Run("extracts items", func(t *testing.T) {
+		sel := domain.ScrapeSelectors{Item: "div.post", Title: "h2", Date: "time, .date", Summary: ".summary"}
+		feed, err := p.Scrape(context.Background(), ts.URL+"/news", sel)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Example News", feed.Title)
+		assert.Equal(t, "Latest news from example", feed.Description)
+		require.Len(t, feed.Items, 2, "duplicate link and empty item skipped")
+
+		assert.Equal(t, "First post", feed.Items[0].Title)
+		assert.Equal(t, ts.URL+"/posts/first", feed.Items[0].Link)
+		assert.Equal(t, ts.URL+"/posts/first", feed.Items[0].GUID)
+		assert.Equal(t, "Summary of the first post", feed.Items[0].Description)
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), feed.Items[0].Published.UTC())
+
+		assert.Equal(t, "Second post", feed.Items[1].Title)
+		assert.Equal(t, "https://other.example.com/second", feed.Items[1].Link, "first link in the item")
+		assert.Equal(t, time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC), feed.Items[1].Published.UTC())
+		assert.Empty(t, feed.Items[1].Description)
+	})
+
+	t.Run("link selector", func(t *testing.T) {
+		sel := domain.ScrapeSelectors{Item: "div.post", Title: "h2", Link: "a.more"}
+		feed, err := p.Scrape(context.Background(), ts.URL+"/news", sel)
+		require.NoError(t, err)
+		require.Len(t, feed.Items, 3)
+		assert.Equal(t, "https://other.example.com/second", feed.Items[1].Link)
+		assert.Empty(t, feed.Items[0].Link, "no element matches the link selector")
+		assert.Equal(t, ts.URL+"/news#First post", feed.Items[0].GUID)
+	})
+
+	t.Run("no matching items", func(t *testing.T) {
+		_, err := p.Scrape(context.Background(), ts.URL, domain.ScrapeSelectors{Item: "article", Title: "h1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no items found with selector "article"`)
+	})
+
+	t.Run("invalid selectors are not fetched", func(t *testing.T) {
+		_, err := p.Scrape(context.Background(), "http://127.0.0.1:1/", domain.ScrapeSelectors{Item: "div[", Title: "h2"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid item selector")
+	})
+}
+
+func TestParser_Fetch_ScrapedFeed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/html; charset=windows-1251")
+		// "Новости" in windows-1251
+		_, _ = w.Write([]byte("<html><body><article><a href=\"/n1\">\xcd\xee\xe2\xee\xf1\xf2\xe8</a></article></body></html>"))
+	}))
+	defer ts.Close()
+
+	p := NewParser(5*time.Second, "test-agent")
+	f := &domain.Feed{Type: domain.FeedTypeHTML, URL: ts.URL, Selectors: domain.ScrapeSelectors{Item: "article", Title: "a"}}
+	feed, err := p.Fetch(context.Background(), f)
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, "Новости", feed.Items[0].Title)
+	assert.Equal(t, ts.URL+"/n1", feed.Items[0].Link)
+	assert.Equal(t, `"v1"`, feed.ETag)
+
+	f.ETag = feed.ETag
+	feed, err = p.Fetch(context.Background(), f)
+	require.NoError(t, err)
+	assert.True(t, feed.NotModified)
+}
+
+func TestValidateSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     domain.ScrapeSelectors
+		wantErr string
+	}{
+		{name: "valid", sel: domain.ScrapeSelectors{Item: "div.post", Title: "h2 > a", Link: "a", Date: "time", Summary: "p:first-of-type"}},
+		{name: "missing item", sel: domain.ScrapeSelectors{Title: "h2"}, wantErr: "item selector is required"},
+		{name: "missing title", sel: domain.ScrapeSelectors{Item: "div", Title: " "}, wantErr: "title selector is required"},
+		{name: "invalid date", sel: domain.ScrapeSelectors{Item: "div", Title: "h2", Date: "::"}, wantErr: `invalid date selector "::"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSelectors(tt.sel)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
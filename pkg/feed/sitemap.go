@@ -0,0 +1,182 @@
+package feed
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"golang.org/x/net/html/charset"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
+)
+
+const (
+	maxSitemapItems    = 100      // newest URLs taken from a sitemap
+	maxSitemapChildren = 5        // newest child sitemaps read from a sitemap index
+	maxSitemapSize     = 50 << 20 // sitemap protocol limit for uncompressed sitemap
+)
+
+// sitemapDoc is either a urlset or a sitemapindex, told apart by the root element
+type sitemapDoc struct {
+	XMLName  xml.Name
+	URLs     []sitemapURL   `xml:"url"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+// sitemapURL is a <url> entry, News is set for Google News sitemaps
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+	News    struct {
+		PublicationDate string `xml:"publication_date"`
+		Title           string `xml:"title"`
+	} `xml:"news"`
+}
+
+// sitemapEntry is a child sitemap of a sitemap index
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// sitemap reads items from a sitemap or a sitemap index. for an index the newest child sitemaps
+// are fetched and merged, index reports it so the caller doesn't make the next fetch conditional
+// on the index validators while children could change.
+// items are the newest URLs, titles are set for news sitemaps only and left to the extractor otherwise.
+// child sitemaps on the feed's host are fetched with the feed credentials.
+func (p *Parser) sitemap(ctx context.Context, r io.Reader, f *domain.Feed) (result *domain.ParsedFeed, index bool, err error) {
+	doc, err := decodeSitemap(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch doc.XMLName.Local {
+	case "urlset":
+		return &domain.ParsedFeed{Items: sitemapItems(doc.URLs)}, false, nil
+	case "sitemapindex":
+	default:
+		return nil, false, fmt.Errorf("parse sitemap: unexpected root element %q", doc.XMLName.Local)
+	}
+
+	children := doc.Sitemaps
+	sort.SliceStable(children, func(i, j int) bool {
+		return parseSitemapDate(children[i].LastMod).After(parseSitemapDate(children[j].LastMod))
+	})
+	if len(children) > maxSitemapChildren {
+		children = children[:maxSitemapChildren]
+	}
+
+	// nested indexes are not followed, a broken child sitemap doesn't fail the others
+	var urls []sitemapURL
+	var errs []error
+	for _, child := range children {
+		childURL := strings.TrimSpace(child.Loc)
+		childDoc, err := p.fetchSitemap(ctx, &domain.Feed{URL: childURL, Auth: httpclient.AuthFor(f, childURL), Proxy: f.Proxy})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("child sitemap %s: %w", child.Loc, err))
+			continue
+		}
+		urls = append(urls, childDoc.URLs...)
+	}
+	if len(urls) == 0 && len(errs) > 0 {
+		return nil, true, errors.Join(errs...)
+	}
+	return &domain.ParsedFeed{Items: sitemapItems(urls)}, true, nil
+}
+
+// fetchSitemap fetches and decodes a child sitemap, never conditional
+func (p *Parser) fetchSitemap(ctx context.Context, child *domain.Feed) (*sitemapDoc, error) {
+	resp, err := p.fetch(ctx, child)
+	if errors.Is(err, domain.ErrFeedGone) {
+		// a gone child doesn't make the index feed gone
+		return nil, errors.New("sitemap is gone, server responded 410")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return decodeSitemap(resp.Body)
+}
+
+// decodeSitemap decodes sitemap xml, gzipped sitemaps (sitemap.xml.gz) are detected by the magic bytes
+func decodeSitemap(r io.Reader) (*sitemapDoc, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("decompress sitemap: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	var doc sitemapDoc
+	decoder := xml.NewDecoder(io.LimitReader(r, maxSitemapSize))
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse sitemap: %w", err)
+	}
+	return &doc, nil
+}
+
+// sitemapItems converts sitemap URLs to items, newest first and limited to maxSitemapItems.
+// the publication date of news sitemaps is preferred over lastmod, URLs without a date go last
+// and are dated by the time they were found.
+func sitemapItems(urls []sitemapURL) []domain.ParsedItem {
+	items := make([]domain.ParsedItem, 0, len(urls))
+	seen := make(map[string]bool, len(urls))
+	for _, u := range urls {
+		loc := strings.TrimSpace(u.Loc)
+		if loc == "" || seen[loc] {
+			continue
+		}
+		seen[loc] = true
+
+		published := parseSitemapDate(u.News.PublicationDate)
+		if published.IsZero() {
+			published = parseSitemapDate(u.LastMod)
+		}
+		items = append(items, domain.ParsedItem{
+			GUID:      loc,
+			Link:      loc,
+			Title:     collapseSpaces(u.News.Title),
+			Published: published,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Published.After(items[j].Published) })
+	if len(items) > maxSitemapItems {
+		items = items[:maxSitemapItems]
+	}
+	now := time.Now()
+	for i := range items {
+		if items[i].Published.IsZero() {
+			items[i].Published, items[i].Undated = now, true
+		}
+	}
+	return items
+}
+
+// parseSitemapDate parses W3C datetime used by sitemaps, zero time if missing or invalid
+func parseSitemapDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := dateparse.ParseAny(s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,205 @@
+package feed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+const newsSitemap = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+	<url>
+		<loc>https://example.com/older</loc>
+		<lastmod>2024-03-01</lastmod>
+	</url>
+	<url>
+		<loc> https://example.com/news </loc>
+		<lastmod>2024-02-01</lastmod>
+		<news:news>
+			<news:publication>
+				<news:name>Example</news:name>
+				<news:language>en</news:language>
+			</news:publication>
+			<news:publication_date>2024-03-05T10:00:00+00:00</news:publication_date>
+			<news:title>  Breaking   news </news:title>
+		</news:news>
+	</url>
+	<url><loc>https://example.com/undated</loc></url>
+	<url><loc>https://example.com/older</loc></url>
+	<url><loc></loc></url>
+</urlset>`
+
+func TestParser_FetchSitemap(t *testing.T) {
+	t.Run("news sitemap", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(newsSitemap))
+		}))
+		defer ts.Close()
+
+		p := NewParser(5*time.Second, "test-agent")
+		feed, err := p.Fetch(context.Background(), &domain.Feed{Type: domain.FeedTypeSitemap, URL: ts.URL})
+		require.NoError(t, err)
+		assert.Equal(t, `"v1"`, feed.ETag, "plain sitemap keeps validators")
+		require.Len(t, feed.Items, 3, "duplicate and empty locations skipped")
+
+		assert.Equal(t, "https://example.com/news", feed.Items[0].Link)
+		assert.Equal(t, "https://example.com/news", feed.Items[0].GUID)
+		assert.Equal(t, "Breaking news", feed.Items[0].Title)
+		assert.Equal(t, time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), feed.Items[0].Published.UTC(), "publication date preferred")
+
+		assert.Equal(t, "https://example.com/older", feed.Items[1].Link)
+		assert.Empty(t, feed.Items[1].Title, "title is left to the extractor")
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), feed.Items[1].Published.UTC())
+
+		assert.Equal(t, "https://example.com/undated", feed.Items[2].Link)
+		assert.WithinDuration(t, time.Now(), feed.Items[2].Published, time.Minute)
+		assert.True(t, feed.Items[2].Undated)
+		assert.False(t, feed.Items[1].Undated)
+	})
+
+	t.Run("sitemap index with gzipped children", func(t *testing.T) {
+		var gz bytes.Buffer
+		zw := gzip.NewWriter(&gz)
+		_, err := zw.Write([]byte(`<urlset><url><loc>https://example.com/new</loc><lastmod>2024-03-02</lastmod></url></urlset>`))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		var mu sync.Mutex
+		var requested []string
+		mux := http.NewServeMux()
+		ts := httptest.NewServer(mux)
+		defer ts.Close()
+		mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"index"`)
+			var children strings.Builder
+			for i, name := range []string{"old-1", "old-2", "old-3", "old-4", "old-5", "broken"} {
+				children.WriteString("<sitemap><loc>" + ts.URL + "/" + name + ".xml</loc><lastmod>2024-01-0" + string(rune('1'+i)) + "</lastmod></sitemap>")
+			}
+			children.WriteString("<sitemap><loc>" + ts.URL + "/new.xml.gz</loc><lastmod>2024-03-02</lastmod></sitemap>")
+			_, _ = w.Write([]byte("<sitemapindex>" + children.String() + "</sitemapindex>"))
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requested = append(requested, r.URL.Path)
+			mu.Unlock()
+			switch r.URL.Path {
+			case "/new.xml.gz":
+				w.Header().Set("Content-Type", "application/x-gzip")
+				_, _ = w.Write(gz.Bytes())
+			case "/broken.xml":
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				_, _ = w.Write([]byte(`<urlset><url><loc>https://example.com` + strings.TrimSuffix(r.URL.Path, ".xml") + `</loc></url></urlset>`))
+			}
+		})
+
+		p := NewParser(5*time.Second, "test-agent")
+		feed, err := p.Fetch(context.Background(), &domain.Feed{Type: domain.FeedTypeSitemap, URL: ts.URL + "/sitemap.xml"})
+		require.NoError(t, err)
+		assert.Empty(t, feed.ETag, "index fetch is not conditional")
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"/new.xml.gz", "/broken.xml", "/old-5.xml", "/old-4.xml", "/old-3.xml"}, requested,
+			"newest children only")
+		require.Len(t, feed.Items, 4, "broken child skipped")
+		assert.Equal(t, "https://example.com/new", feed.Items[0].Link)
+	})
+
+	t.Run("all children broken", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/sitemap.xml" {
+				_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>http://` + r.Host + `/child.xml</loc></sitemap></sitemapindex>`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		p := NewParser(5*time.Second, "test-agent")
+		_, err := p.Fetch(context.Background(), &domain.Feed{Type: domain.FeedTypeSitemap, URL: ts.URL + "/sitemap.xml"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "child sitemap")
+	})
+
+	t.Run("not a sitemap", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>feed</title></channel></rss>`))
+		}))
+		defer ts.Close()
+
+		p := NewParser(5*time.Second, "test-agent")
+		_, err := p.Fetch(context.Background(), &domain.Feed{Type: domain.FeedTypeSitemap, URL: ts.URL})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unexpected root element "rss"`)
+	})
+}
+
+func TestParser_FetchSitemap_AuthForChildren(t *testing.T) {
+	var mu sync.Mutex
+	authorized := map[string]bool{}
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		authorized[r.Host+r.URL.Path] = r.Header.Get("Authorization") == "Bearer token"
+	}
+	mux.HandleFunc("/index.xml", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		// same server reached by another host name, like a sitemap on a CDN
+		otherHost := strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)
+		_, _ = fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%s/own.xml</loc></sitemap><sitemap><loc>%s/other.xml</loc></sitemap></sitemapindex>`,
+			ts.URL, otherHost)
+	})
+	mux.HandleFunc("/own.xml", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_, _ = w.Write([]byte(`<urlset><url><loc>https://example.com/own</loc></url></urlset>`))
+	})
+	mux.HandleFunc("/other.xml", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_, _ = w.Write([]byte(`<urlset><url><loc>https://example.com/other</loc></url></urlset>`))
+	})
+
+	p := NewParser(5*time.Second, "test-agent")
+	feed, err := p.Fetch(context.Background(), &domain.Feed{Type: domain.FeedTypeSitemap, URL: ts.URL + "/index.xml",
+		Auth: domain.FeedAuth{Token: "token"}})
+	require.NoError(t, err)
+	assert.Len(t, feed.Items, 2)
+
+	host := strings.TrimPrefix(ts.URL, "http://")
+	assert.Equal(t, map[string]bool{
+		host + "/index.xml": true,
+		host + "/own.xml":   true,
+		strings.Replace(host, "127.0.0.1", "localhost", 1) + "/other.xml": false,
+	}, authorized, "credentials sent to the feed host only")
+}
+
+func TestSitemapItems_Limit(t *testing.T) {
+	urls := make([]sitemapURL, 0, maxSitemapItems+20)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range maxSitemapItems + 20 {
+		urls = append(urls, sitemapURL{
+			Loc:     "https://example.com/" + base.Add(time.Duration(i)*time.Hour).Format("2006-01-02T15"),
+			LastMod: base.Add(time.Duration(i) * time.Hour).Format(time.RFC3339),
+		})
+	}
+
+	items := sitemapItems(urls)
+	require.Len(t, items, maxSitemapItems)
+	assert.Equal(t, base.Add(time.Duration(maxSitemapItems+19)*time.Hour), items[0].Published.UTC(), "newest first")
+	assert.Equal(t, base.Add(20*time.Hour), items[maxSitemapItems-1].Published.UTC(), "oldest dropped")
+}
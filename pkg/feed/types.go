@@ -32,11 +32,49 @@ type AtomLink struct {
 
 // RSSItem represents an item in an RSS feed
 type RSSItem struct {
-	Title       string   `xml:"title"`
-	Link        string   `xml:"link"`
-	GUID        string   `xml:"guid"`
-	Description string   `xml:"description"`
-	Author      string   `xml:"author,omitempty"`
-	PubDate     string   `xml:"pubDate"`
-	Categories  []string `xml:"category"`
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        string        `xml:"guid"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"author,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *RSSEnclosure `xml:"enclosure,omitempty"`
+}
+
+// RSSEnclosure represents a media file attached to an RSS item
+type RSSEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+// OPML represents the root element of an OPML 2.0 document
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+// OPMLHead represents the head of an OPML document
+type OPMLHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// OPMLBody represents the body of an OPML document
+type OPMLBody struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// OPMLOutline represents a subscription or, if it has no xmlUrl, a folder with nested outlines
+type OPMLOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLUrl   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLUrl  string        `xml:"htmlUrl,attr,omitempty"`
+	Interval string        `xml:"fetchInterval,attr,omitempty"` // newscope extension, fetch interval in minutes
+	Outlines []OPMLOutline `xml:"outline"`
 }
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+)
+
+// hubAtomTranslator keeps WebSub hub and self links of Atom feeds, which the default
+// translator drops, in the Custom map of the translated feed
+type hubAtomTranslator struct {
+	gofeed.DefaultAtomTranslator
+}
+
+// Translate converts atom feed to the universal feed, preserving hub and self links
+func (t *hubAtomTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	res, err := t.DefaultAtomTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+	af, ok := feed.(*atom.Feed)
+	if !ok {
+		return res, nil
+	}
+	for _, l := range af.Links {
+		rel := strings.ToLower(strings.TrimSpace(l.Rel))
+		if (rel == "hub" || rel == "self") && l.Href != "" {
+			if res.Custom == nil {
+				res.Custom = map[string]string{}
+			}
+			if _, exists := res.Custom[rel]; !exists {
+				res.Custom[rel] = strings.TrimSpace(l.Href)
+			}
+		}
+	}
+	return res, nil
+}
+
+// hubLinks returns WebSub hub and self URLs of the feed, if advertised. Atom feeds have them
+// collected by hubAtomTranslator, RSS feeds carry them as atom:link extension elements.
+func hubLinks(feed *gofeed.Feed) (hub, self string) {
+	hub, self = feed.Custom["hub"], feed.Custom["self"]
+	for _, elems := range feed.Extensions {
+		for _, ext := range elems["link"] {
+			href := strings.TrimSpace(ext.Attrs["href"])
+			switch strings.ToLower(strings.TrimSpace(ext.Attrs["rel"])) {
+			case "hub":
+				if hub == "" {
+					hub = href
+				}
+			case "self":
+				if self == "" {
+					self = href
+				}
+			}
+		}
+	}
+	if self == "" {
+		self = feed.FeedLink
+	}
+	return hub, self
+}
@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseContent_HubLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantHub  string
+		wantSelf string
+		items    int
+	}{
+		{
+			name: "rss with atom links",
+			content: `<?xml version="1.0"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel><title>RSS</title>
+  <atom:link rel="hub" href="https://pubsubhubbub.appspot.com/"/>
+  <atom:link rel="self" type="application/rss+xml" href="https://example.com/rss.xml"/>
+  <item><title>one</title><guid>1</guid></item>
+</channel></rss>`,
+			wantHub:  "https://pubsubhubbub.appspot.com/",
+			wantSelf: "https://example.com/rss.xml",
+			items:    1,
+		},
+		{
+			name: "rss with custom atom prefix",
+			content: `<?xml version="1.0"?>
+<rss version="2.0" xmlns:atom10="http://www.w3.org/2005/Atom"><channel><title>RSS</title>
+  <atom10:link rel="hub" href="https://hub.example.com/"/>
+</channel></rss>`,
+			wantHub: "https://hub.example.com/",
+		},
+		{
+			name: "atom with hub",
+			content: `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title>
+  <link rel="alternate" href="https://example.com/"/>
+  <link rel="hub" href="https://websub.example.com/hub"/>
+  <link rel="self" href="https://example.com/atom.xml"/>
+  <entry><title>a</title><id>1</id></entry><entry><title>b</title><id>2</id></entry>
+</feed>`,
+			wantHub:  "https://websub.example.com/hub",
+			wantSelf: "https://example.com/atom.xml",
+			items:    2,
+		},
+		{
+			name:    "no hub",
+			content: `<rss version="2.0"><channel><title>plain</title><item><title>x</title></item></channel></rss>`,
+			items:   1,
+		},
+	}
+
+	parser := NewParser(time.Second, "test")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parser.ParseContent([]byte(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHub, parsed.HubURL)
+			assert.Equal(t, tt.wantSelf, parsed.SelfURL)
+			assert.Len(t, parsed.Items, tt.items)
+		})
+	}
+
+	_, err := parser.ParseContent([]byte("not a feed"))
+	require.Error(t, err)
+}
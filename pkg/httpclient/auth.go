@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+type authKey struct{}
+
+// ApplyAuth sets the credentials on the request, overriding headers set before
+func ApplyAuth(req *http.Request, a domain.FeedAuth) {
+	if a.Username != "" || a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+	if a.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.Token)
+	}
+	for name, value := range a.Headers {
+		req.Header.Set(name, value)
+	}
+	if a.Cookies != "" {
+		req.Header.Set("Cookie", a.Cookies)
+	}
+}
+
+// removeAuth deletes the headers ApplyAuth sets from the request
+func removeAuth(req *http.Request, a domain.FeedAuth) {
+	if a.Username != "" || a.Password != "" || a.Token != "" {
+		req.Header.Del("Authorization")
+	}
+	for name := range a.Headers {
+		req.Header.Del(name)
+	}
+	if a.Cookies != "" {
+		req.Header.Del("Cookie")
+	}
+}
+
+// WithAuth returns a context marking the credentials applied to requests made with it,
+// so CheckAuthRedirect can drop them from redirects
+func WithAuth(ctx context.Context, a domain.FeedAuth) context.Context {
+	if a.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, authKey{}, a)
+}
+
+// CheckAuthRedirect is the redirect policy of clients sending feed credentials. http.Client drops
+// only Authorization and Cookie on redirects to other domains, this drops every credential set with
+// WithAuth once the redirect leaves the host of the first request or goes from https to plain http.
+// stops after 10 redirects, as the default policy.
+func CheckAuthRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	auth, ok := req.Context().Value(authKey{}).(domain.FeedAuth)
+	if !ok || authAllowed(via[0].URL, req.URL) {
+		return nil
+	}
+	removeAuth(req, auth)
+	return nil
+}
+
+// AuthFor returns the feed credentials if the link is on the feed's host or its subdomain,
+// so they are never sent to other sites the feed links to, nor over plain http for an https feed.
+// returns empty credentials otherwise.
+func AuthFor(f *domain.Feed, link string) domain.FeedAuth {
+	if f.Auth.IsZero() {
+		return domain.FeedAuth{}
+	}
+	feedURL, err := url.Parse(f.URL)
+	if err != nil {
+		return domain.FeedAuth{}
+	}
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return domain.FeedAuth{}
+	}
+	if !authAllowed(feedURL, linkURL) {
+		return domain.FeedAuth{}
+	}
+	return f.Auth
+}
+
+// authAllowed reports whether credentials for the base URL may be sent to the target one,
+// on the same host or its subdomain and not downgraded from https to http
+func authAllowed(base, target *url.URL) bool {
+	baseHost, targetHost := strings.ToLower(base.Hostname()), strings.ToLower(target.Hostname())
+	if baseHost == "" || (targetHost != baseHost && !strings.HasSuffix(targetHost, "."+baseHost)) {
+		return false
+	}
+	return base.Scheme != "https" || target.Scheme == "https"
+}
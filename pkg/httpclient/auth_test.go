@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestApplyAuth(t *testing.T) {
+	auth := domain.FeedAuth{Token: "secret", Headers: map[string]string{"PRIVATE-TOKEN": "abc"}, Cookies: "session=1"}
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/feed", http.NoBody)
+	req.Header.Set("Authorization", "Basic old")
+	ApplyAuth(req, auth)
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"), "overrides headers set before")
+	assert.Equal(t, "abc", req.Header.Get("PRIVATE-TOKEN"))
+	assert.Equal(t, "session=1", req.Header.Get("Cookie"))
+
+	removeAuth(req, auth)
+	assert.Empty(t, req.Header)
+
+	req = httptest.NewRequest(http.MethodGet, "https://example.com/feed", http.NoBody)
+	ApplyAuth(req, domain.FeedAuth{Username: "user", Password: "pass"})
+	username, password, ok := req.BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+}
+
+func TestCheckAuthRedirect(t *testing.T) {
+	auth := domain.FeedAuth{Token: "secret", Headers: map[string]string{"PRIVATE-TOKEN": "abc"}}
+	redirect := func(ctx context.Context, from, to string) *http.Request {
+		first := httptest.NewRequest(http.MethodGet, from, http.NoBody)
+		req := httptest.NewRequest(http.MethodGet, to, http.NoBody).WithContext(ctx)
+		ApplyAuth(req, auth)
+		require.NoError(t, CheckAuthRedirect(req, []*http.Request{first}))
+		return req
+	}
+
+	ctx := WithAuth(context.Background(), auth)
+	req := redirect(ctx, "https://example.com/feed", "https://cdn.example.com/feed")
+	assert.Equal(t, "abc", req.Header.Get("PRIVATE-TOKEN"), "kept on a subdomain")
+
+	req = redirect(ctx, "https://example.com/feed", "https://other.com/feed")
+	assert.Empty(t, req.Header.Get("PRIVATE-TOKEN"), "dropped on another host")
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	req = redirect(ctx, "https://example.com/feed", "http://example.com/feed")
+	assert.Empty(t, req.Header.Get("PRIVATE-TOKEN"), "dropped on downgrade to http")
+
+	req = redirect(context.Background(), "https://example.com/feed", "https://other.com/feed")
+	assert.Equal(t, "abc", req.Header.Get("PRIVATE-TOKEN"), "headers not set with WithAuth are left alone")
+
+	via := make([]*http.Request, 10)
+	for i := range via {
+		via[i] = httptest.NewRequest(http.MethodGet, "https://example.com/feed", http.NoBody)
+	}
+	require.EqualError(t, CheckAuthRedirect(via[0], via), "stopped after 10 redirects")
+}
+
+func TestAuthFor(t *testing.T) {
+	f := &domain.Feed{URL: "https://example.com/feed.xml", Auth: domain.FeedAuth{Token: "secret"}}
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{"https://example.com/post", true},
+		{"https://EXAMPLE.com/post", true},
+		{"https://blog.example.com/post", true},
+		{"http://example.com/post", false},
+		{"https://notexample.com/post", false},
+		{"https://other.com/post", false},
+		{"://bad", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			auth := AuthFor(f, tt.link)
+			assert.Equal(t, tt.want, !auth.IsZero())
+		})
+	}
+	assert.True(t, AuthFor(&domain.Feed{URL: "https://example.com/feed.xml"}, "https://example.com/post").IsZero(), "public feed")
+}
@@ -0,0 +1,169 @@
+// Package httpclient builds the HTTP transport shared by feed fetching, content extraction and
+// the LLM client: outbound proxy (global or per request), extra root CAs and per-host TLS verification.
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Direct is the proxy value that bypasses the configured proxy
+const Direct = "direct"
+
+// Params defines the transport configuration, the zero value makes a transport
+// with proxies from the environment (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) and system root CAs
+type Params struct {
+	Proxy         string   // http, https or socks5 proxy URL, empty to use the environment
+	CAFiles       []string // PEM files with root CAs trusted in addition to the system ones
+	InsecureHosts []string // hosts with TLS verification disabled, "*.example.com" matches subdomains
+}
+
+// NewTransport creates a transport for the params
+func NewTransport(p Params) (http.RoundTripper, error) {
+	var fallback func(*http.Request) (*url.URL, error) // no proxy for Direct
+	switch p.Proxy {
+	case "":
+		fallback = http.ProxyFromEnvironment
+	case Direct:
+	default:
+		proxyURL, err := ParseProxy(p.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		fallback = http.ProxyURL(proxyURL)
+	}
+
+	roots, err := rootCAs(p.CAFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	secure := &http.Transport{
+		Proxy: Proxy(fallback),
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+	}
+	if len(p.InsecureHosts) == 0 {
+		return secure, nil
+	}
+
+	insecure := secure.Clone()
+	insecure.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // enabled explicitly for the configured hosts
+	return &hostTransport{secure: secure, insecure: insecure, insecureHosts: p.InsecureHosts}, nil
+}
+
+// hostTransport sends requests to the insecure hosts with TLS verification disabled.
+// the choice is made per request, so it follows redirects between secure and insecure hosts.
+type hostTransport struct {
+	secure, insecure *http.Transport
+	insecureHosts    []string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if matchHost(req.URL.Hostname(), t.insecureHosts) {
+		return t.insecure.RoundTrip(req)
+	}
+	return t.secure.RoundTrip(req)
+}
+
+// ParseProxy parses and checks a proxy URL
+func ParseProxy(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("parse proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", u.Redacted())
+	}
+	return u, nil
+}
+
+type proxyKey struct{}
+
+// WithProxy returns a context making requests of transports from NewTransport use the proxy
+// instead of the configured one. Direct bypasses the proxy, empty proxy keeps the configured one.
+func WithProxy(ctx context.Context, proxy string) context.Context {
+	if proxy == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, proxyKey{}, proxy)
+}
+
+// Proxy returns the proxy function selecting the proxy set with WithProxy in the request's context,
+// or the fallback one if there is none. nil fallback means no proxy.
+func Proxy(fallback func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if proxy, ok := req.Context().Value(proxyKey{}).(string); ok {
+			if proxy == Direct {
+				return nil, nil
+			}
+			return ParseProxy(proxy)
+		}
+		if fallback == nil {
+			return nil, nil
+		}
+		return fallback(req)
+	}
+}
+
+// rootCAs returns the system root CAs with certificates from the files added, nil if there are no files
+func rootCAs(files []string) (*x509.CertPool, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file) //nolint:gosec // file path comes from the config
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates in CA file %s", file)
+		}
+	}
+	return pool, nil
+}
+
+// matchHost reports whether the host is one of the patterns, "*.example.com" matches subdomains of example.com
+func matchHost(host string, patterns []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if suffix, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProxy starts a plain http proxy answering requests itself with its name and the requested URL
+func newProxy(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, name+" "+r.URL.String())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, ctx context.Context, tr http.RoundTripper, url string) (string, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: tr}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body), nil
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	global, perFeed := newProxy(t, "global"), newProxy(t, "feed")
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "direct")
+	}))
+	defer target.Close()
+
+	tr, err := NewTransport(Params{Proxy: global.URL})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	body, err := get(t, ctx, tr, "http://feeds.example.com/rss")
+	require.NoError(t, err)
+	assert.Equal(t, "global http://feeds.example.com/rss", body)
+
+	body, err = get(t, WithProxy(ctx, perFeed.URL), tr, "http://feeds.example.com/rss")
+	require.NoError(t, err)
+	assert.Equal(t, "feed http://feeds.example.com/rss", body, "proxy from context overrides the configured one")
+
+	body, err = get(t, WithProxy(ctx, Direct), tr, target.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "direct", body, "configured proxy bypassed")
+
+	_, err = get(t, WithProxy(ctx, "ftp://proxy.example.com"), tr, target.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported proxy scheme")
+
+	direct, err := NewTransport(Params{Proxy: Direct})
+	require.NoError(t, err)
+	body, err = get(t, ctx, direct, target.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "direct", body)
+
+	_, err = NewTransport(Params{Proxy: "socks4://127.0.0.1:1080"})
+	require.Error(t, err)
+}
+
+func TestNewTransport_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+	ctx := WithProxy(context.Background(), Direct)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	t.Run("unknown authority", func(t *testing.T) {
+		tr, err := NewTransport(Params{})
+		require.NoError(t, err)
+		_, err = get(t, ctx, tr, srv.URL)
+		require.Error(t, err)
+	})
+
+	t.Run("extra root CA", func(t *testing.T) {
+		tr, err := NewTransport(Params{CAFiles: []string{caFile}})
+		require.NoError(t, err)
+		body, err := get(t, ctx, tr, srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("insecure host", func(t *testing.T) {
+		tr, err := NewTransport(Params{InsecureHosts: []string{"127.0.0.1"}})
+		require.NoError(t, err)
+		body, err := get(t, ctx, tr, srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("other hosts still verified", func(t *testing.T) {
+		tr, err := NewTransport(Params{InsecureHosts: []string{"*.example.com"}})
+		require.NoError(t, err)
+		_, err = get(t, ctx, tr, srv.URL)
+		require.Error(t, err)
+
+		tr, err = NewTransport(Params{CAFiles: []string{caFile}, InsecureHosts: []string{"*.example.com"}})
+		require.NoError(t, err)
+		_, err = get(t, ctx, tr, srv.URL)
+		require.NoError(t, err, "extra root CAs used with insecure hosts set")
+	})
+
+	t.Run("bad CA files", func(t *testing.T) {
+		_, err := NewTransport(Params{CAFiles: []string{filepath.Join(t.TempDir(), "missing.pem")}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read CA file")
+
+		empty := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+		_, err = NewTransport(Params{CAFiles: []string{empty}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no certificates")
+	})
+}
+
+func TestMatchHost(t *testing.T) {
+	patterns := []string{"intranet.local", "*.corp.example.com"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"intranet.local", true},
+		{"INTRANET.local.", true},
+		{"www.intranet.local", false},
+		{"git.corp.example.com", true},
+		{"a.b.corp.example.com", true},
+		{"corp.example.com", false},
+		{"notcorp.example.com", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchHost(tt.host, patterns), tt.host)
+	}
+}
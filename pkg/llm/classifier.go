@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 	"unicode"
@@ -25,11 +26,6 @@ type Classifier struct {
 
 // NewClassifier creates a new LLM classifier
 func NewClassifier(cfg config.LLMConfig) *Classifier {
-	clientConfig := openai.DefaultConfig(cfg.APIKey)
-	if cfg.Endpoint != "" {
-		clientConfig.BaseURL = cfg.Endpoint
-	}
-
 	// use custom system prompt if provided, otherwise use default
 	systemMsg := cfg.SystemPrompt
 	if systemMsg == "" {
@@ -37,12 +33,29 @@ func NewClassifier(cfg config.LLMConfig) *Classifier {
 	}
 
 	return &Classifier{
-		client:    openai.NewClientWithConfig(clientConfig),
+		client:    openai.NewClientWithConfig(clientConfig(cfg, nil)),
 		config:    cfg,
 		systemMsg: systemMsg,
 	}
 }
 
+// SetTransport sets the transport used for API requests, e.g. one with a proxy or custom root CAs
+func (c *Classifier) SetTransport(t http.RoundTripper) {
+	c.client = openai.NewClientWithConfig(clientConfig(c.config, t))
+}
+
+// clientConfig makes the API client config, nil transport means the default one
+func clientConfig(cfg config.LLMConfig, t http.RoundTripper) openai.ClientConfig {
+	cc := openai.DefaultConfig(cfg.APIKey)
+	if cfg.Endpoint != "" {
+		cc.BaseURL = cfg.Endpoint
+	}
+	if t != nil {
+		cc.HTTPClient = &http.Client{Transport: t}
+	}
+	return cc
+}
+
 // default system prompt for article classification
 const defaultSystemPrompt = `You are an AI assistant that evaluates articles for relevance to the user's interests.
 Rate each article from 0-10 where:
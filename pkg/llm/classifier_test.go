@@ -135,6 +135,33 @@ func TestClassifier_ClassifyArticles_EmptyInput(t *testing.T) {
 	assert.Empty(t, classifications)
 }
 
+// roundTripFunc is an http.RoundTripper made of a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClassifier_SetTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openai.ChatCompletionResponse{Choices: []openai.ChatCompletionChoice{
+			{Message: openai.ChatCompletionMessage{Content: `[{"guid": "item1", "score": 7, "topics": ["go"]}]`}},
+		}})
+	}))
+	defer server.Close()
+
+	var requests []string
+	classifier := NewClassifier(config.LLMConfig{Endpoint: server.URL + "/v1", APIKey: "test-key", Model: "gpt-4"})
+	classifier.SetTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r.URL.Path)
+		return http.DefaultTransport.RoundTrip(r)
+	}))
+
+	classifications, err := classifier.ClassifyItems(context.Background(), ClassifyRequest{Articles: []domain.Item{{GUID: "item1", Title: "Go"}}})
+	require.NoError(t, err)
+	require.Len(t, classifications, 1)
+	assert.Equal(t, []string{"/v1/chat/completions"}, requests, "request sent with the transport")
+}
+
 func TestClassifier_CustomSystemPrompt(t *testing.T) {
 	customPrompt := "You are a specialized tech curator. Rate articles 0-10."
 
@@ -0,0 +1,92 @@
+// Package mute matches feed items against user-defined mute rules. items are matched
+// as soon as they are stored, before extraction and classification spend any time or tokens on them.
+package mute
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// Matcher matches items against a set of mute rules
+type Matcher struct {
+	rules []rule
+}
+
+// rule is a mute rule prepared for matching
+type rule struct {
+	domain.MuteRule
+	re      *regexp.Regexp // compiled pattern of keyword and regex rules
+	pattern string         // normalized pattern of the other rules
+}
+
+// NewMatcher prepares the rules for matching, invalid rules are skipped
+func NewMatcher(rules []domain.MuteRule) *Matcher {
+	m := &Matcher{rules: make([]rule, 0, len(rules))}
+	for _, r := range rules {
+		if r.Validate() != nil {
+			continue
+		}
+		prepared := rule{MuteRule: r, pattern: strings.ToLower(strings.TrimSpace(r.Pattern))}
+		switch r.Kind {
+		case domain.MuteKeyword:
+			// whole words only, "ai" doesn't match "said"
+			prepared.re = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(strings.TrimSpace(r.Pattern)) +
+				`(?:$|[^\p{L}\p{N}_])`)
+		case domain.MuteRegex:
+			prepared.re = regexp.MustCompile(r.Pattern)
+		case domain.MuteDomain:
+			prepared.pattern = strings.TrimPrefix(hostOf(prepared.pattern), "www.")
+		}
+		m.rules = append(m.rules, prepared)
+	}
+	return m
+}
+
+// Match returns the first rule matching the item
+func (m *Matcher) Match(item *domain.Item) (domain.MuteRule, bool) {
+	for _, r := range m.rules {
+		if r.FeedID != 0 && r.FeedID != item.FeedID {
+			continue
+		}
+		if r.match(item) {
+			return r.MuteRule, true
+		}
+	}
+	return domain.MuteRule{}, false
+}
+
+// match reports whether the item matches the rule, the rule's feed is checked by the caller
+func (r *rule) match(item *domain.Item) bool {
+	switch r.Kind {
+	case domain.MuteKeyword, domain.MuteRegex:
+		return r.re.MatchString(item.Title) || r.re.MatchString(item.Description)
+	case domain.MuteAuthor:
+		return strings.EqualFold(strings.TrimSpace(item.Author), r.pattern)
+	case domain.MuteDomain:
+		return matchDomain(item.Link, r.pattern) || matchDomain(item.CanonicalLink, r.pattern)
+	case domain.MuteTopic:
+		return slices.ContainsFunc(item.Categories, func(c string) bool { return strings.EqualFold(strings.TrimSpace(c), r.pattern) })
+	}
+	return false
+}
+
+// matchDomain reports whether the link's host is the domain or its subdomain
+func matchDomain(link, domainName string) bool {
+	if link == "" || domainName == "" {
+		return false
+	}
+	host := hostOf(link)
+	return host == domainName || strings.HasSuffix(host, "."+domainName)
+}
+
+// hostOf returns the lowercase host of a link, or the string itself if it has no host, e.g. "example.com"
+func hostOf(s string) string {
+	if u, err := url.Parse(s); err == nil && u.Hostname() != "" {
+		return strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	}
+	return strings.ToLower(strings.Trim(s, "./"))
+}
@@ -0,0 +1,55 @@
+package mute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher([]domain.MuteRule{
+		{ID: 1, Kind: domain.MuteKeyword, Pattern: "Sponsored"},
+		{ID: 2, Kind: domain.MuteRegex, Pattern: `(?i)^\[ad\]`},
+		{ID: 3, Kind: domain.MuteAuthor, Pattern: "John Doe"},
+		{ID: 4, Kind: domain.MuteDomain, Pattern: "https://www.Spam.example/"},
+		{ID: 5, Kind: domain.MuteTopic, Pattern: "sports", FeedID: 7},
+		{ID: 6, Kind: domain.MuteRegex, Pattern: "(unclosed"}, // invalid, skipped
+		{ID: 7, Kind: domain.MuteKeyword, Pattern: "ai", FeedID: 8},
+	})
+
+	tests := []struct {
+		name string
+		item domain.Item
+		want int64 // id of the matched rule, zero if none
+	}{
+		{"keyword in title", domain.Item{Title: "SPONSORED: new laptop"}, 1},
+		{"keyword in description", domain.Item{Title: "Laptop", Description: "This post is sponsored by X"}, 1},
+		{"keyword as part of a word", domain.Item{FeedID: 8, Title: "He said so"}, 0},
+		{"keyword as a word", domain.Item{FeedID: 8, Title: "New AI model"}, 7},
+		{"keyword of another feed", domain.Item{FeedID: 1, Title: "New AI model"}, 0},
+		{"regex", domain.Item{Title: "[Ad] Buy now"}, 2},
+		{"author", domain.Item{Title: "News", Author: " john doe "}, 3},
+		{"other author", domain.Item{Title: "News", Author: "John Doe Jr."}, 0},
+		{"domain", domain.Item{Title: "News", Link: "https://spam.example/post"}, 4},
+		{"subdomain", domain.Item{Title: "News", Link: "https://blog.spam.example/post"}, 4},
+		{"domain of the canonical link", domain.Item{Title: "News", Link: "https://t.co/x", CanonicalLink: "https://spam.example/p"}, 4},
+		{"similar domain", domain.Item{Title: "News", Link: "https://notspam.example/post"}, 0},
+		{"topic of the feed", domain.Item{FeedID: 7, Title: "Match", Categories: []string{"News", "Sports"}}, 5},
+		{"topic of another feed", domain.Item{FeedID: 3, Title: "Match", Categories: []string{"Sports"}}, 0},
+		{"no match", domain.Item{Title: "Regular article", Link: "https://example.com/a"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := m.Match(&tt.item)
+			assert.Equal(t, tt.want != 0, ok)
+			assert.Equal(t, tt.want, rule.ID)
+		})
+	}
+}
+
+func TestMatcher_NoRules(t *testing.T) {
+	_, ok := NewMatcher(nil).Match(&domain.Item{Title: "anything"})
+	assert.False(t, ok)
+}
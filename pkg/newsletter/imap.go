@@ -0,0 +1,260 @@
+package newsletter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxIMAPMessages = 200 // limits the number of newest messages fetched in one session
+	imapFetchBatch  = 10  // messages fetched with one command, bodies of a batch are held in memory together
+)
+
+// IMAPParams configures an IMAP source
+type IMAPParams struct {
+	Addr     string        // server address as host:port
+	NoTLS    bool          // connect without TLS, for local servers and tests
+	Username string        // login user name
+	Password string        // login password
+	Folder   string        // folder with newsletters
+	Timeout  time.Duration // timeout of the whole session
+}
+
+// IMAP reads newsletters from an IMAP folder. the folder is opened read-only and bodies are
+// fetched with BODY.PEEK, so messages stay unread in the mail client.
+// only the small subset of IMAP4rev1 needed for that is implemented.
+type IMAP struct {
+	params IMAPParams
+	batch  int // messages fetched with one command
+}
+
+// NewIMAP makes an IMAP source
+func NewIMAP(params IMAPParams) *IMAP {
+	return &IMAP{params: params, batch: imapFetchBatch}
+}
+
+// Fetch returns messages received since the given day, oldest first. IMAP searches by date only,
+// so messages of the whole day are returned. bodies are fetched in small batches and messages over
+// maxMessageSize are skipped, to bound the memory used. messages which can't be parsed are skipped and
+// reported in the returned error along with the messages read.
+func (c *IMAP) Fetch(ctx context.Context, since time.Time) ([]Message, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	if _, err := conn.cmd("LOGIN %s %s", imapQuote(c.params.Username), imapQuote(c.params.Password)); err != nil {
+		return nil, fmt.Errorf("login: %w", err)
+	}
+	if _, err := conn.cmd("EXAMINE %s", imapQuote(c.params.Folder)); err != nil {
+		return nil, fmt.Errorf("open folder %s: %w", c.params.Folder, err)
+	}
+
+	resp, err := conn.cmd("UID SEARCH SINCE %s", since.Format("2-Jan-2006"))
+	if err != nil {
+		return nil, fmt.Errorf("search messages: %w", err)
+	}
+	uids := searchUIDs(resp)
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	sort.Ints(uids)
+	if len(uids) > maxIMAPMessages {
+		uids = uids[len(uids)-maxIMAPMessages:]
+	}
+
+	var messages []Message
+	var errs []error
+	for batch := range slices.Chunk(uids, max(c.batch, 1)) {
+		set := make([]string, len(batch))
+		for i, uid := range batch {
+			set[i] = strconv.Itoa(uid)
+		}
+		if resp, err = conn.cmd("UID FETCH %s (BODY.PEEK[])", strings.Join(set, ",")); err != nil {
+			return nil, fmt.Errorf("fetch messages: %w", err)
+		}
+		for _, r := range resp {
+			if len(r.literals) == 0 || !strings.Contains(r.line, "FETCH") {
+				continue
+			}
+			if r.literals[0] == nil {
+				errs = append(errs, fmt.Errorf("message %s: over %d MB", strings.TrimPrefix(r.line, "* "), maxMessageSize>>20))
+				continue
+			}
+			msg, err := Parse(bytes.NewReader(r.literals[0]))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("message %s: %w", strings.TrimPrefix(r.line, "* "), err))
+				continue
+			}
+			messages = append(messages, *msg)
+		}
+	}
+	_, _ = conn.cmd("LOGOUT") // the session is done, a failed logout changes nothing
+
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].Date.Before(messages[j].Date) })
+	return messages, errors.Join(errs...)
+}
+
+// dial connects to the server and reads the greeting, the session ends with the context or the timeout
+func (c *IMAP) dial(ctx context.Context) (*imapConn, error) {
+	dialer := &net.Dialer{Timeout: c.params.Timeout}
+	var conn net.Conn
+	var err error
+	if c.params.NoTLS {
+		conn, err = dialer.DialContext(ctx, "tcp", c.params.Addr)
+	} else {
+		host, _, _ := net.SplitHostPort(c.params.Addr)
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", c.params.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to imap server: %w", err)
+	}
+
+	deadline := time.Now().Add(c.params.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("set imap deadline: %w", err)
+	}
+
+	ic := &imapConn{conn: conn, r: bufio.NewReader(conn), done: make(chan struct{})}
+	go func() { // unblock reads on cancellation
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-ic.done:
+		}
+	}()
+
+	greeting, err := ic.readResponse()
+	if err != nil {
+		ic.close()
+		return nil, fmt.Errorf("read imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting.line, "* OK") && !strings.HasPrefix(greeting.line, "* PREAUTH") {
+		ic.close()
+		return nil, fmt.Errorf("imap server refused connection: %s", greeting.line)
+	}
+	return ic, nil
+}
+
+// imapConn is an IMAP session sending tagged commands one at a time
+type imapConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+	done chan struct{}
+}
+
+// imapResponse is a response line with the literals it contained, e.g. message bodies
+type imapResponse struct {
+	line     string
+	literals [][]byte
+}
+
+// cmd sends a command and returns its untagged responses, a NO or BAD completion is an error
+func (c *imapConn) cmd(format string, args ...any) ([]imapResponse, error) {
+	c.tag++
+	tag := "a" + strconv.Itoa(c.tag)
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, fmt.Errorf("send command: %w", err)
+	}
+
+	var untagged []imapResponse
+	for {
+		resp, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(resp.line, tag+" ") {
+			untagged = append(untagged, resp)
+			continue
+		}
+		status := strings.TrimPrefix(resp.line, tag+" ")
+		if !strings.HasPrefix(status, "OK") {
+			return nil, fmt.Errorf("imap: %s", status)
+		}
+		return untagged, nil
+	}
+}
+
+var imapLiteralRe = regexp.MustCompile(`\{(\d+)\}$`)
+
+// readResponse reads a response line, literals ({size} followed by size bytes) are read
+// into the response and the line continues after them
+func (c *imapConn) readResponse() (imapResponse, error) {
+	var resp imapResponse
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return resp, fmt.Errorf("read response: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		resp.line += line
+
+		m := imapLiteralRe.FindStringSubmatch(line)
+		if m == nil {
+			return resp, nil
+		}
+		size, err := strconv.Atoi(m[1])
+		if err != nil {
+			return resp, fmt.Errorf("invalid literal size %s", m[1])
+		}
+		if size > maxMessageSize {
+			// too large to be a newsletter, skipped with an empty literal to keep the session in sync
+			if _, err := io.CopyN(io.Discard, c.r, int64(size)); err != nil {
+				return resp, fmt.Errorf("skip literal: %w", err)
+			}
+			resp.literals = append(resp.literals, nil)
+			continue
+		}
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.r, literal); err != nil {
+			return resp, fmt.Errorf("read literal: %w", err)
+		}
+		resp.literals = append(resp.literals, literal)
+	}
+}
+
+func (c *imapConn) close() {
+	close(c.done)
+	_ = c.conn.Close()
+}
+
+// searchUIDs collects the UIDs of SEARCH responses
+func searchUIDs(resp []imapResponse) []int {
+	var uids []int
+	for _, r := range resp {
+		fields := strings.Fields(r.line)
+		if len(fields) < 2 || fields[0] != "*" || fields[1] != "SEARCH" {
+			continue
+		}
+		for _, f := range fields[2:] {
+			if uid, err := strconv.Atoi(f); err == nil {
+				uids = append(uids, uid)
+			}
+		}
+	}
+	return uids
+}
+
+// imapQuote makes an IMAP quoted string
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,167 @@
+package newsletter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIMAPServer answers commands of a single session with the handler's responses
+// and records the commands it received
+type fakeIMAPServer struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	commands []string
+}
+
+func newFakeIMAPServer(t *testing.T, handler func(tag, cmd string) string) *fakeIMAPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeIMAPServer{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = fmt.Fprint(conn, "* OK IMAP4rev1 ready\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			tag, cmd, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+			s.mu.Lock()
+			s.commands = append(s.commands, cmd)
+			s.mu.Unlock()
+			_, _ = fmt.Fprint(conn, handler(tag, cmd))
+		}
+	}()
+	return s
+}
+
+func (s *fakeIMAPServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+func TestIMAP_Fetch(t *testing.T) {
+	newsletterMsg := "From: Weekly <weekly@example.com>\r\nSubject: Issue 1\r\nMessage-ID: <1@example.com>\r\n" +
+		"Date: Tue, 05 Mar 2024 10:00:00 +0000\r\nContent-Type: text/html\r\n\r\n<p>Hello</p>\r\n"
+	olderMsg := "From: daily@example.com\r\nSubject: Daily\r\nDate: Mon, 04 Mar 2024 10:00:00 +0000\r\n\r\ntext\r\n"
+
+	t.Run("reads messages without marking them", func(t *testing.T) {
+		srv := newFakeIMAPServer(t, func(tag, cmd string) string {
+			switch {
+			case strings.HasPrefix(cmd, "LOGIN"):
+				return tag + " OK logged in\r\n"
+			case strings.HasPrefix(cmd, "EXAMINE"):
+				return "* 3 EXISTS\r\n" + tag + " OK [READ-ONLY] done\r\n"
+			case strings.HasPrefix(cmd, "UID SEARCH"):
+				return "* SEARCH 12 7 15\r\n" + tag + " OK done\r\n"
+			case strings.HasPrefix(cmd, "UID FETCH"):
+				return fmt.Sprintf("* 1 FETCH (UID 12 BODY[] {%d}\r\n%s)\r\n", len(newsletterMsg), newsletterMsg) +
+					fmt.Sprintf("* 2 FETCH (UID 7 BODY[] {%d}\r\n%s)\r\n", len(olderMsg), olderMsg) +
+					"* 3 FETCH (UID 15 BODY[] {9}\r\nnot email)\r\n" +
+					tag + " OK done\r\n"
+			case cmd == "LOGOUT":
+				return "* BYE\r\n" + tag + " OK bye\r\n"
+			}
+			return tag + " BAD unexpected\r\n"
+		})
+
+		src := NewIMAP(IMAPParams{Addr: srv.ln.Addr().String(), NoTLS: true, Username: "user",
+			Password: `pa"ss`, Folder: "News", Timeout: 5 * time.Second})
+		messages, err := src.Fetch(context.Background(), time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC))
+		require.Error(t, err, "unparsable message reported")
+		assert.Contains(t, err.Error(), "UID 15")
+
+		require.Len(t, messages, 2)
+		assert.Equal(t, "Daily", messages[0].Subject, "oldest first")
+		assert.Equal(t, "Issue 1", messages[1].Subject)
+		assert.Equal(t, "weekly@example.com", messages[1].From)
+		assert.Equal(t, "<p>Hello</p>", messages[1].HTML)
+
+		assert.Equal(t, []string{
+			`LOGIN "user" "pa\"ss"`,
+			`EXAMINE "News"`,
+			"UID SEARCH SINCE 1-Mar-2024",
+			"UID FETCH 7,12,15 (BODY.PEEK[])",
+			"LOGOUT",
+		}, srv.received())
+	})
+
+	t.Run("fetches in batches and skips oversized messages", func(t *testing.T) {
+		huge := strings.Repeat("x", maxMessageSize+1)
+		srv := newFakeIMAPServer(t, func(tag, cmd string) string {
+			switch {
+			case strings.HasPrefix(cmd, "UID SEARCH"):
+				return "* SEARCH 7 12 15\r\n" + tag + " OK done\r\n"
+			case cmd == "UID FETCH 7,12 (BODY.PEEK[])":
+				return fmt.Sprintf("* 1 FETCH (UID 7 BODY[] {%d}\r\n%s)\r\n", len(olderMsg), olderMsg) +
+					fmt.Sprintf("* 2 FETCH (UID 12 BODY[] {%d}\r\n%s)\r\n", len(huge), huge) +
+					tag + " OK done\r\n"
+			case cmd == "UID FETCH 15 (BODY.PEEK[])":
+				return fmt.Sprintf("* 3 FETCH (UID 15 BODY[] {%d}\r\n%s)\r\n", len(newsletterMsg), newsletterMsg) +
+					tag + " OK done\r\n"
+			}
+			return tag + " OK done\r\n"
+		})
+
+		src := NewIMAP(IMAPParams{Addr: srv.ln.Addr().String(), NoTLS: true, Folder: "INBOX", Timeout: 5 * time.Second})
+		src.batch = 2
+		messages, err := src.Fetch(context.Background(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.Error(t, err, "oversized message reported")
+		assert.Contains(t, err.Error(), "UID 12")
+
+		require.Len(t, messages, 2)
+		assert.Equal(t, "Daily", messages[0].Subject)
+		assert.Equal(t, "Issue 1", messages[1].Subject)
+		assert.Equal(t, []string{
+			`LOGIN "" ""`,
+			`EXAMINE "INBOX"`,
+			"UID SEARCH SINCE 1-Mar-2024",
+			"UID FETCH 7,12 (BODY.PEEK[])",
+			"UID FETCH 15 (BODY.PEEK[])",
+			"LOGOUT",
+		}, srv.received())
+	})
+
+	t.Run("login rejected", func(t *testing.T) {
+		srv := newFakeIMAPServer(t, func(tag, cmd string) string {
+			return tag + " NO [AUTHENTICATIONFAILED] invalid credentials\r\n"
+		})
+
+		src := NewIMAP(IMAPParams{Addr: srv.ln.Addr().String(), NoTLS: true, Folder: "INBOX", Timeout: 5 * time.Second})
+		_, err := src.Fetch(context.Background(), time.Now())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "login: imap: NO [AUTHENTICATIONFAILED]")
+	})
+
+	t.Run("no new messages", func(t *testing.T) {
+		srv := newFakeIMAPServer(t, func(tag, cmd string) string {
+			if strings.HasPrefix(cmd, "UID SEARCH") {
+				return "* SEARCH\r\n" + tag + " OK done\r\n"
+			}
+			return tag + " OK done\r\n"
+		})
+
+		src := NewIMAP(IMAPParams{Addr: srv.ln.Addr().String(), NoTLS: true, Folder: "INBOX", Timeout: 5 * time.Second})
+		messages, err := src.Fetch(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+}
@@ -0,0 +1,69 @@
+package newsletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Maildir reads newsletters from a maildir directory. messages are only read,
+// never moved or flagged, so the directory can be shared with a mail client.
+type Maildir struct {
+	dir string
+}
+
+// NewMaildir makes a maildir source for the directory containing new and cur subdirectories
+func NewMaildir(dir string) *Maildir {
+	return &Maildir{dir: dir}
+}
+
+// Fetch returns messages delivered since the given time, oldest first. messages which can't be parsed
+// are skipped and reported in the returned error along with the messages read.
+func (m *Maildir) Fetch(ctx context.Context, since time.Time) ([]Message, error) {
+	var messages []Message
+	var errs []error
+	for _, sub := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(m.dir, sub))
+		if err != nil {
+			return nil, fmt.Errorf("read maildir: %w", err)
+		}
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || info.ModTime().Before(since) {
+				continue // removed meanwhile or delivered before the lookback period
+			}
+			msg, err := m.parseFile(filepath.Join(m.dir, sub, entry.Name()))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("message %s: %w", entry.Name(), err))
+				continue
+			}
+			if msg.Date.IsZero() {
+				msg.Date = info.ModTime()
+			}
+			messages = append(messages, *msg)
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].Date.Before(messages[j].Date) })
+	return messages, errors.Join(errs...)
+}
+
+func (m *Maildir) parseFile(path string) (*Message, error) {
+	f, err := os.Open(path) //nolint:gosec // path is built from the configured maildir
+	if err != nil {
+		return nil, fmt.Errorf("open message: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
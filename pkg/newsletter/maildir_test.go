@@ -0,0 +1,45 @@
+package newsletter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaildir_Fetch(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0o750))
+	}
+	write := func(path, content string, mtime time.Time) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(filepath.Join(dir, path), mtime, mtime))
+	}
+
+	now := time.Now()
+	write("new/1.host", "From: a@example.com\r\nSubject: Newer\r\nDate: Tue, 05 Mar 2024 10:00:00 +0000\r\n\r\nbody", now)
+	write("cur/2.host:2,S", "From: b@example.com\r\nSubject: Older\r\nDate: Mon, 04 Mar 2024 10:00:00 +0000\r\n\r\nbody", now)
+	write("cur/3.host:2,S", "From: c@example.com\r\nSubject: Undated\r\n\r\nbody", now.Add(-time.Hour))
+	write("cur/4.host:2,S", "From: d@example.com\r\nSubject: Too old\r\n\r\nbody", now.Add(-48*time.Hour))
+	write("new/5.host", "Subject: broken\r\n\r\nbody", now)
+	write("tmp/6.host", "From: e@example.com\r\nSubject: Being delivered\r\n\r\nbody", now)
+
+	messages, err := NewMaildir(dir).Fetch(context.Background(), now.Add(-24*time.Hour))
+	require.Error(t, err, "broken message reported")
+	assert.Contains(t, err.Error(), "5.host")
+
+	subjects := make([]string, 0, len(messages))
+	for _, m := range messages {
+		subjects = append(subjects, m.Subject)
+	}
+	assert.Equal(t, []string{"Older", "Newer", "Undated"}, subjects, "oldest first, undated dated by delivery")
+
+	_, err = NewMaildir(filepath.Join(dir, "missing")).Fetch(context.Background(), now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read maildir")
+}
@@ -0,0 +1,178 @@
+// Package newsletter reads email newsletters from a maildir directory or an IMAP folder
+// and turns them into sanitized messages ready to be stored as items.
+package newsletter
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// maxMessageSize limits the size of a single message read from a source
+const maxMessageSize = 10 << 20
+
+// Message is a parsed newsletter email
+type Message struct {
+	ID       string    // Message-ID without angle brackets, generated from the headers if missing
+	From     string    // sender address, lowercased
+	FromName string    // sender display name, may be empty
+	Subject  string    // decoded subject
+	Date     time.Time // Date header, zero if missing or invalid
+	HTML     string    // sanitized HTML body, plain text bodies are converted to HTML
+	Text     string    // plain text body, extracted from HTML if the message has no text part
+}
+
+// Sender returns the sender name to show, the address if the message has no display name
+func (m *Message) Sender() string {
+	if m.FromName != "" {
+		return m.FromName
+	}
+	return m.From
+}
+
+// Parse reads an RFC 5322 message. the HTML part is preferred for the body, attachments are ignored.
+func Parse(r io.Reader) (*Message, error) {
+	msg, err := mail.ReadMessage(io.LimitReader(r, maxMessageSize))
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+	addrParser := &mail.AddressParser{WordDecoder: decoder}
+	from, err := addrParser.Parse(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("parse sender: %w", err)
+	}
+
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject") // keep the raw subject rather than nothing
+	}
+
+	result := &Message{
+		ID:       strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>"),
+		From:     strings.ToLower(from.Address),
+		FromName: strings.TrimSpace(from.Name),
+		Subject:  strings.TrimSpace(subject),
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		result.Date = date
+	}
+	if result.ID == "" {
+		// no Message-ID, make a stable one so the message is stored only once
+		h := sha256.Sum256([]byte(result.From + "|" + result.Subject + "|" + msg.Header.Get("Date")))
+		result.ID = hex.EncodeToString(h[:16]) + "@newscope"
+	}
+
+	htmlBody, textBody, err := readBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	if htmlBody == "" && textBody == "" {
+		return nil, errors.New("message has no text body")
+	}
+	if htmlBody == "" {
+		htmlBody = "<p>" + strings.ReplaceAll(html.EscapeString(textBody), "\n", "<br>") + "</p>"
+	}
+	result.HTML = strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(htmlBody))
+	if textBody == "" {
+		textBody = PlainText(result.HTML)
+	}
+	result.Text = strings.TrimSpace(textBody)
+	return result, nil
+}
+
+// PlainText returns the text of an HTML body with whitespace collapsed. text of separate
+// elements is separated by a space, so paragraphs don't run together.
+func PlainText(htmlBody string) string {
+	doc, err := html.Parse(strings.NewReader(htmlBody))
+	if err != nil {
+		return ""
+	}
+	var parts []string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+			return
+		}
+		if n.Type == html.TextNode {
+			parts = append(parts, n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
+
+// readBody returns the first HTML and the first plain text part of the body, walking multipart bodies
+func readBody(contentType, encoding string, body io.Reader) (htmlBody, textBody string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain" // RFC 2045 default
+		params = map[string]string{}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body) // line breaks are ignored by the decoder
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return htmlBody, textBody, nil
+			}
+			if err != nil {
+				return htmlBody, textBody, fmt.Errorf("read message part: %w", err)
+			}
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+			partHTML, partText, err := readBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return htmlBody, textBody, err
+			}
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+			if textBody == "" {
+				textBody = partText
+			}
+		}
+	case mediaType == "text/html", mediaType == "text/plain":
+		if cs := params["charset"]; cs != "" {
+			if body, err = charset.NewReaderLabel(cs, body); err != nil {
+				return "", "", fmt.Errorf("decode charset %s: %w", cs, err)
+			}
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return "", "", fmt.Errorf("read message body: %w", err)
+		}
+		if mediaType == "text/html" {
+			return string(data), "", nil
+		}
+		return "", strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+	}
+	return "", "", nil // images and other parts
+}
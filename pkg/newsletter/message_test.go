@@ -0,0 +1,103 @@
+package newsletter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multipartMessage = "From: =?UTF-8?Q?Caf=C3=A9_Weekly?= <News@Cafe.example.com>\r\n" +
+	"To: reader@example.com\r\n" +
+	"Subject: =?UTF-8?B?V2Vla2x5IGRpZ2VzdCDigJQgIzQy?=\r\n" +
+	"Date: Tue, 05 Mar 2024 10:00:00 +0000\r\n" +
+	"Message-ID: <issue-42@cafe.example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Plain version of the issue\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"<html><body><h1>Caf=E9 news</h1><script>alert(1)</script>" +
+	"<p onclick=3D\"x()\">Read <a href=3D\"https://cafe.example.com/a\">more</a></p></body></html>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/html\r\n" +
+	"Content-Disposition: attachment; filename=\"old.html\"\r\n" +
+	"\r\n" +
+	"<p>attached, ignored</p>\r\n" +
+	"--outer--\r\n"
+
+func TestParse(t *testing.T) {
+	t.Run("multipart newsletter", func(t *testing.T) {
+		msg, err := Parse(strings.NewReader(multipartMessage))
+		require.NoError(t, err)
+
+		assert.Equal(t, "issue-42@cafe.example.com", msg.ID)
+		assert.Equal(t, "news@cafe.example.com", msg.From)
+		assert.Equal(t, "Café Weekly", msg.FromName)
+		assert.Equal(t, "Café Weekly", msg.Sender())
+		assert.Equal(t, "Weekly digest — #42", msg.Subject)
+		assert.Equal(t, time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), msg.Date.UTC())
+		assert.Equal(t, "Plain version of the issue", msg.Text)
+
+		assert.Contains(t, msg.HTML, "<h1>Café news</h1>")
+		assert.Contains(t, msg.HTML, `href="https://cafe.example.com/a"`)
+		assert.NotContains(t, msg.HTML, "script")
+		assert.NotContains(t, msg.HTML, "onclick")
+		assert.NotContains(t, msg.HTML, "attached")
+	})
+
+	t.Run("base64 html only", func(t *testing.T) {
+		raw := "From: digest@example.com\r\n" +
+			"Subject: Digest\r\n" +
+			"Content-Type: text/html; charset=utf-8\r\n" +
+			"Content-Transfer-Encoding: base64\r\n" +
+			"\r\n" +
+			"PGRpdj48cD5GaXJzdCAgc3Rvcnk8L3A+\r\nPHA+U2Vjb25kIHN0b3J5PC9wPjwvZGl2Pg==\r\n"
+		msg, err := Parse(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "<div><p>First  story</p><p>Second story</p></div>", msg.HTML)
+		assert.Equal(t, "First story Second story", msg.Text, "text is taken from html")
+		assert.Equal(t, "digest@example.com", msg.Sender())
+		assert.True(t, msg.Date.IsZero())
+		assert.True(t, strings.HasSuffix(msg.ID, "@newscope"), "id generated without Message-ID")
+
+		again, err := Parse(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, msg.ID, again.ID, "generated id is stable")
+	})
+
+	t.Run("plain text only", func(t *testing.T) {
+		raw := "From: Letters <letters@example.com>\r\nSubject: Hi\r\n\r\nline <one>\r\nline two\r\n"
+		msg, err := Parse(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "<p>line &lt;one&gt;<br>line two<br></p>", msg.HTML)
+		assert.Equal(t, "line <one>\nline two", msg.Text)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("Subject: no sender\r\n\r\nbody"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parse sender")
+
+		_, err = Parse(strings.NewReader("From: a@example.com\r\nContent-Type: image/png\r\n\r\nPNG"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no text body")
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Title some text", PlainText("<style>p{}</style><h1>Title</h1>\n<p>some   text</p>"))
+	assert.Empty(t, PlainText(""))
+}
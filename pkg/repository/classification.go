@@ -22,21 +22,29 @@ type ClassificationRepository struct {
 
 // itemWithFeedSQL represents an item with feed information for SQL operations
 type itemWithFeedSQL struct {
-	ID          int64     `db:"id"`
-	FeedID      int64     `db:"feed_id"`
-	GUID        string    `db:"guid"`
-	Title       string    `db:"title"`
-	Link        string    `db:"link"`
-	Description string    `db:"description"`
-	Content     string    `db:"content"`
-	Author      string    `db:"author"`
-	Published   time.Time `db:"published"`
+	ID            int64     `db:"id"`
+	FeedID        int64     `db:"feed_id"`
+	GUID          string    `db:"guid"`
+	Title         string    `db:"title"`
+	Link          string    `db:"link"`
+	CanonicalLink string    `db:"canonical_link"`
+	ResolvedLink  string    `db:"resolved_link"`
+	Description   string    `db:"description"`
+	Content       string    `db:"content"`
+	Author        string    `db:"author"`
+	Published     time.Time `db:"published"`
+
+	// feed attachments
+	Image      string            `db:"image"`
+	Enclosures enclosuresSQL     `db:"enclosures"`
+	Categories classificationSQL `db:"categories"`
 
 	// extracted content
 	ExtractedContent     string     `db:"extracted_content"`
 	ExtractedRichContent string     `db:"extracted_rich_content"`
 	ExtractedAt          *time.Time `db:"extracted_at"`
 	ExtractionError      string     `db:"extraction_error"`
+	ExtractedBy          string     `db:"extracted_by"`
 
 	// LLM classification
 	RelevanceScore float64           `db:"relevance_score"`
@@ -49,13 +57,22 @@ type itemWithFeedSQL struct {
 	UserFeedback string     `db:"user_feedback"`
 	FeedbackAt   *time.Time `db:"feedback_at"`
 
+	// near-duplicate detection
+	Simhash     int64  `db:"simhash"`
+	DuplicateOf *int64 `db:"duplicate_of"`
+
+	// mute rules
+	MutedBy string `db:"muted_by"`
+
 	// metadata
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 
 	// joined data (not stored in DB, populated by queries)
-	FeedTitle string `db:"feed_title"`
-	FeedURL   string `db:"feed_url"`
+	FeedTitle string     `db:"feed_title"`
+	FeedURL   string     `db:"feed_url"`
+	JobError  string     `db:"job_error"` // error of the item's failed processing job
+	FailedAt  *time.Time `db:"failed_at"` // when the item's processing job failed
 }
 
 // classificationSQL is a JSON array of topic strings for SQL operations
@@ -123,6 +140,12 @@ func (r *ClassificationRepository) GetClassifiedItems(ctx context.Context, filte
 		args = append(args, filter.FeedName, filter.FeedName)
 	}
 
+	// add folder filter if specified, subfolders included
+	if filter.Folder != "" {
+		query += ` AND (f.folder = ? OR INSTR(f.folder, ?) = 1)`
+		args = append(args, filter.Folder, filter.Folder+"/")
+	}
+
 	// add liked only filter if specified
 	if filter.ShowLikedOnly {
 		query += ` AND i.user_feedback = 'like'`
@@ -154,6 +177,62 @@ func (r *ClassificationRepository) GetClassifiedItems(ctx context.Context, filte
 		return nil, fmt.Errorf("get classified items: %w", err)
 	}
 
+	items := make([]*domain.ClassifiedItem, len(sqlItems))
+	for i, sqlItem := range sqlItems {
+		items[i] = r.toDomainClassifiedItem(&sqlItem)
+	}
+	if err := r.addDuplicates(ctx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetMutedItems returns the most recently stored items muted by mute rules, with feed information
+func (r *ClassificationRepository) GetMutedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+	query := `
+		SELECT 
+			i.*,
+			f.title as feed_title,
+			f.url as feed_url
+		FROM items i
+		JOIN feeds f ON i.feed_id = f.id
+		WHERE i.muted_by != ''
+		ORDER BY i.created_at DESC, i.id DESC
+		LIMIT ?`
+
+	var sqlItems []itemWithFeedSQL
+	if err := r.db.SelectContext(ctx, &sqlItems, query, limit); err != nil {
+		return nil, fmt.Errorf("get muted items: %w", err)
+	}
+
+	items := make([]*domain.ClassifiedItem, len(sqlItems))
+	for i, sqlItem := range sqlItems {
+		items[i] = r.toDomainClassifiedItem(&sqlItem)
+	}
+	return items, nil
+}
+
+// GetFailedItems returns items whose processing jobs failed after all attempts, most recently failed first
+func (r *ClassificationRepository) GetFailedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+	query := `
+		SELECT 
+			i.*,
+			f.title as feed_title,
+			f.url as feed_url,
+			j.error as job_error,
+			j.updated_at as failed_at
+		FROM items i
+		JOIN feeds f ON i.feed_id = f.id
+		JOIN process_jobs j ON j.item_id = i.id
+		WHERE j.state = 'failed'
+		ORDER BY j.updated_at DESC, i.id DESC
+		LIMIT ?`
+
+	var sqlItems []itemWithFeedSQL
+	if err := r.db.SelectContext(ctx, &sqlItems, query, limit); err != nil {
+		return nil, fmt.Errorf("get failed items: %w", err)
+	}
+
 	items := make([]*domain.ClassifiedItem, len(sqlItems))
 	for i, sqlItem := range sqlItems {
 		items[i] = r.toDomainClassifiedItem(&sqlItem)
@@ -180,7 +259,59 @@ func (r *ClassificationRepository) GetClassifiedItem(ctx context.Context, itemID
 		}
 		return nil, fmt.Errorf("get classified item: %w", err)
 	}
-	return r.toDomainClassifiedItem(&sqlItem), nil
+	item := r.toDomainClassifiedItem(&sqlItem)
+	if err := r.addDuplicates(ctx, []*domain.ClassifiedItem{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// addDuplicates sets near-duplicates of the items, one per feed. duplicates from the item's own feed are skipped.
+func (r *ClassificationRepository) addDuplicates(ctx context.Context, items []*domain.ClassifiedItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*domain.ClassifiedItem, len(items))
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT d.id, d.duplicate_of, d.feed_id, COALESCE(NULLIF(d.resolved_link, ''), d.link) AS link,
+			f.title AS feed_title, f.url AS feed_url
+		FROM items d
+		JOIN feeds f ON d.feed_id = f.id
+		WHERE d.duplicate_of IN (?)
+		ORDER BY d.id`, ids)
+	if err != nil {
+		return fmt.Errorf("build duplicates query: %w", err)
+	}
+	var rows []struct {
+		ID          int64  `db:"id"`
+		DuplicateOf int64  `db:"duplicate_of"`
+		FeedID      int64  `db:"feed_id"`
+		Link        string `db:"link"`
+		FeedTitle   string `db:"feed_title"`
+		FeedURL     string `db:"feed_url"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("get duplicates: %w", err)
+	}
+
+	seen := make(map[[2]int64]bool) // item and feed IDs
+	for _, row := range rows {
+		item := byID[row.DuplicateOf]
+		key := [2]int64{row.DuplicateOf, row.FeedID}
+		if item == nil || row.FeedID == item.FeedID || seen[key] {
+			continue
+		}
+		seen[key] = true
+		item.Duplicates = append(item.Duplicates, domain.Duplicate{ItemID: row.ID, Link: row.Link,
+			FeedName: row.FeedTitle, FeedURL: row.FeedURL})
+	}
+	return nil
 }
 
 // GetTopics returns all unique topics from classified items
@@ -408,17 +539,25 @@ func (r *ClassificationRepository) GetFeedbackSince(ctx context.Context, offset
 func (r *ClassificationRepository) toDomainClassifiedItem(sqlItem *itemWithFeedSQL) *domain.ClassifiedItem {
 	item := &domain.ClassifiedItem{
 		Item: &domain.Item{
-			ID:          sqlItem.ID,
-			FeedID:      sqlItem.FeedID,
-			GUID:        sqlItem.GUID,
-			Title:       sqlItem.Title,
-			Link:        sqlItem.Link,
-			Description: sqlItem.Description,
-			Content:     sqlItem.Content,
-			Author:      sqlItem.Author,
-			Published:   sqlItem.Published,
-			CreatedAt:   sqlItem.CreatedAt,
-			UpdatedAt:   sqlItem.UpdatedAt,
+			ID:            sqlItem.ID,
+			FeedID:        sqlItem.FeedID,
+			GUID:          sqlItem.GUID,
+			Title:         sqlItem.Title,
+			Link:          sqlItem.Link,
+			CanonicalLink: sqlItem.CanonicalLink,
+			ResolvedLink:  sqlItem.ResolvedLink,
+			Description:   sqlItem.Description,
+			Content:       sqlItem.Content,
+			Author:        sqlItem.Author,
+			Published:     sqlItem.Published,
+			Image:         sqlItem.Image,
+			Enclosures:    sqlItem.Enclosures,
+			Categories:    sqlItem.Categories,
+			MutedBy:       sqlItem.MutedBy,
+			ProcessError:  sqlItem.JobError,
+			FailedAt:      sqlItem.FailedAt,
+			CreatedAt:     sqlItem.CreatedAt,
+			UpdatedAt:     sqlItem.UpdatedAt,
 		},
 		FeedName: sqlItem.FeedTitle,
 		FeedURL:  sqlItem.FeedURL,
@@ -431,6 +570,7 @@ func (r *ClassificationRepository) toDomainClassifiedItem(sqlItem *itemWithFeedS
 			RichHTML:    sqlItem.ExtractedRichContent,
 			ExtractedAt: *sqlItem.ExtractedAt,
 			Error:       sqlItem.ExtractionError,
+			Extractor:   sqlItem.ExtractedBy,
 		}
 	}
 
@@ -482,6 +622,12 @@ func (r *ClassificationRepository) GetClassifiedItemsCount(ctx context.Context,
 		args = append(args, filter.FeedName, filter.FeedName)
 	}
 
+	// add folder filter if specified, subfolders included
+	if filter.Folder != "" {
+		query += ` AND (f.folder = ? OR INSTR(f.folder, ?) = 1)`
+		args = append(args, filter.Folder, filter.Folder+"/")
+	}
+
 	// add liked only filter if specified
 	if filter.ShowLikedOnly {
 		query += ` AND i.user_feedback = 'like'`
@@ -560,6 +706,12 @@ func (r *ClassificationRepository) buildSearchWhereClause(searchQuery string, fi
 		args = append(args, filter.FeedName, filter.FeedName)
 	}
 
+	// add folder filter if specified, subfolders included
+	if filter.Folder != "" {
+		whereClause += ` AND (f.folder = ? OR INSTR(f.folder, ?) = 1)`
+		args = append(args, filter.Folder, filter.Folder+"/")
+	}
+
 	// add liked only filter if specified
 	if filter.ShowLikedOnly {
 		whereClause += ` AND i.user_feedback = 'like'`
@@ -613,6 +765,9 @@ func (r *ClassificationRepository) SearchItems(ctx context.Context, searchQuery
 	for i, sqlItem := range sqlItems {
 		items[i] = r.toDomainClassifiedItem(&sqlItem)
 	}
+	if err := r.addDuplicates(ctx, items); err != nil {
+		return nil, err
+	}
 	return items, nil
 }
 
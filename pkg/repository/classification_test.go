@@ -1254,6 +1254,57 @@ func TestClassificationRepository_GetClassifiedItems_LikedFilter(t *testing.T) {
 	})
 }
 
+func TestClassificationRepository_FolderFilter(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// feeds in a folder, its subfolder, a folder with the same prefix and at the top level
+	folders := []string{"Tech", "Tech/Go", "Technology", ""}
+	for i, folder := range folders {
+		feed := &domain.Feed{URL: fmt.Sprintf("https://example.com/feed%d.xml", i), Title: fmt.Sprintf("Feed %d", i),
+			Folder: folder, FetchInterval: 3600, Enabled: true}
+		require.NoError(t, repos.Feed.CreateFeed(context.Background(), feed))
+
+		item := &domain.Item{FeedID: feed.ID, GUID: fmt.Sprintf("item-%d", i), Title: fmt.Sprintf("golang news %d", i),
+			Link: fmt.Sprintf("https://example.com/%d", i), Published: time.Now().Add(time.Duration(i) * time.Hour)}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), item))
+		require.NoError(t, repos.Item.UpdateItemClassification(context.Background(), item.ID,
+			&domain.Classification{GUID: item.GUID, Score: 7, Topics: []string{"go"}}))
+	}
+
+	tests := []struct {
+		folder string
+		want   []string
+	}{
+		{folder: "", want: []string{"golang news 3", "golang news 2", "golang news 1", "golang news 0"}},
+		{folder: "Tech", want: []string{"golang news 1", "golang news 0"}},
+		{folder: "Tech/Go", want: []string{"golang news 1"}},
+		{folder: "Go", want: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.folder, func(t *testing.T) {
+			filter := &domain.ItemFilter{Folder: tc.folder, SortBy: "published", Limit: 10}
+
+			items, err := repos.Classification.GetClassifiedItems(context.Background(), filter)
+			require.NoError(t, err)
+			titles := []string{}
+			for _, item := range items {
+				titles = append(titles, item.Title)
+			}
+			assert.Equal(t, tc.want, titles)
+
+			count, err := repos.Classification.GetClassifiedItemsCount(context.Background(), filter)
+			require.NoError(t, err)
+			assert.Equal(t, len(tc.want), count)
+
+			found, err := repos.Classification.SearchItems(context.Background(), "golang", filter)
+			require.NoError(t, err)
+			assert.Len(t, found, len(tc.want), "search is filtered by folder too")
+		})
+	}
+}
+
 func TestClassificationRepository_SearchItems(t *testing.T) {
 	// setup test database
 	repos, cleanup := setupTestDB(t)
@@ -2,7 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-pkgz/repeater/v2"
@@ -11,6 +17,9 @@ import (
 	"github.com/umputun/newscope/pkg/domain"
 )
 
+// feedFetchesKept is the number of latest fetch attempts kept in the fetch log of each feed
+const feedFetchesKept = 100
+
 // FeedRepository handles feed-related database operations
 type FeedRepository struct {
 	db *sqlx.DB
@@ -18,17 +27,96 @@ type FeedRepository struct {
 
 // feedSQL represents a feed for SQL operations
 type feedSQL struct {
-	ID            int64      `db:"id"`
-	URL           string     `db:"url"`
-	Title         string     `db:"title"`
-	Description   string     `db:"description"`
-	LastFetched   *time.Time `db:"last_fetched"`
-	NextFetch     *time.Time `db:"next_fetch"`
-	FetchInterval int        `db:"fetch_interval"`
-	ErrorCount    int        `db:"error_count"`
-	LastError     string     `db:"last_error"`
-	Enabled       bool       `db:"enabled"`
-	CreatedAt     time.Time  `db:"created_at"`
+	ID               int64        `db:"id"`
+	FeedType         string       `db:"feed_type"`
+	URL              string       `db:"url"`
+	Title            string       `db:"title"`
+	Description      string       `db:"description"`
+	Folder           string       `db:"folder"`
+	LastFetched      *time.Time   `db:"last_fetched"`
+	NextFetch        *time.Time   `db:"next_fetch"`
+	FetchInterval    int          `db:"fetch_interval"`
+	AdaptiveInterval int          `db:"adaptive_interval"`
+	ErrorCount       int          `db:"error_count"`
+	LastError        string       `db:"last_error"`
+	Enabled          bool         `db:"enabled"`
+	DisabledReason   string       `db:"disabled_reason"`
+	CreatedAt        time.Time    `db:"created_at"`
+	LastNewItem      *time.Time   `db:"last_new_item"`
+	Selectors        selectorsSQL `db:"selectors"`
+	Auth             authSQL      `db:"auth"`
+	Proxy            string       `db:"proxy"`
+	MovedTo          string       `db:"moved_to"`
+	ETag             string       `db:"etag"`
+	LastModified     string       `db:"last_modified"`
+	LastSize         int64        `db:"last_size"`
+	BytesSaved       int64        `db:"bytes_saved"`
+	HubURL           string       `db:"hub_url"`
+	WebSubTopic      string       `db:"websub_topic"`
+	WebSubSecret     string       `db:"websub_secret"`
+	WebSubExpires    *time.Time   `db:"websub_expires"`
+}
+
+// selectorsSQL is a JSON object with scraping selectors for SQL operations, empty for regular feeds
+type selectorsSQL domain.ScrapeSelectors
+
+// Value implements driver.Valuer for database storage
+func (s selectorsSQL) Value() (driver.Value, error) {
+	if s == (selectorsSQL{}) {
+		return "", nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshal selectors: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (s *selectorsSQL) Scan(value interface{}) error {
+	*s = selectorsSQL{}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
+
+// authSQL is a JSON object with credentials of a private feed for SQL operations, empty for public feeds
+type authSQL domain.FeedAuth
+
+// Value implements driver.Valuer for database storage
+func (a authSQL) Value() (driver.Value, error) {
+	if domain.FeedAuth(a).IsZero() {
+		return "", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("marshal auth: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (a *authSQL) Scan(value interface{}) error {
+	*a = authSQL{}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, a)
 }
 
 // NewFeedRepository creates a new feed repository
@@ -38,17 +126,26 @@ func NewFeedRepository(database *sqlx.DB) *FeedRepository {
 
 // CreateFeed inserts a new feed
 func (r *FeedRepository) CreateFeed(ctx context.Context, feed *domain.Feed) error {
+	feedType := feed.Type
+	if feedType == "" {
+		feedType = domain.FeedTypeRSS
+	}
 	sqlFeed := &feedSQL{
+		FeedType:      string(feedType),
 		URL:           feed.URL,
 		Title:         feed.Title,
 		Description:   feed.Description,
+		Folder:        feed.Folder,
 		FetchInterval: int(feed.FetchInterval.Seconds()),
 		Enabled:       feed.Enabled,
+		Selectors:     selectorsSQL(feed.Selectors),
+		Auth:          authSQL(feed.Auth),
+		Proxy:         feed.Proxy,
 	}
 
 	query := `
-		INSERT INTO feeds (url, title, description, fetch_interval, enabled)
-		VALUES (:url, :title, :description, :fetch_interval, :enabled)
+		INSERT INTO feeds (feed_type, url, title, description, folder, fetch_interval, enabled, selectors, auth, proxy)
+		VALUES (:feed_type, :url, :title, :description, :folder, :fetch_interval, :enabled, :selectors, :auth, :proxy)
 	`
 	result, err := r.db.NamedExecContext(ctx, query, sqlFeed)
 	if err != nil {
@@ -74,6 +171,19 @@ func (r *FeedRepository) GetFeed(ctx context.Context, id int64) (*domain.Feed, e
 	return r.toDomainFeed(&sqlFeed), nil
 }
 
+// GetFeedByURL retrieves a feed by URL, nil if there is no such feed
+func (r *FeedRepository) GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error) {
+	var sqlFeed feedSQL
+	err := r.db.GetContext(ctx, &sqlFeed, "SELECT * FROM feeds WHERE url = ?", url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get feed by url: %w", err)
+	}
+	return r.toDomainFeed(&sqlFeed), nil
+}
+
 // GetFeeds retrieves feeds with optional filtering
 func (r *FeedRepository) GetFeeds(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
 	query := "SELECT * FROM feeds"
@@ -95,12 +205,13 @@ func (r *FeedRepository) GetFeeds(ctx context.Context, enabledOnly bool) ([]doma
 	return feeds, nil
 }
 
-// GetFeedsToFetch retrieves feeds that need updating
+// GetFeedsToFetch retrieves feeds that need updating, newsletter pseudo-feeds are never fetched
 func (r *FeedRepository) GetFeedsToFetch(ctx context.Context, limit int) ([]domain.Feed, error) {
 	query := `
 		SELECT * FROM feeds 
 		WHERE enabled = 1 
-		AND (next_fetch IS NULL OR next_fetch <= datetime('now'))
+		AND feed_type != 'newsletter'
+		AND (next_fetch IS NULL OR datetime(next_fetch) <= datetime('now'))
 		ORDER BY next_fetch ASC
 		LIMIT ?
 	`
@@ -130,7 +241,7 @@ func (r *FeedRepository) UpdateFeedFetched(ctx context.Context, feedID int64, ne
 			    last_error = ''
 			WHERE id = ?
 		`
-		_, err := r.db.ExecContext(ctx, query, nextFetch, feedID)
+		_, err := r.db.ExecContext(ctx, query, sqliteTime(nextFetch), feedID)
 		if err != nil {
 			if isLockError(err) {
 				return err // retry
@@ -141,6 +252,110 @@ func (r *FeedRepository) UpdateFeedFetched(ctx context.Context, feedID int64, ne
 	})
 }
 
+// UpdateFeedAdaptiveInterval stores the fetch interval derived from the feed's publishing frequency
+func (r *FeedRepository) UpdateFeedAdaptiveInterval(ctx context.Context, feedID int64, interval time.Duration) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET adaptive_interval = ? WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, int(interval.Seconds()), feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("update feed adaptive interval: %w", err)}
+		}
+		return nil
+	})
+}
+
+// UpdateFeedCache stores HTTP validators and body size of the last full response,
+// used to make the next fetch conditional
+func (r *FeedRepository) UpdateFeedCache(ctx context.Context, feedID int64, etag, lastModified string, size int64) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET etag = ?, last_modified = ?, last_size = ? WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, etag, lastModified, size, feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("update feed cache: %w", err)}
+		}
+		return nil
+	})
+}
+
+// AddFeedBytesSaved increments the number of bytes saved by not modified responses
+func (r *FeedRepository) AddFeedBytesSaved(ctx context.Context, feedID, bytes int64) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET bytes_saved = bytes_saved + ? WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, bytes, feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("add feed bytes saved: %w", err)}
+		}
+		return nil
+	})
+}
+
+// UpdateFeedWebSub records a push subscription request sent to the hub.
+// the subscription stays pending (no expiration) until the hub verifies it.
+func (r *FeedRepository) UpdateFeedWebSub(ctx context.Context, feedID int64, hubURL, topic, secret string) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET hub_url = ?, websub_topic = ?, websub_secret = ?, websub_expires = NULL WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, hubURL, topic, secret, feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("update feed websub: %w", err)}
+		}
+		return nil
+	})
+}
+
+// ConfirmFeedWebSub sets the lease expiration of a push subscription verified by the hub
+func (r *FeedRepository) ConfirmFeedWebSub(ctx context.Context, feedID int64, expires time.Time) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET websub_expires = ? WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, expires.UTC(), feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("confirm feed websub: %w", err)}
+		}
+		return nil
+	})
+}
+
+// ClearFeedWebSub drops the push subscription state, keeping the advertised hub URL
+func (r *FeedRepository) ClearFeedWebSub(ctx context.Context, feedID int64) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET websub_topic = '', websub_secret = '', websub_expires = NULL WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("clear feed websub: %w", err)}
+		}
+		return nil
+	})
+}
+
 // UpdateFeedError updates feed after fetch error with exponential backoff
 func (r *FeedRepository) UpdateFeedError(ctx context.Context, feedID int64, errMsg string) error {
 	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
@@ -175,9 +390,9 @@ func (r *FeedRepository) UpdateFeedError(ctx context.Context, feedID int64, errM
 	})
 }
 
-// UpdateFeedStatus enables or disables a feed
+// UpdateFeedStatus enables or disables a feed. Any change made this way clears the auto-disable reason.
 func (r *FeedRepository) UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error {
-	query := "UPDATE feeds SET enabled = ? WHERE id = ?"
+	query := "UPDATE feeds SET enabled = ?, disabled_reason = '' WHERE id = ?"
 	_, err := r.db.ExecContext(ctx, query, enabled, feedID)
 	if err != nil {
 		return fmt.Errorf("update feed status: %w", err)
@@ -185,6 +400,33 @@ func (r *FeedRepository) UpdateFeedStatus(ctx context.Context, feedID int64, ena
 	return nil
 }
 
+// RetryFeed re-enables a broken feed and resets its errors and backoff, so it is due right away
+// and gets the whole error budget again
+func (r *FeedRepository) RetryFeed(ctx context.Context, feedID int64) error {
+	query := "UPDATE feeds SET enabled = 1, disabled_reason = '', error_count = 0, next_fetch = NULL WHERE id = ?"
+	if _, err := r.db.ExecContext(ctx, query, feedID); err != nil {
+		return fmt.Errorf("retry feed: %w", err)
+	}
+	return nil
+}
+
+// DisableFeed disables a feed automatically and records the reason
+func (r *FeedRepository) DisableFeed(ctx context.Context, feedID int64, reason string) error {
+	retrier := repeater.NewBackoff(5, 50*time.Millisecond, repeater.WithMaxDelay(2*time.Second))
+
+	return retrier.Do(ctx, func() error {
+		query := "UPDATE feeds SET enabled = 0, disabled_reason = ? WHERE id = ?"
+		_, err := r.db.ExecContext(ctx, query, reason, feedID)
+		if err != nil {
+			if isLockError(err) {
+				return err // retry
+			}
+			return &criticalError{err: fmt.Errorf("disable feed: %w", err)}
+		}
+		return nil
+	})
+}
+
 // UpdateFeed updates feed title and interval
 func (r *FeedRepository) UpdateFeed(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error {
 	query := "UPDATE feeds SET title = ?, fetch_interval = ? WHERE id = ?"
@@ -195,6 +437,61 @@ func (r *FeedRepository) UpdateFeed(ctx context.Context, feedID int64, title str
 	return nil
 }
 
+// UpdateFeedURL changes the URL of a feed, e.g. after a permanent redirect, and drops the unconfirmed redirect
+func (r *FeedRepository) UpdateFeedURL(ctx context.Context, feedID int64, url string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE feeds SET url = ?, moved_to = '' WHERE id = ?", url, feedID)
+	if err != nil {
+		return fmt.Errorf("update feed url: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedMovedTo records the target of a permanent redirect seen on a fetch, empty if there was none
+func (r *FeedRepository) UpdateFeedMovedTo(ctx context.Context, feedID int64, url string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE feeds SET moved_to = ? WHERE id = ?", url, feedID)
+	if err != nil {
+		return fmt.Errorf("update feed moved to: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedSelectors updates scraping selectors of a web page feed
+func (r *FeedRepository) UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+	query := "UPDATE feeds SET selectors = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, selectorsSQL(selectors), feedID)
+	if err != nil {
+		return fmt.Errorf("update feed selectors: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedAuth replaces credentials of a feed, empty auth makes the feed public
+func (r *FeedRepository) UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE feeds SET auth = ? WHERE id = ?", authSQL(auth), feedID)
+	if err != nil {
+		return fmt.Errorf("update feed auth: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedProxy sets the proxy used to fetch a feed, empty proxy makes the feed use the configured one
+func (r *FeedRepository) UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE feeds SET proxy = ? WHERE id = ?", proxy, feedID)
+	if err != nil {
+		return fmt.Errorf("update feed proxy: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedFolder moves a feed to the folder, empty folder moves it to the top level
+func (r *FeedRepository) UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE feeds SET folder = ? WHERE id = ?", folder, feedID)
+	if err != nil {
+		return fmt.Errorf("update feed folder: %w", err)
+	}
+	return nil
+}
+
 // DeleteFeed removes a feed and all its items
 func (r *FeedRepository) DeleteFeed(ctx context.Context, id int64) error {
 	_, err := r.db.ExecContext(ctx, "DELETE FROM feeds WHERE id = ?", id)
@@ -204,8 +501,9 @@ func (r *FeedRepository) DeleteFeed(ctx context.Context, id int64) error {
 	return nil
 }
 
-// GetActiveFeedNames returns distinct feed names for feeds that have classified articles
-func (r *FeedRepository) GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error) {
+// GetActiveFeedNames returns distinct feed names for feeds that have classified articles.
+// non-empty folder limits the names to feeds of the folder and its subfolders.
+func (r *FeedRepository) GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error) {
 	query := `
 		SELECT DISTINCT 
 			CASE 
@@ -216,29 +514,169 @@ func (r *FeedRepository) GetActiveFeedNames(ctx context.Context, minScore float6
 		JOIN feeds f ON i.feed_id = f.id
 		WHERE i.relevance_score >= ?
 		AND i.classified_at IS NOT NULL
+		AND (? = '' OR f.folder = ? OR INSTR(f.folder, ?) = 1)
 		ORDER BY feed_name
 	`
 
 	var feedNames []string
-	if err := r.db.SelectContext(ctx, &feedNames, query, minScore); err != nil {
+	if err := r.db.SelectContext(ctx, &feedNames, query, minScore, folder, folder, folder+"/"); err != nil {
 		return nil, fmt.Errorf("get active feed names: %w", err)
 	}
 	return feedNames, nil
 }
 
+// GetFolders returns all folder paths in use, sorted. parents of nested folders are included,
+// so "Tech/Go" adds both "Tech" and "Tech/Go".
+func (r *FeedRepository) GetFolders(ctx context.Context) ([]string, error) {
+	var folders []string
+	if err := r.db.SelectContext(ctx, &folders, "SELECT DISTINCT folder FROM feeds WHERE folder != ''"); err != nil {
+		return nil, fmt.Errorf("get folders: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	res := []string{}
+	for _, folder := range folders {
+		parts := strings.Split(folder, "/")
+		for i := range parts {
+			path := strings.Join(parts[:i+1], "/")
+			if !seen[path] {
+				seen[path] = true
+				res = append(res, path)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// AddFeedEvent records a change made to a feed automatically
+func (r *FeedRepository) AddFeedEvent(ctx context.Context, event *domain.FeedEvent) error {
+	query := "INSERT INTO feed_events (feed_id, event_type, message) VALUES (?, ?, ?)"
+	result, err := r.db.ExecContext(ctx, query, event.FeedID, string(event.Type), event.Message)
+	if err != nil {
+		return fmt.Errorf("add feed event: %w", err)
+	}
+	if event.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get insert id: %w", err)
+	}
+	return nil
+}
+
+// GetFeedEvents returns the most recent events of a feed, newest first
+func (r *FeedRepository) GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+	var rows []struct {
+		ID        int64     `db:"id"`
+		FeedID    int64     `db:"feed_id"`
+		Type      string    `db:"event_type"`
+		Message   string    `db:"message"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	query := "SELECT id, feed_id, event_type, message, created_at FROM feed_events WHERE feed_id = ? ORDER BY created_at DESC, id DESC LIMIT ?"
+	if err := r.db.SelectContext(ctx, &rows, query, feedID, limit); err != nil {
+		return nil, fmt.Errorf("get feed events: %w", err)
+	}
+
+	events := make([]domain.FeedEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, domain.FeedEvent{ID: row.ID, FeedID: row.FeedID, Type: domain.FeedEventType(row.Type),
+			Message: row.Message, CreatedAt: row.CreatedAt})
+	}
+	return events, nil
+}
+
+// AddFeedFetch records a fetch attempt in the feed's fetch log, keeping only the latest
+// feedFetchesKept attempts of the feed. a fetch with new items updates the feed's last new item time.
+func (r *FeedRepository) AddFeedFetch(ctx context.Context, fetch *domain.FeedFetch) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO feed_fetches (feed_id, fetched_at, status_code, duration_ms, bytes, items, new_items, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := tx.ExecContext(ctx, query, fetch.FeedID, fetch.FetchedAt, fetch.StatusCode,
+		fetch.Duration.Milliseconds(), fetch.Bytes, fetch.Items, fetch.NewItems, fetch.Error)
+	if err != nil {
+		return fmt.Errorf("add feed fetch: %w", err)
+	}
+	if fetch.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get insert id: %w", err)
+	}
+
+	if fetch.NewItems > 0 {
+		if _, err := tx.ExecContext(ctx, "UPDATE feeds SET last_new_item = ? WHERE id = ?", fetch.FetchedAt, fetch.FeedID); err != nil {
+			return fmt.Errorf("update last new item: %w", err)
+		}
+	}
+
+	query = `DELETE FROM feed_fetches WHERE feed_id = ? AND id NOT IN (
+		SELECT id FROM feed_fetches WHERE feed_id = ? ORDER BY id DESC LIMIT ?)`
+	if _, err := tx.ExecContext(ctx, query, fetch.FeedID, fetch.FeedID, feedFetchesKept); err != nil {
+		return fmt.Errorf("trim feed fetches: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetFeedFetches returns up to limit latest fetch attempts of each feed, oldest first, keyed by feed ID
+func (r *FeedRepository) GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+	var rows []struct {
+		ID         int64     `db:"id"`
+		FeedID     int64     `db:"feed_id"`
+		FetchedAt  time.Time `db:"fetched_at"`
+		StatusCode int       `db:"status_code"`
+		DurationMS int64     `db:"duration_ms"`
+		Bytes      int64     `db:"bytes"`
+		Items      int       `db:"items"`
+		NewItems   int       `db:"new_items"`
+		Error      string    `db:"error"`
+	}
+	query := `SELECT id, feed_id, fetched_at, status_code, duration_ms, bytes, items, new_items, error FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY feed_id ORDER BY id DESC) AS rn FROM feed_fetches
+		) WHERE rn <= ? ORDER BY feed_id, id`
+	if err := r.db.SelectContext(ctx, &rows, query, limit); err != nil {
+		return nil, fmt.Errorf("get feed fetches: %w", err)
+	}
+
+	fetches := make(map[int64][]domain.FeedFetch)
+	for _, row := range rows {
+		fetches[row.FeedID] = append(fetches[row.FeedID], domain.FeedFetch{ID: row.ID, FeedID: row.FeedID,
+			FetchedAt: row.FetchedAt, StatusCode: row.StatusCode, Duration: time.Duration(row.DurationMS) * time.Millisecond,
+			Bytes: row.Bytes, Items: row.Items, NewItems: row.NewItems, Error: row.Error})
+	}
+	return fetches, nil
+}
+
 // toDomainFeed converts feedSQL to domain.Feed
 func (r *FeedRepository) toDomainFeed(sqlFeed *feedSQL) *domain.Feed {
 	return &domain.Feed{
-		ID:            sqlFeed.ID,
-		URL:           sqlFeed.URL,
-		Title:         sqlFeed.Title,
-		Description:   sqlFeed.Description,
-		LastFetched:   sqlFeed.LastFetched,
-		NextFetch:     sqlFeed.NextFetch,
-		FetchInterval: time.Duration(sqlFeed.FetchInterval) * time.Second,
-		ErrorCount:    sqlFeed.ErrorCount,
-		LastError:     sqlFeed.LastError,
-		Enabled:       sqlFeed.Enabled,
-		CreatedAt:     sqlFeed.CreatedAt,
+		ID:               sqlFeed.ID,
+		Type:             domain.FeedType(sqlFeed.FeedType),
+		URL:              sqlFeed.URL,
+		Title:            sqlFeed.Title,
+		Description:      sqlFeed.Description,
+		Folder:           sqlFeed.Folder,
+		LastFetched:      sqlFeed.LastFetched,
+		NextFetch:        sqlFeed.NextFetch,
+		FetchInterval:    time.Duration(sqlFeed.FetchInterval) * time.Second,
+		AdaptiveInterval: time.Duration(sqlFeed.AdaptiveInterval) * time.Second,
+		ErrorCount:       sqlFeed.ErrorCount,
+		LastError:        sqlFeed.LastError,
+		Enabled:          sqlFeed.Enabled,
+		DisabledReason:   sqlFeed.DisabledReason,
+		CreatedAt:        sqlFeed.CreatedAt,
+		LastNewItem:      sqlFeed.LastNewItem,
+		Selectors:        domain.ScrapeSelectors(sqlFeed.Selectors),
+		Auth:             domain.FeedAuth(sqlFeed.Auth),
+		Proxy:            sqlFeed.Proxy,
+		MovedTo:          sqlFeed.MovedTo,
+		ETag:             sqlFeed.ETag,
+		LastModified:     sqlFeed.LastModified,
+		LastSize:         sqlFeed.LastSize,
+		BytesSaved:       sqlFeed.BytesSaved,
+		HubURL:           sqlFeed.HubURL,
+		WebSubTopic:      sqlFeed.WebSubTopic,
+		WebSubSecret:     sqlFeed.WebSubSecret,
+		WebSubExpires:    sqlFeed.WebSubExpires,
 	}
 }
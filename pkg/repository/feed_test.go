@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -52,8 +53,15 @@ func TestFeedRepository_GetFeedsToFetch(t *testing.T) {
 		Enabled:       true,
 	}
 
+	newsletterFeed := &domain.Feed{
+		Type:    domain.FeedTypeNewsletter,
+		URL:     "mailto:news@example.com",
+		Title:   "Newsletter",
+		Enabled: true,
+	}
+
 	// create feeds
-	feeds := []*domain.Feed{recentFeed, oldFeed, disabledFeed, neverFetchedFeed}
+	feeds := []*domain.Feed{recentFeed, oldFeed, disabledFeed, neverFetchedFeed, newsletterFeed}
 	for _, feed := range feeds {
 		err := repos.Feed.CreateFeed(context.Background(), feed)
 		require.NoError(t, err)
@@ -84,9 +92,66 @@ func TestFeedRepository_GetFeedsToFetch(t *testing.T) {
 		assert.True(t, guidMap["https://example.com/never.xml"])
 		assert.False(t, guidMap["https://example.com/recent.xml"])
 		assert.False(t, guidMap["https://example.com/disabled.xml"])
+		assert.False(t, guidMap["mailto:news@example.com"], "newsletter feeds are not fetched")
+	})
+
+	t.Run("limit applied", func(t *testing.T) {
+		feedsToFetch, err := repos.Feed.GetFeedsToFetch(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, feedsToFetch, 1)
+		assert.Equal(t, "https://example.com/never.xml", feedsToFetch[0].URL, "never fetched feeds go first")
+	})
+
+	t.Run("next fetch in non-utc timezone", func(t *testing.T) {
+		// 30 minutes in the future, but with a +5h offset the local wall clock looks far ahead of utc
+		loc := time.FixedZone("UTC+5", 5*60*60)
+		err := repos.Feed.UpdateFeedFetched(context.Background(), oldFeed.ID, now.Add(30*time.Minute).In(loc))
+		require.NoError(t, err)
+		err = repos.Feed.UpdateFeedFetched(context.Background(), neverFetchedFeed.ID, now.Add(-30*time.Minute).In(loc))
+		require.NoError(t, err)
+
+		feedsToFetch, err := repos.Feed.GetFeedsToFetch(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, feedsToFetch, 1)
+		assert.Equal(t, "https://example.com/never.xml", feedsToFetch[0].URL)
 	})
 }
 
+func TestFeedRepository_GetFeedByURL(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	testFeed := createTestFeed(t, repos, "By URL")
+
+	feed, err := repos.Feed.GetFeedByURL(context.Background(), testFeed.URL)
+	require.NoError(t, err)
+	require.NotNil(t, feed)
+	assert.Equal(t, testFeed.ID, feed.ID)
+
+	feed, err = repos.Feed.GetFeedByURL(context.Background(), "https://example.com/missing.xml")
+	require.NoError(t, err)
+	assert.Nil(t, feed)
+}
+
+func TestFeedRepository_UpdateFeedAdaptiveInterval(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	testFeed := createTestFeed(t, repos, "Adaptive Feed")
+	assert.Zero(t, testFeed.AdaptiveInterval)
+
+	err := repos.Feed.UpdateFeedAdaptiveInterval(context.Background(), testFeed.ID, 90*time.Minute)
+	require.NoError(t, err)
+
+	updatedFeed, err := repos.Feed.GetFeed(context.Background(), testFeed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, updatedFeed.AdaptiveInterval)
+	assert.Equal(t, 90*time.Minute, updatedFeed.EffectiveInterval())
+
+	updatedFeed.FetchInterval = 2 * time.Hour
+	assert.Equal(t, 2*time.Hour, updatedFeed.EffectiveInterval(), "configured interval is the floor")
+}
+
 func TestFeedRepository_UpdateFeedFetched(t *testing.T) {
 	repos, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -265,6 +330,7 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 		URL:           "https://example.com/feed1.xml",
 		Title:         "High Quality Feed",
 		Description:   "Feed with high quality articles",
+		Folder:        "Tech/Go",
 		FetchInterval: 3600,
 		Enabled:       true,
 	}
@@ -281,6 +347,7 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 		URL:           "https://noname.com/feed3.xml",
 		Title:         "", // no title, should use URL
 		Description:   "Feed without title",
+		Folder:        "Tech",
 		FetchInterval: 3600,
 		Enabled:       true,
 	}
@@ -363,7 +430,7 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 	}
 
 	t.Run("get active feeds with high score threshold", func(t *testing.T) {
-		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 8.0)
+		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 8.0, "")
 		require.NoError(t, err)
 
 		// should return only feed1 (has articles with scores >= 8.0)
@@ -372,7 +439,7 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 	})
 
 	t.Run("get active feeds with medium score threshold", func(t *testing.T) {
-		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 6.0)
+		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 6.0, "")
 		require.NoError(t, err)
 
 		// should return feed1 and feed3 (feed2 has max score 3.0)
@@ -382,7 +449,7 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 	})
 
 	t.Run("get active feeds with low score threshold", func(t *testing.T) {
-		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 2.0)
+		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 2.0, "")
 		require.NoError(t, err)
 
 		// should return all three feeds
@@ -393,10 +460,379 @@ func TestFeedRepository_GetActiveFeedNames(t *testing.T) {
 	})
 
 	t.Run("get active feeds with very high threshold", func(t *testing.T) {
-		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 10.0)
+		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 10.0, "")
 		require.NoError(t, err)
 
 		// no feeds should meet this threshold
 		assert.Empty(t, feedNames)
 	})
+
+	t.Run("get active feeds of a folder", func(t *testing.T) {
+		feedNames, err := repos.Feed.GetActiveFeedNames(context.Background(), 2.0, "Tech")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High Quality Feed", "noname.comfeed3.xml"}, feedNames, "subfolders included")
+
+		feedNames, err = repos.Feed.GetActiveFeedNames(context.Background(), 2.0, "Tech/Go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High Quality Feed"}, feedNames)
+
+		feedNames, err = repos.Feed.GetActiveFeedNames(context.Background(), 2.0, "Te")
+		require.NoError(t, err)
+		assert.Empty(t, feedNames, "folder prefix is not a folder")
+	})
+}
+
+func TestFeedRepository_Folders(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	folders, err := repos.Feed.GetFolders(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, folders)
+
+	for i, folder := range []string{"Tech/Go/Tools", "News", "", "Tech/Go"} {
+		feed := &domain.Feed{URL: fmt.Sprintf("https://example.com/feed%d.xml", i), Folder: folder, FetchInterval: 3600, Enabled: true}
+		require.NoError(t, repos.Feed.CreateFeed(context.Background(), feed))
+	}
+
+	folders, err = repos.Feed.GetFolders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"News", "Tech", "Tech/Go", "Tech/Go/Tools"}, folders, "parent folders included")
+
+	feed, err := repos.Feed.GetFeedByURL(context.Background(), "https://example.com/feed1.xml")
+	require.NoError(t, err)
+	require.NoError(t, repos.Feed.UpdateFeedFolder(context.Background(), feed.ID, "Politics"))
+	feed, err = repos.Feed.GetFeedByURL(context.Background(), "https://example.com/feed0.xml")
+	require.NoError(t, err)
+	require.NoError(t, repos.Feed.UpdateFeedFolder(context.Background(), feed.ID, ""))
+
+	feeds, err := repos.Feed.GetFeeds(context.Background(), false)
+	require.NoError(t, err)
+	got := map[string]string{}
+	for _, f := range feeds {
+		got[f.URL] = f.Folder
+	}
+	assert.Equal(t, "Politics", got["https://example.com/feed1.xml"])
+	assert.Empty(t, got["https://example.com/feed0.xml"])
+
+	folders, err = repos.Feed.GetFolders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Politics", "Tech", "Tech/Go"}, folders)
+}
+
+func TestFeedRepository_UpdateFeedCache(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	testFeed := createTestFeed(t, repos, "Cached Feed")
+
+	err := repos.Feed.UpdateFeedCache(context.Background(), testFeed.ID, `"abc"`, "Mon, 02 Jan 2006 15:04:05 GMT", 1500)
+	require.NoError(t, err)
+
+	// two not modified responses, each saving the last full body size
+	require.NoError(t, repos.Feed.AddFeedBytesSaved(context.Background(), testFeed.ID, 1500))
+	require.NoError(t, repos.Feed.AddFeedBytesSaved(context.Background(), testFeed.ID, 1500))
+
+	updatedFeed, err := repos.Feed.GetFeed(context.Background(), testFeed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `"abc"`, updatedFeed.ETag)
+	assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 GMT", updatedFeed.LastModified)
+	assert.Equal(t, int64(1500), updatedFeed.LastSize)
+	assert.Equal(t, int64(3000), updatedFeed.BytesSaved)
+}
+
+func TestFeedRepository_DisableFeed(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	testFeed := createTestFeed(t, repos, "Broken Feed")
+	require.NoError(t, repos.Feed.UpdateFeedError(context.Background(), testFeed.ID, "connection refused"))
+
+	err := repos.Feed.DisableFeed(context.Background(), testFeed.ID, "disabled after 10 consecutive errors")
+	require.NoError(t, err)
+
+	feed, err := repos.Feed.GetFeed(context.Background(), testFeed.ID)
+	require.NoError(t, err)
+	assert.False(t, feed.Enabled)
+	assert.Equal(t, "disabled after 10 consecutive errors", feed.DisabledReason)
+	assert.True(t, feed.IsBroken())
+
+	// disabled feed is not fetched
+	due, err := repos.Feed.GetFeedsToFetch(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	// enabling clears the reason, successful fetch resets errors
+	require.NoError(t, repos.Feed.UpdateFeedStatus(context.Background(), testFeed.ID, true))
+	require.NoError(t, repos.Feed.UpdateFeedFetched(context.Background(), testFeed.ID, time.Now().Add(time.Hour)))
+	feed, err = repos.Feed.GetFeed(context.Background(), testFeed.ID)
+	require.NoError(t, err)
+	assert.True(t, feed.Enabled)
+	assert.Empty(t, feed.DisabledReason)
+	assert.Zero(t, feed.ErrorCount)
+	assert.False(t, feed.IsBroken())
+}
+
+func TestFeedRepository_RetryFeed(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	testFeed := createTestFeed(t, repos, "Broken Feed")
+	for range 3 {
+		require.NoError(t, repos.Feed.UpdateFeedError(ctx, testFeed.ID, "connection refused"))
+	}
+	require.NoError(t, repos.Feed.DisableFeed(ctx, testFeed.ID, "disabled after 3 consecutive errors"))
+
+	require.NoError(t, repos.Feed.RetryFeed(ctx, testFeed.ID))
+	feed, err := repos.Feed.GetFeed(ctx, testFeed.ID)
+	require.NoError(t, err)
+	assert.True(t, feed.Enabled)
+	assert.Empty(t, feed.DisabledReason)
+	assert.Zero(t, feed.ErrorCount, "failed retry starts the error count over")
+	assert.Nil(t, feed.NextFetch)
+
+	due, err := repos.Feed.GetFeedsToFetch(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1, "due right away")
+	assert.Equal(t, testFeed.ID, due[0].ID)
+}
+
+func TestFeedRepository_CreateFeedWithFolder(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	feed := &domain.Feed{
+		URL:           "https://example.com/go.xml",
+		Title:         "Go Blog",
+		Folder:        "Tech/Go",
+		FetchInterval: 2 * time.Hour,
+		Enabled:       true,
+	}
+	require.NoError(t, repos.Feed.CreateFeed(context.Background(), feed))
+
+	stored, err := repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Tech/Go", stored.Folder)
+	assert.Equal(t, 2*time.Hour, stored.FetchInterval)
+}
+
+func TestFeedRepository_WebSub(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	testFeed := createTestFeed(t, repos, "Pushed Feed")
+	ctx := context.Background()
+
+	// subscription requested, pending until the hub verifies it
+	err := repos.Feed.UpdateFeedWebSub(ctx, testFeed.ID, "https://hub.example.com/", "https://example.com/feed.xml", "secret")
+	require.NoError(t, err)
+	feed, err := repos.Feed.GetFeed(ctx, testFeed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hub.example.com/", feed.HubURL)
+	assert.Equal(t, "https://example.com/feed.xml", feed.WebSubTopic)
+	assert.Equal(t, "secret", feed.WebSubSecret)
+	assert.Nil(t, feed.WebSubExpires)
+	assert.True(t, feed.WebSubPending())
+	assert.False(t, feed.WebSubActive(time.Now()))
+
+	// hub confirmed the lease
+	expires := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	require.NoError(t, repos.Feed.ConfirmFeedWebSub(ctx, testFeed.ID, expires))
+	feed, err = repos.Feed.GetFeed(ctx, testFeed.ID)
+	require.NoError(t, err)
+	require.NotNil(t, feed.WebSubExpires)
+	assert.True(t, expires.Equal(*feed.WebSubExpires))
+	assert.True(t, feed.WebSubActive(time.Now()))
+	assert.False(t, feed.WebSubActive(expires.Add(time.Minute)))
+	assert.False(t, feed.WebSubPending())
+
+	// hub denied the subscription, hub url is kept
+	require.NoError(t, repos.Feed.ClearFeedWebSub(ctx, testFeed.ID))
+	feed, err = repos.Feed.GetFeed(ctx, testFeed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hub.example.com/", feed.HubURL)
+	assert.Empty(t, feed.WebSubTopic)
+	assert.Empty(t, feed.WebSubSecret)
+	assert.Nil(t, feed.WebSubExpires)
+	assert.False(t, feed.WebSubPending())
+}
+
+func TestFeedRepository_ScrapedFeed(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	feed := &domain.Feed{
+		Type:          domain.FeedTypeHTML,
+		URL:           "https://example.com/news",
+		Title:         "Example News",
+		FetchInterval: time.Hour,
+		Enabled:       true,
+		Selectors:     domain.ScrapeSelectors{Item: "article", Title: "h2", Date: "time"},
+	}
+	require.NoError(t, repos.Feed.CreateFeed(ctx, feed))
+
+	stored, err := repos.Feed.GetFeed(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.FeedTypeHTML, stored.Type)
+	assert.True(t, stored.IsScraped())
+	assert.Equal(t, domain.ScrapeSelectors{Item: "article", Title: "h2", Date: "time"}, stored.Selectors)
+
+	sel := domain.ScrapeSelectors{Item: ".post", Title: ".post-title", Link: "a.more", Summary: "p"}
+	require.NoError(t, repos.Feed.UpdateFeedSelectors(ctx, feed.ID, sel))
+	stored, err = repos.Feed.GetFeed(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sel, stored.Selectors)
+
+	// regular feeds default to rss without selectors
+	regular := createTestFeed(t, repos, "Regular Feed")
+	stored, err = repos.Feed.GetFeed(ctx, regular.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.FeedTypeRSS, stored.Type)
+	assert.False(t, stored.IsScraped())
+	assert.Equal(t, domain.ScrapeSelectors{}, stored.Selectors)
+}
+
+func TestFeedRepository_Auth(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	auth := domain.FeedAuth{Username: "user", Password: "pass", Headers: map[string]string{"Private-Token": "abc"}}
+	feed := &domain.Feed{URL: "https://gitlab.example.com/activity.atom", Auth: auth, FetchInterval: time.Hour, Enabled: true}
+	require.NoError(t, repos.Feed.CreateFeed(context.Background(), feed))
+
+	got, err := repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, auth, got.Auth)
+
+	updated := domain.FeedAuth{Token: "token", Cookies: "session=1"}
+	require.NoError(t, repos.Feed.UpdateFeedAuth(context.Background(), feed.ID, updated))
+	got, err = repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, updated, got.Auth)
+
+	require.NoError(t, repos.Feed.UpdateFeedAuth(context.Background(), feed.ID, domain.FeedAuth{}))
+	var stored string
+	require.NoError(t, repos.DB.GetContext(context.Background(), &stored, "SELECT auth FROM feeds WHERE id = ?", feed.ID))
+	assert.Empty(t, stored, "no credentials stored as empty string")
+	got, err = repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Auth.IsZero())
+}
+
+func TestFeedRepository_Proxy(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	feed := &domain.Feed{URL: "https://example.com/feed.xml", Proxy: "socks5://127.0.0.1:1080", FetchInterval: time.Hour, Enabled: true}
+	require.NoError(t, repos.Feed.CreateFeed(context.Background(), feed))
+
+	got, err := repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "socks5://127.0.0.1:1080", got.Proxy)
+
+	require.NoError(t, repos.Feed.UpdateFeedProxy(context.Background(), feed.ID, "direct"))
+	got, err = repos.Feed.GetFeed(context.Background(), feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "direct", got.Proxy)
+}
+
+func TestFeedRepository_Events(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	feed := &domain.Feed{URL: "http://example.com/rss", FetchInterval: time.Hour, Enabled: true}
+	require.NoError(t, repos.Feed.CreateFeed(ctx, feed))
+
+	require.NoError(t, repos.Feed.UpdateFeedMovedTo(ctx, feed.ID, "https://example.com/feed.xml"))
+	got, err := repos.Feed.GetFeed(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "http://example.com/rss", got.URL, "redirect recorded only")
+	assert.Equal(t, "https://example.com/feed.xml", got.MovedTo)
+
+	require.NoError(t, repos.Feed.UpdateFeedURL(ctx, feed.ID, "https://example.com/feed.xml"))
+	got, err = repos.Feed.GetFeed(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/feed.xml", got.URL)
+	assert.Empty(t, got.MovedTo, "confirmed redirect dropped")
+
+	moved := &domain.FeedEvent{FeedID: feed.ID, Type: domain.FeedEventMoved, Message: "moved from http://example.com/rss"}
+	require.NoError(t, repos.Feed.AddFeedEvent(ctx, moved))
+	assert.NotZero(t, moved.ID)
+	require.NoError(t, repos.Feed.AddFeedEvent(ctx, &domain.FeedEvent{FeedID: feed.ID, Type: domain.FeedEventGone, Message: "disabled"}))
+
+	events, err := repos.Feed.GetFeedEvents(ctx, feed.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.FeedEventGone, events[0].Type, "newest first")
+	assert.Equal(t, domain.FeedEventMoved, events[1].Type)
+	assert.Equal(t, "moved from http://example.com/rss", events[1].Message)
+	assert.WithinDuration(t, time.Now(), events[1].CreatedAt, time.Minute)
+
+	events, err = repos.Feed.GetFeedEvents(ctx, feed.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	require.NoError(t, repos.Feed.DeleteFeed(ctx, feed.ID))
+	events, err = repos.Feed.GetFeedEvents(ctx, feed.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events, "events deleted with the feed")
+}
+
+func TestFeedRepository_Fetches(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	feed1 := &domain.Feed{URL: "http://example.com/rss", FetchInterval: time.Hour, Enabled: true}
+	require.NoError(t, repos.Feed.CreateFeed(ctx, feed1))
+	feed2 := &domain.Feed{URL: "http://example.org/rss", FetchInterval: time.Hour, Enabled: true}
+	require.NoError(t, repos.Feed.CreateFeed(ctx, feed2))
+
+	start := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	for i := range feedFetchesKept + 5 {
+		fetch := &domain.FeedFetch{FeedID: feed1.ID, FetchedAt: start.Add(time.Duration(i) * time.Minute),
+			StatusCode: 200, Duration: 1500 * time.Millisecond, Bytes: 2048, Items: 10}
+		if i == 3 {
+			fetch.NewItems = 2
+		}
+		require.NoError(t, repos.Feed.AddFeedFetch(ctx, fetch))
+		assert.NotZero(t, fetch.ID)
+	}
+	require.NoError(t, repos.Feed.AddFeedFetch(ctx, &domain.FeedFetch{FeedID: feed2.ID, FetchedAt: start,
+		StatusCode: 503, Duration: time.Second, Error: "unexpected status code: 503"}))
+
+	got, err := repos.Feed.GetFeed(ctx, feed1.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastNewItem)
+	assert.True(t, start.Add(3*time.Minute).Equal(*got.LastNewItem))
+	got, err = repos.Feed.GetFeed(ctx, feed2.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.LastNewItem)
+
+	fetches, err := repos.Feed.GetFeedFetches(ctx, 20)
+	require.NoError(t, err)
+	require.Len(t, fetches[feed1.ID], 20)
+	last := fetches[feed1.ID][19]
+	assert.True(t, start.Add((feedFetchesKept+4)*time.Minute).Equal(last.FetchedAt), "oldest first, latest last")
+	assert.Equal(t, 200, last.StatusCode)
+	assert.Equal(t, 1500*time.Millisecond, last.Duration)
+	assert.Equal(t, int64(2048), last.Bytes)
+	assert.Equal(t, 10, last.Items)
+	assert.True(t, last.OK())
+
+	require.Len(t, fetches[feed2.ID], 1)
+	assert.Equal(t, 503, fetches[feed2.ID][0].StatusCode)
+	assert.False(t, fetches[feed2.ID][0].OK())
+
+	var count int
+	require.NoError(t, repos.DB.GetContext(ctx, &count, "SELECT COUNT(*) FROM feed_fetches WHERE feed_id = ?", feed1.ID))
+	assert.Equal(t, feedFetchesKept, count, "older fetches trimmed")
+
+	require.NoError(t, repos.Feed.DeleteFeed(ctx, feed1.ID))
+	fetches, err = repos.Feed.GetFeedFetches(ctx, 20)
+	require.NoError(t, err)
+	assert.Empty(t, fetches[feed1.ID], "fetches deleted with the feed")
 }
@@ -5,12 +5,14 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-pkgz/repeater/v2"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/simhash"
 )
 
 // ItemRepository handles item-related database operations
@@ -20,21 +22,29 @@ type ItemRepository struct {
 
 // itemSQL represents an item for SQL operations
 type itemSQL struct {
-	ID          int64     `db:"id"`
-	FeedID      int64     `db:"feed_id"`
-	GUID        string    `db:"guid"`
-	Title       string    `db:"title"`
-	Link        string    `db:"link"`
-	Description string    `db:"description"`
-	Content     string    `db:"content"`
-	Author      string    `db:"author"`
-	Published   time.Time `db:"published"`
+	ID            int64     `db:"id"`
+	FeedID        int64     `db:"feed_id"`
+	GUID          string    `db:"guid"`
+	Title         string    `db:"title"`
+	Link          string    `db:"link"`
+	CanonicalLink string    `db:"canonical_link"`
+	ResolvedLink  string    `db:"resolved_link"`
+	Description   string    `db:"description"`
+	Content       string    `db:"content"`
+	Author        string    `db:"author"`
+	Published     time.Time `db:"published"`
+
+	// feed attachments
+	Image      string        `db:"image"`
+	Enclosures enclosuresSQL `db:"enclosures"`
+	Categories topicsSQL     `db:"categories"`
 
 	// extracted content
 	ExtractedContent     string     `db:"extracted_content"`
 	ExtractedRichContent string     `db:"extracted_rich_content"`
 	ExtractedAt          *time.Time `db:"extracted_at"`
 	ExtractionError      string     `db:"extraction_error"`
+	ExtractedBy          string     `db:"extracted_by"`
 
 	// LLM classification
 	RelevanceScore float64    `db:"relevance_score"`
@@ -47,6 +57,13 @@ type itemSQL struct {
 	UserFeedback string     `db:"user_feedback"`
 	FeedbackAt   *time.Time `db:"feedback_at"`
 
+	// near-duplicate detection
+	Simhash     int64  `db:"simhash"`      // fingerprint stored as signed integer, zero if none
+	DuplicateOf *int64 `db:"duplicate_of"` // item this one repeats, nil for articles
+
+	// mute rules
+	MutedBy string `db:"muted_by"` // description of the matched mute rule, empty if not muted
+
 	// metadata
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -56,6 +73,37 @@ type itemSQL struct {
 	FeedURL   string `db:"feed_url"`
 }
 
+// enclosuresSQL is a JSON array of item enclosures for SQL operations
+type enclosuresSQL []domain.Enclosure
+
+// Value implements driver.Valuer for database storage
+func (e enclosuresSQL) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan implements sql.Scanner for database retrieval
+func (e *enclosuresSQL) Scan(value interface{}) error {
+	if value == nil {
+		*e = enclosuresSQL{}
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return json.Unmarshal([]byte("[]"), e)
+	}
+
+	return json.Unmarshal(data, e)
+}
+
 // topicsSQL is a JSON array of topic strings for SQL operations
 type topicsSQL []string
 
@@ -95,23 +143,28 @@ func NewItemRepository(database *sqlx.DB) *ItemRepository {
 // CreateItem inserts a new item
 func (r *ItemRepository) CreateItem(ctx context.Context, item *domain.Item) error {
 	sqlItem := &itemSQL{
-		FeedID:      item.FeedID,
-		GUID:        item.GUID,
-		Title:       item.Title,
-		Link:        item.Link,
-		Description: item.Description,
-		Content:     item.Content,
-		Author:      item.Author,
-		Published:   item.Published,
+		FeedID:        item.FeedID,
+		GUID:          item.GUID,
+		Title:         item.Title,
+		Link:          item.Link,
+		CanonicalLink: item.CanonicalLink,
+		ResolvedLink:  item.ResolvedLink,
+		Description:   item.Description,
+		Content:       item.Content,
+		Author:        item.Author,
+		Published:     item.Published,
+		Image:         item.Image,
+		Enclosures:    item.Enclosures,
+		Categories:    item.Categories,
 	}
 
 	query := `
 		INSERT INTO items (
-			feed_id, guid, title, link, description, content, 
-			author, published
+			feed_id, guid, title, link, canonical_link, resolved_link, description, content,
+			author, published, image, enclosures, categories
 		) VALUES (
-			:feed_id, :guid, :title, :link, :description, :content,
-			:author, :published
+			:feed_id, :guid, :title, :link, :canonical_link, :resolved_link, :description, :content,
+			:author, :published, :image, :enclosures, :categories
 		)
 	`
 	result, err := r.db.NamedExecContext(ctx, query, sqlItem)
@@ -166,6 +219,7 @@ func (r *ItemRepository) GetUnclassifiedItems(ctx context.Context, limit int) ([
 		WHERE classified_at IS NULL
 		AND extracted_content != ''
 		AND extraction_error = ''
+		AND duplicate_of IS NULL
 		ORDER BY published DESC
 		LIMIT ?
 	`
@@ -188,6 +242,7 @@ func (r *ItemRepository) GetItemsNeedingExtraction(ctx context.Context, limit in
 		SELECT * FROM items 
 		WHERE extracted_at IS NULL
 		AND extraction_error = ''
+		AND muted_by = ''
 		ORDER BY published DESC
 		LIMIT ?
 	`
@@ -267,11 +322,19 @@ func (r *ItemRepository) UpdateItemProcessed(ctx context.Context, itemID int64,
 			    explanation = ?,
 			    topics = ?,
 			    summary = ?,
-			    classified_at = datetime('now')
+			    classified_at = datetime('now'),
+			    title = CASE WHEN title = '' THEN ? ELSE title END,
+			    simhash = ?,
+			    canonical_link = COALESCE(NULLIF(?, ''), canonical_link),
+			    resolved_link = COALESCE(NULLIF(?, ''), resolved_link),
+			    image = CASE WHEN image = '' THEN ? ELSE image END,
+			    extracted_by = ?
 			WHERE id = ?
 		`
 		args = []interface{}{extraction.PlainText, extraction.RichHTML, classification.Score,
-			classification.Explanation, topicsSQL(classification.Topics), classification.Summary, itemID}
+			classification.Explanation, topicsSQL(classification.Topics), classification.Summary, extraction.Title,
+			int64(extraction.Fingerprint), extraction.CanonicalLink, extraction.ResolvedLink, extraction.Image,
+			extraction.Extractor, itemID} //nolint:gosec // fingerprint bits stored as is
 
 		_, err := r.db.ExecContext(ctx, query, args...)
 		if err != nil {
@@ -297,18 +360,131 @@ func (r *ItemRepository) ItemExists(ctx context.Context, feedID int64, guid stri
 	return exists, nil
 }
 
-// ItemExistsByTitleOrURL checks if an item with the same title or URL already exists in any feed
+// ItemExistsByTitleOrURL checks if an item with the same title or URL already exists in any feed.
+// the URL is compared to both original and canonical links of stored items.
+// empty titles and URLs never match, items from sitemaps have no title until extracted.
 func (r *ItemRepository) ItemExistsByTitleOrURL(ctx context.Context, title, url string) (bool, error) {
 	var exists bool
 	err := r.db.GetContext(ctx, &exists,
-		"SELECT EXISTS(SELECT 1 FROM items WHERE title = ? OR link = ?)",
-		title, url)
+		"SELECT EXISTS(SELECT 1 FROM items WHERE (title = ? AND title != '') OR (? != '' AND (link = ? OR canonical_link = ?)))",
+		title, url, url, url)
 	if err != nil {
 		return false, fmt.Errorf("check item exists by title or url: %w", err)
 	}
 	return exists, nil
 }
 
+// fingerprints are split into bands of their bits, each indexed. fingerprints differing in fewer bits than
+// there are bands have at least one band in common, so only items sharing a band are compared.
+const (
+	fingerprintBands    = 9
+	fingerprintBandBits = 7 // the last band takes the remaining bits
+)
+
+// fingerprintBand returns the SQL expression of the band i of the simhash column, as indexed,
+// and the value of the band in the fingerprint
+func fingerprintBand(i int, fingerprint uint64) (expr string, value int64) {
+	shift, mask := i*fingerprintBandBits, uint64(1)<<fingerprintBandBits-1
+	if i == fingerprintBands-1 {
+		mask = uint64(1)<<(64-shift) - 1
+	}
+	return fmt.Sprintf("((simhash >> %d) & %d)", shift, mask), int64((fingerprint >> shift) & mask) //nolint:gosec // masked band fits
+}
+
+// FindDuplicate returns the article created within the window with the fingerprint closest to the given one,
+// if it differs in at most maxDistance bits. returns 0 if there is no such article. items with a stored
+// fingerprint are considered as soon as they are extracted, unclassified ones only if created before the item,
+// so copies processed at the same time link the later one to the earlier one and never to each other.
+// near-duplicates themselves are not considered. maxDistance has to be below the number of fingerprint bands.
+func (r *ItemRepository) FindDuplicate(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+	if fingerprint == 0 {
+		return 0, nil
+	}
+	if maxDistance >= fingerprintBands {
+		return 0, fmt.Errorf("find duplicate: max distance %d not below %d bands", maxDistance, fingerprintBands)
+	}
+
+	// candidates share a band with the fingerprint, each band looked up in its index
+	bands := make([]string, 0, fingerprintBands)
+	args := make([]any, 0, fingerprintBands+3)
+	for i := range fingerprintBands {
+		expr, value := fingerprintBand(i, fingerprint)
+		bands = append(bands, "SELECT id FROM items WHERE simhash != 0 AND "+expr+" = ?")
+		args = append(args, value)
+	}
+	var rows []struct {
+		ID      int64 `db:"id"`
+		Simhash int64 `db:"simhash"`
+	}
+	query := `
+		SELECT id, simhash FROM items
+		WHERE id IN (` + strings.Join(bands, " UNION ") + `)
+		AND duplicate_of IS NULL
+		AND (classified_at IS NOT NULL OR id < ?)
+		AND id != ?
+		AND created_at >= datetime('now', ?)
+		ORDER BY id
+	`
+	since := fmt.Sprintf("-%d seconds", int64(window.Seconds()))
+	args = append(args, itemID, itemID, since)
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return 0, fmt.Errorf("find duplicate: %w", err)
+	}
+
+	var found int64
+	best := maxDistance + 1
+	for _, row := range rows {
+		if d := simhash.Distance(fingerprint, uint64(row.Simhash)); d < best { //nolint:gosec // fingerprint bits stored as is
+			found, best = row.ID, d
+		}
+	}
+	return found, nil
+}
+
+// UpdateItemFingerprint stores the fingerprint of an extracted item before it is classified,
+// so copies of the article processed meanwhile find it with FindDuplicate
+func (r *ItemRepository) UpdateItemFingerprint(ctx context.Context, itemID int64, fingerprint uint64) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE items SET simhash = ? WHERE id = ?", int64(fingerprint), itemID) //nolint:gosec // fingerprint bits stored as is
+	if err != nil {
+		return fmt.Errorf("update item fingerprint: %w", err)
+	}
+	return nil
+}
+
+// UpdateItemDuplicate stores the extracted content of a near-duplicate and links it to the article it repeats.
+// the item is not classified and not shown as an article.
+func (r *ItemRepository) UpdateItemDuplicate(ctx context.Context, itemID, duplicateOf int64, extraction *domain.ExtractedContent) error {
+	query := `
+		UPDATE items
+		SET extracted_content = ?,
+		    extracted_rich_content = ?,
+		    extracted_at = datetime('now'),
+		    title = CASE WHEN title = '' THEN ? ELSE title END,
+		    simhash = ?,
+		    canonical_link = COALESCE(NULLIF(?, ''), canonical_link),
+		    resolved_link = COALESCE(NULLIF(?, ''), resolved_link),
+		    image = CASE WHEN image = '' THEN ? ELSE image END,
+		    extracted_by = ?,
+		    duplicate_of = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, extraction.PlainText, extraction.RichHTML, extraction.Title,
+		int64(extraction.Fingerprint), extraction.CanonicalLink, extraction.ResolvedLink, extraction.Image, extraction.Extractor,
+		duplicateOf, itemID) //nolint:gosec // fingerprint bits stored as is
+	if err != nil {
+		return fmt.Errorf("update item duplicate: %w", err)
+	}
+	return nil
+}
+
+// UpdateItemMuted marks the item as muted by the rule with the given description
+func (r *ItemRepository) UpdateItemMuted(ctx context.Context, itemID int64, rule string) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE items SET muted_by = ? WHERE id = ?", rule, itemID); err != nil {
+		return fmt.Errorf("update item muted: %w", err)
+	}
+	return nil
+}
+
 // DeleteOldItems removes articles older than specified age with score below threshold
 func (r *ItemRepository) DeleteOldItems(ctx context.Context, age time.Duration, minScore float64) (int64, error) {
 	cutoffTime := time.Now().Add(-age)
@@ -335,16 +511,22 @@ func (r *ItemRepository) DeleteOldItems(ctx context.Context, age time.Duration,
 // toDomainItem converts itemSQL to domain.Item
 func (r *ItemRepository) toDomainItem(sqlItem *itemSQL) *domain.Item {
 	return &domain.Item{
-		ID:          sqlItem.ID,
-		FeedID:      sqlItem.FeedID,
-		GUID:        sqlItem.GUID,
-		Title:       sqlItem.Title,
-		Link:        sqlItem.Link,
-		Description: sqlItem.Description,
-		Content:     sqlItem.Content,
-		Author:      sqlItem.Author,
-		Published:   sqlItem.Published,
-		CreatedAt:   sqlItem.CreatedAt,
-		UpdatedAt:   sqlItem.UpdatedAt,
+		ID:            sqlItem.ID,
+		FeedID:        sqlItem.FeedID,
+		GUID:          sqlItem.GUID,
+		Title:         sqlItem.Title,
+		Link:          sqlItem.Link,
+		CanonicalLink: sqlItem.CanonicalLink,
+		ResolvedLink:  sqlItem.ResolvedLink,
+		Description:   sqlItem.Description,
+		Content:       sqlItem.Content,
+		Author:        sqlItem.Author,
+		Published:     sqlItem.Published,
+		Image:         sqlItem.Image,
+		Enclosures:    sqlItem.Enclosures,
+		Categories:    sqlItem.Categories,
+		MutedBy:       sqlItem.MutedBy,
+		CreatedAt:     sqlItem.CreatedAt,
+		UpdatedAt:     sqlItem.UpdatedAt,
 	}
 }
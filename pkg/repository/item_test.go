@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -395,6 +396,88 @@ func TestItemRepository_UpdateItemProcessed(t *testing.T) {
 		assert.Empty(t, summary)
 	})
 
+	t.Run("extracted title fills empty title only", func(t *testing.T) {
+		untitled := &domain.Item{FeedID: testFeed.ID, GUID: "untitled-item", Link: "https://example.com/untitled", Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), untitled))
+
+		extraction := &domain.ExtractedContent{PlainText: "text", Title: "Extracted Title"}
+		classification := &domain.Classification{GUID: untitled.GUID, Score: 6.0, Topics: []string{"general"}}
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), untitled.ID, extraction, classification))
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), testItem.ID, extraction, classification))
+
+		var title string
+		require.NoError(t, repos.DB.GetContext(context.Background(), &title, "SELECT title FROM items WHERE id = ?", untitled.ID))
+		assert.Equal(t, "Extracted Title", title)
+		require.NoError(t, repos.DB.GetContext(context.Background(), &title, "SELECT title FROM items WHERE id = ?", testItem.ID))
+		assert.Equal(t, "Test Article", title)
+	})
+
+	t.Run("canonical link from the page", func(t *testing.T) {
+		item := &domain.Item{FeedID: testFeed.ID, GUID: "canonical-item", Title: "Canonical",
+			Link: "https://example.com/c?utm_source=rss", CanonicalLink: "https://example.com/c", Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), item))
+		classification := &domain.Classification{GUID: item.GUID, Score: 6.0, Topics: []string{"general"}}
+
+		extraction := &domain.ExtractedContent{PlainText: "text"}
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), item.ID, extraction, classification))
+		stored, err := repos.Item.GetItem(context.Background(), item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/c", stored.CanonicalLink, "kept without page canonical")
+
+		extraction.CanonicalLink = "https://example.com/news/c"
+		extraction.ResolvedLink = "https://www.example.com/news/c"
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), item.ID, extraction, classification))
+		stored, err = repos.Item.GetItem(context.Background(), item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/news/c", stored.CanonicalLink)
+		assert.Equal(t, "https://www.example.com/news/c", stored.ResolvedLink)
+		assert.Equal(t, "https://www.example.com/news/c", stored.DisplayLink())
+		assert.Equal(t, "https://example.com/c?utm_source=rss", stored.Link)
+	})
+
+	t.Run("extractor recorded", func(t *testing.T) {
+		item := &domain.Item{FeedID: testFeed.ID, GUID: "fallback-item", Title: "Fallback",
+			Link: "https://example.com/fallback", Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), item))
+		classification := &domain.Classification{GUID: item.GUID, Score: 6.0, Topics: []string{"general"}}
+
+		extraction := &domain.ExtractedContent{PlainText: "text", ExtractedAt: time.Now(), Extractor: "fallback"}
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), item.ID, extraction, classification))
+		stored, err := repos.Classification.GetClassifiedItem(context.Background(), item.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Extraction)
+		assert.Equal(t, "fallback", stored.Extraction.Extractor)
+	})
+
+	t.Run("feed media and page image", func(t *testing.T) {
+		item := &domain.Item{FeedID: testFeed.ID, GUID: "media-item", Title: "Episode", Link: "https://example.com/ep1",
+			Enclosures: []domain.Enclosure{{URL: "https://example.com/ep1.mp3", Type: "audio/mpeg", Length: 1024}},
+			Categories: []string{"podcast", "tech"}, Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), item))
+		classification := &domain.Classification{GUID: item.GUID, Score: 6.0, Topics: []string{"general"}}
+
+		extraction := &domain.ExtractedContent{PlainText: "text", Image: "https://example.com/page.jpg"}
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), item.ID, extraction, classification))
+		stored, err := repos.Item.GetItem(context.Background(), item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/page.jpg", stored.Image, "page image used for an item without one")
+		assert.Equal(t, item.Enclosures, stored.Enclosures)
+		assert.Equal(t, []string{"podcast", "tech"}, stored.Categories)
+
+		extraction.Image = "https://example.com/other.jpg"
+		require.NoError(t, repos.Item.UpdateItemProcessed(context.Background(), item.ID, extraction, classification))
+		classified, err := repos.Classification.GetClassifiedItem(context.Background(), item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/page.jpg", classified.Image, "stored image kept")
+		assert.Equal(t, item.Enclosures, classified.Enclosures)
+		assert.Equal(t, []string{"podcast", "tech"}, classified.Categories)
+
+		plain, err := repos.Item.GetItem(context.Background(), testItem.ID)
+		require.NoError(t, err)
+		assert.Empty(t, plain.Enclosures)
+		assert.Empty(t, plain.Categories)
+	})
+
 	t.Run("update non-existent item", func(t *testing.T) {
 		extraction := &domain.ExtractedContent{
 			PlainText: "Some text",
@@ -414,6 +497,107 @@ func TestItemRepository_UpdateItemProcessed(t *testing.T) {
 	})
 }
 
+func TestItemRepository_Duplicates(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	feedA, feedB, feedC := createTestFeed(t, repos, "Feed A"), createTestFeed(t, repos, "Feed B"), createTestFeed(t, repos, "Feed C")
+	newItem := func(feed *domain.Feed, guid string) *domain.Item {
+		item := &domain.Item{FeedID: feed.ID, GUID: guid, Title: guid, Link: "https://example.com/" + guid, Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(ctx, item))
+		return item
+	}
+	classification := &domain.Classification{Score: 7, Topics: []string{"tech"}}
+
+	original := newItem(feedA, "original")
+	require.NoError(t, repos.Item.UpdateItemProcessed(ctx, original.ID,
+		&domain.ExtractedContent{PlainText: "story", Fingerprint: 0xF0F0F0F0F0F0F0F0}, classification))
+	other := newItem(feedA, "other")
+	require.NoError(t, repos.Item.UpdateItemProcessed(ctx, other.ID,
+		&domain.ExtractedContent{PlainText: "other story", Fingerprint: 0x0F0F0F0F0F0F0F0F}, classification))
+
+	t.Run("find duplicate", func(t *testing.T) {
+		id, err := repos.Item.FindDuplicate(ctx, 100, 0xF0F0F0F0F0F0F0F3, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, original.ID, id)
+
+		id, err = repos.Item.FindDuplicate(ctx, 100, 0xF0F0F0F0F0F0F0FF, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, id, "too distant")
+
+		id, err = repos.Item.FindDuplicate(ctx, original.ID, 0xF0F0F0F0F0F0F0F0, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, id, "item itself skipped")
+
+		id, err = repos.Item.FindDuplicate(ctx, 100, 0, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, id, "no fingerprint")
+
+		// one differing bit in each band but the last, no band left to share above the max distance
+		var spread uint64
+		for i := range fingerprintBands - 1 {
+			spread |= 1 << (i * fingerprintBandBits)
+		}
+		id, err = repos.Item.FindDuplicate(ctx, 100, 0xF0F0F0F0F0F0F0F0^spread, fingerprintBands-1, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, original.ID, id, "found by the only band in common")
+
+		_, err = repos.Item.FindDuplicate(ctx, 100, 0xF0F0F0F0F0F0F0F0, fingerprintBands, time.Hour)
+		require.Error(t, err, "max distance not covered by the bands")
+	})
+
+	dupB, dupB2, dupC, dupA := newItem(feedB, "dup-b"), newItem(feedB, "dup-b2"), newItem(feedC, "dup-c"), newItem(feedA, "dup-a")
+	for _, item := range []*domain.Item{dupB, dupB2, dupC, dupA} {
+		require.NoError(t, repos.Item.UpdateItemDuplicate(ctx, item.ID, original.ID,
+			&domain.ExtractedContent{PlainText: "same story", Fingerprint: 0xF0F0F0F0F0F0F0F1}))
+	}
+
+	t.Run("duplicates are not articles", func(t *testing.T) {
+		id, err := repos.Item.FindDuplicate(ctx, 100, 0xF0F0F0F0F0F0F0F1, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, original.ID, id, "duplicates not used as originals")
+
+		unclassified, err := repos.Item.GetUnclassifiedItems(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, unclassified)
+
+		items, err := repos.Classification.GetClassifiedItems(ctx, &domain.ItemFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		for _, item := range items {
+			if item.ID == other.ID {
+				assert.Empty(t, item.Duplicates)
+				continue
+			}
+			assert.Equal(t, []domain.Duplicate{
+				{ItemID: dupB.ID, Link: dupB.Link, FeedName: "Feed B", FeedURL: feedB.URL},
+				{ItemID: dupC.ID, Link: dupC.Link, FeedName: "Feed C", FeedURL: feedC.URL},
+			}, item.Duplicates, "one per feed, original's feed skipped")
+		}
+	})
+
+	t.Run("single item", func(t *testing.T) {
+		item, err := repos.Classification.GetClassifiedItem(ctx, original.ID)
+		require.NoError(t, err)
+		assert.Len(t, item.Duplicates, 2)
+	})
+
+	t.Run("copies extracted at the same time", func(t *testing.T) {
+		first, second := newItem(feedB, "wire-1"), newItem(feedC, "wire-2")
+		require.NoError(t, repos.Item.UpdateItemFingerprint(ctx, first.ID, 0x00FF00FF00FF00FF))
+		require.NoError(t, repos.Item.UpdateItemFingerprint(ctx, second.ID, 0x00FF00FF00FF00FE))
+
+		id, err := repos.Item.FindDuplicate(ctx, second.ID, 0x00FF00FF00FF00FE, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, id, "earlier copy matched before it is classified")
+
+		id, err = repos.Item.FindDuplicate(ctx, first.ID, 0x00FF00FF00FF00FF, 3, time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, id, "later unclassified copy not matched")
+	})
+}
+
 func TestItemRepository_ItemExistsByTitleOrURL(t *testing.T) {
 	repos, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -481,6 +665,34 @@ func TestItemRepository_ItemExistsByTitleOrURL(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, exists) // should find by title match
 	})
+
+	t.Run("empty title doesn't match untitled items", func(t *testing.T) {
+		untitled := &domain.Item{FeedID: testFeed.ID, GUID: "untitled-1", Link: "https://example.com/untitled-1", Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), untitled))
+
+		exists, err := repos.Item.ItemExistsByTitleOrURL(context.Background(), "", "https://example.com/untitled-2")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		exists, err = repos.Item.ItemExistsByTitleOrURL(context.Background(), "", "https://example.com/untitled-1")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("item exists by canonical link", func(t *testing.T) {
+		tracked := &domain.Item{FeedID: testFeed.ID, GUID: "tracked-1", Title: "Tracked",
+			Link: "https://example.com/tracked?utm_source=rss", CanonicalLink: "https://example.com/tracked", Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(context.Background(), tracked))
+
+		exists, err := repos.Item.ItemExistsByTitleOrURL(context.Background(), "Other Title", "https://example.com/tracked")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		item, err := repos.Item.GetItem(context.Background(), tracked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/tracked?utm_source=rss", item.Link)
+		assert.Equal(t, "https://example.com/tracked", item.CanonicalLink)
+	})
 }
 
 func TestItemRepository_DeleteOldItems(t *testing.T) {
@@ -622,3 +834,27 @@ func TestItemRepository_DeleteOldItems_NoMatchingItems(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, items, 1)
 }
+
+func BenchmarkItemRepository_FindDuplicate(b *testing.B) {
+	ctx := context.Background()
+	repos, err := NewRepositories(ctx, Config{DSN: ":memory:", MaxOpenConns: 1, MaxIdleConns: 1})
+	require.NoError(b, err)
+	defer repos.Close()
+
+	feed := &domain.Feed{URL: "https://example.com/feed", Title: "Feed", Enabled: true}
+	require.NoError(b, repos.Feed.CreateFeed(ctx, feed))
+	tx, err := repos.DB.BeginTxx(ctx, nil)
+	require.NoError(b, err)
+	for i := range 20000 { // a week of a busy subscription list
+		_, err = tx.ExecContext(ctx, `INSERT INTO items (feed_id, guid, title, link, simhash, classified_at)
+			VALUES (?, ?, 'title', 'https://example.com', ?, datetime('now'))`, feed.ID, fmt.Sprintf("item-%d", i), rand.Int64())
+		require.NoError(b, err)
+	}
+	require.NoError(b, tx.Commit())
+
+	b.ResetTimer()
+	for b.Loop() {
+		_, err := repos.Item.FindDuplicate(ctx, 0, rand.Uint64(), 8, 7*24*time.Hour)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// JobRepository handles the persistent processing queue, one job per item
+type JobRepository struct {
+	db *sqlx.DB
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(db *sqlx.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// jobSQL represents a processing job for SQL operations
+type jobSQL struct {
+	ID          int64      `db:"id"`
+	ItemID      int64      `db:"item_id"`
+	State       string     `db:"state"`
+	Priority    int        `db:"priority"`
+	Attempts    int        `db:"attempts"`
+	Error       string     `db:"error"`
+	RunAfter    time.Time  `db:"run_after"`
+	LockedUntil *time.Time `db:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+const jobColumns = "id, item_id, state, priority, attempts, error, run_after, locked_until, created_at, updated_at"
+
+// EnqueueJob queues the item for processing with the priority. a done or failed job of the item
+// is queued again with its attempts reset, a queued one keeps the higher of both priorities.
+func (r *JobRepository) EnqueueJob(ctx context.Context, itemID int64, priority int) error {
+	query := `
+		INSERT INTO process_jobs (item_id, priority) VALUES (?, ?)
+		ON CONFLICT(item_id) DO UPDATE SET
+			state = CASE WHEN state IN ('done', 'failed') THEN 'pending' ELSE state END,
+			attempts = CASE WHEN state IN ('done', 'failed') THEN 0 ELSE attempts END,
+			error = CASE WHEN state IN ('done', 'failed') THEN '' ELSE error END,
+			run_after = CASE WHEN state IN ('done', 'failed') OR excluded.priority > priority
+				THEN CURRENT_TIMESTAMP ELSE run_after END,
+			priority = MAX(priority, excluded.priority),
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := r.db.ExecContext(ctx, query, itemID, priority); err != nil {
+		return fmt.Errorf("enqueue job: %w", err)
+	}
+	return nil
+}
+
+// ClaimJob takes the next due job with the highest priority, oldest first, and locks it for the timeout.
+// jobs whose lock expired are claimed again, e.g. left by a crashed worker. returns nil if nothing is due.
+func (r *JobRepository) ClaimJob(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error) {
+	query := `
+		UPDATE process_jobs
+		SET state = 'extracting',
+		    attempts = attempts + 1,
+		    locked_until = datetime('now', ?),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM process_jobs
+			WHERE (state = 'pending' AND datetime(run_after) <= datetime('now'))
+			OR (state IN ('extracting', 'classifying') AND datetime(locked_until) <= datetime('now'))
+			ORDER BY priority DESC, id
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+
+	var job jobSQL
+	err := r.db.GetContext(ctx, &job, query, sqliteOffset(timeout))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim job: %w", err)
+	}
+	return r.toDomainJob(&job), nil
+}
+
+// ClaimItemJob claims the item's job for immediate processing regardless of its run time,
+// creating the job if the item has none. a done or failed job starts over with its attempts reset.
+// returns nil if a worker holds the job's lock, the item is already being processed.
+func (r *JobRepository) ClaimItemJob(ctx context.Context, itemID int64, timeout time.Duration) (*domain.ProcessJob, error) {
+	query := `
+		INSERT INTO process_jobs (item_id, state, priority, attempts, locked_until)
+		VALUES (?, 'extracting', ?, 1, datetime('now', ?))
+		ON CONFLICT(item_id) DO UPDATE SET
+			state = 'extracting',
+			attempts = CASE WHEN state IN ('done', 'failed') THEN 1 ELSE attempts + 1 END,
+			priority = MAX(priority, excluded.priority),
+			locked_until = excluded.locked_until,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE state NOT IN ('extracting', 'classifying') OR datetime(locked_until) <= datetime('now')
+		RETURNING ` + jobColumns
+
+	var job jobSQL
+	err := r.db.GetContext(ctx, &job, query, itemID, domain.PriorityManual, sqliteOffset(timeout))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim item job: %w", err)
+	}
+	return r.toDomainJob(&job), nil
+}
+
+// UpdateJobState moves the item's job to the state, errMsg replaces the job's error.
+// done and failed jobs are unlocked, claimed ones keep their lock.
+func (r *JobRepository) UpdateJobState(ctx context.Context, itemID int64, state domain.JobState, errMsg string) error {
+	query := `
+		UPDATE process_jobs
+		SET state = ?,
+		    error = ?,
+		    locked_until = CASE WHEN ? IN ('done', 'failed') THEN NULL ELSE locked_until END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE item_id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, string(state), errMsg, string(state), itemID); err != nil {
+		return fmt.Errorf("update job state: %w", err)
+	}
+	return nil
+}
+
+// RetryJob returns the item's job to the queue after a failed attempt, it isn't claimed before the delay passed
+func (r *JobRepository) RetryJob(ctx context.Context, itemID int64, errMsg string, delay time.Duration) error {
+	query := `
+		UPDATE process_jobs
+		SET state = 'pending',
+		    error = ?,
+		    run_after = datetime('now', ?),
+		    locked_until = NULL,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE item_id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, errMsg, sqliteOffset(delay), itemID); err != nil {
+		return fmt.Errorf("retry job: %w", err)
+	}
+	return nil
+}
+
+// RecoverJobs returns jobs claimed by a previous run to the queue and queues unprocessed items
+// without a job, e.g. stored before the queue existed. returns the number of jobs queued.
+func (r *JobRepository) RecoverJobs(ctx context.Context) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE process_jobs
+		SET state = 'pending', locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE state IN ('extracting', 'classifying')
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("release claimed jobs: %w", err)
+	}
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get released jobs: %w", err)
+	}
+
+	result, err = tx.ExecContext(ctx, `
+		INSERT INTO process_jobs (item_id)
+		SELECT i.id FROM items i
+		WHERE i.classified_at IS NULL
+		AND i.extraction_error = ''
+		AND i.duplicate_of IS NULL
+		AND i.muted_by = ''
+		AND NOT EXISTS (SELECT 1 FROM process_jobs j WHERE j.item_id = i.id)
+		ORDER BY i.id
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("enqueue unprocessed items: %w", err)
+	}
+	queued, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get queued items: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return released + queued, nil
+}
+
+// GetQueueStats counts jobs of the processing queue by state
+func (r *JobRepository) GetQueueStats(ctx context.Context) (domain.QueueStats, error) {
+	var rows []struct {
+		State string `db:"state"`
+		Count int    `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, "SELECT state, COUNT(*) AS count FROM process_jobs GROUP BY state"); err != nil {
+		return domain.QueueStats{}, fmt.Errorf("get queue stats: %w", err)
+	}
+
+	var stats domain.QueueStats
+	for _, row := range rows {
+		switch domain.JobState(row.State) {
+		case domain.JobPending:
+			stats.Pending += row.Count
+		case domain.JobExtracting, domain.JobClassifying:
+			stats.InProgress += row.Count
+		case domain.JobDone:
+			stats.Done += row.Count
+		case domain.JobFailed:
+			stats.Failed += row.Count
+		}
+	}
+	return stats, nil
+}
+
+// toDomainJob converts jobSQL to domain.ProcessJob
+func (r *JobRepository) toDomainJob(j *jobSQL) *domain.ProcessJob {
+	return &domain.ProcessJob{
+		ID:          j.ID,
+		ItemID:      j.ItemID,
+		State:       domain.JobState(j.State),
+		Priority:    j.Priority,
+		Attempts:    j.Attempts,
+		Error:       j.Error,
+		RunAfter:    j.RunAfter,
+		LockedUntil: j.LockedUntil,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+	}
+}
+
+// sqliteOffset formats the duration as a datetime modifier, e.g. "+600 seconds"
+func sqliteOffset(d time.Duration) string {
+	return fmt.Sprintf("%+d seconds", int64(d.Seconds()))
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestJobRepository_ClaimJob(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	feed := createTestFeed(t, repos, "Test Feed")
+
+	items := make([]*domain.Item, 3)
+	for i, guid := range []string{"first", "second", "manual"} {
+		items[i] = &domain.Item{FeedID: feed.ID, GUID: guid, Title: guid, Link: "https://example.com/" + guid, Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(ctx, items[i]))
+	}
+
+	job, err := repos.Job.ClaimJob(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, job, "empty queue")
+
+	require.NoError(t, repos.Job.EnqueueJob(ctx, items[0].ID, domain.PriorityNormal))
+	require.NoError(t, repos.Job.EnqueueJob(ctx, items[1].ID, domain.PriorityNormal))
+	require.NoError(t, repos.Job.EnqueueJob(ctx, items[2].ID, domain.PriorityManual))
+
+	t.Run("higher priority first, then oldest", func(t *testing.T) {
+		var claimed []int64
+		for range items {
+			job, err := repos.Job.ClaimJob(ctx, time.Minute)
+			require.NoError(t, err)
+			require.NotNil(t, job)
+			assert.Equal(t, domain.JobExtracting, job.State)
+			assert.Equal(t, 1, job.Attempts)
+			require.NotNil(t, job.LockedUntil)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), *job.LockedUntil, 5*time.Second)
+			claimed = append(claimed, job.ItemID)
+		}
+		assert.Equal(t, []int64{items[2].ID, items[0].ID, items[1].ID}, claimed)
+
+		job, err := repos.Job.ClaimJob(ctx, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, job, "claimed jobs are locked")
+	})
+
+	t.Run("retry waits for its run time", func(t *testing.T) {
+		require.NoError(t, repos.Job.RetryJob(ctx, items[0].ID, "classify: timeout", time.Hour))
+		require.NoError(t, repos.Job.RetryJob(ctx, items[1].ID, "classify: timeout", 0))
+
+		job, err := repos.Job.ClaimJob(ctx, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, items[1].ID, job.ItemID, "delayed retry skipped")
+		assert.Equal(t, 2, job.Attempts)
+		assert.Equal(t, "classify: timeout", job.Error)
+
+		job, err = repos.Job.ClaimJob(ctx, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	t.Run("expired lock claimed again", func(t *testing.T) {
+		require.NoError(t, repos.Job.UpdateJobState(ctx, items[2].ID, domain.JobDone, ""))
+		require.NoError(t, repos.Job.RetryJob(ctx, items[1].ID, "", 0))
+
+		job, err := repos.Job.ClaimJob(ctx, -time.Second) // claimed by a worker which never finishes
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, items[1].ID, job.ItemID)
+
+		job, err = repos.Job.ClaimJob(ctx, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, items[1].ID, job.ItemID)
+		assert.Equal(t, 4, job.Attempts)
+	})
+}
+
+func TestJobRepository_EnqueueJob(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	feed := createTestFeed(t, repos, "Test Feed")
+
+	item := &domain.Item{FeedID: feed.ID, GUID: "item", Title: "Item", Link: "https://example.com/item", Published: time.Now()}
+	require.NoError(t, repos.Item.CreateItem(ctx, item))
+
+	require.NoError(t, repos.Job.EnqueueJob(ctx, item.ID, domain.PriorityNormal))
+	require.NoError(t, repos.Job.EnqueueJob(ctx, item.ID, domain.PriorityNormal), "queued item enqueued again")
+	job, err := repos.Job.ClaimJob(ctx, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	require.NoError(t, repos.Job.UpdateJobState(ctx, item.ID, domain.JobFailed, "no classification returned"))
+
+	items, err := repos.Classification.GetFailedItems(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, item.ID, items[0].ID)
+	assert.Equal(t, "Test Feed", items[0].FeedName)
+	assert.Equal(t, "no classification returned", items[0].ProcessError)
+	require.NotNil(t, items[0].FailedAt)
+
+	// retry of the failed item starts over with the raised priority
+	require.NoError(t, repos.Job.EnqueueJob(ctx, item.ID, domain.PriorityManual))
+	items, err = repos.Classification.GetFailedItems(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	job, err = repos.Job.ClaimJob(ctx, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, domain.PriorityManual, job.Priority)
+	assert.Empty(t, job.Error)
+
+	// manual claim leaves the job locked by the worker alone
+	job, err = repos.Job.ClaimItemJob(ctx, item.ID, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, job, "job is in progress")
+
+	// claimed again once the worker's lock expired, counting another attempt
+	_, err = repos.DB.ExecContext(ctx, "UPDATE process_jobs SET locked_until = datetime('now', '-1 minute') WHERE item_id = ?", item.ID)
+	require.NoError(t, err)
+	job, err = repos.Job.ClaimItemJob(ctx, item.ID, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, domain.JobExtracting, job.State)
+
+	require.NoError(t, repos.Feed.DeleteFeed(ctx, feed.ID))
+	stats, err := repos.Job.GetQueueStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QueueStats{}, stats, "jobs removed with their items")
+}
+
+func TestJobRepository_RecoverJobs(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	feed := createTestFeed(t, repos, "Test Feed")
+
+	newItem := func(guid string) *domain.Item {
+		item := &domain.Item{FeedID: feed.ID, GUID: guid, Title: guid, Link: "https://example.com/" + guid, Published: time.Now()}
+		require.NoError(t, repos.Item.CreateItem(ctx, item))
+		return item
+	}
+	claimed, queued, done := newItem("claimed"), newItem("queued"), newItem("done")
+	legacy, muted, processed := newItem("legacy"), newItem("muted"), newItem("processed")
+	require.NoError(t, repos.Item.UpdateItemMuted(ctx, muted.ID, `keyword "ad"`))
+	require.NoError(t, repos.Item.UpdateItemProcessed(ctx, processed.ID,
+		&domain.ExtractedContent{PlainText: "text", ExtractedAt: time.Now()},
+		&domain.Classification{Score: 5, ClassifiedAt: time.Now()}))
+
+	for _, item := range []*domain.Item{claimed, queued, done} {
+		require.NoError(t, repos.Job.EnqueueJob(ctx, item.ID, domain.PriorityNormal))
+	}
+	_, err := repos.Job.ClaimItemJob(ctx, claimed.ID, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repos.Job.UpdateJobState(ctx, claimed.ID, domain.JobClassifying, ""))
+	require.NoError(t, repos.Job.UpdateJobState(ctx, done.ID, domain.JobDone, ""))
+
+	stats, err := repos.Job.GetQueueStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QueueStats{Pending: 1, InProgress: 1, Done: 1}, stats)
+
+	recovered, err := repos.Job.RecoverJobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), recovered, "claimed job released, legacy item queued")
+
+	stats, err = repos.Job.GetQueueStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QueueStats{Pending: 3, Done: 1}, stats)
+
+	var ids []int64
+	for {
+		job, err := repos.Job.ClaimJob(ctx, time.Minute)
+		require.NoError(t, err)
+		if job == nil {
+			break
+		}
+		ids = append(ids, job.ItemID)
+	}
+	assert.Equal(t, []int64{claimed.ID, queued.ID, legacy.ID}, ids)
+}
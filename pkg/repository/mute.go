@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// MuteRuleRepository handles mute rule database operations
+type MuteRuleRepository struct {
+	db *sqlx.DB
+}
+
+// NewMuteRuleRepository creates a new mute rule repository
+func NewMuteRuleRepository(db *sqlx.DB) *MuteRuleRepository {
+	return &MuteRuleRepository{db: db}
+}
+
+// CreateMuteRule stores a new mute rule
+func (r *MuteRuleRepository) CreateMuteRule(ctx context.Context, rule *domain.MuteRule) error {
+	feedID := sql.NullInt64{Int64: rule.FeedID, Valid: rule.FeedID != 0}
+	result, err := r.db.ExecContext(ctx, "INSERT INTO mute_rules (kind, pattern, feed_id) VALUES (?, ?, ?)",
+		string(rule.Kind), rule.Pattern, feedID)
+	if err != nil {
+		return fmt.Errorf("create mute rule: %w", err)
+	}
+	if rule.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get insert id: %w", err)
+	}
+	return nil
+}
+
+// GetMuteRules returns all mute rules with titles of their feeds, oldest first
+func (r *MuteRuleRepository) GetMuteRules(ctx context.Context) ([]domain.MuteRule, error) {
+	var rows []struct {
+		ID        int64         `db:"id"`
+		Kind      string        `db:"kind"`
+		Pattern   string        `db:"pattern"`
+		FeedID    sql.NullInt64 `db:"feed_id"`
+		FeedTitle string        `db:"feed_title"`
+		CreatedAt time.Time     `db:"created_at"`
+	}
+	query := `
+		SELECT m.id, m.kind, m.pattern, m.feed_id, COALESCE(NULLIF(f.title, ''), f.url, '') AS feed_title, m.created_at
+		FROM mute_rules m
+		LEFT JOIN feeds f ON m.feed_id = f.id
+		ORDER BY m.id
+	`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("get mute rules: %w", err)
+	}
+
+	rules := make([]domain.MuteRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, domain.MuteRule{ID: row.ID, Kind: domain.MuteKind(row.Kind), Pattern: row.Pattern,
+			FeedID: row.FeedID.Int64, FeedName: row.FeedTitle, CreatedAt: row.CreatedAt})
+	}
+	return rules, nil
+}
+
+// DeleteMuteRule removes a mute rule, items it muted stay muted
+func (r *MuteRuleRepository) DeleteMuteRule(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM mute_rules WHERE id = ?", id); err != nil {
+		return fmt.Errorf("delete mute rule: %w", err)
+	}
+	return nil
+}
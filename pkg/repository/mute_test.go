@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestMuteRuleRepository(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	feed := createTestFeed(t, repos, "Sports Daily")
+
+	keyword := &domain.MuteRule{Kind: domain.MuteKeyword, Pattern: "sponsored"}
+	require.NoError(t, repos.MuteRule.CreateMuteRule(ctx, keyword))
+	assert.NotZero(t, keyword.ID)
+	topic := &domain.MuteRule{Kind: domain.MuteTopic, Pattern: "football", FeedID: feed.ID}
+	require.NoError(t, repos.MuteRule.CreateMuteRule(ctx, topic))
+
+	rules, err := repos.MuteRule.GetMuteRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, keyword.ID, rules[0].ID)
+	assert.Equal(t, domain.MuteKeyword, rules[0].Kind)
+	assert.Equal(t, "sponsored", rules[0].Pattern)
+	assert.Zero(t, rules[0].FeedID)
+	assert.Empty(t, rules[0].FeedName)
+	assert.Equal(t, feed.ID, rules[1].FeedID)
+	assert.Equal(t, "Sports Daily", rules[1].FeedName)
+	assert.Equal(t, `topic "football" in Sports Daily`, rules[1].String())
+
+	require.NoError(t, repos.MuteRule.DeleteMuteRule(ctx, keyword.ID))
+	rules, err = repos.MuteRule.GetMuteRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, topic.ID, rules[0].ID)
+
+	require.NoError(t, repos.Feed.DeleteFeed(ctx, feed.ID))
+	rules, err = repos.MuteRule.GetMuteRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules, "rules of a deleted feed removed")
+}
+
+func TestItemRepository_UpdateItemMuted(t *testing.T) {
+	repos, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	feed := createTestFeed(t, repos, "Test Feed")
+
+	muted := &domain.Item{FeedID: feed.ID, GUID: "muted", Title: "Sponsored post", Link: "https://example.com/ad", Published: time.Now()}
+	require.NoError(t, repos.Item.CreateItem(ctx, muted))
+	regular := &domain.Item{FeedID: feed.ID, GUID: "regular", Title: "Article", Link: "https://example.com/a", Published: time.Now()}
+	require.NoError(t, repos.Item.CreateItem(ctx, regular))
+
+	require.NoError(t, repos.Item.UpdateItemMuted(ctx, muted.ID, `keyword "sponsored"`))
+
+	stored, err := repos.Item.GetItem(ctx, muted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `keyword "sponsored"`, stored.MutedBy)
+
+	items, err := repos.Classification.GetMutedItems(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, muted.ID, items[0].ID)
+	assert.Equal(t, `keyword "sponsored"`, items[0].MutedBy)
+	assert.Equal(t, "Test Feed", items[0].FeedName)
+
+	pending, err := repos.Item.GetItemsNeedingExtraction(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "muted item not extracted")
+	assert.Equal(t, regular.ID, pending[0].ID)
+}
@@ -28,6 +28,8 @@ type Repositories struct {
 	Item           *ItemRepository
 	Classification *ClassificationRepository
 	Setting        *SettingRepository
+	MuteRule       *MuteRuleRepository
+	Job            *JobRepository
 	DB             *sqlx.DB
 }
 
@@ -78,12 +80,19 @@ func NewRepositories(ctx context.Context, cfg Config) (*Repositories, error) {
 		return nil, fmt.Errorf("init schema: %w", err)
 	}
 
+	// bring existing databases up to date
+	if err := migrateSchema(ctx, db); err != nil {
+		return nil, fmt.Errorf("migrate schema: %w", err)
+	}
+
 	// create repositories
 	repos := &Repositories{
 		Feed:           NewFeedRepository(db),
 		Item:           NewItemRepository(db),
 		Classification: NewClassificationRepository(db),
 		Setting:        NewSettingRepository(db),
+		MuteRule:       NewMuteRuleRepository(db),
+		Job:            NewJobRepository(db),
 		DB:             db,
 	}
 
@@ -114,6 +123,95 @@ func initSchema(ctx context.Context, db *sqlx.DB) error {
 	return nil
 }
 
+// schemaMigration describes a column added after the initial schema.
+// new databases get the column from schema.sql, existing ones via ALTER TABLE.
+// the index, if set, is created in both cases, schema.sql can't index columns old databases don't have yet.
+type schemaMigration struct {
+	table      string
+	column     string
+	definition string
+	index      string
+}
+
+// schemaMigrations lists all columns added to the schema, in order of introduction
+var schemaMigrations = []schemaMigration{
+	{table: "feeds", column: "adaptive_interval", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "etag", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "last_modified", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "last_size", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "bytes_saved", definition: "INTEGER DEFAULT 0"},
+	{table: "feeds", column: "disabled_reason", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "folder", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "hub_url", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "websub_topic", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "websub_secret", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "websub_expires", definition: "DATETIME"},
+	{table: "feeds", column: "feed_type", definition: "TEXT DEFAULT 'rss'"},
+	{table: "feeds", column: "selectors", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "auth", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "proxy", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "last_new_item", definition: "DATETIME"},
+	{table: "items", column: "simhash", definition: "INTEGER DEFAULT 0"},
+	{table: "items", column: "duplicate_of", definition: "INTEGER REFERENCES items(id) ON DELETE SET NULL",
+		index: "CREATE INDEX IF NOT EXISTS idx_items_duplicate_of ON items(duplicate_of) WHERE duplicate_of IS NOT NULL"},
+	{table: "items", column: "canonical_link", definition: "TEXT DEFAULT ''"},
+	{table: "items", column: "image", definition: "TEXT DEFAULT ''"},
+	{table: "items", column: "enclosures", definition: "JSON DEFAULT '[]'"},
+	{table: "items", column: "categories", definition: "JSON DEFAULT '[]'"},
+	{table: "items", column: "muted_by", definition: "TEXT DEFAULT ''",
+		index: "CREATE INDEX IF NOT EXISTS idx_items_muted ON items(created_at DESC) WHERE muted_by != ''"},
+	{table: "items", column: "extracted_by", definition: "TEXT DEFAULT ''"},
+	{table: "items", column: "resolved_link", definition: "TEXT DEFAULT ''"},
+	{table: "feeds", column: "moved_to", definition: "TEXT DEFAULT ''"},
+}
+
+// migrateSchema adds columns missing in databases created by older versions
+// and fixes data stored in formats SQLite can't compare
+func migrateSchema(ctx context.Context, db *sqlx.DB) error {
+	for _, m := range schemaMigrations {
+		var count int
+		query := "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+		if err := db.GetContext(ctx, &count, query, m.table, m.column); err != nil {
+			return fmt.Errorf("check column %s.%s: %w", m.table, m.column, err)
+		}
+		if count == 0 {
+			alter := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition)
+			if _, err := db.ExecContext(ctx, alter); err != nil {
+				return fmt.Errorf("add column %s.%s: %w", m.table, m.column, err)
+			}
+		}
+		if m.index == "" {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, m.index); err != nil {
+			return fmt.Errorf("index column %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	// bands of item fingerprints looked up by FindDuplicate, the expressions have to match its query
+	for i := range fingerprintBands {
+		expr, _ := fingerprintBand(i, 0)
+		index := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_items_simhash_band%d ON items(%s) WHERE simhash != 0", i, expr)
+		if _, err := db.ExecContext(ctx, index); err != nil {
+			return fmt.Errorf("index fingerprint band %d: %w", i, err)
+		}
+	}
+
+	// next fetch times written as Go's time.String are unreadable for datetime(), such feeds are fetched
+	// once more and get a next fetch time in the SQLite format
+	query := "UPDATE feeds SET next_fetch = NULL WHERE next_fetch IS NOT NULL AND datetime(next_fetch) IS NULL"
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("reset unreadable next fetch times: %w", err)
+	}
+	return nil
+}
+
+// sqliteTime formats the time in UTC as SQLite's date and time functions expect it, e.g. "2025-01-02 15:04:05".
+// the driver stores a bound time.Time in Go's own format, which datetime() can't parse.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
 // criticalError wraps an error to signal repeater to stop retrying
 type criticalError struct {
 	err error
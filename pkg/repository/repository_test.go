@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -152,6 +153,47 @@ func TestRepositories_Close(t *testing.T) {
 	assert.NoError(t, repos.Close())
 }
 
+func TestMigrateSchema(t *testing.T) {
+	db, err := sqlx.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	// simulate database created by an older version without adaptive_interval
+	_, err = db.Exec(`CREATE TABLE feeds (id INTEGER PRIMARY KEY AUTOINCREMENT, url TEXT NOT NULL UNIQUE,
+		title TEXT DEFAULT '', description TEXT DEFAULT '', last_fetched DATETIME, next_fetch DATETIME,
+		fetch_interval INTEGER DEFAULT 1800, error_count INTEGER DEFAULT 0, last_error TEXT DEFAULT '',
+		enabled BOOLEAN DEFAULT 1, created_at DATETIME DEFAULT CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO feeds (url, title, next_fetch) VALUES ('https://example.com/feed.xml', 'Old Feed', ?)",
+		"2025-01-02 15:04:05.123 +0000 UTC") // time.String format, written by an older version
+	require.NoError(t, err)
+	// and items without near-duplicate columns, which are indexed
+	_, err = db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, feed_id INTEGER NOT NULL,
+		guid TEXT NOT NULL, title TEXT NOT NULL, link TEXT NOT NULL, description TEXT DEFAULT '', content TEXT DEFAULT '',
+		author TEXT DEFAULT '', published DATETIME, extracted_content TEXT DEFAULT '', extracted_rich_content TEXT DEFAULT '',
+		extracted_at DATETIME, extraction_error TEXT DEFAULT '', relevance_score REAL DEFAULT 0, explanation TEXT DEFAULT '',
+		topics JSON DEFAULT '[]', summary TEXT DEFAULT '', classified_at DATETIME, user_feedback TEXT DEFAULT '',
+		feedback_at DATETIME, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(feed_id, guid))`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, initSchema(ctx, db))
+	require.NoError(t, migrateSchema(ctx, db))
+	require.NoError(t, migrateSchema(ctx, db), "migration should be idempotent")
+
+	feed, err := NewFeedRepository(db).GetFeed(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Old Feed", feed.Title)
+	assert.Zero(t, feed.AdaptiveInterval)
+	assert.Nil(t, feed.NextFetch, "unreadable next fetch time reset")
+
+	var indexes int
+	require.NoError(t, db.Get(&indexes, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_items_duplicate_of'"))
+	assert.Equal(t, 1, indexes)
+}
+
 func TestCriticalError(t *testing.T) {
 	originalErr := fmt.Errorf("test error message")
 	critErr := &criticalError{err: originalErr}
@@ -3,16 +3,33 @@
 -- Feed sources
 CREATE TABLE IF NOT EXISTS feeds (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
+    feed_type TEXT DEFAULT 'rss',       -- rss for RSS/Atom/JSON feeds, html for scraped web pages
     url TEXT NOT NULL UNIQUE,
     title TEXT DEFAULT '',
     description TEXT DEFAULT '',
+    folder TEXT DEFAULT '',             -- slash-separated folder path, empty for top level
     last_fetched DATETIME,
     next_fetch DATETIME,
     fetch_interval INTEGER DEFAULT 1800, -- 30 minutes
+    adaptive_interval INTEGER DEFAULT 0, -- interval derived from publishing frequency, 0 if unknown
     error_count INTEGER DEFAULT 0,
     last_error TEXT DEFAULT '',
     enabled BOOLEAN DEFAULT 1,
-    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    disabled_reason TEXT DEFAULT '',    -- why the feed was disabled automatically
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_new_item DATETIME,             -- last fetch that found new items
+    selectors TEXT DEFAULT '',          -- JSON with CSS selectors of scraped web pages
+    auth TEXT DEFAULT '',               -- JSON with credentials of private feeds
+    proxy TEXT DEFAULT '',              -- proxy URL for fetching the feed, empty for the configured one
+    moved_to TEXT DEFAULT '',           -- permanent redirect seen on the last fetch, not confirmed yet
+    etag TEXT DEFAULT '',               -- validators of the last full response for conditional GET
+    last_modified TEXT DEFAULT '',
+    last_size INTEGER DEFAULT 0,        -- body size of the last full response
+    bytes_saved INTEGER DEFAULT 0,      -- total bytes saved by 304 responses
+    hub_url TEXT DEFAULT '',            -- websub hub advertised by the feed
+    websub_topic TEXT DEFAULT '',       -- topic subscribed at the hub
+    websub_secret TEXT DEFAULT '',      -- secret for signed pushes, empty if not subscribed
+    websub_expires DATETIME             -- lease expiration, NULL while pending
 );
 
 -- Articles with LLM classification
@@ -22,16 +39,22 @@ CREATE TABLE IF NOT EXISTS items (
     guid TEXT NOT NULL,
     title TEXT NOT NULL,
     link TEXT NOT NULL,
+    canonical_link TEXT DEFAULT '', -- dedupe key without tracking parameters, redirect wrappers, AMP and mobile variants
+    resolved_link TEXT DEFAULT '',  -- link with redirect wrappers resolved, the page's canonical after extraction
     description TEXT DEFAULT '',
     content TEXT DEFAULT '',        -- Original RSS content
     author TEXT DEFAULT '',
     published DATETIME,
+    image TEXT DEFAULT '',          -- lead image URL
+    enclosures JSON DEFAULT '[]',   -- media files attached by the feed, with type and length
+    categories JSON DEFAULT '[]',   -- categories assigned by the feed
     
     -- Extracted content
     extracted_content TEXT DEFAULT '',   -- Full article text
     extracted_rich_content TEXT DEFAULT '',  -- HTML formatted content
     extracted_at DATETIME,
     extraction_error TEXT DEFAULT '',
+    extracted_by TEXT DEFAULT '',        -- extractor which produced the content: trafilatura, fallback or pdf
     
     -- LLM classification results
     relevance_score REAL DEFAULT 0,     -- 0-10 score from LLM
@@ -44,6 +67,13 @@ CREATE TABLE IF NOT EXISTS items (
     user_feedback TEXT DEFAULT '',      -- 'like', 'dislike', 'spam', empty
     feedback_at DATETIME,
     
+    -- Near-duplicate detection
+    simhash INTEGER DEFAULT 0,          -- SimHash of title and extracted text, 0 if not computed
+    duplicate_of INTEGER REFERENCES items(id) ON DELETE SET NULL, -- article this item repeats
+    
+    -- Mute rules
+    muted_by TEXT DEFAULT '',           -- description of the matched mute rule, empty if not muted
+    
     -- Metadata
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
@@ -52,6 +82,55 @@ CREATE TABLE IF NOT EXISTS items (
     FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
 );
 
+-- Changes made to feeds automatically, e.g. URL updated after a permanent redirect
+CREATE TABLE IF NOT EXISTS feed_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    feed_id INTEGER NOT NULL,
+    event_type TEXT NOT NULL,           -- moved or gone
+    message TEXT DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
+);
+
+-- Fetch attempts of feeds, the latest ones are kept for the health view
+CREATE TABLE IF NOT EXISTS feed_fetches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    feed_id INTEGER NOT NULL,
+    fetched_at DATETIME NOT NULL,
+    status_code INTEGER DEFAULT 0,      -- 0 if there was no response
+    duration_ms INTEGER DEFAULT 0,
+    bytes INTEGER DEFAULT 0,
+    items INTEGER DEFAULT 0,
+    new_items INTEGER DEFAULT 0,
+    error TEXT DEFAULT '',
+    FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
+);
+
+-- User-defined rules muting items before extraction and classification
+CREATE TABLE IF NOT EXISTS mute_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,                 -- keyword, regex, author, domain or topic
+    pattern TEXT NOT NULL,
+    feed_id INTEGER,                    -- limits the rule to the feed, NULL for all feeds
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (feed_id) REFERENCES feeds(id) ON DELETE CASCADE
+);
+
+-- Processing queue, one job per item extracted and classified by the workers
+CREATE TABLE IF NOT EXISTS process_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL UNIQUE,
+    state TEXT DEFAULT 'pending',       -- pending, extracting, classifying, done or failed
+    priority INTEGER DEFAULT 0,         -- higher priority jobs are claimed first
+    attempts INTEGER DEFAULT 0,         -- times the job was claimed
+    error TEXT DEFAULT '',              -- error of the last failed attempt
+    run_after DATETIME DEFAULT CURRENT_TIMESTAMP, -- not claimed before this time
+    locked_until DATETIME,              -- visibility timeout of a claimed job
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+);
+
 -- User preferences and settings
 CREATE TABLE IF NOT EXISTS settings (
     key TEXT PRIMARY KEY,
@@ -64,6 +143,9 @@ CREATE INDEX IF NOT EXISTS idx_items_published ON items(published DESC);
 CREATE INDEX IF NOT EXISTS idx_items_score ON items(relevance_score DESC);
 CREATE INDEX IF NOT EXISTS idx_items_feedback ON items(user_feedback, feedback_at DESC);
 CREATE INDEX IF NOT EXISTS idx_feeds_next ON feeds(next_fetch);
+CREATE INDEX IF NOT EXISTS idx_feed_events_feed ON feed_events(feed_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_feed_fetches_feed ON feed_fetches(feed_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_process_jobs_claim ON process_jobs(state, priority DESC, run_after);
 
 -- Additional performance indexes
 CREATE INDEX IF NOT EXISTS idx_items_feed_published ON items(feed_id, published DESC);
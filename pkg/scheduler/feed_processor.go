@@ -3,24 +3,41 @@ package scheduler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-pkgz/lgr"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/umputun/newscope/pkg/canonical"
+	"github.com/umputun/newscope/pkg/content"
 	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
 	"github.com/umputun/newscope/pkg/llm"
+	"github.com/umputun/newscope/pkg/mute"
+	"github.com/umputun/newscope/pkg/newsletter"
+	"github.com/umputun/newscope/pkg/simhash"
+	"github.com/umputun/newscope/pkg/websub"
 )
 
 // FeedProcessor handles feed updating and item processing.
 // It is responsible for:
-//   - Periodically fetching RSS/Atom feeds and detecting new items
+//   - Fetching RSS/Atom feeds when they are due and detecting new items
+//   - Adapting each feed's fetch interval to its publishing frequency
+//   - Subscribing to WebSub hubs and storing pushed items
+//   - Storing email newsletters as items of per-sender pseudo-feeds
+//   - Queueing new items in the persistent processing queue, items of user actions first
+//   - Muting items matching user-defined mute rules before any extraction
 //   - Extracting full content from article URLs
 //   - Classifying items using the LLM classifier with user preferences
-//   - Managing concurrent processing of feeds and items
-//   - Retrying failed operations with exponential backoff
+//   - Managing concurrent feed updates and the extraction and classification stages of queued items,
+//     each stage with its own concurrency limit
+//   - Retrying failed operations and processing jobs with exponential backoff
 //
 // The FeedProcessor delegates database operations to the provided managers
 // and uses the parser, extractor, and classifier for content processing.
@@ -29,24 +46,85 @@ type FeedProcessor struct {
 	itemManager           ItemManager
 	classificationManager ClassificationManager
 	settingManager        SettingManager
+	muteRuleManager       MuteRuleManager
+	jobManager            JobManager
 	parser                Parser
 	extractor             Extractor
 	classifier            Classifier
+	webSubscriber         WebSubscriber
+	linkResolver          LinkResolver
+	newsletters           []NewsletterSource
 
-	maxWorkers int
-	retryFunc  func(ctx context.Context, operation func() error) error
+	maxWorkers           int
+	minFetchInterval     time.Duration
+	maxFetchInterval     time.Duration
+	maxFeedErrors        int
+	webSubCallbackURL    string
+	webSubLease          time.Duration
+	webSubSafetyInterval time.Duration
+	newsletterInterval   time.Duration
+	newsletterLookback   time.Duration
+	jobTimeout           time.Duration
+	maxProcessAttempts   int
+	retryFunc            func(ctx context.Context, operation func() error) error
+
+	extraction     *stage        // network-bound, limited by max concurrent extractions
+	classification *stage        // LLM-bound, limited by max concurrent classifications
+	jobsReady      chan struct{} // signals the processing worker about new jobs, coalesced
+	webSubPushes   chan webSubPush
+
+	muteMu      sync.Mutex
+	muteMatcher *mute.Matcher // compiled mute rules, nil until loaded and after the rules changed
+
+	webSubMu     sync.Mutex
+	webSubUnsubs map[string]time.Time // unsubscribe requests awaiting the hub's verification, by feed id and topic
 }
 
+const (
+	dueFeedsBatchSize     = 100 // max feeds fetched per scheduler tick
+	adaptiveSampleSize    = 20  // number of most recent items used to estimate publishing frequency
+	adaptiveMinSampleSize = 3   // minimum number of dated items required for estimation
+	previewItemsLimit     = 20  // number of latest items shown in a feed preview
+	previewSampleSize     = 10  // number of latest items classified in a feed preview
+
+	duplicateMaxDistance = 8                  // max differing fingerprint bits of near-duplicate articles
+	duplicateWindow      = 7 * 24 * time.Hour // how far back to look for the original of a near-duplicate
+
+	jobPollInterval  = 5 * time.Second // how often an idle worker checks for delayed retries and expired claims
+	jobRetryDelay    = 5 * time.Minute // delay after the first failed attempt of a job, doubled with each attempt
+	jobMaxRetryDelay = 24 * time.Hour  // upper bound for the delay between attempts of a job
+
+	webSubUnsubscribeWait = time.Hour // how long an unsubscribe request waits for the hub's verification
+	webSubPushQueueSize   = 100       // pushes waiting to be stored, hubs retry later ones
+)
+
 // FeedProcessorConfig holds configuration for FeedProcessor
 type FeedProcessorConfig struct {
 	FeedManager           FeedManager
 	ItemManager           ItemManager
 	ClassificationManager ClassificationManager
 	SettingManager        SettingManager
+	MuteRuleManager       MuteRuleManager // provides mute rules, nil to never mute items
+	JobManager            JobManager      // persistent processing queue
 	Parser                Parser
 	Extractor             Extractor
 	Classifier            Classifier
-	MaxWorkers            int
+	MaxWorkers            int                // concurrent feed updates
+	MaxExtractions        int                // concurrent content extractions
+	MaxClassifications    int                // concurrent LLM classifications
+	MinFetchInterval      time.Duration      // lower bound for adaptive interval
+	MaxFetchInterval      time.Duration      // upper bound for adaptive interval
+	MaxFeedErrors         int                // consecutive errors before the feed is disabled, 0 or negative to never disable
+	WebSubscriber         WebSubscriber      // subscribes to websub hubs, nil to disable push
+	LinkResolver          LinkResolver       // resolves redirect wrappers of item links, nil to only clean links
+	WebSubCallbackURL     string             // public base URL, hubs call {base}/websub/{feed id}
+	WebSubLease           time.Duration      // requested lease, also used if the hub doesn't send one
+	WebSubSafetyInterval  time.Duration      // min polling interval for feeds with an active push subscription
+	Newsletters           []NewsletterSource // email newsletter sources, empty to disable
+	NewsletterInterval    time.Duration      // newsletter check interval, shown as the pseudo-feeds' interval
+	NewsletterLookback    time.Duration      // only newsletters received within this period are read
+	JobTimeout            time.Duration      // visibility timeout, a job still claimed after it is claimed again
+	MaxProcessAttempts    int                // attempts of a job before it fails
 	RetryFunc             func(ctx context.Context, operation func() error) error
 }
 
@@ -59,63 +137,202 @@ func NewFeedProcessor(cfg FeedProcessorConfig) *FeedProcessor {
 		itemManager:           cfg.ItemManager,
 		classificationManager: cfg.ClassificationManager,
 		settingManager:        cfg.SettingManager,
+		muteRuleManager:       cfg.MuteRuleManager,
+		jobManager:            cfg.JobManager,
 		parser:                cfg.Parser,
 		extractor:             cfg.Extractor,
 		classifier:            cfg.Classifier,
+		webSubscriber:         cfg.WebSubscriber,
+		linkResolver:          cfg.LinkResolver,
+		newsletters:           cfg.Newsletters,
 		maxWorkers:            cfg.MaxWorkers,
+		minFetchInterval:      cfg.MinFetchInterval,
+		maxFetchInterval:      cfg.MaxFetchInterval,
+		maxFeedErrors:         cfg.MaxFeedErrors,
+		webSubCallbackURL:     cfg.WebSubCallbackURL,
+		webSubLease:           cfg.WebSubLease,
+		webSubSafetyInterval:  cfg.WebSubSafetyInterval,
+		newsletterInterval:    cfg.NewsletterInterval,
+		newsletterLookback:    cfg.NewsletterLookback,
+		jobTimeout:            cfg.JobTimeout,
+		maxProcessAttempts:    cfg.MaxProcessAttempts,
 		retryFunc:             cfg.RetryFunc,
+		extraction:            newStage(cfg.MaxExtractions),
+		classification:        newStage(cfg.MaxClassifications),
+		jobsReady:             make(chan struct{}, 1),
+		webSubPushes:          make(chan webSubPush, webSubPushQueueSize),
+		webSubUnsubs:          map[string]time.Time{},
 	}
 }
 
-// ProcessingWorker processes items from the channel with concurrent workers.
-// It manages a pool of workers (limited by maxWorkers) that process items
-// for content extraction and classification. This method blocks until the
-// channel is closed or the context is canceled.
-func (fp *FeedProcessor) ProcessingWorker(ctx context.Context, items <-chan domain.Item) {
-	g, ctx := errgroup.WithContext(ctx)
-	g.SetLimit(fp.maxWorkers)
+// ProcessingWorker claims jobs from the persistent queue, jobs of a higher priority first, and processes
+// their items. Items pass the extraction and classification stages, each with its own concurrency limit,
+// and no more jobs are claimed than both stages can run at once. On start it returns jobs interrupted by
+// the previous run to the queue. While the queue is empty it waits for new jobs and polls for delayed
+// retries and expired claims. This method blocks until the context is canceled and claimed jobs finish.
+func (fp *FeedProcessor) ProcessingWorker(ctx context.Context) {
+	recovered, err := fp.jobManager.RecoverJobs(ctx)
+	if err != nil {
+		lgr.Printf("[WARN] failed to recover processing jobs: %v", err)
+	}
+	if recovered > 0 {
+		lgr.Printf("[INFO] %d interrupted or unqueued items returned to the processing queue", recovered)
+	}
 
-	for item := range items {
-		g.Go(func() error {
-			fp.ProcessItem(ctx, &item)
-			return nil
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	slots := make(chan struct{}, cap(fp.extraction.slots)+cap(fp.classification.slots))
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// wait for a free worker before claiming, so claims don't expire while jobs wait for one
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		job, err := fp.jobManager.ClaimJob(ctx, fp.jobTimeout)
+		if err != nil && ctx.Err() == nil {
+			lgr.Printf("[WARN] failed to claim processing job: %v", err)
+		}
+		if job == nil {
+			<-slots
+			select {
+			case <-fp.jobsReady:
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fp.processJob(ctx, job)
+		}()
+	}
+}
+
+// processJob processes the item of a claimed job and records the outcome in the queue
+func (fp *FeedProcessor) processJob(ctx context.Context, job *domain.ProcessJob) {
+	if job.Attempts > fp.maxProcessAttempts {
+		// all previous claims expired without the job being finished, e.g. the item hangs the extractor
+		fp.finishJob(ctx, job, fmt.Errorf("not finished after %d attempts", job.Attempts-1))
+		return
+	}
+
+	item, err := fp.itemManager.GetItem(ctx, job.ItemID)
+	if err != nil {
+		fp.finishJob(ctx, job, fmt.Errorf("get item: %w", err))
+		return
+	}
+	fp.finishJob(ctx, job, fp.ProcessItem(ctx, item))
+}
+
+// finishJob records the outcome of a job's attempt. a failed attempt is retried with exponential backoff
+// until the job runs out of attempts and fails. nothing is recorded if the context is canceled, the job
+// stays claimed and is returned to the queue on the next start.
+func (fp *FeedProcessor) finishJob(ctx context.Context, job *domain.ProcessJob, procErr error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	var err error
+	switch {
+	case procErr == nil:
+		err = fp.retryFunc(ctx, func() error {
+			return fp.jobManager.UpdateJobState(ctx, job.ItemID, domain.JobDone, "")
+		})
+	case job.Attempts >= fp.maxProcessAttempts:
+		lgr.Printf("[WARN] processing of item %d failed after %d attempts: %v", job.ItemID, job.Attempts, procErr)
+		err = fp.retryFunc(ctx, func() error {
+			return fp.jobManager.UpdateJobState(ctx, job.ItemID, domain.JobFailed, procErr.Error())
+		})
+	default:
+		delay := retryDelay(job.Attempts)
+		lgr.Printf("[WARN] failed to process item %d, attempt %d of %d, retry in %v: %v",
+			job.ItemID, job.Attempts, fp.maxProcessAttempts, delay, procErr)
+		err = fp.retryFunc(ctx, func() error {
+			return fp.jobManager.RetryJob(ctx, job.ItemID, procErr.Error(), delay)
 		})
 	}
+	if err != nil {
+		lgr.Printf("[WARN] failed to update processing job of item %d after retries: %v", job.ItemID, err)
+	}
+}
 
-	if err := g.Wait(); err != nil {
-		lgr.Printf("[ERROR] processing worker error: %v", err)
+// retryDelay returns the delay after the given failed attempt of a job, jobRetryDelay doubled
+// with each attempt and capped at jobMaxRetryDelay
+func retryDelay(attempt int) time.Duration {
+	delay := jobRetryDelay
+	for i := 1; i < attempt && delay < jobMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, jobMaxRetryDelay)
+}
+
+// enqueue stores a processing job of the new item and wakes up the processing worker. an item
+// which failed to queue is picked up by the job recovery on the next start.
+func (fp *FeedProcessor) enqueue(ctx context.Context, item *domain.Item, priority int) {
+	err := fp.retryFunc(ctx, func() error {
+		return fp.jobManager.EnqueueJob(ctx, item.ID, priority)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to queue item %d for processing after retries: %v", item.ID, err)
+		return
+	}
+	select {
+	case fp.jobsReady <- struct{}{}:
+	default: // the worker is already notified
 	}
 }
 
 // ProcessItem handles extraction and classification for a single item.
+// Items matching a mute rule are marked muted and not processed further.
 // The processing pipeline includes:
 // 1. Extracting full content from the item's URL
 // 2. Gathering context (feedback, topics, preferences) for classification
 // 3. Classifying the item using the LLM with user preferences
 // 4. Persisting both extraction and classification results
-// Errors at any stage are logged but don't stop the overall process.
-func (fp *FeedProcessor) ProcessItem(ctx context.Context, item *domain.Item) {
+// Extraction errors are stored with the item and end its processing. Returns an error if the item
+// should be processed again, e.g. failed to classify, the caller's job is retried then.
+func (fp *FeedProcessor) ProcessItem(ctx context.Context, item *domain.Item) error {
 	itemID := fp.getItemIdentifier(item)
 	lgr.Printf("[DEBUG] processing item: %s", itemID)
 
+	// muted items don't spend extraction time or LLM tokens
+	if fp.markMuted(ctx, item) {
+		return nil
+	}
+
 	// 1. Extract content
-	extracted, err := fp.extractor.Extract(ctx, item.Link)
+	extracted, err := fp.extract(ctx, item)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("extract: %w", ctx.Err()) // interrupted, not an error of the item
+	}
+	if errors.Is(err, content.ErrRateLimited) {
+		return fmt.Errorf("extract: %w", err) // the host asked to come back later, the job is retried
+	}
 	if err != nil {
-		// check if error indicates unsupported content type (PDF, images, etc)
+		// check if error indicates unsupported content type (images, archives, etc)
 		if strings.Contains(err.Error(), "unsupported content type") {
 			lgr.Printf("[INFO] non-HTML content for item %d from %s: %v", item.ID, item.Link, err)
 			// store error for non-HTML content so user knows why it wasn't extracted
 			extraction := &domain.ExtractedContent{
-				Error:       "Binary content (PDF, image, or other non-HTML format)",
+				Error:       "Binary content (image, archive, or other non-text format)",
 				ExtractedAt: time.Now(),
 			}
 			updateErr := fp.retryFunc(ctx, func() error {
 				return fp.itemManager.UpdateItemExtraction(ctx, item.ID, extraction)
 			})
 			if updateErr != nil {
-				lgr.Printf("[WARN] failed to update extraction status for item %d after retries: %v", item.ID, updateErr)
+				return fmt.Errorf("update extraction status: %w", updateErr)
 			}
-			return
+			return nil
 		}
 		lgr.Printf("[WARN] failed to extract content for item %d from %s: %v", item.ID, item.Link, err)
 		extraction := &domain.ExtractedContent{
@@ -126,96 +343,253 @@ func (fp *FeedProcessor) ProcessItem(ctx context.Context, item *domain.Item) {
 			return fp.itemManager.UpdateItemExtraction(ctx, item.ID, extraction)
 		})
 		if updateErr != nil {
-			lgr.Printf("[WARN] failed to update extraction error for item %d after retries: %v", item.ID, updateErr)
+			return fmt.Errorf("update extraction error: %w", updateErr)
 		}
-		return
+		return nil
+	}
+
+	// set extracted content for classification
+	item.Content = extracted.Content
+	if item.Title == "" {
+		// items from sitemaps have no title, use the one found by the extractor
+		item.Title = extracted.Title
+	}
+
+	extraction := &domain.ExtractedContent{
+		PlainText:   extracted.Content,
+		RichHTML:    extracted.RichContent,
+		Title:       item.Title,
+		ExtractedAt: time.Now(),
+		Fingerprint: simhash.Fingerprint(item.Title + " " + extracted.Content),
+		Extractor:   extracted.Extractor,
+	}
+	if !item.IsNewsletter() {
+		extraction.ResolvedLink = canonical.Prefer(item.DisplayLink(), extracted.Canonical)
+		extraction.CanonicalLink = canonical.Clean(extraction.ResolvedLink)
+		extraction.Image = extracted.Image
+	}
+
+	// near-duplicates of recent articles are linked to them and not classified
+	if fp.markDuplicate(ctx, item, extraction) {
+		return nil
+	}
+
+	// 2. Get context for classification and 3. classify the item
+	fp.updateJobState(ctx, item, domain.JobClassifying)
+	classifications, err := fp.classify(ctx, itemID, item)
+	if err != nil {
+		return fmt.Errorf("classify: %w", err)
+	}
+
+	if len(classifications) == 0 {
+		return errors.New("no classification returned")
+	}
+
+	// 4. Update item with both extraction and classification results
+	classification := classifications[0]
+	classification.ClassifiedAt = time.Now()
+
+	err = fp.retryFunc(ctx, func() error {
+		return fp.itemManager.UpdateItemProcessed(ctx, item.ID, extraction, &classification)
+	})
+	if err != nil {
+		return fmt.Errorf("update item processing: %w", err)
+	}
+
+	lgr.Printf("[DEBUG] processed item %d: %s (score: %.1f, topics: %s)", item.ID, item.Title, classification.Score, strings.Join(classification.Topics, ", "))
+	return nil
+}
+
+// updateJobState moves the item's claimed job to the state, a failed update only makes the state stale
+func (fp *FeedProcessor) updateJobState(ctx context.Context, item *domain.Item, state domain.JobState) {
+	err := fp.retryFunc(ctx, func() error {
+		return fp.jobManager.UpdateJobState(ctx, item.ID, state, "")
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to update processing job of item %d to %s: %v", item.ID, state, err)
 	}
+}
 
-	// 2. Get context for classification
+// markMuted marks the item as muted if it matches a mute rule. returns true if the item was muted.
+func (fp *FeedProcessor) markMuted(ctx context.Context, item *domain.Item) bool {
+	if fp.muteRuleManager == nil {
+		return false
+	}
+	matcher, err := fp.muteRules(ctx)
+	if err != nil {
+		lgr.Printf("[WARN] failed to get mute rules, item %d processed: %v", item.ID, err)
+		return false
+	}
+	rule, ok := matcher.Match(item)
+	if !ok {
+		return false
+	}
+	err = fp.retryFunc(ctx, func() error {
+		return fp.itemManager.UpdateItemMuted(ctx, item.ID, rule.String())
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to mark item %d as muted after retries: %v", item.ID, err)
+		return false
+	}
+	lgr.Printf("[DEBUG] item %d (%s) muted by %s", item.ID, item.Title, rule.String())
+	return true
+}
+
+// muteRules returns the compiled mute rules, loaded once and kept until MuteRulesChanged
+func (fp *FeedProcessor) muteRules(ctx context.Context) (*mute.Matcher, error) {
+	fp.muteMu.Lock()
+	defer fp.muteMu.Unlock()
+	if fp.muteMatcher != nil {
+		return fp.muteMatcher, nil
+	}
+	rules, err := fp.muteRuleManager.GetMuteRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fp.muteMatcher = mute.NewMatcher(rules)
+	return fp.muteMatcher, nil
+}
+
+// MuteRulesChanged drops the compiled mute rules, the next item loads the current ones
+func (fp *FeedProcessor) MuteRulesChanged() {
+	fp.muteMu.Lock()
+	defer fp.muteMu.Unlock()
+	fp.muteMatcher = nil
+}
+
+// markDuplicate links the item to the recent article it nearly repeats, e.g. the same wire story
+// from another outlet. the fingerprint is stored first, so copies extracted while this one is classified
+// find it. returns true if the item was stored as a duplicate.
+func (fp *FeedProcessor) markDuplicate(ctx context.Context, item *domain.Item, extraction *domain.ExtractedContent) bool {
+	if extraction.Fingerprint == 0 {
+		return false // too short to fingerprint
+	}
+	err := fp.retryFunc(ctx, func() error {
+		return fp.itemManager.UpdateItemFingerprint(ctx, item.ID, extraction.Fingerprint)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to store fingerprint of item %d: %v", item.ID, err)
+	}
+	originalID, err := fp.itemManager.FindDuplicate(ctx, item.ID, extraction.Fingerprint, duplicateMaxDistance, duplicateWindow)
+	if err != nil {
+		lgr.Printf("[WARN] failed to look up duplicates of item %d: %v", item.ID, err)
+		return false
+	}
+	if originalID == 0 {
+		return false
+	}
+	err = fp.retryFunc(ctx, func() error {
+		return fp.itemManager.UpdateItemDuplicate(ctx, item.ID, originalID, extraction)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to mark item %d as duplicate after retries: %v", item.ID, err)
+		return false
+	}
+	lgr.Printf("[DEBUG] item %d (%s) is a duplicate of item %d", item.ID, item.Title, originalID)
+	return true
+}
+
+// extract returns the full content of the item. newsletters carry their content,
+// everything else is extracted from the item's URL with the credentials and proxy of the feed.
+// the extractor takes a slot of the extraction stage for each request, so a host making it wait
+// doesn't hold up items of other hosts. pages disallowed by the site's robots.txt use the feed's content.
+func (fp *FeedProcessor) extract(ctx context.Context, item *domain.Item) (*content.ExtractResult, error) {
+	if item.IsNewsletter() {
+		return &content.ExtractResult{Content: newsletter.PlainText(item.Content), RichContent: item.Content}, nil
+	}
+	ctx, auth := fp.itemAccess(ctx, item)
+	result, err := fp.extractor.Extract(content.WithSlots(ctx, fp.extraction), item.DisplayLink(), auth)
+	if errors.Is(err, content.ErrDisallowed) {
+		lgr.Printf("[DEBUG] item %d from %s disallowed by robots.txt, feed content used", item.ID, item.DisplayLink())
+		feedContent := item.Content
+		if feedContent == "" {
+			feedContent = item.Description
+		}
+		return &content.ExtractResult{Content: newsletter.PlainText(feedContent), RichContent: feedContent}, nil
+	}
+	return result, err
+}
+
+// classify classifies the item with the current context once the classification stage has a free slot
+func (fp *FeedProcessor) classify(ctx context.Context, itemID string, item *domain.Item) ([]domain.Classification, error) {
+	if err := fp.classification.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer fp.classification.Release()
+	return fp.classifier.ClassifyItems(ctx, fp.classifyRequest(ctx, itemID, []domain.Item{*item}))
+}
+
+// itemAccess returns the context with the proxy of the item's feed, used for the page, its robots.txt
+// and the fallback extraction API, and credentials of the feed valid for the item link, empty for public
+// feeds or links to other sites. a failed feed lookup is logged and the item extracted as configured globally.
+func (fp *FeedProcessor) itemAccess(ctx context.Context, item *domain.Item) (context.Context, domain.FeedAuth) {
+	f, err := fp.feedManager.GetFeed(ctx, item.FeedID)
+	if err != nil {
+		lgr.Printf("[WARN] failed to get feed %d of item %d: %v", item.FeedID, item.ID, err)
+		return ctx, domain.FeedAuth{}
+	}
+	return httpclient.WithProxy(ctx, f.Proxy), httpclient.AuthFor(f, item.DisplayLink())
+}
+
+// classifyRequest builds a classification request for the articles with the current context:
+// recent feedback, canonical topics, preference summary and topic preferences.
+// failures to get any part of the context are logged and the part is left empty.
+func (fp *FeedProcessor) classifyRequest(ctx context.Context, id string, articles []domain.Item) llm.ClassifyRequest {
 	feedbacks, err := fp.classificationManager.GetRecentFeedback(ctx, "", 50)
 	if err != nil {
-		lgr.Printf("[WARN] item %d: failed to get feedback examples: %v", item.ID, err)
+		lgr.Printf("[WARN] %s: failed to get feedback examples: %v", id, err)
 		feedbacks = []domain.FeedbackExample{}
 	}
 
 	topics, err := fp.classificationManager.GetTopics(ctx)
 	if err != nil {
-		lgr.Printf("[WARN] item %d: failed to get canonical topics: %v", item.ID, err)
+		lgr.Printf("[WARN] %s: failed to get canonical topics: %v", id, err)
 		topics = []string{}
 	}
 
 	preferenceSummary, err := fp.settingManager.GetSetting(ctx, "preference_summary")
 	if err != nil {
-		lgr.Printf("[WARN] item %d: failed to get preference summary: %v", item.ID, err)
+		lgr.Printf("[WARN] %s: failed to get preference summary: %v", id, err)
 		preferenceSummary = ""
 	}
 
 	// get topic preferences
-	preferredTopics, avoidedTopics := fp.getTopicPreferences(ctx, itemID)
-
-	// set extracted content for classification
-	item.Content = extracted.Content
+	preferredTopics, avoidedTopics := fp.getTopicPreferences(ctx, id)
 
-	// 3. Classify the item
-	req := llm.ClassifyRequest{
-		Articles:          []domain.Item{*item},
+	return llm.ClassifyRequest{
+		Articles:          articles,
 		Feedbacks:         feedbacks,
 		CanonicalTopics:   topics,
 		PreferenceSummary: preferenceSummary,
 		PreferredTopics:   preferredTopics,
 		AvoidedTopics:     avoidedTopics,
 	}
-	classifications, err := fp.classifier.ClassifyItems(ctx, req)
-	if err != nil {
-		lgr.Printf("[WARN] failed to classify item: %v", err)
-		return
-	}
-
-	if len(classifications) == 0 {
-		lgr.Printf("[WARN] no classification returned for item: %s", item.Title)
-		return
-	}
-
-	// 4. Update item with both extraction and classification results
-	extraction := &domain.ExtractedContent{
-		PlainText:   extracted.Content,
-		RichHTML:    extracted.RichContent,
-		ExtractedAt: time.Now(),
-	}
-
-	classification := classifications[0]
-	classification.ClassifiedAt = time.Now()
+}
 
-	err = fp.retryFunc(ctx, func() error {
-		return fp.itemManager.UpdateItemProcessed(ctx, item.ID, extraction, &classification)
-	})
+// UpdateDueFeeds fetches and updates enabled feeds whose next fetch time has passed.
+// It retrieves due feeds from the database, then processes each feed in parallel
+// (limited by maxWorkers). New items discovered during the update are stored in
+// the processing queue for extraction and classification.
+func (fp *FeedProcessor) UpdateDueFeeds(ctx context.Context) {
+	feeds, err := fp.feedManager.GetFeedsToFetch(ctx, dueFeedsBatchSize)
 	if err != nil {
-		lgr.Printf("[WARN] failed to update item %d processing after retries: %v", item.ID, err)
+		lgr.Printf("[ERROR] failed to get feeds to fetch: %v", err)
 		return
 	}
 
-	lgr.Printf("[DEBUG] processed item %d: %s (score: %.1f, topics: %s)", item.ID, item.Title, classification.Score, strings.Join(classification.Topics, ", "))
-}
-
-// UpdateAllFeeds fetches and updates all enabled feeds concurrently.
-// It retrieves all enabled feeds from the database, then processes each
-// feed in parallel (limited by maxWorkers). New items discovered during
-// the update are sent to the processCh channel for extraction and classification.
-func (fp *FeedProcessor) UpdateAllFeeds(ctx context.Context, processCh chan<- domain.Item) {
-	feeds, err := fp.feedManager.GetFeeds(ctx, true)
-	if err != nil {
-		lgr.Printf("[ERROR] failed to get enabled feeds: %v", err)
+	if len(feeds) == 0 {
+		lgr.Printf("[DEBUG] no feeds due for update")
 		return
 	}
 
-	lgr.Printf("[INFO] updating %d feeds", len(feeds))
+	lgr.Printf("[INFO] updating %d due feeds", len(feeds))
 
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(fp.maxWorkers)
 
 	for _, f := range feeds {
 		g.Go(func() error {
-			fp.UpdateFeed(ctx, &f, processCh)
+			fp.UpdateFeed(ctx, &f, domain.PriorityNormal)
 			return nil
 		})
 	}
@@ -227,23 +601,106 @@ func (fp *FeedProcessor) UpdateAllFeeds(ctx context.Context, processCh chan<- do
 	lgr.Printf("[INFO] feed update completed")
 }
 
-// UpdateFeed fetches and stores new items for a single feed
-func (fp *FeedProcessor) UpdateFeed(ctx context.Context, f *domain.Feed, processCh chan<- domain.Item) {
+// UpdateFeed fetches and stores new items for a single feed, queued for processing with the priority
+func (fp *FeedProcessor) UpdateFeed(ctx context.Context, f *domain.Feed, priority int) {
 	feedID := fp.getFeedIdentifier(f)
 	lgr.Printf("[DEBUG] updating feed: %s", feedID)
 
-	parsedFeed, err := fp.parser.Parse(ctx, f.URL)
+	start := time.Now()
+	parsedFeed, err := fp.parser.Fetch(ctx, f)
+	fetch := &domain.FeedFetch{FeedID: f.ID, FetchedAt: start.UTC(), Duration: time.Since(start)}
+	defer fp.recordFetch(ctx, f, fetch)
+	if err != nil {
+		fetch.Error = err.Error()
+		fetch.StatusCode = fetchStatus(err)
+	}
+
+	if errors.Is(err, domain.ErrFeedGone) {
+		fp.handleFeedGone(ctx, f)
+		return
+	}
 	if err != nil {
-		lgr.Printf("[WARN] failed to parse feed %s: %v", feedID, err)
-		if err := fp.feedManager.UpdateFeedError(ctx, f.ID, err.Error()); err != nil {
-			lgr.Printf("[WARN] failed to update error status for feed %s: %v", feedID, err)
+		fp.handleFeedError(ctx, f, err)
+		return
+	}
+
+	fetch.StatusCode, fetch.Bytes, fetch.Items = http.StatusOK, parsedFeed.Size, len(parsedFeed.Items)
+	if parsedFeed.NotModified {
+		fetch.StatusCode = http.StatusNotModified
+	}
+
+	movedTo := parsedFeed.MovedTo
+	if movedTo == f.URL {
+		movedTo = ""
+	}
+	switch {
+	case movedTo != "" && movedTo == f.MovedTo:
+		if !fp.handleFeedMoved(ctx, f, movedTo) {
+			return
 		}
+	case movedTo != f.MovedTo:
+		fp.recordFeedRedirect(ctx, f, movedTo)
+	}
+
+	// unchanged feed, nothing to parse. counts as a successful fetch
+	if parsedFeed.NotModified {
+		lgr.Printf("[DEBUG] feed %s not modified", feedID)
+		if f.LastSize > 0 {
+			err = fp.retryFunc(ctx, func() error {
+				return fp.feedManager.AddFeedBytesSaved(ctx, f.ID, f.LastSize)
+			})
+			if err != nil {
+				lgr.Printf("[WARN] failed to update bytes saved for feed %s: %v", feedID, err)
+			}
+		}
+		fp.scheduleNextFetch(ctx, f)
 		return
 	}
 
-	// store new items
+	// remember validators for the next conditional fetch
+	if parsedFeed.ETag != f.ETag || parsedFeed.LastModified != f.LastModified || parsedFeed.Size != f.LastSize {
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.UpdateFeedCache(ctx, f.ID, parsedFeed.ETag, parsedFeed.LastModified, parsedFeed.Size)
+		})
+		if err != nil {
+			lgr.Printf("[WARN] failed to update cache validators for feed %s: %v", feedID, err)
+		}
+	}
+
+	fp.subscribeWebSub(ctx, f, parsedFeed)
+
+	newCount := fp.storeNewItems(ctx, f, parsedFeed.Items, priority)
+	fetch.NewItems = newCount
+	if ctx.Err() != nil {
+		return
+	}
+
+	// adapt fetch interval to the feed's publishing frequency
+	if adaptive := fp.adaptiveInterval(parsedFeed.Items, f.AdaptiveInterval); adaptive > 0 && adaptive != f.AdaptiveInterval {
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.UpdateFeedAdaptiveInterval(ctx, f.ID, adaptive)
+		})
+		if err != nil {
+			lgr.Printf("[WARN] failed to update adaptive interval for feed %s: %v", feedID, err)
+		} else {
+			lgr.Printf("[DEBUG] adaptive interval for feed %s changed from %v to %v", feedID, f.AdaptiveInterval, adaptive)
+			f.AdaptiveInterval = adaptive
+		}
+	}
+
+	fp.scheduleNextFetch(ctx, f)
+
+	if newCount > 0 {
+		lgr.Printf("[INFO] added %d new items from feed %s", newCount, feedID)
+	}
+}
+
+// storeNewItems creates items not stored yet and queues them for extraction and classification
+// with the priority. returns the number of new items.
+func (fp *FeedProcessor) storeNewItems(ctx context.Context, f *domain.Feed, items []domain.ParsedItem, priority int) int {
+	feedID := fp.getFeedIdentifier(f)
 	newCount := 0
-	for _, item := range parsedFeed.Items {
+	for _, item := range items {
 		// check if item exists
 		exists, err := fp.itemManager.ItemExists(ctx, f.ID, item.GUID)
 		if err != nil {
@@ -254,8 +711,10 @@ func (fp *FeedProcessor) UpdateFeed(ctx context.Context, f *domain.Feed, process
 			continue
 		}
 
-		// check for duplicates
-		duplicateExists, err := fp.itemManager.ItemExistsByTitleOrURL(ctx, item.Title, item.Link)
+		// check for duplicates, the same article may be linked with tracking parameters or through a wrapper
+		resolvedLink := fp.resolveLink(httpclient.WithProxy(ctx, f.Proxy), item.Link)
+		canonicalLink := canonical.Clean(resolvedLink)
+		duplicateExists, err := fp.itemManager.ItemExistsByTitleOrURL(ctx, item.Title, canonicalLink)
 		if err != nil {
 			lgr.Printf("[WARN] failed to check duplicate item in feed %s (title: %s): %v", feedID, item.Title, err)
 			continue
@@ -266,14 +725,19 @@ func (fp *FeedProcessor) UpdateFeed(ctx context.Context, f *domain.Feed, process
 		}
 
 		domainItem := domain.Item{
-			FeedID:      f.ID,
-			GUID:        item.GUID,
-			Title:       item.Title,
-			Link:        item.Link,
-			Description: item.Description,
-			Content:     item.Content,
-			Author:      item.Author,
-			Published:   item.Published,
+			FeedID:        f.ID,
+			GUID:          item.GUID,
+			Title:         item.Title,
+			Link:          item.Link,
+			CanonicalLink: canonicalLink,
+			ResolvedLink:  resolvedLink,
+			Description:   item.Description,
+			Content:       item.Content,
+			Author:        item.Author,
+			Published:     item.Published,
+			Image:         item.Image,
+			Enclosures:    item.Enclosures,
+			Categories:    item.Categories,
 		}
 
 		// retry on SQLite lock errors
@@ -286,27 +750,349 @@ func (fp *FeedProcessor) UpdateFeed(ctx context.Context, f *domain.Feed, process
 		}
 
 		newCount++
+		fp.enqueue(ctx, &domainItem, priority)
+	}
+	return newCount
+}
 
-		// send to processing channel
-		select {
-		case processCh <- domainItem:
-		case <-ctx.Done():
-			return
+// resolveLink returns the article the link points to, with feed proxies and link shorteners
+// followed if there is a resolver and only the wrappers carrying the target in the link unwrapped otherwise
+func (fp *FeedProcessor) resolveLink(ctx context.Context, link string) string {
+	if fp.linkResolver == nil {
+		return canonical.Unwrap(link)
+	}
+	return fp.linkResolver.Resolve(ctx, link)
+}
+
+// handleFeedError records a failed fetch. the repository pushes next_fetch back exponentially
+// based on the error count, and after maxFeedErrors consecutive failures the feed is disabled
+// with the reason recorded, so a dead feed stops being retried and spamming the log.
+func (fp *FeedProcessor) handleFeedError(ctx context.Context, f *domain.Feed, fetchErr error) {
+	feedID := fp.getFeedIdentifier(f)
+	errCount := f.ErrorCount + 1
+	lgr.Printf("[WARN] failed to parse feed %s (error %d): %v", feedID, errCount, fetchErr)
+
+	if err := fp.feedManager.UpdateFeedError(ctx, f.ID, fetchErr.Error()); err != nil {
+		lgr.Printf("[WARN] failed to update error status for feed %s: %v", feedID, err)
+	}
+
+	if fp.maxFeedErrors <= 0 || errCount < fp.maxFeedErrors {
+		return
+	}
+
+	reason := fmt.Sprintf("disabled after %d consecutive errors, last error: %v", errCount, fetchErr)
+	err := fp.retryFunc(ctx, func() error {
+		return fp.feedManager.DisableFeed(ctx, f.ID, reason)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to disable broken feed %s: %v", feedID, err)
+		return
+	}
+	lgr.Printf("[WARN] feed %s disabled after %d consecutive errors", feedID, errCount)
+	fp.dropWebSub(ctx, f)
+}
+
+// handleFeedGone disables a feed which responded 410 Gone, there is no point to retry it
+func (fp *FeedProcessor) handleFeedGone(ctx context.Context, f *domain.Feed) {
+	feedID := fp.getFeedIdentifier(f)
+	reason := "feed is gone, server responded 410"
+	err := fp.retryFunc(ctx, func() error {
+		return fp.feedManager.DisableFeed(ctx, f.ID, reason)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to disable gone feed %s: %v", feedID, err)
+		return
+	}
+	lgr.Printf("[WARN] feed %s is gone, disabled", feedID)
+	fp.dropWebSub(ctx, f)
+	fp.addFeedEvent(ctx, f, domain.FeedEventGone, "disabled, "+f.URL+" responded 410 Gone")
+}
+
+// recordFeedRedirect remembers the target of a permanent redirect, or that the redirect is gone.
+// a single redirect may come from a misconfigured server or a captive portal, so the URL is
+// updated only if the next fetch is redirected to the same target.
+func (fp *FeedProcessor) recordFeedRedirect(ctx context.Context, f *domain.Feed, movedTo string) {
+	feedID := fp.getFeedIdentifier(f)
+	err := fp.retryFunc(ctx, func() error {
+		return fp.feedManager.UpdateFeedMovedTo(ctx, f.ID, movedTo)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to record redirect of feed %s: %v", feedID, err)
+		return
+	}
+	f.MovedTo = movedTo
+	if movedTo != "" {
+		lgr.Printf("[INFO] feed %s redirected permanently to %s, waiting for the next fetch to confirm", feedID, movedTo)
+	}
+}
+
+// handleFeedMoved updates the URL of a feed reached only through permanent redirects. the move is
+// confirmed by the same redirect seen on two fetches in a row. a feed moved to the URL of another
+// subscribed feed is disabled as a duplicate. returns false if the feed shouldn't be processed further.
+func (fp *FeedProcessor) handleFeedMoved(ctx context.Context, f *domain.Feed, newURL string) bool {
+	feedID := fp.getFeedIdentifier(f)
+	existing, err := fp.feedManager.GetFeedByURL(ctx, newURL)
+	if err != nil {
+		lgr.Printf("[WARN] failed to check moved feed %s for duplicates: %v", feedID, err)
+		return true // keep the old URL, the redirect is followed again on the next fetch
+	}
+
+	if existing != nil && existing.ID != f.ID {
+		reason := fmt.Sprintf("moved to %s, already subscribed as %q", newURL, existing.Title)
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.DisableFeed(ctx, f.ID, reason)
+		})
+		if err != nil {
+			lgr.Printf("[WARN] failed to disable moved duplicate feed %s: %v", feedID, err)
+			return true
 		}
+		lgr.Printf("[INFO] feed %s %s, disabled", feedID, reason)
+		fp.dropWebSub(ctx, f)
+		fp.addFeedEvent(ctx, f, domain.FeedEventMoved, reason+", disabled as a duplicate")
+		return false
 	}
 
-	// update last fetched timestamp
-	nextFetch := time.Now().Add(f.FetchInterval)
 	err = fp.retryFunc(ctx, func() error {
+		return fp.feedManager.UpdateFeedURL(ctx, f.ID, newURL)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to update URL of moved feed %s: %v", feedID, err)
+		return true
+	}
+	lgr.Printf("[INFO] feed %s moved permanently to %s", feedID, newURL)
+	fp.addFeedEvent(ctx, f, domain.FeedEventMoved, fmt.Sprintf("moved permanently from %s to %s", f.URL, newURL))
+	f.URL, f.MovedTo = newURL, ""
+	return true
+}
+
+// addFeedEvent records the event in the feed's history, failures are only logged
+func (fp *FeedProcessor) addFeedEvent(ctx context.Context, f *domain.Feed, eventType domain.FeedEventType, msg string) {
+	err := fp.retryFunc(ctx, func() error {
+		return fp.feedManager.AddFeedEvent(ctx, &domain.FeedEvent{FeedID: f.ID, Type: eventType, Message: msg})
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to record %s event of feed %s: %v", eventType, fp.getFeedIdentifier(f), err)
+	}
+}
+
+// recordFetch adds the fetch attempt to the feed's fetch log, failures are only logged
+func (fp *FeedProcessor) recordFetch(ctx context.Context, f *domain.Feed, fetch *domain.FeedFetch) {
+	if ctx.Err() != nil {
+		return // interrupted fetch, nothing meaningful to record
+	}
+	err := fp.retryFunc(ctx, func() error { return fp.feedManager.AddFeedFetch(ctx, fetch) })
+	if err != nil {
+		lgr.Printf("[WARN] failed to record fetch of feed %s: %v", fp.getFeedIdentifier(f), err)
+	}
+}
+
+// fetchStatus returns the HTTP status of a failed fetch, zero if the server didn't respond
+func fetchStatus(err error) int {
+	if errors.Is(err, domain.ErrFeedGone) {
+		return http.StatusGone
+	}
+	var statusErr *domain.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+// scheduleNextFetch updates last fetched timestamp and schedules the next fetch using the effective interval.
+// feeds with an active push subscription are polled only as a safety net, at most once per safety interval.
+func (fp *FeedProcessor) scheduleNextFetch(ctx context.Context, f *domain.Feed) {
+	nextFetch := time.Now().UTC().Add(fp.pollInterval(f))
+	err := fp.retryFunc(ctx, func() error {
 		return fp.feedManager.UpdateFeedFetched(ctx, f.ID, nextFetch)
 	})
 	if err != nil {
-		lgr.Printf("[WARN] failed to update last fetched for feed %s after retries: %v", feedID, err)
+		lgr.Printf("[WARN] failed to update last fetched for feed %s after retries: %v", fp.getFeedIdentifier(f), err)
 	}
+}
 
-	if newCount > 0 {
-		lgr.Printf("[INFO] added %d new items from feed %s", newCount, feedID)
+// pollInterval returns the interval to the next fetch of the feed
+func (fp *FeedProcessor) pollInterval(f *domain.Feed) time.Duration {
+	interval := f.EffectiveInterval()
+	if f.WebSubActive(time.Now()) {
+		interval = max(interval, fp.webSubSafetyInterval)
+	}
+	return interval
+}
+
+// subscribeWebSub requests a push subscription if the feed advertises a hub and there is no
+// active subscription for it, or the lease expires before the feed is polled twice more.
+// the secret is stored before the request because the hub verifies the intent right away.
+// a subscription to a hub or topic the feed no longer advertises is canceled.
+func (fp *FeedProcessor) subscribeWebSub(ctx context.Context, f *domain.Feed, parsed *domain.ParsedFeed) {
+	if fp.webSubscriber == nil {
+		return
+	}
+	if parsed.HubURL == "" {
+		fp.dropWebSub(ctx, f)
+		return
+	}
+	topic := parsed.SelfURL
+	if topic == "" {
+		topic = f.URL
+	}
+
+	renewBefore := time.Now().Add(2 * fp.pollInterval(f))
+	subscribed := f.HubURL == parsed.HubURL && f.WebSubTopic == topic && f.WebSubSecret != ""
+	if subscribed && f.WebSubExpires != nil && f.WebSubExpires.After(renewBefore) {
+		return
+	}
+
+	if f.WebSubSecret != "" && !subscribed {
+		fp.unsubscribeWebSub(ctx, f, f.HubURL, f.WebSubTopic) // the new subscription replaces the stored state
+	}
+
+	feedID := fp.getFeedIdentifier(f)
+	secret, err := websub.NewSecret()
+	if err != nil {
+		lgr.Printf("[WARN] failed to subscribe feed %s to hub %s: %v", feedID, parsed.HubURL, err)
+		return
+	}
+	err = fp.retryFunc(ctx, func() error {
+		return fp.feedManager.UpdateFeedWebSub(ctx, f.ID, parsed.HubURL, topic, secret)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to store websub subscription for feed %s: %v", feedID, err)
+		return
+	}
+	f.HubURL, f.WebSubTopic, f.WebSubSecret, f.WebSubExpires = parsed.HubURL, topic, secret, nil
+
+	if err := fp.webSubscriber.Subscribe(ctx, parsed.HubURL, topic, fp.webSubCallback(f.ID), secret, fp.webSubLease); err != nil {
+		lgr.Printf("[WARN] failed to subscribe feed %s to hub %s: %v", feedID, parsed.HubURL, err)
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.ClearFeedWebSub(ctx, f.ID)
+		})
+		if err != nil {
+			lgr.Printf("[WARN] failed to clear websub subscription for feed %s: %v", feedID, err)
+		}
+		f.WebSubTopic, f.WebSubSecret = "", ""
+		return
 	}
+	lgr.Printf("[INFO] requested websub subscription for feed %s at hub %s", feedID, parsed.HubURL)
+}
+
+// UnsubscribeWebSub cancels the push subscription of a feed about to be deleted or disabled, if it has one
+func (fp *FeedProcessor) UnsubscribeWebSub(ctx context.Context, feedID int64) error {
+	f, err := fp.feedManager.GetFeed(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("get feed %d: %w", feedID, err)
+	}
+	fp.dropWebSub(ctx, f)
+	return nil
+}
+
+// dropWebSub asks the hub to stop pushing the feed and clears the subscription state.
+// does nothing if the feed has no subscription, a failed request is only logged as the lease runs out anyway.
+func (fp *FeedProcessor) dropWebSub(ctx context.Context, f *domain.Feed) {
+	if f.WebSubSecret == "" {
+		return
+	}
+	fp.unsubscribeWebSub(ctx, f, f.HubURL, f.WebSubTopic)
+	err := fp.retryFunc(ctx, func() error {
+		return fp.feedManager.ClearFeedWebSub(ctx, f.ID)
+	})
+	if err != nil {
+		lgr.Printf("[WARN] failed to clear websub subscription for feed %s: %v", fp.getFeedIdentifier(f), err)
+		return
+	}
+	f.WebSubTopic, f.WebSubSecret, f.WebSubExpires = "", "", nil
+}
+
+// unsubscribeWebSub sends the unsubscribe request for the topic and remembers it,
+// so the hub's intent verification is confirmed by VerifyWebSub
+func (fp *FeedProcessor) unsubscribeWebSub(ctx context.Context, f *domain.Feed, hubURL, topic string) {
+	if fp.webSubscriber == nil || hubURL == "" || topic == "" {
+		return
+	}
+	feedID := fp.getFeedIdentifier(f)
+	key := webSubKey(f.ID, topic)
+	now := time.Now()
+	fp.webSubMu.Lock()
+	for k, requested := range fp.webSubUnsubs {
+		if now.Sub(requested) > webSubUnsubscribeWait {
+			delete(fp.webSubUnsubs, k)
+		}
+	}
+	fp.webSubUnsubs[key] = now
+	fp.webSubMu.Unlock()
+
+	if err := fp.webSubscriber.Unsubscribe(ctx, hubURL, topic, fp.webSubCallback(f.ID)); err != nil {
+		lgr.Printf("[WARN] failed to unsubscribe feed %s from hub %s: %v", feedID, hubURL, err)
+		fp.webSubMu.Lock()
+		delete(fp.webSubUnsubs, key)
+		fp.webSubMu.Unlock()
+		return
+	}
+	lgr.Printf("[INFO] requested websub unsubscription for feed %s at hub %s", feedID, hubURL)
+}
+
+// unsubscribeRequested reports whether an unsubscribe request for the feed's topic awaits verification,
+// the request is forgotten once verified
+func (fp *FeedProcessor) unsubscribeRequested(feedID int64, topic string) bool {
+	fp.webSubMu.Lock()
+	defer fp.webSubMu.Unlock()
+	key := webSubKey(feedID, topic)
+	requested, ok := fp.webSubUnsubs[key]
+	delete(fp.webSubUnsubs, key)
+	return ok && time.Since(requested) <= webSubUnsubscribeWait
+}
+
+// webSubCallback returns the callback URL of the feed, hubs call it to verify intents and push content
+func (fp *FeedProcessor) webSubCallback(feedID int64) string {
+	return fmt.Sprintf("%s/websub/%d", strings.TrimSuffix(fp.webSubCallbackURL, "/"), feedID)
+}
+
+func webSubKey(feedID int64, topic string) string {
+	return fmt.Sprintf("%d %s", feedID, topic)
+}
+
+// adaptiveInterval estimates how often a feed should be polled based on the gaps between
+// its most recent items. the median gap is smoothed with the previous estimate to avoid
+// jumping on a single burst or pause, then clamped to [minFetchInterval, maxFetchInterval]
+// if those bounds are set. returns 0 if there are not enough dated items to make an estimate,
+// items stamped with the fetch time for lack of a date don't count.
+func (fp *FeedProcessor) adaptiveInterval(items []domain.ParsedItem, prev time.Duration) time.Duration {
+	published := make([]time.Time, 0, len(items))
+	for _, item := range items {
+		if !item.Published.IsZero() && !item.Undated {
+			published = append(published, item.Published)
+		}
+	}
+	if len(published) < adaptiveMinSampleSize {
+		return 0
+	}
+
+	// newest first, keep only the most recent sample
+	slices.SortFunc(published, func(a, b time.Time) int { return b.Compare(a) })
+	if len(published) > adaptiveSampleSize {
+		published = published[:adaptiveSampleSize]
+	}
+
+	gaps := make([]time.Duration, 0, len(published)-1)
+	for i := 1; i < len(published); i++ {
+		gaps = append(gaps, published[i-1].Sub(published[i]))
+	}
+	slices.Sort(gaps)
+	median := gaps[len(gaps)/2]
+	if len(gaps)%2 == 0 {
+		median = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+	}
+
+	interval := median
+	if prev > 0 {
+		interval = (prev + median) / 2
+	}
+	if fp.minFetchInterval > 0 {
+		interval = max(interval, fp.minFetchInterval)
+	}
+	if fp.maxFetchInterval > 0 {
+		interval = min(interval, fp.maxFetchInterval)
+	}
+	return interval.Round(time.Minute)
 }
 
 // UpdateFeedNow triggers immediate update of a specific feed
@@ -316,21 +1102,203 @@ func (fp *FeedProcessor) UpdateFeedNow(ctx context.Context, feedID int64) error
 	if err != nil {
 		return fmt.Errorf("get feed %d: %w", feedID, err)
 	}
+	if feed.IsNewsletter() {
+		return fmt.Errorf("feed %d collects newsletters and can't be fetched", feedID)
+	}
+
+	// new items are processed ahead of the backlog
+	fp.UpdateFeed(ctx, feed, domain.PriorityManual)
+	return nil
+}
+
+// DiscoverFeeds finds feeds for the given URL, nothing is stored
+func (fp *FeedProcessor) DiscoverFeeds(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+	candidates, err := fp.parser.Discover(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("discover feeds for %s: %w", pageURL, err)
+	}
+	lgr.Printf("[DEBUG] discovered %d feeds for %s", len(candidates), pageURL)
+	return candidates, nil
+}
+
+// ScrapePage extracts items from a web page with the selectors, used to test selectors
+// of a web page feed before saving it. nothing is stored.
+func (fp *FeedProcessor) ScrapePage(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	parsed, err := fp.parser.Scrape(ctx, pageURL, sel)
+	if err != nil {
+		return nil, fmt.Errorf("scrape %s: %w", pageURL, err)
+	}
+	lgr.Printf("[DEBUG] scraped %d items from %s", len(parsed.Items), pageURL)
+	return parsed, nil
+}
 
-	processCh := make(chan domain.Item, defaultUpdateFeedBuffer)
-	defer close(processCh)
+// PreviewFeed fetches the feed and classifies a sample of its latest items with the current
+// preferences, using content from the feed itself. nothing is stored, neither the feed nor the items.
+func (fp *FeedProcessor) PreviewFeed(ctx context.Context, feedURL string) (*domain.FeedPreview, error) {
+	parsed, err := fp.parser.Fetch(ctx, &domain.Feed{URL: feedURL})
+	if err != nil {
+		return nil, fmt.Errorf("fetch feed %s: %w", feedURL, err)
+	}
 
-	go func() {
-		for item := range processCh {
-			fp.ProcessItem(ctx, &item)
+	preview := &domain.FeedPreview{URL: feedURL, Title: parsed.Title}
+	parsedItems := parsed.Items
+	if len(parsedItems) > previewItemsLimit {
+		parsedItems = parsedItems[:previewItemsLimit]
+	}
+
+	for _, pi := range parsedItems {
+		preview.Items = append(preview.Items, domain.ClassifiedItem{
+			Item: &domain.Item{
+				GUID:        pi.GUID,
+				Title:       pi.Title,
+				Link:        pi.Link,
+				Description: pi.Description,
+				Content:     pi.Content,
+				Author:      pi.Author,
+				Published:   pi.Published,
+				Image:       pi.Image,
+				Enclosures:  pi.Enclosures,
+				Categories:  pi.Categories,
+			},
+			FeedName: parsed.Title,
+			FeedURL:  feedURL,
+		})
+	}
+	if len(preview.Items) == 0 {
+		return preview, nil
+	}
+
+	// classify a sample of the latest items in a single request
+	sample := make([]domain.Item, 0, previewSampleSize)
+	for i := 0; i < len(preview.Items) && i < previewSampleSize; i++ {
+		sample = append(sample, *preview.Items[i].Item)
+	}
+	classifications, err := fp.classifier.ClassifyItems(ctx, fp.classifyRequest(ctx, feedURL, sample))
+	if err != nil {
+		return nil, fmt.Errorf("classify preview items: %w", err)
+	}
+
+	byGUID := make(map[string]domain.Classification, len(classifications))
+	for _, c := range classifications {
+		byGUID[c.GUID] = c
+	}
+	for i := range preview.Items {
+		if c, ok := byGUID[preview.Items[i].GUID]; ok {
+			preview.Items[i].Classification = &c
 		}
-	}()
+	}
 
-	fp.UpdateFeed(ctx, feed, processCh)
-	return nil
+	lgr.Printf("[DEBUG] previewed feed %s: %d items, %d classified, average score %.1f",
+		feedURL, len(preview.Items), preview.ClassifiedCount(), preview.AverageScore())
+	return preview, nil
+}
+
+// VerifyWebSub handles intent verification from a hub for the feed's subscription.
+// subscribe requests are confirmed only for the topic we asked for, with the lease starting now.
+// unsubscribe requests are confirmed only if we sent one, the feed may be deleted by then.
+// denied subscriptions are cleared and acknowledged, anything else is rejected.
+func (fp *FeedProcessor) VerifyWebSub(ctx context.Context, feedID int64, mode, topic string, lease time.Duration) error {
+	if mode == "unsubscribe" {
+		if !fp.unsubscribeRequested(feedID, topic) {
+			return fmt.Errorf("no pending unsubscription for topic %q of feed %d", topic, feedID)
+		}
+		lgr.Printf("[INFO] websub unsubscription for feed %d confirmed", feedID)
+		return nil
+	}
+
+	f, err := fp.feedManager.GetFeed(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("get feed %d: %w", feedID, err)
+	}
+
+	switch mode {
+	case "subscribe":
+		if f.WebSubSecret == "" || topic != f.WebSubTopic {
+			return fmt.Errorf("no pending subscription for topic %q of feed %d", topic, feedID)
+		}
+		if lease <= 0 {
+			lease = fp.webSubLease
+		}
+		expires := time.Now().UTC().Add(lease)
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.ConfirmFeedWebSub(ctx, feedID, expires)
+		})
+		if err != nil {
+			return fmt.Errorf("confirm websub subscription for feed %d: %w", feedID, err)
+		}
+		lgr.Printf("[INFO] websub subscription for feed %s confirmed until %s", fp.getFeedIdentifier(f), expires.Format(time.RFC3339))
+		return nil
+	case "denied":
+		err = fp.retryFunc(ctx, func() error {
+			return fp.feedManager.ClearFeedWebSub(ctx, feedID)
+		})
+		if err != nil {
+			return fmt.Errorf("clear websub subscription for feed %d: %w", feedID, err)
+		}
+		lgr.Printf("[WARN] hub denied websub subscription for feed %s", fp.getFeedIdentifier(f))
+		return nil
+	default:
+		return fmt.Errorf("unexpected websub mode %q for feed %d", mode, feedID)
+	}
+}
+
+// webSubPush is content pushed by a hub for the feed, waiting to be stored
+type webSubPush struct {
+	feed  *domain.Feed
+	items []domain.ParsedItem
 }
 
-// ExtractContentNow triggers immediate content extraction for an item
+// HandleWebSubPush checks and parses content pushed by the hub, its new items are stored by WebSubWorker,
+// so the hub gets its response without waiting for link resolution and storage. content with a missing
+// or wrong signature is ignored without an error, as the spec requires. returns domain.ErrWebSubBusy if
+// too many pushes wait to be stored.
+func (fp *FeedProcessor) HandleWebSubPush(ctx context.Context, feedID int64, body []byte, signature string) error {
+	f, err := fp.feedManager.GetFeed(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("get feed %d: %w", feedID, err)
+	}
+	feedName := fp.getFeedIdentifier(f)
+
+	if !websub.VerifySignature(f.WebSubSecret, body, signature) {
+		lgr.Printf("[WARN] ignored websub push for feed %s with invalid signature", feedName)
+		return nil
+	}
+	if !f.Enabled {
+		lgr.Printf("[DEBUG] ignored websub push for disabled feed %s", feedName)
+		return nil
+	}
+
+	parsed, err := fp.parser.ParseContent(body)
+	if err != nil {
+		return fmt.Errorf("parse pushed content for feed %d: %w", feedID, err)
+	}
+
+	select {
+	case fp.webSubPushes <- webSubPush{feed: f, items: parsed.Items}:
+		return nil
+	default:
+		return fmt.Errorf("push for feed %s: %w", feedName, domain.ErrWebSubBusy)
+	}
+}
+
+// WebSubWorker stores new items of content pushed by hubs and queues them for processing, as polled
+// items are. pushes still waiting when the context is canceled are dropped, the next poll of their
+// feeds picks the items up. This method blocks until the context is canceled.
+func (fp *FeedProcessor) WebSubWorker(ctx context.Context) {
+	for {
+		select {
+		case push := <-fp.webSubPushes:
+			newCount := fp.storeNewItems(ctx, push.feed, push.items, domain.PriorityNormal)
+			lgr.Printf("[INFO] websub push for feed %s: %d items, %d new", fp.getFeedIdentifier(push.feed), len(push.items), newCount)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ExtractContentNow triggers immediate content extraction for an item. its job is claimed ahead of the
+// queue, a failed attempt is retried by the processing worker like any other.
+// an item already being processed by a worker is left to it.
 func (fp *FeedProcessor) ExtractContentNow(ctx context.Context, itemID int64) error {
 	lgr.Printf("[DEBUG] triggering immediate content extraction for item %d", itemID)
 	item, err := fp.itemManager.GetItem(ctx, itemID)
@@ -338,7 +1306,15 @@ func (fp *FeedProcessor) ExtractContentNow(ctx context.Context, itemID int64) er
 		return fmt.Errorf("get item %d: %w", itemID, err)
 	}
 
-	fp.ProcessItem(ctx, item)
+	job, err := fp.jobManager.ClaimItemJob(ctx, itemID, fp.jobTimeout)
+	if err != nil {
+		return fmt.Errorf("claim job of item %d: %w", itemID, err)
+	}
+	if job == nil {
+		lgr.Printf("[DEBUG] item %d is already being processed", itemID)
+		return nil
+	}
+	fp.finishJob(ctx, job, fp.ProcessItem(ctx, item))
 	return nil
 }
 
@@ -376,3 +1352,8 @@ func (fp *FeedProcessor) getItemIdentifier(item *domain.Item) string {
 	}
 	return item.Link
 }
+
+// PipelineStats returns the number of items running and waiting in each stage of the processing pipeline
+func (fp *FeedProcessor) PipelineStats() domain.PipelineStats {
+	return domain.PipelineStats{Extraction: fp.extraction.stats(), Classification: fp.classification.stats()}
+}
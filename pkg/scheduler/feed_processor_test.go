@@ -2,33 +2,49 @@ package scheduler
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/umputun/newscope/pkg/canonical"
 	"github.com/umputun/newscope/pkg/content"
 	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/feed"
+	"github.com/umputun/newscope/pkg/httpclient"
 	"github.com/umputun/newscope/pkg/llm"
 	"github.com/umputun/newscope/pkg/scheduler/mocks"
 )
 
 func TestFeedProcessor_UpdateFeedNow(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
 	parser := &mocks.ParserMock{}
 	extractor := &mocks.ExtractorMock{}
 	classifier := &mocks.ClassifierMock{}
+	jobManager := newJobManager()
 
 	retryFunc := func(ctx context.Context, op func() error) error {
 		return op()
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:            jobManager,
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -66,8 +82,8 @@ func TestFeedProcessor_UpdateFeedNow(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
-		assert.Equal(t, testFeed.URL, url)
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+		assert.Equal(t, testFeed.URL, f.URL)
 		return testParsedFeed, nil
 	}
 
@@ -98,58 +114,21 @@ func TestFeedProcessor_UpdateFeedNow(t *testing.T) {
 		return nil
 	}
 
-	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
-		return &content.ExtractResult{
-			Content:     "extracted content",
-			RichContent: "<p>rich content</p>",
-		}, nil
-	}
-
-	classificationManager.GetRecentFeedbackFunc = func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
-		return []domain.FeedbackExample{}, nil
-	}
-
-	classificationManager.GetTopicsFunc = func(ctx context.Context) ([]string, error) {
-		return []string{"tech"}, nil
-	}
-
-	settingManager.GetSettingFunc = func(ctx context.Context, key string) (string, error) {
-		return "", nil
-	}
-
-	classifier.ClassifyItemsFunc = func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
-		return []domain.Classification{{
-			GUID:        req.Articles[0].GUID,
-			Score:       7.5,
-			Explanation: "test classification",
-			Topics:      []string{"tech"},
-			Summary:     "test summary",
-		}}, nil
-	}
-
-	itemManager.UpdateItemProcessedFunc = func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error {
-		return nil
-	}
-
-	itemManager.UpdateItemExtractionFunc = func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error {
-		return nil
-	}
-
 	// execute
 	err := fp.UpdateFeedNow(context.Background(), 1)
 
-	// wait for background processing to complete
-	time.Sleep(100 * time.Millisecond)
-
 	// verify
 	require.NoError(t, err)
 	assert.Len(t, feedManager.GetFeedCalls(), 1)
-	assert.Len(t, parser.ParseCalls(), 1)
+	assert.Len(t, parser.FetchCalls(), 1)
 	assert.Len(t, itemManager.ItemExistsCalls(), 1)
 	assert.Len(t, itemManager.ItemExistsByTitleOrURLCalls(), 1)
 	assert.Len(t, itemManager.CreateItemCalls(), 1)
 	assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+	require.Len(t, jobManager.EnqueueJobCalls(), 1)
+	assert.Equal(t, int64(123), jobManager.EnqueueJobCalls()[0].ItemID)
+	assert.Equal(t, domain.PriorityManual, jobManager.EnqueueJobCalls()[0].Priority, "queued ahead of the backlog")
+	assert.Empty(t, extractor.ExtractCalls(), "processed by the processing worker")
 }
 
 func TestFeedProcessor_ExtractContentNow(t *testing.T) {
@@ -158,13 +137,21 @@ func TestFeedProcessor_ExtractContentNow(t *testing.T) {
 	settingManager := &mocks.SettingManagerMock{}
 	extractor := &mocks.ExtractorMock{}
 	classifier := &mocks.ClassifierMock{}
+	jobManager := newJobManager()
 
 	retryFunc := func(ctx context.Context, op func() error) error {
 		return op()
 	}
 
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+			return &domain.Feed{ID: id, URL: "https://example.com/feed.xml"}, nil
+		},
+	}
+
 	fp := NewFeedProcessor(FeedProcessorConfig{
-		FeedManager:           &mocks.FeedManagerMock{},
+		JobManager:            jobManager,
+		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
 		SettingManager:        settingManager,
@@ -172,14 +159,17 @@ func TestFeedProcessor_ExtractContentNow(t *testing.T) {
 		Extractor:             extractor,
 		Classifier:            classifier,
 		MaxWorkers:            1,
+		JobTimeout:            time.Minute,
+		MaxProcessAttempts:    3,
 		RetryFunc:             retryFunc,
 	})
 
 	testItem := &domain.Item{
-		ID:    1,
-		GUID:  "test-guid",
-		Link:  "https://example.com/item1",
-		Title: "Test Item",
+		ID:     1,
+		FeedID: 3,
+		GUID:   "test-guid",
+		Link:   "https://example.com/item1",
+		Title:  "Test Item",
 	}
 
 	extractResult := &content.ExtractResult{
@@ -201,8 +191,9 @@ func TestFeedProcessor_ExtractContentNow(t *testing.T) {
 		return testItem, nil
 	}
 
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		assert.Equal(t, testItem.Link, url)
+		assert.True(t, auth.IsZero(), "public feed")
 		return extractResult, nil
 	}
 
@@ -258,16 +249,53 @@ func TestFeedProcessor_ExtractContentNow(t *testing.T) {
 	// verify
 	require.NoError(t, err)
 	assert.Len(t, itemManager.GetItemCalls(), 1)
+	require.Len(t, feedManager.GetFeedCalls(), 1)
+	assert.Equal(t, int64(3), feedManager.GetFeedCalls()[0].ID)
 	assert.Len(t, extractor.ExtractCalls(), 1)
 	assert.Len(t, classificationManager.GetRecentFeedbackCalls(), 1)
 	assert.Len(t, classificationManager.GetTopicsCalls(), 1)
 	assert.Len(t, settingManager.GetSettingCalls(), 3)
 	assert.Len(t, classifier.ClassifyItemsCalls(), 1)
 	assert.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	require.Len(t, jobManager.ClaimItemJobCalls(), 1, "claimed ahead of the queue")
+	assert.Equal(t, time.Minute, jobManager.ClaimItemJobCalls()[0].Timeout)
+	states := jobManager.UpdateJobStateCalls()
+	require.Len(t, states, 2)
+	assert.Equal(t, domain.JobClassifying, states[0].State)
+	assert.Equal(t, domain.JobDone, states[1].State)
+}
+
+func TestFeedProcessor_ExtractContentNow_InProgress(t *testing.T) {
+	itemManager := &mocks.ItemManagerMock{
+		GetItemFunc: func(ctx context.Context, id int64) (*domain.Item, error) {
+			return &domain.Item{ID: id, FeedID: 3, Link: "https://example.com/item1"}, nil
+		},
+	}
+	extractor := &mocks.ExtractorMock{}
+	jobManager := newJobManager()
+	jobManager.ClaimItemJobFunc = func(ctx context.Context, itemID int64, timeout time.Duration) (*domain.ProcessJob, error) {
+		return nil, nil // locked by a worker
+	}
+
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:  jobManager,
+		ItemManager: itemManager,
+		Extractor:   extractor,
+		MaxWorkers:  1,
+		JobTimeout:  time.Minute,
+		RetryFunc:   func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	require.NoError(t, fp.ExtractContentNow(context.Background(), 1))
+	assert.Len(t, jobManager.ClaimItemJobCalls(), 1)
+	assert.Empty(t, extractor.ExtractCalls(), "left to the worker")
+	assert.Empty(t, jobManager.UpdateJobStateCalls())
 }
 
 func TestFeedProcessor_UpdateFeed_ParseError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	parser := &mocks.ParserMock{}
 
 	retryFunc := func(ctx context.Context, op func() error) error {
@@ -275,6 +303,7 @@ func TestFeedProcessor_UpdateFeed_ParseError(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           &mocks.ItemManagerMock{},
 		ClassificationManager: &mocks.ClassificationManagerMock{},
@@ -297,7 +326,7 @@ func TestFeedProcessor_UpdateFeed_ParseError(t *testing.T) {
 	}
 
 	// setup parser to fail
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return nil, assert.AnError
 	}
 
@@ -313,10 +342,386 @@ func TestFeedProcessor_UpdateFeed_ParseError(t *testing.T) {
 
 	// verify - should not return error but should call UpdateFeedError
 	require.NoError(t, err)
-	assert.Len(t, parser.ParseCalls(), 1)
+	assert.Len(t, parser.FetchCalls(), 1)
 	assert.Len(t, feedManager.UpdateFeedErrorCalls(), 1)
 }
 
+func TestFeedProcessor_UpdateFeed_DisableAfterErrors(t *testing.T) {
+	parser := &mocks.ParserMock{
+		FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+			return nil, fmt.Errorf("unexpected status code: 404")
+		},
+	}
+
+	tests := []struct {
+		name          string
+		maxFeedErrors int
+		errorCount    int
+		wantDisabled  bool
+	}{
+		{name: "below threshold", maxFeedErrors: 3, errorCount: 0, wantDisabled: false},
+		{name: "one before threshold", maxFeedErrors: 3, errorCount: 1, wantDisabled: false},
+		{name: "threshold reached", maxFeedErrors: 3, errorCount: 2, wantDisabled: true},
+		{name: "above threshold", maxFeedErrors: 3, errorCount: 7, wantDisabled: true},
+		{name: "disabled quarantine", maxFeedErrors: 0, errorCount: 100, wantDisabled: false},
+		{name: "negative disables quarantine", maxFeedErrors: -1, errorCount: 100, wantDisabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedManager := &mocks.FeedManagerMock{
+				AddFeedFetchFunc:    func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+				UpdateFeedErrorFunc: func(ctx context.Context, feedID int64, errMsg string) error { return nil },
+				DisableFeedFunc:     func(ctx context.Context, feedID int64, reason string) error { return nil },
+			}
+			fp := NewFeedProcessor(FeedProcessorConfig{
+				FeedManager:   feedManager,
+				Parser:        parser,
+				MaxFeedErrors: tt.maxFeedErrors,
+				RetryFunc:     func(ctx context.Context, op func() error) error { return op() },
+			})
+			f := &domain.Feed{ID: 5, URL: "https://example.com/dead.xml", ErrorCount: tt.errorCount}
+			fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+			require.Len(t, feedManager.UpdateFeedErrorCalls(), 1)
+			assert.Empty(t, feedManager.UpdateFeedFetchedCalls())
+			if !tt.wantDisabled {
+				assert.Empty(t, feedManager.DisableFeedCalls())
+				return
+			}
+			require.Len(t, feedManager.DisableFeedCalls(), 1)
+			assert.Equal(t, int64(5), feedManager.DisableFeedCalls()[0].FeedID)
+			assert.Equal(t, fmt.Sprintf("disabled after %d consecutive errors, last error: unexpected status code: 404", tt.errorCount+1),
+				feedManager.DisableFeedCalls()[0].Reason)
+		})
+	}
+}
+
+func TestFeedProcessor_UpdateFeed_Gone(t *testing.T) {
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+		DisableFeedFunc:  func(ctx context.Context, feedID int64, reason string) error { return nil },
+		AddFeedEventFunc: func(ctx context.Context, event *domain.FeedEvent) error { return nil },
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager: feedManager,
+		Parser: &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return nil, fmt.Errorf("fetch feed: %w", domain.ErrFeedGone)
+			},
+		},
+		MaxFeedErrors: 10,
+		RetryFunc:     func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	fp.UpdateFeed(context.Background(), &domain.Feed{ID: 5, URL: "https://example.com/gone.xml"}, domain.PriorityNormal)
+
+	require.Len(t, feedManager.DisableFeedCalls(), 1, "disabled on the first 410")
+	assert.Equal(t, int64(5), feedManager.DisableFeedCalls()[0].FeedID)
+	assert.Contains(t, feedManager.DisableFeedCalls()[0].Reason, "410")
+	require.Len(t, feedManager.AddFeedEventCalls(), 1)
+	event := feedManager.AddFeedEventCalls()[0].Event
+	assert.Equal(t, domain.FeedEventGone, event.Type)
+	assert.Equal(t, int64(5), event.FeedID)
+	assert.Contains(t, event.Message, "https://example.com/gone.xml")
+}
+
+func TestFeedProcessor_UpdateFeed_FetchLog(t *testing.T) {
+	tests := []struct {
+		name   string
+		parsed *domain.ParsedFeed
+		err    error
+		want   domain.FeedFetch
+	}{
+		{name: "new items",
+			parsed: &domain.ParsedFeed{Size: 1024, Items: []domain.ParsedItem{{GUID: "1", Title: "one"}, {GUID: "2", Title: "two"}}},
+			want:   domain.FeedFetch{FeedID: 5, StatusCode: 200, Bytes: 1024, Items: 2, NewItems: 1}},
+		{name: "not modified", parsed: &domain.ParsedFeed{NotModified: true},
+			want: domain.FeedFetch{FeedID: 5, StatusCode: 304}},
+		{name: "status error", err: fmt.Errorf("fetch feed: %w", &domain.StatusError{StatusCode: 503}),
+			want: domain.FeedFetch{FeedID: 5, StatusCode: 503, Error: "fetch feed: unexpected status code: 503"}},
+		{name: "gone", err: fmt.Errorf("fetch feed: %w", domain.ErrFeedGone),
+			want: domain.FeedFetch{FeedID: 5, StatusCode: 410, Error: "fetch feed: feed is gone, server responded 410"}},
+		{name: "no response", err: errors.New("fetch feed: fetch URL: connection refused"),
+			want: domain.FeedFetch{FeedID: 5, Error: "fetch feed: fetch URL: connection refused"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedManager := &mocks.FeedManagerMock{
+				AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+				UpdateFeedCacheFunc:   func(ctx context.Context, feedID int64, etag, lastModified string, size int64) error { return nil },
+				UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+				UpdateFeedErrorFunc:   func(ctx context.Context, feedID int64, errMsg string) error { return nil },
+				DisableFeedFunc:       func(ctx context.Context, feedID int64, reason string) error { return nil },
+				AddFeedEventFunc:      func(ctx context.Context, event *domain.FeedEvent) error { return nil },
+			}
+			itemManager := &mocks.ItemManagerMock{
+				ItemExistsFunc:             func(ctx context.Context, feedID int64, guid string) (bool, error) { return guid == "1", nil },
+				ItemExistsByTitleOrURLFunc: func(ctx context.Context, title, url string) (bool, error) { return false, nil },
+				CreateItemFunc:             func(ctx context.Context, item *domain.Item) error { return nil },
+			}
+			fp := NewFeedProcessor(FeedProcessorConfig{
+				JobManager:  newJobManager(),
+				FeedManager: feedManager,
+				ItemManager: itemManager,
+				Parser: &mocks.ParserMock{
+					FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) { return tt.parsed, tt.err },
+				},
+				RetryFunc: func(ctx context.Context, op func() error) error { return op() },
+			})
+
+			start := time.Now()
+			fp.UpdateFeed(context.Background(), &domain.Feed{ID: 5, URL: "https://example.com/feed.xml"}, domain.PriorityNormal)
+
+			require.Len(t, feedManager.AddFeedFetchCalls(), 1)
+			fetch := *feedManager.AddFeedFetchCalls()[0].Fetch
+			assert.WithinDuration(t, start, fetch.FetchedAt, time.Second)
+			assert.Equal(t, time.UTC, fetch.FetchedAt.Location())
+			fetch.FetchedAt, fetch.Duration = time.Time{}, 0
+			assert.Equal(t, tt.want, fetch)
+		})
+	}
+
+	t.Run("interrupted fetch not recorded", func(t *testing.T) {
+		feedManager := &mocks.FeedManagerMock{
+			UpdateFeedErrorFunc: func(ctx context.Context, feedID int64, errMsg string) error { return nil },
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{
+			FeedManager: feedManager,
+			Parser: &mocks.ParserMock{
+				FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) { return nil, ctx.Err() },
+			},
+			RetryFunc: func(ctx context.Context, op func() error) error { return op() },
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fp.UpdateFeed(ctx, &domain.Feed{ID: 5}, domain.PriorityNormal)
+		assert.Empty(t, feedManager.AddFeedFetchCalls())
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_CanonicalLink(t *testing.T) {
+	parsed := &domain.ParsedFeed{Items: []domain.ParsedItem{
+		{GUID: "1", Title: "Tracked", Link: "https://m.example.com/story?utm_source=rss&id=1",
+			Image: "https://example.com/thumb.jpg", Categories: []string{"news"},
+			Enclosures: []domain.Enclosure{{URL: "https://example.com/story.mp3", Type: "audio/mpeg", Length: 100}}},
+		{GUID: "2", Title: "Shortened", Link: "https://t.co/abc"},
+		{GUID: "3", Title: "Repost", Link: "https://example.com/seen?utm_campaign=x"},
+	}}
+	newProcessor := func(resolver LinkResolver) (*FeedProcessor, *mocks.ItemManagerMock) {
+		itemManager := &mocks.ItemManagerMock{
+			ItemExistsFunc: func(ctx context.Context, feedID int64, guid string) (bool, error) { return false, nil },
+			ItemExistsByTitleOrURLFunc: func(ctx context.Context, title, url string) (bool, error) {
+				return url == "https://example.com/seen", nil
+			},
+			CreateItemFunc: func(ctx context.Context, item *domain.Item) error { return nil },
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{
+			JobManager: newJobManager(),
+			FeedManager: &mocks.FeedManagerMock{
+				AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+				UpdateFeedCacheFunc:   func(ctx context.Context, feedID int64, etag, lastModified string, size int64) error { return nil },
+				UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+			},
+			ItemManager: itemManager,
+			Parser: &mocks.ParserMock{
+				FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) { return parsed, nil },
+			},
+			LinkResolver: resolver,
+			RetryFunc:    func(ctx context.Context, op func() error) error { return op() },
+		})
+		return fp, itemManager
+	}
+
+	t.Run("with resolver", func(t *testing.T) {
+		resolver := &mocks.LinkResolverMock{ResolveFunc: func(ctx context.Context, link string) string {
+			if link == "https://t.co/abc" {
+				return "https://news.example.org/article"
+			}
+			return canonical.Unwrap(link)
+		}}
+		fp, itemManager := newProcessor(resolver)
+		fp.UpdateFeed(context.Background(), &domain.Feed{ID: 5, URL: "https://example.com/feed.xml"}, domain.PriorityNormal)
+
+		assert.Len(t, resolver.ResolveCalls(), 3)
+		require.Len(t, itemManager.ItemExistsByTitleOrURLCalls(), 3)
+		assert.Equal(t, "https://example.com/story?id=1", itemManager.ItemExistsByTitleOrURLCalls()[0].URL)
+		require.Len(t, itemManager.CreateItemCalls(), 2, "repost of a stored article skipped")
+		item := itemManager.CreateItemCalls()[0].Item
+		assert.Equal(t, "https://m.example.com/story?utm_source=rss&id=1", item.Link, "original link kept")
+		assert.Equal(t, "https://example.com/story?id=1", item.CanonicalLink)
+		assert.Equal(t, "https://m.example.com/story?utm_source=rss&id=1", item.ResolvedLink, "no guessed variants")
+		assert.Equal(t, "https://example.com/thumb.jpg", item.Image, "feed media kept")
+		assert.Equal(t, parsed.Items[0].Enclosures, item.Enclosures)
+		assert.Equal(t, []string{"news"}, item.Categories)
+		assert.Equal(t, "https://news.example.org/article", itemManager.CreateItemCalls()[1].Item.CanonicalLink)
+		assert.Equal(t, "https://news.example.org/article", itemManager.CreateItemCalls()[1].Item.ResolvedLink)
+	})
+
+	t.Run("without resolver", func(t *testing.T) {
+		fp, itemManager := newProcessor(nil)
+		fp.UpdateFeed(context.Background(), &domain.Feed{ID: 5, URL: "https://example.com/feed.xml"}, domain.PriorityNormal)
+		require.Len(t, itemManager.CreateItemCalls(), 2)
+		assert.Equal(t, "https://example.com/story?id=1", itemManager.CreateItemCalls()[0].Item.CanonicalLink)
+		assert.Equal(t, "https://t.co/abc", itemManager.CreateItemCalls()[1].Item.CanonicalLink, "wrapper left unresolved")
+		assert.Equal(t, "https://t.co/abc", itemManager.CreateItemCalls()[1].Item.ResolvedLink)
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_Moved(t *testing.T) {
+	parser := &mocks.ParserMock{
+		FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+			return &domain.ParsedFeed{Title: "Feed", MovedTo: "https://new.example.com/feed.xml"}, nil
+		},
+	}
+	newFeedManager := func(existing *domain.Feed) *mocks.FeedManagerMock {
+		return &mocks.FeedManagerMock{
+			AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+			GetFeedByURLFunc:      func(ctx context.Context, url string) (*domain.Feed, error) { return existing, nil },
+			UpdateFeedURLFunc:     func(ctx context.Context, feedID int64, url string) error { return nil },
+			UpdateFeedMovedToFunc: func(ctx context.Context, feedID int64, url string) error { return nil },
+			DisableFeedFunc:       func(ctx context.Context, feedID int64, reason string) error { return nil },
+			AddFeedEventFunc:      func(ctx context.Context, event *domain.FeedEvent) error { return nil },
+			UpdateFeedCacheFunc:   func(ctx context.Context, feedID int64, etag, lastModified string, size int64) error { return nil },
+			UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+		}
+	}
+	retry := func(ctx context.Context, op func() error) error { return op() }
+
+	t.Run("first redirect recorded only", func(t *testing.T) {
+		feedManager := newFeedManager(nil)
+		fp := NewFeedProcessor(FeedProcessorConfig{FeedManager: feedManager, Parser: parser, RetryFunc: retry})
+		f := &domain.Feed{ID: 5, URL: "http://old.example.com/rss"}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedMovedToCalls(), 1)
+		assert.Equal(t, "https://new.example.com/feed.xml", feedManager.UpdateFeedMovedToCalls()[0].URL)
+		assert.Empty(t, feedManager.UpdateFeedURLCalls())
+		assert.Empty(t, feedManager.GetFeedByURLCalls())
+		assert.Empty(t, feedManager.AddFeedEventCalls())
+		assert.Equal(t, "http://old.example.com/rss", f.URL)
+		assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+	})
+
+	t.Run("redirect to another target recorded again", func(t *testing.T) {
+		feedManager := newFeedManager(nil)
+		fp := NewFeedProcessor(FeedProcessorConfig{FeedManager: feedManager, Parser: parser, RetryFunc: retry})
+		f := &domain.Feed{ID: 5, URL: "http://old.example.com/rss", MovedTo: "https://portal.example.com/login"}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedMovedToCalls(), 1)
+		assert.Equal(t, "https://new.example.com/feed.xml", feedManager.UpdateFeedMovedToCalls()[0].URL)
+		assert.Empty(t, feedManager.UpdateFeedURLCalls())
+	})
+
+	t.Run("redirect gone", func(t *testing.T) {
+		feedManager := newFeedManager(nil)
+		direct := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return &domain.ParsedFeed{Title: "Feed"}, nil
+			},
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{FeedManager: feedManager, Parser: direct, RetryFunc: retry})
+		f := &domain.Feed{ID: 5, URL: "http://old.example.com/rss", MovedTo: "https://new.example.com/feed.xml"}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedMovedToCalls(), 1)
+		assert.Empty(t, feedManager.UpdateFeedMovedToCalls()[0].URL)
+		assert.Empty(t, feedManager.UpdateFeedURLCalls())
+		assert.Empty(t, f.MovedTo)
+	})
+
+	t.Run("url updated on the repeated redirect", func(t *testing.T) {
+		feedManager := newFeedManager(nil)
+		fp := NewFeedProcessor(FeedProcessorConfig{FeedManager: feedManager, Parser: parser, RetryFunc: retry})
+		f := &domain.Feed{ID: 5, URL: "http://old.example.com/rss", MovedTo: "https://new.example.com/feed.xml"}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedURLCalls(), 1)
+		assert.Equal(t, "https://new.example.com/feed.xml", feedManager.UpdateFeedURLCalls()[0].URL)
+		assert.Equal(t, "https://new.example.com/feed.xml", f.URL)
+		assert.Empty(t, f.MovedTo)
+		assert.Empty(t, feedManager.UpdateFeedMovedToCalls())
+		require.Len(t, feedManager.AddFeedEventCalls(), 1)
+		event := feedManager.AddFeedEventCalls()[0].Event
+		assert.Equal(t, domain.FeedEventMoved, event.Type)
+		assert.Equal(t, "moved permanently from http://old.example.com/rss to https://new.example.com/feed.xml", event.Message)
+		assert.Empty(t, feedManager.DisableFeedCalls())
+		assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1, "feed processed after the move")
+	})
+
+	t.Run("moved to a subscribed feed", func(t *testing.T) {
+		feedManager := newFeedManager(&domain.Feed{ID: 9, Title: "New Feed", URL: "https://new.example.com/feed.xml"})
+		fp := NewFeedProcessor(FeedProcessorConfig{FeedManager: feedManager, Parser: parser, RetryFunc: retry})
+		fp.UpdateFeed(context.Background(), &domain.Feed{ID: 5, URL: "http://old.example.com/rss",
+			MovedTo: "https://new.example.com/feed.xml"}, domain.PriorityNormal)
+
+		assert.Empty(t, feedManager.UpdateFeedURLCalls())
+		require.Len(t, feedManager.DisableFeedCalls(), 1)
+		assert.Equal(t, `moved to https://new.example.com/feed.xml, already subscribed as "New Feed"`, feedManager.DisableFeedCalls()[0].Reason)
+		require.Len(t, feedManager.AddFeedEventCalls(), 1)
+		assert.Contains(t, feedManager.AddFeedEventCalls()[0].Event.Message, "disabled as a duplicate")
+		assert.Empty(t, feedManager.UpdateFeedFetchedCalls(), "duplicate not processed")
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_MovedNotModified(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/new.xml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Feed</title></channel></rss>`))
+	})
+	mux.Handle("/old.xml", http.RedirectHandler("/new.xml", http.StatusMovedPermanently))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// the stored feed, each poll gets a copy as the due feeds query returns
+	stored := domain.Feed{ID: 5, URL: srv.URL + "/old.xml", FetchInterval: time.Hour}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+		UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+		AddFeedEventFunc:      func(ctx context.Context, event *domain.FeedEvent) error { return nil },
+		GetFeedByURLFunc:      func(ctx context.Context, url string) (*domain.Feed, error) { return nil, nil },
+		UpdateFeedCacheFunc: func(ctx context.Context, feedID int64, etag, lastModified string, size int64) error {
+			stored.ETag, stored.LastModified, stored.LastSize = etag, lastModified, size
+			return nil
+		},
+		AddFeedBytesSavedFunc: func(ctx context.Context, feedID, bytes int64) error { return nil },
+		UpdateFeedMovedToFunc: func(ctx context.Context, feedID int64, url string) error {
+			stored.MovedTo = url
+			return nil
+		},
+		UpdateFeedURLFunc: func(ctx context.Context, feedID int64, url string) error {
+			stored.URL, stored.MovedTo = url, ""
+			return nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager: feedManager,
+		ItemManager: &mocks.ItemManagerMock{},
+		Parser:      feed.NewParser(5*time.Second, "TestAgent/1.0"),
+		RetryFunc:   func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	poll := stored
+	fp.UpdateFeed(context.Background(), &poll, domain.PriorityNormal)
+	assert.Equal(t, srv.URL+"/old.xml", stored.URL, "single redirect not trusted")
+	assert.Equal(t, srv.URL+"/new.xml", stored.MovedTo)
+	require.Equal(t, `"v1"`, stored.ETag)
+
+	poll = stored
+	fp.UpdateFeed(context.Background(), &poll, domain.PriorityNormal)
+	assert.Equal(t, srv.URL+"/new.xml", stored.URL, "redirect confirmed by the 304 poll")
+	assert.Empty(t, stored.MovedTo)
+	require.Len(t, feedManager.UpdateFeedURLCalls(), 1)
+	assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 2)
+}
+
 func TestFeedProcessor_ProcessItem_ExtractionError(t *testing.T) {
 	itemManager := &mocks.ItemManagerMock{}
 	extractor := &mocks.ExtractorMock{}
@@ -326,7 +731,12 @@ func TestFeedProcessor_ProcessItem_ExtractionError(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
-		FeedManager:           &mocks.FeedManagerMock{},
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+				return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+			},
+		},
 		ItemManager:           itemManager,
 		ClassificationManager: &mocks.ClassificationManagerMock{},
 		SettingManager:        &mocks.SettingManagerMock{},
@@ -344,7 +754,7 @@ func TestFeedProcessor_ProcessItem_ExtractionError(t *testing.T) {
 	}
 
 	// setup extraction to fail
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return nil, assert.AnError
 	}
 
@@ -370,7 +780,9 @@ func TestFeedProcessor_ProcessItem_ExtractionError(t *testing.T) {
 }
 
 func TestFeedProcessor_UpdateFeed_DuplicateItems(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -383,6 +795,7 @@ func TestFeedProcessor_UpdateFeed_DuplicateItems(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -420,7 +833,7 @@ func TestFeedProcessor_UpdateFeed_DuplicateItems(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -448,7 +861,7 @@ func TestFeedProcessor_UpdateFeed_DuplicateItems(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -500,7 +913,9 @@ func TestFeedProcessor_UpdateFeed_DuplicateItems(t *testing.T) {
 }
 
 func TestFeedProcessor_UpdateFeed_ItemCreationError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -523,6 +938,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationError(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -551,7 +967,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationError(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -575,7 +991,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationError(t *testing.T) {
 	}
 
 	// setup mocks for background processing (though items won't be created due to error)
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{}, nil
 	}
 
@@ -613,7 +1029,9 @@ func TestFeedProcessor_UpdateFeed_ItemCreationError(t *testing.T) {
 }
 
 func TestFeedProcessor_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -637,6 +1055,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -665,7 +1084,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -694,7 +1113,7 @@ func TestFeedProcessor_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -744,7 +1163,12 @@ func TestFeedProcessor_ProcessItem_NonHTMLContent(t *testing.T) {
 	}
 
 	fp := NewFeedProcessor(FeedProcessorConfig{
-		FeedManager:           &mocks.FeedManagerMock{},
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+				return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+			},
+		},
 		ItemManager:           itemManager,
 		ClassificationManager: &mocks.ClassificationManagerMock{},
 		SettingManager:        &mocks.SettingManagerMock{},
@@ -758,19 +1182,19 @@ func TestFeedProcessor_ProcessItem_NonHTMLContent(t *testing.T) {
 	testItem := &domain.Item{
 		ID:    1,
 		GUID:  "test-guid",
-		Link:  "https://example.com/document.pdf",
-		Title: "PDF Document",
+		Link:  "https://example.com/archive.zip",
+		Title: "Source Archive",
 	}
 
 	// setup extraction to fail with unsupported content type error
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
-		return nil, fmt.Errorf("unsupported content type: application/pdf")
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+		return nil, fmt.Errorf("unsupported content type: application/zip")
 	}
 
 	// setup item manager to expect extraction error update with specific binary content message
 	itemManager.UpdateItemExtractionFunc = func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error {
 		assert.Equal(t, testItem.ID, itemID)
-		assert.Equal(t, "Binary content (PDF, image, or other non-HTML format)", extraction.Error)
+		assert.Equal(t, "Binary content (image, archive, or other non-text format)", extraction.Error)
 		assert.False(t, extraction.ExtractedAt.IsZero())
 		return nil
 	}
@@ -787,3 +1211,1374 @@ func TestFeedProcessor_ProcessItem_NonHTMLContent(t *testing.T) {
 	assert.Len(t, extractor.ExtractCalls(), 1)
 	assert.Len(t, itemManager.UpdateItemExtractionCalls(), 1)
 }
+
+func TestFeedProcessor_ProcessItem_ExtractedTitle(t *testing.T) {
+	itemManager := &mocks.ItemManagerMock{
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+	}
+	extractor := &mocks.ExtractorMock{
+		ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+			return &content.ExtractResult{Content: "text", Title: "Extracted Title", Canonical: url + "?utm_source=page",
+				Image: "https://example.com/lead.jpg"}, nil
+		},
+	}
+	classifier := &mocks.ClassifierMock{
+		ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+			return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 7}}, nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+				return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+			},
+		},
+		ItemManager: itemManager,
+		ClassificationManager: &mocks.ClassificationManagerMock{
+			GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+				return nil, nil
+			},
+			GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+		},
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		Parser:     &mocks.ParserMock{},
+		Extractor:  extractor,
+		Classifier: classifier,
+		MaxWorkers: 1,
+		RetryFunc:  func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	// item from a sitemap has no title
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 1, GUID: "https://example.com/a", Link: "https://example.com/a"})
+	require.Len(t, classifier.ClassifyItemsCalls(), 1)
+	assert.Equal(t, "Extracted Title", classifier.ClassifyItemsCalls()[0].Req.Articles[0].Title)
+	require.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	assert.Equal(t, "Extracted Title", itemManager.UpdateItemProcessedCalls()[0].Extraction.Title)
+	assert.Equal(t, "https://example.com/a", itemManager.UpdateItemProcessedCalls()[0].Extraction.CanonicalLink, "page canonical cleaned")
+	assert.Equal(t, "https://example.com/a?utm_source=page", itemManager.UpdateItemProcessedCalls()[0].Extraction.ResolvedLink)
+	assert.Equal(t, "https://example.com/lead.jpg", itemManager.UpdateItemProcessedCalls()[0].Extraction.Image)
+
+	// feed title is kept, the article extracted from the resolved link and not from the dedupe key
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 2, GUID: "b", Link: "https://t.co/b",
+		ResolvedLink: "https://m.example.com/b/amp", CanonicalLink: "https://example.com/b", Title: "Feed Title"})
+	require.Len(t, classifier.ClassifyItemsCalls(), 2)
+	assert.Equal(t, "Feed Title", classifier.ClassifyItemsCalls()[1].Req.Articles[0].Title)
+	assert.Equal(t, "https://m.example.com/b/amp", extractor.ExtractCalls()[1].URL)
+}
+
+func TestFeedProcessor_ProcessItem_Politeness(t *testing.T) {
+	newProcessor := func(extractErr error, itemManager *mocks.ItemManagerMock, classifier *mocks.ClassifierMock) *FeedProcessor {
+		return NewFeedProcessor(FeedProcessorConfig{
+			JobManager: newJobManager(),
+			FeedManager: &mocks.FeedManagerMock{
+				GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+			},
+			ItemManager: itemManager,
+			ClassificationManager: &mocks.ClassificationManagerMock{
+				GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+					return nil, nil
+				},
+				GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+			},
+			SettingManager: &mocks.SettingManagerMock{
+				GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+			},
+			Extractor: &mocks.ExtractorMock{
+				ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+					return nil, fmt.Errorf("%w: %s", extractErr, url)
+				},
+			},
+			Classifier: classifier,
+			RetryFunc:  func(ctx context.Context, op func() error) error { return op() },
+		})
+	}
+
+	t.Run("disallowed by robots.txt", func(t *testing.T) {
+		itemManager := &mocks.ItemManagerMock{
+			UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+				return nil
+			},
+		}
+		classifier := &mocks.ClassifierMock{
+			ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+				return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 6}}, nil
+			},
+		}
+		fp := newProcessor(content.ErrDisallowed, itemManager, classifier)
+
+		item := &domain.Item{ID: 1, GUID: "a", Title: "Article", Link: "https://example.com/a",
+			Description: "<p>Summary of the <b>article</b></p>"}
+		require.NoError(t, fp.ProcessItem(context.Background(), item))
+		require.Len(t, classifier.ClassifyItemsCalls(), 1, "classified with the feed content")
+		assert.Equal(t, "Summary of the article", classifier.ClassifyItemsCalls()[0].Req.Articles[0].Content)
+		require.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+		extraction := itemManager.UpdateItemProcessedCalls()[0].Extraction
+		assert.Equal(t, "Summary of the article", extraction.PlainText)
+		assert.Equal(t, "<p>Summary of the <b>article</b></p>", extraction.RichHTML)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		fp := newProcessor(content.ErrRateLimited, &mocks.ItemManagerMock{}, &mocks.ClassifierMock{})
+		err := fp.ProcessItem(context.Background(), &domain.Item{ID: 1, GUID: "a", Link: "https://example.com/a"})
+		require.ErrorIs(t, err, content.ErrRateLimited, "job retried later, no extraction error stored")
+	})
+}
+
+func TestFeedProcessor_ProcessItem_Stages(t *testing.T) {
+	extracting, classifying := make(chan struct{}), make(chan struct{})
+	itemManager := &mocks.ItemManagerMock{
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+		},
+		ItemManager: itemManager,
+		ClassificationManager: &mocks.ClassificationManagerMock{
+			GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+				return nil, nil
+			},
+			GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+		},
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		Extractor: &mocks.ExtractorMock{
+			ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+				release, err := content.AcquireSlot(ctx)
+				if err != nil {
+					return nil, err
+				}
+				defer release()
+				<-extracting
+				return &content.ExtractResult{Content: "text"}, nil
+			},
+		},
+		Classifier: &mocks.ClassifierMock{
+			ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+				<-classifying
+				return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 7}}, nil
+			},
+		},
+		MaxExtractions:     1,
+		MaxClassifications: 2,
+		RetryFunc:          func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	var wg sync.WaitGroup
+	for i, host := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, fp.ProcessItem(context.Background(), &domain.Item{ID: int64(i + 1), GUID: host, Link: "https://" + host + "/post"}))
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return fp.PipelineStats().Extraction == domain.StageStats{Waiting: 2, Running: 1, Limit: 1}
+	}, time.Second, 5*time.Millisecond, "one extraction at a time")
+
+	close(extracting)
+	require.Eventually(t, func() bool {
+		return fp.PipelineStats() == domain.PipelineStats{
+			Extraction:     domain.StageStats{Limit: 1},
+			Classification: domain.StageStats{Waiting: 1, Running: 2, Limit: 2},
+		}
+	}, time.Second, 5*time.Millisecond, "classifications limited separately")
+
+	close(classifying)
+	wg.Wait()
+	assert.Len(t, itemManager.UpdateItemProcessedCalls(), 3)
+	assert.Equal(t, domain.StageStats{Limit: 2}, fp.PipelineStats().Classification)
+}
+
+func TestFeedProcessor_Extract_ThrottledHost(t *testing.T) {
+	const article = `<html><body><article><h1>Article</h1><p>This article has enough text ` +
+		`to pass the minimum length check of the extractor used in this test.</p></article></body></html>`
+	var throttled atomic.Int32
+	throttledSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if throttled.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(article))
+	}))
+	defer throttledSrv.Close()
+	otherSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(article))
+	}))
+	defer otherSrv.Close()
+
+	extractor := content.NewHTTPExtractor(5*time.Second, "Newscope/2.0")
+	extractor.SetOptions(50, false, false)
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+		},
+		Extractor:      extractor,
+		MaxExtractions: 1,
+		RetryFunc:      func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	// both servers listen on 127.0.0.1, localhost makes the throttled one a different host for the limiter
+	throttledDone := make(chan time.Time, 1)
+	go func() {
+		link := strings.Replace(throttledSrv.URL, "127.0.0.1", "localhost", 1)
+		_, err := fp.extract(context.Background(), &domain.Item{ID: 1, Link: link})
+		assert.NoError(t, err)
+		throttledDone <- time.Now()
+	}()
+	require.Eventually(t, func() bool { return throttled.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	start := time.Now()
+	_, err := fp.extract(context.Background(), &domain.Item{ID: 2, Link: otherSrv.URL})
+	require.NoError(t, err)
+	otherDone := time.Now()
+	assert.Less(t, otherDone.Sub(start), time.Second, "other host not held up by the throttled one")
+
+	assert.True(t, (<-throttledDone).After(otherDone), "throttled host waited for its retry")
+	assert.Equal(t, int32(2), throttled.Load())
+	assert.Equal(t, domain.StageStats{Limit: 1}, fp.PipelineStats().Extraction)
+}
+
+func TestFeedProcessor_ProcessItem_Duplicate(t *testing.T) {
+	story := strings.Repeat("the council approved the new budget for public transport after a long debate ", 8)
+	itemManager := &mocks.ItemManagerMock{
+		UpdateItemFingerprintFunc: func(ctx context.Context, itemID int64, fingerprint uint64) error { return nil },
+		FindDuplicateFunc: func(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+			if itemID == 2 {
+				return 1, nil
+			}
+			return 0, nil
+		},
+		UpdateItemDuplicateFunc: func(ctx context.Context, itemID, duplicateOf int64, extraction *domain.ExtractedContent) error {
+			return nil
+		},
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+	}
+	classifier := &mocks.ClassifierMock{
+		ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+			return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 7}}, nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+		},
+		ItemManager: itemManager,
+		ClassificationManager: &mocks.ClassificationManagerMock{
+			GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+				return nil, nil
+			},
+			GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+		},
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		Extractor: &mocks.ExtractorMock{
+			ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+				return &content.ExtractResult{Content: story, Extractor: content.ExtractorFallback}, nil
+			},
+		},
+		Classifier: classifier,
+		MaxWorkers: 1,
+		RetryFunc:  func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	// original article is classified with its fingerprint stored before classification
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 1, GUID: "a", Link: "https://a.example.com/budget", Title: "Budget approved"})
+	require.Len(t, itemManager.FindDuplicateCalls(), 1)
+	assert.Equal(t, duplicateMaxDistance, itemManager.FindDuplicateCalls()[0].MaxDistance)
+	require.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	fingerprint := itemManager.UpdateItemProcessedCalls()[0].Extraction.Fingerprint
+	assert.NotZero(t, fingerprint)
+	require.Len(t, itemManager.UpdateItemFingerprintCalls(), 1)
+	assert.Equal(t, int64(1), itemManager.UpdateItemFingerprintCalls()[0].ItemID)
+	assert.Equal(t, fingerprint, itemManager.UpdateItemFingerprintCalls()[0].Fingerprint)
+	assert.Equal(t, content.ExtractorFallback, itemManager.UpdateItemProcessedCalls()[0].Extraction.Extractor)
+
+	// repost from another outlet is linked to the original and not classified
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 2, GUID: "b", Link: "https://b.example.com/budget", Title: "Budget approved"})
+	require.Len(t, itemManager.UpdateItemDuplicateCalls(), 1)
+	assert.Equal(t, int64(2), itemManager.UpdateItemDuplicateCalls()[0].ItemID)
+	assert.Equal(t, int64(1), itemManager.UpdateItemDuplicateCalls()[0].DuplicateOf)
+	assert.Equal(t, fingerprint, itemManager.UpdateItemDuplicateCalls()[0].Extraction.Fingerprint)
+	assert.Len(t, classifier.ClassifyItemsCalls(), 1)
+	assert.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+
+	// short text is not fingerprinted
+	itemManager.FindDuplicateFunc = nil // must not be called
+	fp.extractor = &mocks.ExtractorMock{
+		ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+			return &content.ExtractResult{Content: "short note"}, nil
+		},
+	}
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 3, GUID: "c", Link: "https://c.example.com/note", Title: "Note"})
+	assert.Len(t, classifier.ClassifyItemsCalls(), 2)
+}
+
+func TestFeedProcessor_ProcessItem_Muted(t *testing.T) {
+	itemManager := &mocks.ItemManagerMock{
+		UpdateItemMutedFunc: func(ctx context.Context, itemID int64, rule string) error { return nil },
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+		UpdateItemFingerprintFunc: func(ctx context.Context, itemID int64, fingerprint uint64) error { return nil },
+		FindDuplicateFunc: func(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+			return 0, nil
+		},
+	}
+	muteRules := &mocks.MuteRuleManagerMock{
+		GetMuteRulesFunc: func(ctx context.Context) ([]domain.MuteRule, error) {
+			return []domain.MuteRule{
+				{ID: 1, Kind: domain.MuteKeyword, Pattern: "sponsored"},
+				{ID: 2, Kind: domain.MuteAuthor, Pattern: "Ad Team", FeedID: 3, FeedName: "Tech News"},
+			}, nil
+		},
+	}
+	extractor := &mocks.ExtractorMock{
+		ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+			return &content.ExtractResult{Content: "article text"}, nil
+		},
+	}
+	classifier := &mocks.ClassifierMock{
+		ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+			return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 7}}, nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager: newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+		},
+		ItemManager: itemManager,
+		ClassificationManager: &mocks.ClassificationManagerMock{
+			GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+				return nil, nil
+			},
+			GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+		},
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		MuteRuleManager: muteRules,
+		Extractor:       extractor,
+		Classifier:      classifier,
+		MaxWorkers:      1,
+		RetryFunc:       func(ctx context.Context, op func() error) error { return op() },
+	})
+	ctx := context.Background()
+
+	fp.ProcessItem(ctx, &domain.Item{ID: 1, FeedID: 5, GUID: "1", Title: "Sponsored: a new gadget", Link: "https://example.com/1"})
+	fp.ProcessItem(ctx, &domain.Item{ID: 2, FeedID: 3, GUID: "2", Title: "Deals", Author: "Ad Team", Link: "https://example.com/2"})
+	assert.Empty(t, extractor.ExtractCalls(), "muted items not extracted")
+	assert.Empty(t, classifier.ClassifyItemsCalls(), "muted items not classified")
+	require.Len(t, itemManager.UpdateItemMutedCalls(), 2)
+	assert.Equal(t, int64(1), itemManager.UpdateItemMutedCalls()[0].ItemID)
+	assert.Equal(t, `keyword "sponsored"`, itemManager.UpdateItemMutedCalls()[0].Rule)
+	assert.Equal(t, `author "Ad Team" in Tech News`, itemManager.UpdateItemMutedCalls()[1].Rule)
+
+	// the author rule is limited to its feed
+	fp.ProcessItem(ctx, &domain.Item{ID: 3, FeedID: 4, GUID: "3", Title: "Deals", Author: "Ad Team", Link: "https://example.com/3"})
+	assert.Len(t, itemManager.UpdateItemMutedCalls(), 2)
+	assert.Len(t, extractor.ExtractCalls(), 1)
+	assert.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	assert.Len(t, muteRules.GetMuteRulesCalls(), 1, "rules loaded once")
+
+	// items are processed if the rules can't be loaded
+	muteRules.GetMuteRulesFunc = func(ctx context.Context) ([]domain.MuteRule, error) { return nil, errors.New("db error") }
+	fp.MuteRulesChanged()
+	fp.ProcessItem(ctx, &domain.Item{ID: 4, FeedID: 5, GUID: "4", Title: "Sponsored again", Link: "https://example.com/4"})
+	assert.Len(t, itemManager.UpdateItemMutedCalls(), 2)
+	assert.Len(t, itemManager.UpdateItemProcessedCalls(), 2)
+	assert.Len(t, muteRules.GetMuteRulesCalls(), 2, "rules reloaded after the change")
+
+	// the next item retries loading the rules after a failure
+	muteRules.GetMuteRulesFunc = func(ctx context.Context) ([]domain.MuteRule, error) {
+		return []domain.MuteRule{{ID: 3, Kind: domain.MuteKeyword, Pattern: "deals"}}, nil
+	}
+	fp.ProcessItem(ctx, &domain.Item{ID: 5, FeedID: 4, GUID: "5", Title: "Deals", Link: "https://example.com/5"})
+	require.Len(t, itemManager.UpdateItemMutedCalls(), 3)
+	assert.Equal(t, `keyword "deals"`, itemManager.UpdateItemMutedCalls()[2].Rule)
+}
+
+func TestFeedProcessor_ItemAccess(t *testing.T) {
+	auth := domain.FeedAuth{Username: "user", Password: "secret", Headers: map[string]string{"X-Key": "k"}}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+				if id == 2 {
+					return nil, fmt.Errorf("feed not found")
+				}
+				return &domain.Feed{ID: id, URL: "https://example.com/feed.xml", Auth: auth, Proxy: "socks5://proxy:1080"}, nil
+			},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		item      domain.Item
+		want      domain.FeedAuth
+		wantProxy string
+	}{
+		{name: "same host", item: domain.Item{FeedID: 1, Link: "https://example.com/posts/1"}, want: auth,
+			wantProxy: "socks5://proxy:1080"},
+		{name: "subdomain", item: domain.Item{FeedID: 1, Link: "https://blog.example.com/posts/1"}, want: auth,
+			wantProxy: "socks5://proxy:1080"},
+		{name: "other host", item: domain.Item{FeedID: 1, Link: "https://other.com/posts/1"}, wantProxy: "socks5://proxy:1080"},
+		{name: "suffix of other host", item: domain.Item{FeedID: 1, Link: "https://notexample.com/posts/1"},
+			wantProxy: "socks5://proxy:1080"},
+		{name: "https downgraded", item: domain.Item{FeedID: 1, Link: "http://example.com/posts/1"},
+			wantProxy: "socks5://proxy:1080"},
+		{name: "feed lookup failed", item: domain.Item{FeedID: 2, Link: "https://example.com/posts/1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, got := fp.itemAccess(context.Background(), &tt.item)
+			assert.Equal(t, tt.want, got)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, tt.item.Link, http.NoBody)
+			require.NoError(t, err)
+			proxy, err := httpclient.Proxy(nil)(req)
+			require.NoError(t, err)
+			if tt.wantProxy == "" {
+				assert.Nil(t, proxy)
+				return
+			}
+			require.NotNil(t, proxy)
+			assert.Equal(t, tt.wantProxy, proxy.String())
+		})
+	}
+}
+
+func TestFeedProcessor_AdaptiveInterval(t *testing.T) {
+	fp := NewFeedProcessor(FeedProcessorConfig{MinFetchInterval: 5 * time.Minute, MaxFetchInterval: 24 * time.Hour})
+	now := time.Now()
+
+	// itemsEvery builds n items published with the given gap between them
+	itemsEvery := func(n int, gap time.Duration) []domain.ParsedItem {
+		items := make([]domain.ParsedItem, n)
+		for i := range items {
+			items[i] = domain.ParsedItem{GUID: fmt.Sprintf("item-%d", i), Published: now.Add(-time.Duration(i) * gap)}
+		}
+		return items
+	}
+
+	tests := []struct {
+		name  string
+		items []domain.ParsedItem
+		prev  time.Duration
+		want  time.Duration
+	}{
+		{name: "no items", items: nil, want: 0},
+		{name: "not enough dated items", items: itemsEvery(2, time.Hour), want: 0},
+		{name: "undated items ignored", items: []domain.ParsedItem{{GUID: "1"}, {GUID: "2"}, {GUID: "3"}, {GUID: "4"}}, want: 0},
+		{name: "items stamped with fetch time ignored", items: []domain.ParsedItem{
+			{GUID: "1", Published: now, Undated: true},
+			{GUID: "2", Published: now, Undated: true},
+			{GUID: "3", Published: now.Add(-time.Hour)},
+			{GUID: "4", Published: now.Add(-2 * time.Hour)},
+		}, want: 0},
+		{name: "hourly publishing", items: itemsEvery(10, time.Hour), want: time.Hour},
+		{name: "busy feed clamped to min", items: itemsEvery(10, time.Minute), want: 5 * time.Minute},
+		{name: "quiet feed clamped to max", items: itemsEvery(5, 7*24*time.Hour), want: 24 * time.Hour},
+		{name: "smoothed with previous", items: itemsEvery(10, time.Hour), prev: 3 * time.Hour, want: 2 * time.Hour},
+		{name: "rounded to minute", items: itemsEvery(4, 90*time.Minute+20*time.Second), want: 90 * time.Minute},
+		{name: "only recent sample used", items: append(itemsEvery(21, 2*time.Hour),
+			domain.ParsedItem{GUID: "old", Published: now.Add(-1000 * time.Hour)}), want: 2 * time.Hour},
+		{name: "median ignores single burst", items: []domain.ParsedItem{
+			{GUID: "1", Published: now},
+			{GUID: "2", Published: now.Add(-time.Minute)},
+			{GUID: "3", Published: now.Add(-2 * time.Hour)},
+			{GUID: "4", Published: now.Add(-4 * time.Hour)},
+			{GUID: "5", Published: now.Add(-6 * time.Hour)},
+		}, want: 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fp.adaptiveInterval(tt.items, tt.prev))
+		})
+	}
+
+	t.Run("no bounds", func(t *testing.T) {
+		unbounded := NewFeedProcessor(FeedProcessorConfig{})
+		assert.Equal(t, 30*time.Minute, unbounded.adaptiveInterval(itemsEvery(5, 30*time.Minute), 0))
+		assert.Equal(t, 7*24*time.Hour, unbounded.adaptiveInterval(itemsEvery(5, 7*24*time.Hour), 0))
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_AdaptiveInterval(t *testing.T) {
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
+	itemManager := &mocks.ItemManagerMock{}
+	parser := &mocks.ParserMock{}
+
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:       newJobManager(),
+		FeedManager:      feedManager,
+		ItemManager:      itemManager,
+		Parser:           parser,
+		MaxWorkers:       1,
+		MinFetchInterval: 5 * time.Minute,
+		MaxFetchInterval: 24 * time.Hour,
+		RetryFunc:        func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	now := time.Now()
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+		items := make([]domain.ParsedItem, 5)
+		for i := range items {
+			items[i] = domain.ParsedItem{GUID: fmt.Sprintf("item-%d", i), Published: now.Add(-time.Duration(i) * 2 * time.Hour)}
+		}
+		return &domain.ParsedFeed{Items: items}, nil
+	}
+	itemManager.ItemExistsFunc = func(ctx context.Context, feedID int64, guid string) (bool, error) {
+		return true, nil // nothing new, only scheduling is tested
+	}
+	feedManager.UpdateFeedAdaptiveIntervalFunc = func(ctx context.Context, feedID int64, interval time.Duration) error {
+		return nil
+	}
+	feedManager.UpdateFeedFetchedFunc = func(ctx context.Context, feedID int64, nextFetch time.Time) error {
+		return nil
+	}
+
+	t.Run("interval changed", func(t *testing.T) {
+		f := &domain.Feed{ID: 1, URL: "https://example.com/feed.xml", FetchInterval: 30 * time.Minute}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedAdaptiveIntervalCalls(), 1)
+		assert.Equal(t, 2*time.Hour, feedManager.UpdateFeedAdaptiveIntervalCalls()[0].Interval)
+		assert.Equal(t, 2*time.Hour, f.AdaptiveInterval)
+
+		// next fetch scheduled with adaptive interval, not the configured one
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+		nextFetch := feedManager.UpdateFeedFetchedCalls()[0].NextFetch
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), nextFetch, 5*time.Second)
+		assert.Equal(t, time.UTC, nextFetch.Location())
+	})
+
+	t.Run("interval unchanged", func(t *testing.T) {
+		f := &domain.Feed{ID: 1, URL: "https://example.com/feed.xml", FetchInterval: 30 * time.Minute, AdaptiveInterval: 2 * time.Hour}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+		assert.Len(t, feedManager.UpdateFeedAdaptiveIntervalCalls(), 1, "no extra update for the same interval")
+		assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 2)
+	})
+}
+
+func TestFeedProcessor_UpdateDueFeeds(t *testing.T) {
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
+	itemManager := &mocks.ItemManagerMock{}
+	parser := &mocks.ParserMock{}
+
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:  newJobManager(),
+		FeedManager: feedManager,
+		ItemManager: itemManager,
+		Parser:      parser,
+		MaxWorkers:  2,
+		RetryFunc:   func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+		return &domain.ParsedFeed{}, nil
+	}
+	feedManager.UpdateFeedFetchedFunc = func(ctx context.Context, feedID int64, nextFetch time.Time) error {
+		return nil
+	}
+
+	t.Run("fetches only due feeds", func(t *testing.T) {
+		feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
+			assert.Equal(t, dueFeedsBatchSize, limit)
+			return []domain.Feed{{ID: 1, URL: "https://example.com/due.xml", FetchInterval: time.Hour}}, nil
+		}
+		fp.UpdateDueFeeds(context.Background())
+		require.Len(t, parser.FetchCalls(), 1)
+		assert.Equal(t, "https://example.com/due.xml", parser.FetchCalls()[0].F.URL)
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), feedManager.UpdateFeedFetchedCalls()[0].NextFetch, 5*time.Second)
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
+			return nil, nil
+		}
+		fp.UpdateDueFeeds(context.Background())
+		assert.Len(t, parser.FetchCalls(), 1, "no new parse calls")
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_ConditionalFetch(t *testing.T) {
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+		UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+		AddFeedBytesSavedFunc: func(ctx context.Context, feedID, bytes int64) error { return nil },
+		UpdateFeedCacheFunc: func(ctx context.Context, feedID int64, etag, lastModified string, size int64) error {
+			return nil
+		},
+	}
+	itemManager := &mocks.ItemManagerMock{}
+	parser := &mocks.ParserMock{}
+
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:  newJobManager(),
+		FeedManager: feedManager,
+		ItemManager: itemManager,
+		Parser:      parser,
+		MaxWorkers:  1,
+		RetryFunc:   func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+			assert.Equal(t, `"v1"`, f.ETag)
+			return &domain.ParsedFeed{NotModified: true, ETag: f.ETag}, nil
+		}
+		f := &domain.Feed{ID: 1, URL: "https://example.com/feed.xml", FetchInterval: time.Hour, ETag: `"v1"`, LastSize: 2048}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.AddFeedBytesSavedCalls(), 1)
+		assert.Equal(t, int64(2048), feedManager.AddFeedBytesSavedCalls()[0].Bytes)
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1, "not modified is a successful fetch")
+		assert.WithinDuration(t, time.Now().Add(time.Hour), feedManager.UpdateFeedFetchedCalls()[0].NextFetch, 5*time.Second)
+		assert.Empty(t, feedManager.UpdateFeedCacheCalls())
+		assert.Empty(t, itemManager.ItemExistsCalls())
+	})
+
+	t.Run("modified stores new validators", func(t *testing.T) {
+		parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+			return &domain.ParsedFeed{ETag: `"v2"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT", Size: 4096}, nil
+		}
+		f := &domain.Feed{ID: 1, URL: "https://example.com/feed.xml", FetchInterval: time.Hour, ETag: `"v1"`, LastSize: 2048}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedCacheCalls(), 1)
+		call := feedManager.UpdateFeedCacheCalls()[0]
+		assert.Equal(t, `"v2"`, call.Etag)
+		assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 GMT", call.LastModified)
+		assert.Equal(t, int64(4096), call.Size)
+		assert.Len(t, feedManager.AddFeedBytesSavedCalls(), 1, "no bytes saved on full fetch")
+		assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 2)
+	})
+}
+
+func TestFeedProcessor_DiscoverFeeds(t *testing.T) {
+	t.Run("returns candidates", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			DiscoverFunc: func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+				return []domain.FeedCandidate{{URL: pageURL + "/feed", Title: "Blog", ItemCount: 5}}, nil
+			},
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{Parser: parser})
+
+		candidates, err := fp.DiscoverFeeds(context.Background(), "https://example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.FeedCandidate{{URL: "https://example.com/feed", Title: "Blog", ItemCount: 5}}, candidates)
+	})
+
+	t.Run("discovery error", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			DiscoverFunc: func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+				return nil, fmt.Errorf("connection refused")
+			},
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{Parser: parser})
+
+		_, err := fp.DiscoverFeeds(context.Background(), "https://example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestFeedProcessor_ScrapePage(t *testing.T) {
+	sel := domain.ScrapeSelectors{Item: "article", Title: "h2"}
+
+	t.Run("returns scraped items", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			ScrapeFunc: func(ctx context.Context, pageURL string, s domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+				assert.Equal(t, sel, s)
+				return &domain.ParsedFeed{Title: "News", Items: []domain.ParsedItem{{Title: "first", Link: pageURL + "/1"}}}, nil
+			},
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{Parser: parser, FeedManager: &mocks.FeedManagerMock{}, ItemManager: &mocks.ItemManagerMock{}})
+
+		parsed, err := fp.ScrapePage(context.Background(), "https://example.com/news", sel)
+		require.NoError(t, err)
+		require.Len(t, parsed.Items, 1)
+		assert.Equal(t, "https://example.com/news/1", parsed.Items[0].Link)
+	})
+
+	t.Run("scrape error", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			ScrapeFunc: func(ctx context.Context, pageURL string, s domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+				return nil, fmt.Errorf(`no items found with selector "article"`)
+			},
+		}
+		fp := NewFeedProcessor(FeedProcessorConfig{Parser: parser})
+
+		_, err := fp.ScrapePage(context.Background(), "https://example.com/news", sel)
+		require.EqualError(t, err, `scrape https://example.com/news: no items found with selector "article"`)
+	})
+}
+
+func TestFeedProcessor_PreviewFeed(t *testing.T) {
+	newProcessor := func(parser *mocks.ParserMock, classifier *mocks.ClassifierMock) *FeedProcessor {
+		return NewFeedProcessor(FeedProcessorConfig{
+			JobManager:  newJobManager(),
+			FeedManager: &mocks.FeedManagerMock{}, // no feed or item methods set, preview must not store anything
+			ItemManager: &mocks.ItemManagerMock{},
+			ClassificationManager: &mocks.ClassificationManagerMock{
+				GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+					return []domain.FeedbackExample{{Title: "liked", Feedback: domain.FeedbackLike}}, nil
+				},
+				GetTopicsFunc: func(ctx context.Context) ([]string, error) { return []string{"go"}, nil },
+			},
+			SettingManager: &mocks.SettingManagerMock{
+				GetSettingFunc: func(ctx context.Context, key string) (string, error) {
+					switch key {
+					case "preference_summary":
+						return "likes go", nil
+					case domain.SettingPreferredTopics:
+						return `["golang"]`, nil
+					}
+					return "", nil
+				},
+			},
+			Parser:     parser,
+			Classifier: classifier,
+		})
+	}
+
+	t.Run("classifies sample of latest items", func(t *testing.T) {
+		items := make([]domain.ParsedItem, 25)
+		for i := range items {
+			items[i] = domain.ParsedItem{GUID: fmt.Sprintf("g%d", i), Title: fmt.Sprintf("item %d", i), Link: fmt.Sprintf("https://example.com/%d", i)}
+		}
+		parser := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				assert.Empty(t, f.ETag, "preview fetch is never conditional")
+				return &domain.ParsedFeed{Title: "Example", Items: items}, nil
+			},
+		}
+		classifier := &mocks.ClassifierMock{
+			ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+				res := make([]domain.Classification, 0, len(req.Articles))
+				for i, a := range req.Articles {
+					res = append(res, domain.Classification{GUID: a.GUID, Score: float64(i)}) // scores 0..9
+				}
+				return res, nil
+			},
+		}
+
+		preview, err := newProcessor(parser, classifier).PreviewFeed(context.Background(), "https://example.com/feed")
+		require.NoError(t, err)
+
+		require.Len(t, classifier.ClassifyItemsCalls(), 1)
+		req := classifier.ClassifyItemsCalls()[0].Req
+		assert.Len(t, req.Articles, previewSampleSize)
+		assert.Equal(t, "likes go", req.PreferenceSummary)
+		assert.Equal(t, []string{"golang"}, req.PreferredTopics)
+		assert.Equal(t, []string{"go"}, req.CanonicalTopics)
+		assert.Len(t, req.Feedbacks, 1)
+
+		assert.Equal(t, "Example", preview.Title)
+		require.Len(t, preview.Items, previewItemsLimit)
+		assert.Equal(t, "item 0", preview.Items[0].Title)
+		assert.InDelta(t, 9.0, preview.Items[9].GetRelevanceScore(), 0.001)
+		assert.Nil(t, preview.Items[10].Classification)
+		assert.Equal(t, 10, preview.ClassifiedCount())
+		assert.InDelta(t, 4.5, preview.AverageScore(), 0.001)
+		assert.InDelta(t, 0.5, preview.ShareAbove(5.0), 0.001)
+	})
+
+	t.Run("empty feed is not classified", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return &domain.ParsedFeed{Title: "Empty"}, nil
+			},
+		}
+		classifier := &mocks.ClassifierMock{}
+
+		preview, err := newProcessor(parser, classifier).PreviewFeed(context.Background(), "https://example.com/feed")
+		require.NoError(t, err)
+		assert.Empty(t, preview.Items)
+		assert.Zero(t, preview.AverageScore())
+		assert.Zero(t, preview.ShareAbove(5.0))
+		assert.Empty(t, classifier.ClassifyItemsCalls())
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return nil, fmt.Errorf("unexpected status code: 404")
+			},
+		}
+		_, err := newProcessor(parser, &mocks.ClassifierMock{}).PreviewFeed(context.Background(), "https://example.com/feed")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+
+	t.Run("classification error", func(t *testing.T) {
+		parser := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return &domain.ParsedFeed{Items: []domain.ParsedItem{{GUID: "g1", Title: "one"}}}, nil
+			},
+		}
+		classifier := &mocks.ClassifierMock{
+			ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+				return nil, fmt.Errorf("llm request failed")
+			},
+		}
+		_, err := newProcessor(parser, classifier).PreviewFeed(context.Background(), "https://example.com/feed")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "classify preview items")
+	})
+}
+
+func TestFeedProcessor_UpdateFeed_WebSub(t *testing.T) {
+	newProcessor := func(feedManager *mocks.FeedManagerMock, subscriber *mocks.WebSubscriberMock, hub string) *FeedProcessor {
+		parser := &mocks.ParserMock{
+			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+				return &domain.ParsedFeed{HubURL: hub, SelfURL: "https://example.com/self.xml"}, nil
+			},
+		}
+		return NewFeedProcessor(FeedProcessorConfig{
+			JobManager:           newJobManager(),
+			FeedManager:          feedManager,
+			ItemManager:          &mocks.ItemManagerMock{},
+			Parser:               parser,
+			WebSubscriber:        subscriber,
+			WebSubCallbackURL:    "https://newscope.example.com/",
+			WebSubLease:          240 * time.Hour,
+			WebSubSafetyInterval: 12 * time.Hour,
+			RetryFunc:            func(ctx context.Context, op func() error) error { return op() },
+		})
+	}
+	newFeedManager := func() *mocks.FeedManagerMock {
+		return &mocks.FeedManagerMock{
+			AddFeedFetchFunc:      func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+			UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error { return nil },
+			UpdateFeedWebSubFunc: func(ctx context.Context, feedID int64, hubURL, topic, secret string) error {
+				return nil
+			},
+			ClearFeedWebSubFunc: func(ctx context.Context, feedID int64) error { return nil },
+		}
+	}
+
+	t.Run("subscribes to advertised hub", func(t *testing.T) {
+		feedManager := newFeedManager()
+		subscriber := &mocks.WebSubscriberMock{
+			SubscribeFunc: func(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error {
+				return nil
+			},
+		}
+		fp := newProcessor(feedManager, subscriber, "https://hub.example.com/")
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, feedManager.UpdateFeedWebSubCalls(), 1)
+		stored := feedManager.UpdateFeedWebSubCalls()[0]
+		assert.Equal(t, "https://hub.example.com/", stored.HubURL)
+		assert.Equal(t, "https://example.com/self.xml", stored.Topic)
+		assert.Len(t, stored.Secret, 40)
+
+		require.Len(t, subscriber.SubscribeCalls(), 1)
+		call := subscriber.SubscribeCalls()[0]
+		assert.Equal(t, "https://hub.example.com/", call.HubURL)
+		assert.Equal(t, "https://example.com/self.xml", call.Topic)
+		assert.Equal(t, "https://newscope.example.com/websub/7", call.Callback)
+		assert.Equal(t, stored.Secret, call.Secret)
+		assert.Equal(t, 240*time.Hour, call.Lease)
+		assert.Empty(t, feedManager.ClearFeedWebSubCalls())
+
+		// pending subscription doesn't change polling
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), feedManager.UpdateFeedFetchedCalls()[0].NextFetch, 5*time.Second)
+	})
+
+	t.Run("active subscription polls at safety interval", func(t *testing.T) {
+		feedManager := newFeedManager()
+		fp := newProcessor(feedManager, &mocks.WebSubscriberMock{}, "https://hub.example.com/") // subscribe must not be called
+		expires := time.Now().Add(200 * time.Hour)
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour,
+			HubURL: "https://hub.example.com/", WebSubTopic: "https://example.com/self.xml", WebSubSecret: "s", WebSubExpires: &expires}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		assert.Empty(t, feedManager.UpdateFeedWebSubCalls())
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+		assert.WithinDuration(t, time.Now().Add(12*time.Hour), feedManager.UpdateFeedFetchedCalls()[0].NextFetch, 5*time.Second)
+	})
+
+	t.Run("renews expiring lease", func(t *testing.T) {
+		feedManager := newFeedManager()
+		subscriber := &mocks.WebSubscriberMock{
+			SubscribeFunc: func(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error {
+				return nil
+			},
+		}
+		fp := newProcessor(feedManager, subscriber, "https://hub.example.com/")
+		expires := time.Now().Add(20 * time.Hour)
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour,
+			HubURL: "https://hub.example.com/", WebSubTopic: "https://example.com/self.xml", WebSubSecret: "s", WebSubExpires: &expires}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		assert.Len(t, subscriber.SubscribeCalls(), 1)
+		assert.Len(t, feedManager.UpdateFeedWebSubCalls(), 1)
+	})
+
+	t.Run("rejected subscription is cleared", func(t *testing.T) {
+		feedManager := newFeedManager()
+		subscriber := &mocks.WebSubscriberMock{
+			SubscribeFunc: func(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error {
+				return fmt.Errorf("hub rejected subscription, status 400")
+			},
+		}
+		fp := newProcessor(feedManager, subscriber, "https://hub.example.com/")
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		assert.Len(t, subscriber.SubscribeCalls(), 1)
+		require.Len(t, feedManager.ClearFeedWebSubCalls(), 1)
+		assert.Equal(t, int64(7), feedManager.ClearFeedWebSubCalls()[0].FeedID)
+		assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+	})
+
+	t.Run("hub change unsubscribes from the old hub", func(t *testing.T) {
+		feedManager := newFeedManager()
+		subscriber := &mocks.WebSubscriberMock{
+			SubscribeFunc: func(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error {
+				return nil
+			},
+			UnsubscribeFunc: func(ctx context.Context, hubURL, topic, callback string) error { return nil },
+		}
+		fp := newProcessor(feedManager, subscriber, "https://new-hub.example.com/")
+		expires := time.Now().Add(200 * time.Hour)
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour,
+			HubURL: "https://old-hub.example.com/", WebSubTopic: "https://example.com/self.xml", WebSubSecret: "s", WebSubExpires: &expires}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, subscriber.UnsubscribeCalls(), 1)
+		call := subscriber.UnsubscribeCalls()[0]
+		assert.Equal(t, "https://old-hub.example.com/", call.HubURL)
+		assert.Equal(t, "https://example.com/self.xml", call.Topic)
+		assert.Equal(t, "https://newscope.example.com/websub/7", call.Callback)
+		require.Len(t, subscriber.SubscribeCalls(), 1)
+		assert.Equal(t, "https://new-hub.example.com/", subscriber.SubscribeCalls()[0].HubURL)
+		require.NoError(t, fp.VerifyWebSub(context.Background(), 7, "unsubscribe", "https://example.com/self.xml", 0),
+			"old hub's verification confirmed")
+	})
+
+	t.Run("hub no longer advertised", func(t *testing.T) {
+		feedManager := newFeedManager()
+		subscriber := &mocks.WebSubscriberMock{
+			UnsubscribeFunc: func(ctx context.Context, hubURL, topic, callback string) error { return nil },
+		}
+		fp := newProcessor(feedManager, subscriber, "")
+		expires := time.Now().Add(200 * time.Hour)
+		f := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", FetchInterval: time.Hour,
+			HubURL: "https://hub.example.com/", WebSubTopic: "https://example.com/self.xml", WebSubSecret: "s", WebSubExpires: &expires}
+		fp.UpdateFeed(context.Background(), f, domain.PriorityNormal)
+
+		require.Len(t, subscriber.UnsubscribeCalls(), 1)
+		assert.Equal(t, "https://hub.example.com/", subscriber.UnsubscribeCalls()[0].HubURL)
+		assert.Len(t, feedManager.ClearFeedWebSubCalls(), 1)
+		assert.Empty(t, feedManager.UpdateFeedWebSubCalls())
+		require.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), feedManager.UpdateFeedFetchedCalls()[0].NextFetch, 5*time.Second,
+			"polled at the feed's own interval again")
+	})
+
+	t.Run("no hub or disabled push", func(t *testing.T) {
+		feedManager := newFeedManager()
+		fp := newProcessor(feedManager, &mocks.WebSubscriberMock{}, "")
+		fp.UpdateFeed(context.Background(), &domain.Feed{ID: 7, FetchInterval: time.Hour}, domain.PriorityNormal)
+		assert.Empty(t, feedManager.UpdateFeedWebSubCalls())
+
+		fp = newProcessor(feedManager, nil, "https://hub.example.com/")
+		fp.webSubscriber = nil
+		fp.UpdateFeed(context.Background(), &domain.Feed{ID: 7, FetchInterval: time.Hour}, domain.PriorityNormal)
+		assert.Empty(t, feedManager.UpdateFeedWebSubCalls())
+	})
+}
+
+func TestFeedProcessor_VerifyWebSub(t *testing.T) {
+	feed := &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", WebSubTopic: "https://example.com/self.xml", WebSubSecret: "s"}
+
+	tests := []struct {
+		name        string
+		feed        *domain.Feed
+		mode, topic string
+		lease       time.Duration
+		wantErr     bool
+		wantExpires time.Duration // zero if no confirmation expected
+		wantClear   bool
+	}{
+		{name: "subscribe with lease", feed: feed, mode: "subscribe", topic: feed.WebSubTopic, lease: time.Hour, wantExpires: time.Hour},
+		{name: "subscribe without lease", feed: feed, mode: "subscribe", topic: feed.WebSubTopic, wantExpires: 240 * time.Hour},
+		{name: "subscribe wrong topic", feed: feed, mode: "subscribe", topic: "https://other.example.com/", wantErr: true},
+		{name: "subscribe not requested", feed: &domain.Feed{ID: 7}, mode: "subscribe", topic: "", wantErr: true},
+		{name: "denied", feed: feed, mode: "denied", topic: feed.WebSubTopic, wantClear: true},
+		{name: "unsubscribe not requested", feed: feed, mode: "unsubscribe", topic: feed.WebSubTopic, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedManager := &mocks.FeedManagerMock{
+				GetFeedFunc:           func(ctx context.Context, id int64) (*domain.Feed, error) { return tt.feed, nil },
+				ConfirmFeedWebSubFunc: func(ctx context.Context, feedID int64, expires time.Time) error { return nil },
+				ClearFeedWebSubFunc:   func(ctx context.Context, feedID int64) error { return nil },
+			}
+			fp := NewFeedProcessor(FeedProcessorConfig{
+				FeedManager: feedManager,
+				WebSubLease: 240 * time.Hour,
+				RetryFunc:   func(ctx context.Context, op func() error) error { return op() },
+			})
+
+			err := fp.VerifyWebSub(context.Background(), 7, tt.mode, tt.topic, tt.lease)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantExpires > 0 {
+				require.Len(t, feedManager.ConfirmFeedWebSubCalls(), 1)
+				assert.WithinDuration(t, time.Now().Add(tt.wantExpires), feedManager.ConfirmFeedWebSubCalls()[0].Expires, 5*time.Second)
+			} else {
+				assert.Empty(t, feedManager.ConfirmFeedWebSubCalls())
+			}
+			if tt.wantClear {
+				assert.Len(t, feedManager.ClearFeedWebSubCalls(), 1)
+			} else {
+				assert.Empty(t, feedManager.ClearFeedWebSubCalls())
+			}
+		})
+	}
+}
+
+func TestFeedProcessor_UnsubscribeWebSub(t *testing.T) {
+	const topic = "https://example.com/self.xml"
+	subscribed := func() *domain.Feed {
+		return &domain.Feed{ID: 7, URL: "https://example.com/feed.xml", HubURL: "https://hub.example.com/",
+			WebSubTopic: topic, WebSubSecret: "s"}
+	}
+	newProcessor := func(feed *domain.Feed, subscriber *mocks.WebSubscriberMock) (*FeedProcessor, *mocks.FeedManagerMock) {
+		feedManager := &mocks.FeedManagerMock{
+			GetFeedFunc:         func(ctx context.Context, id int64) (*domain.Feed, error) { return feed, nil },
+			ClearFeedWebSubFunc: func(ctx context.Context, feedID int64) error { return nil },
+			DisableFeedFunc:     func(ctx context.Context, feedID int64, reason string) error { return nil },
+			AddFeedEventFunc:    func(ctx context.Context, event *domain.FeedEvent) error { return nil },
+		}
+		return NewFeedProcessor(FeedProcessorConfig{
+			FeedManager:       feedManager,
+			WebSubscriber:     subscriber,
+			WebSubCallbackURL: "https://newscope.example.com",
+			RetryFunc:         func(ctx context.Context, op func() error) error { return op() },
+		}), feedManager
+	}
+
+	t.Run("unsubscribes and confirms the verification once", func(t *testing.T) {
+		subscriber := &mocks.WebSubscriberMock{
+			UnsubscribeFunc: func(ctx context.Context, hubURL, topic, callback string) error { return nil },
+		}
+		fp, feedManager := newProcessor(subscribed(), subscriber)
+		require.NoError(t, fp.UnsubscribeWebSub(context.Background(), 7))
+
+		require.Len(t, subscriber.UnsubscribeCalls(), 1)
+		call := subscriber.UnsubscribeCalls()[0]
+		assert.Equal(t, "https://hub.example.com/", call.HubURL)
+		assert.Equal(t, topic, call.Topic)
+		assert.Equal(t, "https://newscope.example.com/websub/7", call.Callback)
+		assert.Len(t, feedManager.ClearFeedWebSubCalls(), 1)
+
+		// the feed may be deleted by the time the hub verifies
+		feedManager.GetFeedFunc = func(ctx context.Context, id int64) (*domain.Feed, error) { return nil, fmt.Errorf("feed not found") }
+		require.Error(t, fp.VerifyWebSub(context.Background(), 7, "unsubscribe", "https://other.example.com/", 0))
+		require.NoError(t, fp.VerifyWebSub(context.Background(), 7, "unsubscribe", topic, 0))
+		require.Error(t, fp.VerifyWebSub(context.Background(), 7, "unsubscribe", topic, 0), "confirmed only once")
+	})
+
+	t.Run("rejected request is not confirmed", func(t *testing.T) {
+		subscriber := &mocks.WebSubscriberMock{
+			UnsubscribeFunc: func(ctx context.Context, hubURL, topic, callback string) error {
+				return fmt.Errorf("hub rejected unsubscribe, status 404")
+			},
+		}
+		fp, feedManager := newProcessor(subscribed(), subscriber)
+		require.NoError(t, fp.UnsubscribeWebSub(context.Background(), 7))
+		assert.Len(t, feedManager.ClearFeedWebSubCalls(), 1, "cleared anyway, the lease runs out")
+		require.Error(t, fp.VerifyWebSub(context.Background(), 7, "unsubscribe", topic, 0))
+	})
+
+	t.Run("no subscription", func(t *testing.T) {
+		fp, feedManager := newProcessor(&domain.Feed{ID: 7, HubURL: "https://hub.example.com/"}, &mocks.WebSubscriberMock{})
+		require.NoError(t, fp.UnsubscribeWebSub(context.Background(), 7))
+		assert.Empty(t, feedManager.ClearFeedWebSubCalls())
+	})
+
+	t.Run("feed not found", func(t *testing.T) {
+		fp, feedManager := newProcessor(nil, &mocks.WebSubscriberMock{})
+		feedManager.GetFeedFunc = func(ctx context.Context, id int64) (*domain.Feed, error) { return nil, fmt.Errorf("feed not found") }
+		require.Error(t, fp.UnsubscribeWebSub(context.Background(), 7))
+	})
+
+	t.Run("gone feed is unsubscribed", func(t *testing.T) {
+		subscriber := &mocks.WebSubscriberMock{
+			UnsubscribeFunc: func(ctx context.Context, hubURL, topic, callback string) error { return nil },
+		}
+		fp, feedManager := newProcessor(nil, subscriber)
+		fp.handleFeedGone(context.Background(), subscribed())
+		require.Len(t, feedManager.DisableFeedCalls(), 1)
+		assert.Len(t, subscriber.UnsubscribeCalls(), 1)
+		assert.Len(t, feedManager.ClearFeedWebSubCalls(), 1)
+	})
+}
+
+func TestFeedProcessor_HandleWebSubPush(t *testing.T) {
+	body := []byte(`<rss><channel><item><guid>g1</guid></item></channel></rss>`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	jobManager := newJobManager()
+	newProcessor := func(itemManager *mocks.ItemManagerMock, feed *domain.Feed) *FeedProcessor {
+		return NewFeedProcessor(FeedProcessorConfig{
+			FeedManager: &mocks.FeedManagerMock{
+				GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return feed, nil },
+			},
+			ItemManager: itemManager,
+			JobManager:  jobManager,
+			Parser: &mocks.ParserMock{
+				ParseContentFunc: func(content []byte) (*domain.ParsedFeed, error) {
+					if string(content) != string(body) {
+						return nil, fmt.Errorf("bad content")
+					}
+					return &domain.ParsedFeed{Items: []domain.ParsedItem{{GUID: "g1", Title: "Pushed", Link: "https://example.com/1"}}}, nil
+				},
+			},
+			RetryFunc: func(ctx context.Context, op func() error) error { return op() },
+		})
+	}
+	feed := &domain.Feed{ID: 7, Title: "Pushed Feed", Enabled: true, WebSubSecret: "secret"}
+
+	t.Run("stores and queues new items", func(t *testing.T) {
+		itemManager := &mocks.ItemManagerMock{
+			ItemExistsFunc:             func(ctx context.Context, feedID int64, guid string) (bool, error) { return false, nil },
+			ItemExistsByTitleOrURLFunc: func(ctx context.Context, title, url string) (bool, error) { return false, nil },
+			CreateItemFunc: func(ctx context.Context, item *domain.Item) error {
+				item.ID = 42
+				return nil
+			},
+		}
+		fp := newProcessor(itemManager, feed)
+		err := fp.HandleWebSubPush(context.Background(), 7, body, signature)
+		require.NoError(t, err)
+		assert.Empty(t, itemManager.CreateItemCalls(), "stored by the worker")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			fp.WebSubWorker(ctx)
+			close(done)
+		}()
+		require.Eventually(t, func() bool { return len(jobManager.EnqueueJobCalls()) == 1 }, time.Second, 5*time.Millisecond)
+		cancel()
+		<-done
+
+		require.Len(t, itemManager.CreateItemCalls(), 1)
+		assert.Equal(t, int64(7), itemManager.CreateItemCalls()[0].Item.FeedID)
+		assert.Equal(t, "Pushed", itemManager.CreateItemCalls()[0].Item.Title)
+		require.Len(t, jobManager.EnqueueJobCalls(), 1)
+		assert.Equal(t, int64(42), jobManager.EnqueueJobCalls()[0].ItemID)
+		assert.Equal(t, domain.PriorityNormal, jobManager.EnqueueJobCalls()[0].Priority)
+	})
+
+	t.Run("invalid signature ignored", func(t *testing.T) {
+		itemManager := &mocks.ItemManagerMock{} // nothing must be stored
+		err := newProcessor(itemManager, feed).HandleWebSubPush(context.Background(), 7, body, "sha256=00")
+		require.NoError(t, err)
+	})
+
+	t.Run("not subscribed ignored", func(t *testing.T) {
+		itemManager := &mocks.ItemManagerMock{}
+		notSubscribed := &domain.Feed{ID: 7, Enabled: true}
+		err := newProcessor(itemManager, notSubscribed).HandleWebSubPush(context.Background(), 7, body, signature)
+		require.NoError(t, err)
+	})
+
+	t.Run("unparsable content", func(t *testing.T) {
+		other := []byte("not a feed")
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(other)
+		err := newProcessor(&mocks.ItemManagerMock{}, feed).HandleWebSubPush(context.Background(), 7, other,
+			"sha256="+hex.EncodeToString(mac.Sum(nil)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parse pushed content")
+	})
+
+	t.Run("too many waiting pushes", func(t *testing.T) {
+		fp := newProcessor(&mocks.ItemManagerMock{}, feed)
+		for range webSubPushQueueSize {
+			require.NoError(t, fp.HandleWebSubPush(context.Background(), 7, body, signature))
+		}
+		err := fp.HandleWebSubPush(context.Background(), 7, body, signature)
+		require.ErrorIs(t, err, domain.ErrWebSubBusy)
+	})
+}
+
+func TestFeedProcessor_ProcessingWorker(t *testing.T) {
+	var queueMu sync.Mutex
+	var queue []int64
+	jobManager := newJobManager()
+	jobManager.EnqueueJobFunc = func(ctx context.Context, itemID int64, priority int) error {
+		queueMu.Lock()
+		defer queueMu.Unlock()
+		queue = append(queue, itemID)
+		return nil
+	}
+	jobManager.ClaimJobFunc = func(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error) {
+		queueMu.Lock()
+		defer queueMu.Unlock()
+		if len(queue) == 0 {
+			return nil, nil
+		}
+		job := &domain.ProcessJob{ItemID: queue[0], State: domain.JobExtracting, Attempts: 1}
+		queue = queue[1:]
+		return job, nil
+	}
+	itemManager := &mocks.ItemManagerMock{
+		GetItemFunc: func(ctx context.Context, id int64) (*domain.Item, error) {
+			return &domain.Item{ID: id, FeedID: 1, Link: "https://example.com/report.pdf"}, nil
+		},
+		UpdateItemExtractionFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error { return nil },
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+		},
+		ItemManager: itemManager,
+		JobManager:  jobManager,
+		Extractor: &mocks.ExtractorMock{
+			ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+				return nil, errors.New("unsupported content type: image/png")
+			},
+		},
+		MaxExtractions:     2,
+		JobTimeout:         time.Minute,
+		MaxProcessAttempts: 3,
+		RetryFunc:          func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		fp.ProcessingWorker(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return len(jobManager.ClaimJobCalls()) > 0 }, time.Second, 5*time.Millisecond)
+	for _, id := range []int64{1, 2, 3} {
+		fp.enqueue(ctx, &domain.Item{ID: id}, domain.PriorityNormal)
+	}
+	// new jobs wake up the idle worker without waiting for the next poll
+	require.Eventually(t, func() bool { return len(jobManager.UpdateJobStateCalls()) == 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Len(t, jobManager.RecoverJobsCalls(), 1, "interrupted jobs recovered on start")
+	assert.Equal(t, time.Minute, jobManager.ClaimJobCalls()[0].Timeout)
+	assert.Len(t, itemManager.UpdateItemExtractionCalls(), 3)
+	for _, call := range jobManager.UpdateJobStateCalls() {
+		assert.Equal(t, domain.JobDone, call.State)
+	}
+}
+
+func TestFeedProcessor_FinishJob(t *testing.T) {
+	newProcessor := func(jobManager JobManager) *FeedProcessor {
+		return NewFeedProcessor(FeedProcessorConfig{
+			JobManager:         jobManager,
+			MaxProcessAttempts: 3,
+			RetryFunc:          func(ctx context.Context, op func() error) error { return op() },
+		})
+	}
+
+	t.Run("done", func(t *testing.T) {
+		jobManager := newJobManager()
+		newProcessor(jobManager).finishJob(context.Background(), &domain.ProcessJob{ItemID: 1, Attempts: 3}, nil)
+		require.Len(t, jobManager.UpdateJobStateCalls(), 1)
+		assert.Equal(t, domain.JobDone, jobManager.UpdateJobStateCalls()[0].State)
+		assert.Empty(t, jobManager.RetryJobCalls())
+	})
+
+	t.Run("retried with backoff", func(t *testing.T) {
+		jobManager := newJobManager()
+		newProcessor(jobManager).finishJob(context.Background(), &domain.ProcessJob{ItemID: 1, Attempts: 2}, errors.New("classify: timeout"))
+		require.Len(t, jobManager.RetryJobCalls(), 1)
+		assert.Equal(t, "classify: timeout", jobManager.RetryJobCalls()[0].ErrMsg)
+		assert.Equal(t, 2*jobRetryDelay, jobManager.RetryJobCalls()[0].Delay)
+		assert.Empty(t, jobManager.UpdateJobStateCalls())
+	})
+
+	t.Run("failed out of attempts", func(t *testing.T) {
+		jobManager := newJobManager()
+		newProcessor(jobManager).finishJob(context.Background(), &domain.ProcessJob{ItemID: 1, Attempts: 3}, errors.New("classify: timeout"))
+		require.Len(t, jobManager.UpdateJobStateCalls(), 1)
+		assert.Equal(t, domain.JobFailed, jobManager.UpdateJobStateCalls()[0].State)
+		assert.Equal(t, "classify: timeout", jobManager.UpdateJobStateCalls()[0].ErrMsg)
+		assert.Empty(t, jobManager.RetryJobCalls())
+	})
+
+	t.Run("left claimed on shutdown", func(t *testing.T) {
+		jobManager := &mocks.JobManagerMock{} // nothing must be recorded
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		newProcessor(jobManager).finishJob(ctx, &domain.ProcessJob{ItemID: 1, Attempts: 1}, context.Canceled)
+	})
+
+	t.Run("expired claims count as attempts", func(t *testing.T) {
+		jobManager := newJobManager()
+		fp := newProcessor(jobManager)
+		fp.itemManager = &mocks.ItemManagerMock{} // item not processed
+		fp.processJob(context.Background(), &domain.ProcessJob{ItemID: 1, Attempts: 4})
+		require.Len(t, jobManager.UpdateJobStateCalls(), 1)
+		assert.Equal(t, domain.JobFailed, jobManager.UpdateJobStateCalls()[0].State)
+		assert.Equal(t, "not finished after 3 attempts", jobManager.UpdateJobStateCalls()[0].ErrMsg)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, retryDelay(1))
+	assert.Equal(t, 10*time.Minute, retryDelay(2))
+	assert.Equal(t, 40*time.Minute, retryDelay(4))
+	assert.Equal(t, 24*time.Hour, retryDelay(20), "capped at a day")
+}
+
+// newJobManager returns a job manager mock of an empty queue accepting all updates, ClaimItemJob claims the first attempt
+func newJobManager() *mocks.JobManagerMock {
+	return &mocks.JobManagerMock{
+		EnqueueJobFunc:  func(ctx context.Context, itemID int64, priority int) error { return nil },
+		RecoverJobsFunc: func(ctx context.Context) (int64, error) { return 0, nil },
+		ClaimJobFunc:    func(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error) { return nil, nil },
+		ClaimItemJobFunc: func(ctx context.Context, itemID int64, timeout time.Duration) (*domain.ProcessJob, error) {
+			return &domain.ProcessJob{ItemID: itemID, State: domain.JobExtracting, Priority: domain.PriorityManual, Attempts: 1}, nil
+		},
+		UpdateJobStateFunc: func(ctx context.Context, itemID int64, state domain.JobState, errMsg string) error { return nil },
+		RetryJobFunc:       func(ctx context.Context, itemID int64, errMsg string, delay time.Duration) error { return nil },
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/umputun/newscope/pkg/content"
+	"github.com/umputun/newscope/pkg/domain"
 )
 
 // ExtractorMock is a mock implementation of scheduler.Extractor.
@@ -16,7 +17,7 @@ import (
 //
 //		// make and configure a mocked scheduler.Extractor
 //		mockedExtractor := &ExtractorMock{
-//			ExtractFunc: func(ctx context.Context, url string) (*content.ExtractResult, error) {
+//			ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 //				panic("mock out the Extract method")
 //			},
 //		}
@@ -27,7 +28,7 @@ import (
 //	}
 type ExtractorMock struct {
 	// ExtractFunc mocks the Extract method.
-	ExtractFunc func(ctx context.Context, url string) (*content.ExtractResult, error)
+	ExtractFunc func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -37,27 +38,31 @@ type ExtractorMock struct {
 			Ctx context.Context
 			// URL is the url argument value.
 			URL string
+			// Auth is the auth argument value.
+			Auth domain.FeedAuth
 		}
 	}
 	lockExtract sync.RWMutex
 }
 
 // Extract calls ExtractFunc.
-func (mock *ExtractorMock) Extract(ctx context.Context, url string) (*content.ExtractResult, error) {
+func (mock *ExtractorMock) Extract(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 	if mock.ExtractFunc == nil {
 		panic("ExtractorMock.ExtractFunc: method is nil but Extractor.Extract was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		URL string
+		Ctx  context.Context
+		URL  string
+		Auth domain.FeedAuth
 	}{
-		Ctx: ctx,
-		URL: url,
+		Ctx:  ctx,
+		URL:  url,
+		Auth: auth,
 	}
 	mock.lockExtract.Lock()
 	mock.calls.Extract = append(mock.calls.Extract, callInfo)
 	mock.lockExtract.Unlock()
-	return mock.ExtractFunc(ctx, url)
+	return mock.ExtractFunc(ctx, url, auth)
 }
 
 // ExtractCalls gets all the calls that were made to Extract.
@@ -65,12 +70,14 @@ func (mock *ExtractorMock) Extract(ctx context.Context, url string) (*content.Ex
 //
 //	len(mockedExtractor.ExtractCalls())
 func (mock *ExtractorMock) ExtractCalls() []struct {
-	Ctx context.Context
-	URL string
+	Ctx  context.Context
+	URL  string
+	Auth domain.FeedAuth
 } {
 	var calls []struct {
-		Ctx context.Context
-		URL string
+		Ctx  context.Context
+		URL  string
+		Auth domain.FeedAuth
 	}
 	mock.lockExtract.RLock()
 	calls = mock.calls.Extract
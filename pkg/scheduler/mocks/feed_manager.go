@@ -17,11 +17,41 @@ import (
 //
 //		// make and configure a mocked scheduler.FeedManager
 //		mockedFeedManager := &FeedManagerMock{
+//			AddFeedBytesSavedFunc: func(ctx context.Context, feedID int64, bytes int64) error {
+//				panic("mock out the AddFeedBytesSaved method")
+//			},
+//			AddFeedEventFunc: func(ctx context.Context, event *domain.FeedEvent) error {
+//				panic("mock out the AddFeedEvent method")
+//			},
+//			AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error {
+//				panic("mock out the AddFeedFetch method")
+//			},
+//			ClearFeedWebSubFunc: func(ctx context.Context, feedID int64) error {
+//				panic("mock out the ClearFeedWebSub method")
+//			},
+//			ConfirmFeedWebSubFunc: func(ctx context.Context, feedID int64, expires time.Time) error {
+//				panic("mock out the ConfirmFeedWebSub method")
+//			},
+//			CreateFeedFunc: func(ctx context.Context, feed *domain.Feed) error {
+//				panic("mock out the CreateFeed method")
+//			},
+//			DisableFeedFunc: func(ctx context.Context, feedID int64, reason string) error {
+//				panic("mock out the DisableFeed method")
+//			},
 //			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
 //				panic("mock out the GetFeed method")
 //			},
-//			GetFeedsFunc: func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
-//				panic("mock out the GetFeeds method")
+//			GetFeedByURLFunc: func(ctx context.Context, url string) (*domain.Feed, error) {
+//				panic("mock out the GetFeedByURL method")
+//			},
+//			GetFeedsToFetchFunc: func(ctx context.Context, limit int) ([]domain.Feed, error) {
+//				panic("mock out the GetFeedsToFetch method")
+//			},
+//			UpdateFeedAdaptiveIntervalFunc: func(ctx context.Context, feedID int64, interval time.Duration) error {
+//				panic("mock out the UpdateFeedAdaptiveInterval method")
+//			},
+//			UpdateFeedCacheFunc: func(ctx context.Context, feedID int64, etag string, lastModified string, size int64) error {
+//				panic("mock out the UpdateFeedCache method")
 //			},
 //			UpdateFeedErrorFunc: func(ctx context.Context, feedID int64, errMsg string) error {
 //				panic("mock out the UpdateFeedError method")
@@ -29,6 +59,15 @@ import (
 //			UpdateFeedFetchedFunc: func(ctx context.Context, feedID int64, nextFetch time.Time) error {
 //				panic("mock out the UpdateFeedFetched method")
 //			},
+//			UpdateFeedMovedToFunc: func(ctx context.Context, feedID int64, url string) error {
+//				panic("mock out the UpdateFeedMovedTo method")
+//			},
+//			UpdateFeedURLFunc: func(ctx context.Context, feedID int64, url string) error {
+//				panic("mock out the UpdateFeedURL method")
+//			},
+//			UpdateFeedWebSubFunc: func(ctx context.Context, feedID int64, hubURL string, topic string, secret string) error {
+//				panic("mock out the UpdateFeedWebSub method")
+//			},
 //		}
 //
 //		// use mockedFeedManager in code that requires scheduler.FeedManager
@@ -36,11 +75,41 @@ import (
 //
 //	}
 type FeedManagerMock struct {
+	// AddFeedBytesSavedFunc mocks the AddFeedBytesSaved method.
+	AddFeedBytesSavedFunc func(ctx context.Context, feedID int64, bytes int64) error
+
+	// AddFeedEventFunc mocks the AddFeedEvent method.
+	AddFeedEventFunc func(ctx context.Context, event *domain.FeedEvent) error
+
+	// AddFeedFetchFunc mocks the AddFeedFetch method.
+	AddFeedFetchFunc func(ctx context.Context, fetch *domain.FeedFetch) error
+
+	// ClearFeedWebSubFunc mocks the ClearFeedWebSub method.
+	ClearFeedWebSubFunc func(ctx context.Context, feedID int64) error
+
+	// ConfirmFeedWebSubFunc mocks the ConfirmFeedWebSub method.
+	ConfirmFeedWebSubFunc func(ctx context.Context, feedID int64, expires time.Time) error
+
+	// CreateFeedFunc mocks the CreateFeed method.
+	CreateFeedFunc func(ctx context.Context, feed *domain.Feed) error
+
+	// DisableFeedFunc mocks the DisableFeed method.
+	DisableFeedFunc func(ctx context.Context, feedID int64, reason string) error
+
 	// GetFeedFunc mocks the GetFeed method.
 	GetFeedFunc func(ctx context.Context, id int64) (*domain.Feed, error)
 
-	// GetFeedsFunc mocks the GetFeeds method.
-	GetFeedsFunc func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error)
+	// GetFeedByURLFunc mocks the GetFeedByURL method.
+	GetFeedByURLFunc func(ctx context.Context, url string) (*domain.Feed, error)
+
+	// GetFeedsToFetchFunc mocks the GetFeedsToFetch method.
+	GetFeedsToFetchFunc func(ctx context.Context, limit int) ([]domain.Feed, error)
+
+	// UpdateFeedAdaptiveIntervalFunc mocks the UpdateFeedAdaptiveInterval method.
+	UpdateFeedAdaptiveIntervalFunc func(ctx context.Context, feedID int64, interval time.Duration) error
+
+	// UpdateFeedCacheFunc mocks the UpdateFeedCache method.
+	UpdateFeedCacheFunc func(ctx context.Context, feedID int64, etag string, lastModified string, size int64) error
 
 	// UpdateFeedErrorFunc mocks the UpdateFeedError method.
 	UpdateFeedErrorFunc func(ctx context.Context, feedID int64, errMsg string) error
@@ -48,8 +117,72 @@ type FeedManagerMock struct {
 	// UpdateFeedFetchedFunc mocks the UpdateFeedFetched method.
 	UpdateFeedFetchedFunc func(ctx context.Context, feedID int64, nextFetch time.Time) error
 
+	// UpdateFeedMovedToFunc mocks the UpdateFeedMovedTo method.
+	UpdateFeedMovedToFunc func(ctx context.Context, feedID int64, url string) error
+
+	// UpdateFeedURLFunc mocks the UpdateFeedURL method.
+	UpdateFeedURLFunc func(ctx context.Context, feedID int64, url string) error
+
+	// UpdateFeedWebSubFunc mocks the UpdateFeedWebSub method.
+	UpdateFeedWebSubFunc func(ctx context.Context, feedID int64, hubURL string, topic string, secret string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddFeedBytesSaved holds details about calls to the AddFeedBytesSaved method.
+		AddFeedBytesSaved []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Bytes is the bytes argument value.
+			Bytes int64
+		}
+		// AddFeedEvent holds details about calls to the AddFeedEvent method.
+		AddFeedEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event *domain.FeedEvent
+		}
+		// AddFeedFetch holds details about calls to the AddFeedFetch method.
+		AddFeedFetch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fetch is the fetch argument value.
+			Fetch *domain.FeedFetch
+		}
+		// ClearFeedWebSub holds details about calls to the ClearFeedWebSub method.
+		ClearFeedWebSub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+		}
+		// ConfirmFeedWebSub holds details about calls to the ConfirmFeedWebSub method.
+		ConfirmFeedWebSub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Expires is the expires argument value.
+			Expires time.Time
+		}
+		// CreateFeed holds details about calls to the CreateFeed method.
+		CreateFeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Feed is the feed argument value.
+			Feed *domain.Feed
+		}
+		// DisableFeed holds details about calls to the DisableFeed method.
+		DisableFeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Reason is the reason argument value.
+			Reason string
+		}
 		// GetFeed holds details about calls to the GetFeed method.
 		GetFeed []struct {
 			// Ctx is the ctx argument value.
@@ -57,12 +190,41 @@ type FeedManagerMock struct {
 			// ID is the id argument value.
 			ID int64
 		}
-		// GetFeeds holds details about calls to the GetFeeds method.
-		GetFeeds []struct {
+		// GetFeedByURL holds details about calls to the GetFeedByURL method.
+		GetFeedByURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// URL is the url argument value.
+			URL string
+		}
+		// GetFeedsToFetch holds details about calls to the GetFeedsToFetch method.
+		GetFeedsToFetch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// UpdateFeedAdaptiveInterval holds details about calls to the UpdateFeedAdaptiveInterval method.
+		UpdateFeedAdaptiveInterval []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Interval is the interval argument value.
+			Interval time.Duration
+		}
+		// UpdateFeedCache holds details about calls to the UpdateFeedCache method.
+		UpdateFeedCache []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// EnabledOnly is the enabledOnly argument value.
-			EnabledOnly bool
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Etag is the etag argument value.
+			Etag string
+			// LastModified is the lastModified argument value.
+			LastModified string
+			// Size is the size argument value.
+			Size int64
 		}
 		// UpdateFeedError holds details about calls to the UpdateFeedError method.
 		UpdateFeedError []struct {
@@ -82,11 +244,319 @@ type FeedManagerMock struct {
 			// NextFetch is the nextFetch argument value.
 			NextFetch time.Time
 		}
+		// UpdateFeedMovedTo holds details about calls to the UpdateFeedMovedTo method.
+		UpdateFeedMovedTo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// URL is the url argument value.
+			URL string
+		}
+		// UpdateFeedURL holds details about calls to the UpdateFeedURL method.
+		UpdateFeedURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// URL is the url argument value.
+			URL string
+		}
+		// UpdateFeedWebSub holds details about calls to the UpdateFeedWebSub method.
+		UpdateFeedWebSub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// HubURL is the hubURL argument value.
+			HubURL string
+			// Topic is the topic argument value.
+			Topic string
+			// Secret is the secret argument value.
+			Secret string
+		}
+	}
+	lockAddFeedBytesSaved          sync.RWMutex
+	lockAddFeedEvent               sync.RWMutex
+	lockAddFeedFetch               sync.RWMutex
+	lockClearFeedWebSub            sync.RWMutex
+	lockConfirmFeedWebSub          sync.RWMutex
+	lockCreateFeed                 sync.RWMutex
+	lockDisableFeed                sync.RWMutex
+	lockGetFeed                    sync.RWMutex
+	lockGetFeedByURL               sync.RWMutex
+	lockGetFeedsToFetch            sync.RWMutex
+	lockUpdateFeedAdaptiveInterval sync.RWMutex
+	lockUpdateFeedCache            sync.RWMutex
+	lockUpdateFeedError            sync.RWMutex
+	lockUpdateFeedFetched          sync.RWMutex
+	lockUpdateFeedMovedTo          sync.RWMutex
+	lockUpdateFeedURL              sync.RWMutex
+	lockUpdateFeedWebSub           sync.RWMutex
+}
+
+// AddFeedBytesSaved calls AddFeedBytesSavedFunc.
+func (mock *FeedManagerMock) AddFeedBytesSaved(ctx context.Context, feedID int64, bytes int64) error {
+	if mock.AddFeedBytesSavedFunc == nil {
+		panic("FeedManagerMock.AddFeedBytesSavedFunc: method is nil but FeedManager.AddFeedBytesSaved was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Bytes  int64
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Bytes:  bytes,
+	}
+	mock.lockAddFeedBytesSaved.Lock()
+	mock.calls.AddFeedBytesSaved = append(mock.calls.AddFeedBytesSaved, callInfo)
+	mock.lockAddFeedBytesSaved.Unlock()
+	return mock.AddFeedBytesSavedFunc(ctx, feedID, bytes)
+}
+
+// AddFeedBytesSavedCalls gets all the calls that were made to AddFeedBytesSaved.
+// Check the length with:
+//
+//	len(mockedFeedManager.AddFeedBytesSavedCalls())
+func (mock *FeedManagerMock) AddFeedBytesSavedCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Bytes  int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Bytes  int64
+	}
+	mock.lockAddFeedBytesSaved.RLock()
+	calls = mock.calls.AddFeedBytesSaved
+	mock.lockAddFeedBytesSaved.RUnlock()
+	return calls
+}
+
+// AddFeedEvent calls AddFeedEventFunc.
+func (mock *FeedManagerMock) AddFeedEvent(ctx context.Context, event *domain.FeedEvent) error {
+	if mock.AddFeedEventFunc == nil {
+		panic("FeedManagerMock.AddFeedEventFunc: method is nil but FeedManager.AddFeedEvent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Event *domain.FeedEvent
+	}{
+		Ctx:   ctx,
+		Event: event,
+	}
+	mock.lockAddFeedEvent.Lock()
+	mock.calls.AddFeedEvent = append(mock.calls.AddFeedEvent, callInfo)
+	mock.lockAddFeedEvent.Unlock()
+	return mock.AddFeedEventFunc(ctx, event)
+}
+
+// AddFeedEventCalls gets all the calls that were made to AddFeedEvent.
+// Check the length with:
+//
+//	len(mockedFeedManager.AddFeedEventCalls())
+func (mock *FeedManagerMock) AddFeedEventCalls() []struct {
+	Ctx   context.Context
+	Event *domain.FeedEvent
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Event *domain.FeedEvent
 	}
-	lockGetFeed           sync.RWMutex
-	lockGetFeeds          sync.RWMutex
-	lockUpdateFeedError   sync.RWMutex
-	lockUpdateFeedFetched sync.RWMutex
+	mock.lockAddFeedEvent.RLock()
+	calls = mock.calls.AddFeedEvent
+	mock.lockAddFeedEvent.RUnlock()
+	return calls
+}
+
+// AddFeedFetch calls AddFeedFetchFunc.
+func (mock *FeedManagerMock) AddFeedFetch(ctx context.Context, fetch *domain.FeedFetch) error {
+	if mock.AddFeedFetchFunc == nil {
+		panic("FeedManagerMock.AddFeedFetchFunc: method is nil but FeedManager.AddFeedFetch was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Fetch *domain.FeedFetch
+	}{
+		Ctx:   ctx,
+		Fetch: fetch,
+	}
+	mock.lockAddFeedFetch.Lock()
+	mock.calls.AddFeedFetch = append(mock.calls.AddFeedFetch, callInfo)
+	mock.lockAddFeedFetch.Unlock()
+	return mock.AddFeedFetchFunc(ctx, fetch)
+}
+
+// AddFeedFetchCalls gets all the calls that were made to AddFeedFetch.
+// Check the length with:
+//
+//	len(mockedFeedManager.AddFeedFetchCalls())
+func (mock *FeedManagerMock) AddFeedFetchCalls() []struct {
+	Ctx   context.Context
+	Fetch *domain.FeedFetch
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Fetch *domain.FeedFetch
+	}
+	mock.lockAddFeedFetch.RLock()
+	calls = mock.calls.AddFeedFetch
+	mock.lockAddFeedFetch.RUnlock()
+	return calls
+}
+
+// ClearFeedWebSub calls ClearFeedWebSubFunc.
+func (mock *FeedManagerMock) ClearFeedWebSub(ctx context.Context, feedID int64) error {
+	if mock.ClearFeedWebSubFunc == nil {
+		panic("FeedManagerMock.ClearFeedWebSubFunc: method is nil but FeedManager.ClearFeedWebSub was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+	}
+	mock.lockClearFeedWebSub.Lock()
+	mock.calls.ClearFeedWebSub = append(mock.calls.ClearFeedWebSub, callInfo)
+	mock.lockClearFeedWebSub.Unlock()
+	return mock.ClearFeedWebSubFunc(ctx, feedID)
+}
+
+// ClearFeedWebSubCalls gets all the calls that were made to ClearFeedWebSub.
+// Check the length with:
+//
+//	len(mockedFeedManager.ClearFeedWebSubCalls())
+func (mock *FeedManagerMock) ClearFeedWebSubCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+	}
+	mock.lockClearFeedWebSub.RLock()
+	calls = mock.calls.ClearFeedWebSub
+	mock.lockClearFeedWebSub.RUnlock()
+	return calls
+}
+
+// ConfirmFeedWebSub calls ConfirmFeedWebSubFunc.
+func (mock *FeedManagerMock) ConfirmFeedWebSub(ctx context.Context, feedID int64, expires time.Time) error {
+	if mock.ConfirmFeedWebSubFunc == nil {
+		panic("FeedManagerMock.ConfirmFeedWebSubFunc: method is nil but FeedManager.ConfirmFeedWebSub was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		FeedID  int64
+		Expires time.Time
+	}{
+		Ctx:     ctx,
+		FeedID:  feedID,
+		Expires: expires,
+	}
+	mock.lockConfirmFeedWebSub.Lock()
+	mock.calls.ConfirmFeedWebSub = append(mock.calls.ConfirmFeedWebSub, callInfo)
+	mock.lockConfirmFeedWebSub.Unlock()
+	return mock.ConfirmFeedWebSubFunc(ctx, feedID, expires)
+}
+
+// ConfirmFeedWebSubCalls gets all the calls that were made to ConfirmFeedWebSub.
+// Check the length with:
+//
+//	len(mockedFeedManager.ConfirmFeedWebSubCalls())
+func (mock *FeedManagerMock) ConfirmFeedWebSubCalls() []struct {
+	Ctx     context.Context
+	FeedID  int64
+	Expires time.Time
+} {
+	var calls []struct {
+		Ctx     context.Context
+		FeedID  int64
+		Expires time.Time
+	}
+	mock.lockConfirmFeedWebSub.RLock()
+	calls = mock.calls.ConfirmFeedWebSub
+	mock.lockConfirmFeedWebSub.RUnlock()
+	return calls
+}
+
+// CreateFeed calls CreateFeedFunc.
+func (mock *FeedManagerMock) CreateFeed(ctx context.Context, feed *domain.Feed) error {
+	if mock.CreateFeedFunc == nil {
+		panic("FeedManagerMock.CreateFeedFunc: method is nil but FeedManager.CreateFeed was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Feed *domain.Feed
+	}{
+		Ctx:  ctx,
+		Feed: feed,
+	}
+	mock.lockCreateFeed.Lock()
+	mock.calls.CreateFeed = append(mock.calls.CreateFeed, callInfo)
+	mock.lockCreateFeed.Unlock()
+	return mock.CreateFeedFunc(ctx, feed)
+}
+
+// CreateFeedCalls gets all the calls that were made to CreateFeed.
+// Check the length with:
+//
+//	len(mockedFeedManager.CreateFeedCalls())
+func (mock *FeedManagerMock) CreateFeedCalls() []struct {
+	Ctx  context.Context
+	Feed *domain.Feed
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Feed *domain.Feed
+	}
+	mock.lockCreateFeed.RLock()
+	calls = mock.calls.CreateFeed
+	mock.lockCreateFeed.RUnlock()
+	return calls
+}
+
+// DisableFeed calls DisableFeedFunc.
+func (mock *FeedManagerMock) DisableFeed(ctx context.Context, feedID int64, reason string) error {
+	if mock.DisableFeedFunc == nil {
+		panic("FeedManagerMock.DisableFeedFunc: method is nil but FeedManager.DisableFeed was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Reason string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Reason: reason,
+	}
+	mock.lockDisableFeed.Lock()
+	mock.calls.DisableFeed = append(mock.calls.DisableFeed, callInfo)
+	mock.lockDisableFeed.Unlock()
+	return mock.DisableFeedFunc(ctx, feedID, reason)
+}
+
+// DisableFeedCalls gets all the calls that were made to DisableFeed.
+// Check the length with:
+//
+//	len(mockedFeedManager.DisableFeedCalls())
+func (mock *FeedManagerMock) DisableFeedCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Reason string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Reason string
+	}
+	mock.lockDisableFeed.RLock()
+	calls = mock.calls.DisableFeed
+	mock.lockDisableFeed.RUnlock()
+	return calls
 }
 
 // GetFeed calls GetFeedFunc.
@@ -125,39 +595,163 @@ func (mock *FeedManagerMock) GetFeedCalls() []struct {
 	return calls
 }
 
-// GetFeeds calls GetFeedsFunc.
-func (mock *FeedManagerMock) GetFeeds(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
-	if mock.GetFeedsFunc == nil {
-		panic("FeedManagerMock.GetFeedsFunc: method is nil but FeedManager.GetFeeds was just called")
+// GetFeedByURL calls GetFeedByURLFunc.
+func (mock *FeedManagerMock) GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error) {
+	if mock.GetFeedByURLFunc == nil {
+		panic("FeedManagerMock.GetFeedByURLFunc: method is nil but FeedManager.GetFeedByURL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		URL string
+	}{
+		Ctx: ctx,
+		URL: url,
+	}
+	mock.lockGetFeedByURL.Lock()
+	mock.calls.GetFeedByURL = append(mock.calls.GetFeedByURL, callInfo)
+	mock.lockGetFeedByURL.Unlock()
+	return mock.GetFeedByURLFunc(ctx, url)
+}
+
+// GetFeedByURLCalls gets all the calls that were made to GetFeedByURL.
+// Check the length with:
+//
+//	len(mockedFeedManager.GetFeedByURLCalls())
+func (mock *FeedManagerMock) GetFeedByURLCalls() []struct {
+	Ctx context.Context
+	URL string
+} {
+	var calls []struct {
+		Ctx context.Context
+		URL string
+	}
+	mock.lockGetFeedByURL.RLock()
+	calls = mock.calls.GetFeedByURL
+	mock.lockGetFeedByURL.RUnlock()
+	return calls
+}
+
+// GetFeedsToFetch calls GetFeedsToFetchFunc.
+func (mock *FeedManagerMock) GetFeedsToFetch(ctx context.Context, limit int) ([]domain.Feed, error) {
+	if mock.GetFeedsToFetchFunc == nil {
+		panic("FeedManagerMock.GetFeedsToFetchFunc: method is nil but FeedManager.GetFeedsToFetch was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetFeedsToFetch.Lock()
+	mock.calls.GetFeedsToFetch = append(mock.calls.GetFeedsToFetch, callInfo)
+	mock.lockGetFeedsToFetch.Unlock()
+	return mock.GetFeedsToFetchFunc(ctx, limit)
+}
+
+// GetFeedsToFetchCalls gets all the calls that were made to GetFeedsToFetch.
+// Check the length with:
+//
+//	len(mockedFeedManager.GetFeedsToFetchCalls())
+func (mock *FeedManagerMock) GetFeedsToFetchCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetFeedsToFetch.RLock()
+	calls = mock.calls.GetFeedsToFetch
+	mock.lockGetFeedsToFetch.RUnlock()
+	return calls
+}
+
+// UpdateFeedAdaptiveInterval calls UpdateFeedAdaptiveIntervalFunc.
+func (mock *FeedManagerMock) UpdateFeedAdaptiveInterval(ctx context.Context, feedID int64, interval time.Duration) error {
+	if mock.UpdateFeedAdaptiveIntervalFunc == nil {
+		panic("FeedManagerMock.UpdateFeedAdaptiveIntervalFunc: method is nil but FeedManager.UpdateFeedAdaptiveInterval was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FeedID   int64
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		FeedID:   feedID,
+		Interval: interval,
+	}
+	mock.lockUpdateFeedAdaptiveInterval.Lock()
+	mock.calls.UpdateFeedAdaptiveInterval = append(mock.calls.UpdateFeedAdaptiveInterval, callInfo)
+	mock.lockUpdateFeedAdaptiveInterval.Unlock()
+	return mock.UpdateFeedAdaptiveIntervalFunc(ctx, feedID, interval)
+}
+
+// UpdateFeedAdaptiveIntervalCalls gets all the calls that were made to UpdateFeedAdaptiveInterval.
+// Check the length with:
+//
+//	len(mockedFeedManager.UpdateFeedAdaptiveIntervalCalls())
+func (mock *FeedManagerMock) UpdateFeedAdaptiveIntervalCalls() []struct {
+	Ctx      context.Context
+	FeedID   int64
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FeedID   int64
+		Interval time.Duration
+	}
+	mock.lockUpdateFeedAdaptiveInterval.RLock()
+	calls = mock.calls.UpdateFeedAdaptiveInterval
+	mock.lockUpdateFeedAdaptiveInterval.RUnlock()
+	return calls
+}
+
+// UpdateFeedCache calls UpdateFeedCacheFunc.
+func (mock *FeedManagerMock) UpdateFeedCache(ctx context.Context, feedID int64, etag string, lastModified string, size int64) error {
+	if mock.UpdateFeedCacheFunc == nil {
+		panic("FeedManagerMock.UpdateFeedCacheFunc: method is nil but FeedManager.UpdateFeedCache was just called")
 	}
 	callInfo := struct {
-		Ctx         context.Context
-		EnabledOnly bool
+		Ctx          context.Context
+		FeedID       int64
+		Etag         string
+		LastModified string
+		Size         int64
 	}{
-		Ctx:         ctx,
-		EnabledOnly: enabledOnly,
+		Ctx:          ctx,
+		FeedID:       feedID,
+		Etag:         etag,
+		LastModified: lastModified,
+		Size:         size,
 	}
-	mock.lockGetFeeds.Lock()
-	mock.calls.GetFeeds = append(mock.calls.GetFeeds, callInfo)
-	mock.lockGetFeeds.Unlock()
-	return mock.GetFeedsFunc(ctx, enabledOnly)
+	mock.lockUpdateFeedCache.Lock()
+	mock.calls.UpdateFeedCache = append(mock.calls.UpdateFeedCache, callInfo)
+	mock.lockUpdateFeedCache.Unlock()
+	return mock.UpdateFeedCacheFunc(ctx, feedID, etag, lastModified, size)
 }
 
-// GetFeedsCalls gets all the calls that were made to GetFeeds.
+// UpdateFeedCacheCalls gets all the calls that were made to UpdateFeedCache.
 // Check the length with:
 //
-//	len(mockedFeedManager.GetFeedsCalls())
-func (mock *FeedManagerMock) GetFeedsCalls() []struct {
-	Ctx         context.Context
-	EnabledOnly bool
+//	len(mockedFeedManager.UpdateFeedCacheCalls())
+func (mock *FeedManagerMock) UpdateFeedCacheCalls() []struct {
+	Ctx          context.Context
+	FeedID       int64
+	Etag         string
+	LastModified string
+	Size         int64
 } {
 	var calls []struct {
-		Ctx         context.Context
-		EnabledOnly bool
+		Ctx          context.Context
+		FeedID       int64
+		Etag         string
+		LastModified string
+		Size         int64
 	}
-	mock.lockGetFeeds.RLock()
-	calls = mock.calls.GetFeeds
-	mock.lockGetFeeds.RUnlock()
+	mock.lockUpdateFeedCache.RLock()
+	calls = mock.calls.UpdateFeedCache
+	mock.lockUpdateFeedCache.RUnlock()
 	return calls
 }
 
@@ -240,3 +834,131 @@ func (mock *FeedManagerMock) UpdateFeedFetchedCalls() []struct {
 	mock.lockUpdateFeedFetched.RUnlock()
 	return calls
 }
+
+// UpdateFeedMovedTo calls UpdateFeedMovedToFunc.
+func (mock *FeedManagerMock) UpdateFeedMovedTo(ctx context.Context, feedID int64, url string) error {
+	if mock.UpdateFeedMovedToFunc == nil {
+		panic("FeedManagerMock.UpdateFeedMovedToFunc: method is nil but FeedManager.UpdateFeedMovedTo was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		URL    string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		URL:    url,
+	}
+	mock.lockUpdateFeedMovedTo.Lock()
+	mock.calls.UpdateFeedMovedTo = append(mock.calls.UpdateFeedMovedTo, callInfo)
+	mock.lockUpdateFeedMovedTo.Unlock()
+	return mock.UpdateFeedMovedToFunc(ctx, feedID, url)
+}
+
+// UpdateFeedMovedToCalls gets all the calls that were made to UpdateFeedMovedTo.
+// Check the length with:
+//
+//	len(mockedFeedManager.UpdateFeedMovedToCalls())
+func (mock *FeedManagerMock) UpdateFeedMovedToCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	URL    string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		URL    string
+	}
+	mock.lockUpdateFeedMovedTo.RLock()
+	calls = mock.calls.UpdateFeedMovedTo
+	mock.lockUpdateFeedMovedTo.RUnlock()
+	return calls
+}
+
+// UpdateFeedURL calls UpdateFeedURLFunc.
+func (mock *FeedManagerMock) UpdateFeedURL(ctx context.Context, feedID int64, url string) error {
+	if mock.UpdateFeedURLFunc == nil {
+		panic("FeedManagerMock.UpdateFeedURLFunc: method is nil but FeedManager.UpdateFeedURL was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		URL    string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		URL:    url,
+	}
+	mock.lockUpdateFeedURL.Lock()
+	mock.calls.UpdateFeedURL = append(mock.calls.UpdateFeedURL, callInfo)
+	mock.lockUpdateFeedURL.Unlock()
+	return mock.UpdateFeedURLFunc(ctx, feedID, url)
+}
+
+// UpdateFeedURLCalls gets all the calls that were made to UpdateFeedURL.
+// Check the length with:
+//
+//	len(mockedFeedManager.UpdateFeedURLCalls())
+func (mock *FeedManagerMock) UpdateFeedURLCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	URL    string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		URL    string
+	}
+	mock.lockUpdateFeedURL.RLock()
+	calls = mock.calls.UpdateFeedURL
+	mock.lockUpdateFeedURL.RUnlock()
+	return calls
+}
+
+// UpdateFeedWebSub calls UpdateFeedWebSubFunc.
+func (mock *FeedManagerMock) UpdateFeedWebSub(ctx context.Context, feedID int64, hubURL string, topic string, secret string) error {
+	if mock.UpdateFeedWebSubFunc == nil {
+		panic("FeedManagerMock.UpdateFeedWebSubFunc: method is nil but FeedManager.UpdateFeedWebSub was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		HubURL string
+		Topic  string
+		Secret string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		HubURL: hubURL,
+		Topic:  topic,
+		Secret: secret,
+	}
+	mock.lockUpdateFeedWebSub.Lock()
+	mock.calls.UpdateFeedWebSub = append(mock.calls.UpdateFeedWebSub, callInfo)
+	mock.lockUpdateFeedWebSub.Unlock()
+	return mock.UpdateFeedWebSubFunc(ctx, feedID, hubURL, topic, secret)
+}
+
+// UpdateFeedWebSubCalls gets all the calls that were made to UpdateFeedWebSub.
+// Check the length with:
+//
+//	len(mockedFeedManager.UpdateFeedWebSubCalls())
+func (mock *FeedManagerMock) UpdateFeedWebSubCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	HubURL string
+	Topic  string
+	Secret string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		HubURL string
+		Topic  string
+		Secret string
+	}
+	mock.lockUpdateFeedWebSub.RLock()
+	calls = mock.calls.UpdateFeedWebSub
+	mock.lockUpdateFeedWebSub.RUnlock()
+	return calls
+}
@@ -23,6 +23,9 @@ import (
 //			DeleteOldItemsFunc: func(ctx context.Context, age time.Duration, minScore float64) (int64, error) {
 //				panic("mock out the DeleteOldItems method")
 //			},
+//			FindDuplicateFunc: func(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+//				panic("mock out the FindDuplicate method")
+//			},
 //			GetItemFunc: func(ctx context.Context, id int64) (*domain.Item, error) {
 //				panic("mock out the GetItem method")
 //			},
@@ -32,9 +35,18 @@ import (
 //			ItemExistsByTitleOrURLFunc: func(ctx context.Context, title string, url string) (bool, error) {
 //				panic("mock out the ItemExistsByTitleOrURL method")
 //			},
+//			UpdateItemDuplicateFunc: func(ctx context.Context, itemID int64, duplicateOf int64, extraction *domain.ExtractedContent) error {
+//				panic("mock out the UpdateItemDuplicate method")
+//			},
 //			UpdateItemExtractionFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error {
 //				panic("mock out the UpdateItemExtraction method")
 //			},
+//			UpdateItemFingerprintFunc: func(ctx context.Context, itemID int64, fingerprint uint64) error {
+//				panic("mock out the UpdateItemFingerprint method")
+//			},
+//			UpdateItemMutedFunc: func(ctx context.Context, itemID int64, rule string) error {
+//				panic("mock out the UpdateItemMuted method")
+//			},
 //			UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error {
 //				panic("mock out the UpdateItemProcessed method")
 //			},
@@ -51,6 +63,9 @@ type ItemManagerMock struct {
 	// DeleteOldItemsFunc mocks the DeleteOldItems method.
 	DeleteOldItemsFunc func(ctx context.Context, age time.Duration, minScore float64) (int64, error)
 
+	// FindDuplicateFunc mocks the FindDuplicate method.
+	FindDuplicateFunc func(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error)
+
 	// GetItemFunc mocks the GetItem method.
 	GetItemFunc func(ctx context.Context, id int64) (*domain.Item, error)
 
@@ -60,9 +75,18 @@ type ItemManagerMock struct {
 	// ItemExistsByTitleOrURLFunc mocks the ItemExistsByTitleOrURL method.
 	ItemExistsByTitleOrURLFunc func(ctx context.Context, title string, url string) (bool, error)
 
+	// UpdateItemDuplicateFunc mocks the UpdateItemDuplicate method.
+	UpdateItemDuplicateFunc func(ctx context.Context, itemID int64, duplicateOf int64, extraction *domain.ExtractedContent) error
+
 	// UpdateItemExtractionFunc mocks the UpdateItemExtraction method.
 	UpdateItemExtractionFunc func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error
 
+	// UpdateItemFingerprintFunc mocks the UpdateItemFingerprint method.
+	UpdateItemFingerprintFunc func(ctx context.Context, itemID int64, fingerprint uint64) error
+
+	// UpdateItemMutedFunc mocks the UpdateItemMuted method.
+	UpdateItemMutedFunc func(ctx context.Context, itemID int64, rule string) error
+
 	// UpdateItemProcessedFunc mocks the UpdateItemProcessed method.
 	UpdateItemProcessedFunc func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error
 
@@ -84,6 +108,19 @@ type ItemManagerMock struct {
 			// MinScore is the minScore argument value.
 			MinScore float64
 		}
+		// FindDuplicate holds details about calls to the FindDuplicate method.
+		FindDuplicate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItemID is the itemID argument value.
+			ItemID int64
+			// Fingerprint is the fingerprint argument value.
+			Fingerprint uint64
+			// MaxDistance is the maxDistance argument value.
+			MaxDistance int
+			// Window is the window argument value.
+			Window time.Duration
+		}
 		// GetItem holds details about calls to the GetItem method.
 		GetItem []struct {
 			// Ctx is the ctx argument value.
@@ -109,6 +146,17 @@ type ItemManagerMock struct {
 			// URL is the url argument value.
 			URL string
 		}
+		// UpdateItemDuplicate holds details about calls to the UpdateItemDuplicate method.
+		UpdateItemDuplicate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItemID is the itemID argument value.
+			ItemID int64
+			// DuplicateOf is the duplicateOf argument value.
+			DuplicateOf int64
+			// Extraction is the extraction argument value.
+			Extraction *domain.ExtractedContent
+		}
 		// UpdateItemExtraction holds details about calls to the UpdateItemExtraction method.
 		UpdateItemExtraction []struct {
 			// Ctx is the ctx argument value.
@@ -118,6 +166,24 @@ type ItemManagerMock struct {
 			// Extraction is the extraction argument value.
 			Extraction *domain.ExtractedContent
 		}
+		// UpdateItemFingerprint holds details about calls to the UpdateItemFingerprint method.
+		UpdateItemFingerprint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItemID is the itemID argument value.
+			ItemID int64
+			// Fingerprint is the fingerprint argument value.
+			Fingerprint uint64
+		}
+		// UpdateItemMuted holds details about calls to the UpdateItemMuted method.
+		UpdateItemMuted []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItemID is the itemID argument value.
+			ItemID int64
+			// Rule is the rule argument value.
+			Rule string
+		}
 		// UpdateItemProcessed holds details about calls to the UpdateItemProcessed method.
 		UpdateItemProcessed []struct {
 			// Ctx is the ctx argument value.
@@ -132,10 +198,14 @@ type ItemManagerMock struct {
 	}
 	lockCreateItem             sync.RWMutex
 	lockDeleteOldItems         sync.RWMutex
+	lockFindDuplicate          sync.RWMutex
 	lockGetItem                sync.RWMutex
 	lockItemExists             sync.RWMutex
 	lockItemExistsByTitleOrURL sync.RWMutex
+	lockUpdateItemDuplicate    sync.RWMutex
 	lockUpdateItemExtraction   sync.RWMutex
+	lockUpdateItemFingerprint  sync.RWMutex
+	lockUpdateItemMuted        sync.RWMutex
 	lockUpdateItemProcessed    sync.RWMutex
 }
 
@@ -215,6 +285,54 @@ func (mock *ItemManagerMock) DeleteOldItemsCalls() []struct {
 	return calls
 }
 
+// FindDuplicate calls FindDuplicateFunc.
+func (mock *ItemManagerMock) FindDuplicate(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+	if mock.FindDuplicateFunc == nil {
+		panic("ItemManagerMock.FindDuplicateFunc: method is nil but ItemManager.FindDuplicate was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ItemID      int64
+		Fingerprint uint64
+		MaxDistance int
+		Window      time.Duration
+	}{
+		Ctx:         ctx,
+		ItemID:      itemID,
+		Fingerprint: fingerprint,
+		MaxDistance: maxDistance,
+		Window:      window,
+	}
+	mock.lockFindDuplicate.Lock()
+	mock.calls.FindDuplicate = append(mock.calls.FindDuplicate, callInfo)
+	mock.lockFindDuplicate.Unlock()
+	return mock.FindDuplicateFunc(ctx, itemID, fingerprint, maxDistance, window)
+}
+
+// FindDuplicateCalls gets all the calls that were made to FindDuplicate.
+// Check the length with:
+//
+//	len(mockedItemManager.FindDuplicateCalls())
+func (mock *ItemManagerMock) FindDuplicateCalls() []struct {
+	Ctx         context.Context
+	ItemID      int64
+	Fingerprint uint64
+	MaxDistance int
+	Window      time.Duration
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ItemID      int64
+		Fingerprint uint64
+		MaxDistance int
+		Window      time.Duration
+	}
+	mock.lockFindDuplicate.RLock()
+	calls = mock.calls.FindDuplicate
+	mock.lockFindDuplicate.RUnlock()
+	return calls
+}
+
 // GetItem calls GetItemFunc.
 func (mock *ItemManagerMock) GetItem(ctx context.Context, id int64) (*domain.Item, error) {
 	if mock.GetItemFunc == nil {
@@ -331,6 +449,50 @@ func (mock *ItemManagerMock) ItemExistsByTitleOrURLCalls() []struct {
 	return calls
 }
 
+// UpdateItemDuplicate calls UpdateItemDuplicateFunc.
+func (mock *ItemManagerMock) UpdateItemDuplicate(ctx context.Context, itemID int64, duplicateOf int64, extraction *domain.ExtractedContent) error {
+	if mock.UpdateItemDuplicateFunc == nil {
+		panic("ItemManagerMock.UpdateItemDuplicateFunc: method is nil but ItemManager.UpdateItemDuplicate was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ItemID      int64
+		DuplicateOf int64
+		Extraction  *domain.ExtractedContent
+	}{
+		Ctx:         ctx,
+		ItemID:      itemID,
+		DuplicateOf: duplicateOf,
+		Extraction:  extraction,
+	}
+	mock.lockUpdateItemDuplicate.Lock()
+	mock.calls.UpdateItemDuplicate = append(mock.calls.UpdateItemDuplicate, callInfo)
+	mock.lockUpdateItemDuplicate.Unlock()
+	return mock.UpdateItemDuplicateFunc(ctx, itemID, duplicateOf, extraction)
+}
+
+// UpdateItemDuplicateCalls gets all the calls that were made to UpdateItemDuplicate.
+// Check the length with:
+//
+//	len(mockedItemManager.UpdateItemDuplicateCalls())
+func (mock *ItemManagerMock) UpdateItemDuplicateCalls() []struct {
+	Ctx         context.Context
+	ItemID      int64
+	DuplicateOf int64
+	Extraction  *domain.ExtractedContent
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ItemID      int64
+		DuplicateOf int64
+		Extraction  *domain.ExtractedContent
+	}
+	mock.lockUpdateItemDuplicate.RLock()
+	calls = mock.calls.UpdateItemDuplicate
+	mock.lockUpdateItemDuplicate.RUnlock()
+	return calls
+}
+
 // UpdateItemExtraction calls UpdateItemExtractionFunc.
 func (mock *ItemManagerMock) UpdateItemExtraction(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error {
 	if mock.UpdateItemExtractionFunc == nil {
@@ -371,6 +533,86 @@ func (mock *ItemManagerMock) UpdateItemExtractionCalls() []struct {
 	return calls
 }
 
+// UpdateItemFingerprint calls UpdateItemFingerprintFunc.
+func (mock *ItemManagerMock) UpdateItemFingerprint(ctx context.Context, itemID int64, fingerprint uint64) error {
+	if mock.UpdateItemFingerprintFunc == nil {
+		panic("ItemManagerMock.UpdateItemFingerprintFunc: method is nil but ItemManager.UpdateItemFingerprint was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ItemID      int64
+		Fingerprint uint64
+	}{
+		Ctx:         ctx,
+		ItemID:      itemID,
+		Fingerprint: fingerprint,
+	}
+	mock.lockUpdateItemFingerprint.Lock()
+	mock.calls.UpdateItemFingerprint = append(mock.calls.UpdateItemFingerprint, callInfo)
+	mock.lockUpdateItemFingerprint.Unlock()
+	return mock.UpdateItemFingerprintFunc(ctx, itemID, fingerprint)
+}
+
+// UpdateItemFingerprintCalls gets all the calls that were made to UpdateItemFingerprint.
+// Check the length with:
+//
+//	len(mockedItemManager.UpdateItemFingerprintCalls())
+func (mock *ItemManagerMock) UpdateItemFingerprintCalls() []struct {
+	Ctx         context.Context
+	ItemID      int64
+	Fingerprint uint64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ItemID      int64
+		Fingerprint uint64
+	}
+	mock.lockUpdateItemFingerprint.RLock()
+	calls = mock.calls.UpdateItemFingerprint
+	mock.lockUpdateItemFingerprint.RUnlock()
+	return calls
+}
+
+// UpdateItemMuted calls UpdateItemMutedFunc.
+func (mock *ItemManagerMock) UpdateItemMuted(ctx context.Context, itemID int64, rule string) error {
+	if mock.UpdateItemMutedFunc == nil {
+		panic("ItemManagerMock.UpdateItemMutedFunc: method is nil but ItemManager.UpdateItemMuted was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ItemID int64
+		Rule   string
+	}{
+		Ctx:    ctx,
+		ItemID: itemID,
+		Rule:   rule,
+	}
+	mock.lockUpdateItemMuted.Lock()
+	mock.calls.UpdateItemMuted = append(mock.calls.UpdateItemMuted, callInfo)
+	mock.lockUpdateItemMuted.Unlock()
+	return mock.UpdateItemMutedFunc(ctx, itemID, rule)
+}
+
+// UpdateItemMutedCalls gets all the calls that were made to UpdateItemMuted.
+// Check the length with:
+//
+//	len(mockedItemManager.UpdateItemMutedCalls())
+func (mock *ItemManagerMock) UpdateItemMutedCalls() []struct {
+	Ctx    context.Context
+	ItemID int64
+	Rule   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ItemID int64
+		Rule   string
+	}
+	mock.lockUpdateItemMuted.RLock()
+	calls = mock.calls.UpdateItemMuted
+	mock.lockUpdateItemMuted.RUnlock()
+	return calls
+}
+
 // UpdateItemProcessed calls UpdateItemProcessedFunc.
 func (mock *ItemManagerMock) UpdateItemProcessed(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error {
 	if mock.UpdateItemProcessedFunc == nil {
@@ -16,8 +16,17 @@ import (
 //
 //		// make and configure a mocked scheduler.Parser
 //		mockedParser := &ParserMock{
-//			ParseFunc: func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
-//				panic("mock out the Parse method")
+//			DiscoverFunc: func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+//				panic("mock out the Discover method")
+//			},
+//			FetchFunc: func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+//				panic("mock out the Fetch method")
+//			},
+//			ParseContentFunc: func(content []byte) (*domain.ParsedFeed, error) {
+//				panic("mock out the ParseContent method")
+//			},
+//			ScrapeFunc: func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+//				panic("mock out the Scrape method")
 //			},
 //		}
 //
@@ -26,54 +35,195 @@ import (
 //
 //	}
 type ParserMock struct {
-	// ParseFunc mocks the Parse method.
-	ParseFunc func(ctx context.Context, url string) (*domain.ParsedFeed, error)
+	// DiscoverFunc mocks the Discover method.
+	DiscoverFunc func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error)
+
+	// FetchFunc mocks the Fetch method.
+	FetchFunc func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error)
+
+	// ParseContentFunc mocks the ParseContent method.
+	ParseContentFunc func(content []byte) (*domain.ParsedFeed, error)
+
+	// ScrapeFunc mocks the Scrape method.
+	ScrapeFunc func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Parse holds details about calls to the Parse method.
-		Parse []struct {
+		// Discover holds details about calls to the Discover method.
+		Discover []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PageURL is the pageURL argument value.
+			PageURL string
+		}
+		// Fetch holds details about calls to the Fetch method.
+		Fetch []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// URL is the url argument value.
-			URL string
+			// F is the f argument value.
+			F *domain.Feed
 		}
+		// ParseContent holds details about calls to the ParseContent method.
+		ParseContent []struct {
+			// Content is the content argument value.
+			Content []byte
+		}
+		// Scrape holds details about calls to the Scrape method.
+		Scrape []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PageURL is the pageURL argument value.
+			PageURL string
+			// Sel is the sel argument value.
+			Sel domain.ScrapeSelectors
+		}
+	}
+	lockDiscover     sync.RWMutex
+	lockFetch        sync.RWMutex
+	lockParseContent sync.RWMutex
+	lockScrape       sync.RWMutex
+}
+
+// Discover calls DiscoverFunc.
+func (mock *ParserMock) Discover(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+	if mock.DiscoverFunc == nil {
+		panic("ParserMock.DiscoverFunc: method is nil but Parser.Discover was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		PageURL string
+	}{
+		Ctx:     ctx,
+		PageURL: pageURL,
+	}
+	mock.lockDiscover.Lock()
+	mock.calls.Discover = append(mock.calls.Discover, callInfo)
+	mock.lockDiscover.Unlock()
+	return mock.DiscoverFunc(ctx, pageURL)
+}
+
+// DiscoverCalls gets all the calls that were made to Discover.
+// Check the length with:
+//
+//	len(mockedParser.DiscoverCalls())
+func (mock *ParserMock) DiscoverCalls() []struct {
+	Ctx     context.Context
+	PageURL string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		PageURL string
 	}
-	lockParse sync.RWMutex
+	mock.lockDiscover.RLock()
+	calls = mock.calls.Discover
+	mock.lockDiscover.RUnlock()
+	return calls
 }
 
-// Parse calls ParseFunc.
-func (mock *ParserMock) Parse(ctx context.Context, url string) (*domain.ParsedFeed, error) {
-	if mock.ParseFunc == nil {
-		panic("ParserMock.ParseFunc: method is nil but Parser.Parse was just called")
+// Fetch calls FetchFunc.
+func (mock *ParserMock) Fetch(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+	if mock.FetchFunc == nil {
+		panic("ParserMock.FetchFunc: method is nil but Parser.Fetch was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		URL string
+		F   *domain.Feed
 	}{
 		Ctx: ctx,
-		URL: url,
+		F:   f,
 	}
-	mock.lockParse.Lock()
-	mock.calls.Parse = append(mock.calls.Parse, callInfo)
-	mock.lockParse.Unlock()
-	return mock.ParseFunc(ctx, url)
+	mock.lockFetch.Lock()
+	mock.calls.Fetch = append(mock.calls.Fetch, callInfo)
+	mock.lockFetch.Unlock()
+	return mock.FetchFunc(ctx, f)
 }
 
-// ParseCalls gets all the calls that were made to Parse.
+// FetchCalls gets all the calls that were made to Fetch.
 // Check the length with:
 //
-//	len(mockedParser.ParseCalls())
-func (mock *ParserMock) ParseCalls() []struct {
+//	len(mockedParser.FetchCalls())
+func (mock *ParserMock) FetchCalls() []struct {
 	Ctx context.Context
-	URL string
+	F   *domain.Feed
 } {
 	var calls []struct {
 		Ctx context.Context
-		URL string
+		F   *domain.Feed
+	}
+	mock.lockFetch.RLock()
+	calls = mock.calls.Fetch
+	mock.lockFetch.RUnlock()
+	return calls
+}
+
+// ParseContent calls ParseContentFunc.
+func (mock *ParserMock) ParseContent(content []byte) (*domain.ParsedFeed, error) {
+	if mock.ParseContentFunc == nil {
+		panic("ParserMock.ParseContentFunc: method is nil but Parser.ParseContent was just called")
+	}
+	callInfo := struct {
+		Content []byte
+	}{
+		Content: content,
+	}
+	mock.lockParseContent.Lock()
+	mock.calls.ParseContent = append(mock.calls.ParseContent, callInfo)
+	mock.lockParseContent.Unlock()
+	return mock.ParseContentFunc(content)
+}
+
+// ParseContentCalls gets all the calls that were made to ParseContent.
+// Check the length with:
+//
+//	len(mockedParser.ParseContentCalls())
+func (mock *ParserMock) ParseContentCalls() []struct {
+	Content []byte
+} {
+	var calls []struct {
+		Content []byte
+	}
+	mock.lockParseContent.RLock()
+	calls = mock.calls.ParseContent
+	mock.lockParseContent.RUnlock()
+	return calls
+}
+
+// Scrape calls ScrapeFunc.
+func (mock *ParserMock) Scrape(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	if mock.ScrapeFunc == nil {
+		panic("ParserMock.ScrapeFunc: method is nil but Parser.Scrape was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		PageURL string
+		Sel     domain.ScrapeSelectors
+	}{
+		Ctx:     ctx,
+		PageURL: pageURL,
+		Sel:     sel,
+	}
+	mock.lockScrape.Lock()
+	mock.calls.Scrape = append(mock.calls.Scrape, callInfo)
+	mock.lockScrape.Unlock()
+	return mock.ScrapeFunc(ctx, pageURL, sel)
+}
+
+// ScrapeCalls gets all the calls that were made to Scrape.
+// Check the length with:
+//
+//	len(mockedParser.ScrapeCalls())
+func (mock *ParserMock) ScrapeCalls() []struct {
+	Ctx     context.Context
+	PageURL string
+	Sel     domain.ScrapeSelectors
+} {
+	var calls []struct {
+		Ctx     context.Context
+		PageURL string
+		Sel     domain.ScrapeSelectors
 	}
-	mock.lockParse.RLock()
-	calls = mock.calls.Parse
-	mock.lockParse.RUnlock()
+	mock.lockScrape.RLock()
+	calls = mock.calls.Scrape
+	mock.lockScrape.RUnlock()
 	return calls
 }
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-pkgz/lgr"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/newsletter"
+)
+
+// newsletterDescriptionLen limits the newsletter text kept as the item description
+const newsletterDescriptionLen = 500
+
+// HasNewsletters reports whether any newsletter source is configured
+func (fp *FeedProcessor) HasNewsletters() bool {
+	return len(fp.newsletters) > 0
+}
+
+// ImportNewsletters reads newsletters from all sources and stores new ones as items of per-sender
+// pseudo-feeds, created on the first message of a sender. new items are queued for processing, where
+// they skip extraction and go straight to classification. disabling a sender's feed ignores its newsletters.
+func (fp *FeedProcessor) ImportNewsletters(ctx context.Context) {
+	since := time.Now().Add(-fp.newsletterLookback)
+	feeds := make(map[string]*domain.Feed) // pseudo-feeds by sender address
+	newCount := 0
+	for _, src := range fp.newsletters {
+		messages, err := src.Fetch(ctx, since)
+		if err != nil {
+			// sources return the messages they could read along with the error
+			lgr.Printf("[WARN] failed to read newsletters: %v", err)
+		}
+		for i := range messages {
+			msg := &messages[i]
+			feed, ok := feeds[msg.From]
+			if !ok {
+				if feed, err = fp.newsletterFeed(ctx, msg); err != nil {
+					lgr.Printf("[WARN] failed to get feed for newsletter %s: %v", msg.ID, err)
+					continue
+				}
+				feeds[msg.From] = feed
+			}
+			if !feed.Enabled {
+				continue
+			}
+			stored, ok := fp.storeNewsletter(ctx, feed, msg)
+			if !ok {
+				continue
+			}
+			newCount++
+			fp.enqueue(ctx, stored, domain.PriorityNormal)
+		}
+	}
+	if newCount > 0 {
+		lgr.Printf("[INFO] stored %d new newsletters", newCount)
+	}
+}
+
+// newsletterFeed returns the pseudo-feed of the message sender, creating it if needed
+func (fp *FeedProcessor) newsletterFeed(ctx context.Context, msg *newsletter.Message) (*domain.Feed, error) {
+	feedURL := "mailto:" + msg.From
+	feed, err := fp.feedManager.GetFeedByURL(ctx, feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("get feed %s: %w", feedURL, err)
+	}
+	if feed != nil {
+		return feed, nil
+	}
+
+	feed = &domain.Feed{
+		Type:          domain.FeedTypeNewsletter,
+		URL:           feedURL,
+		Title:         msg.Sender(),
+		FetchInterval: fp.newsletterInterval,
+		Enabled:       true,
+	}
+	if err := fp.retryFunc(ctx, func() error { return fp.feedManager.CreateFeed(ctx, feed) }); err != nil {
+		return nil, fmt.Errorf("create feed %s: %w", feedURL, err)
+	}
+	lgr.Printf("[INFO] created newsletter feed %q for %s", feed.Title, msg.From)
+	return feed, nil
+}
+
+// storeNewsletter stores the message as an item unless it is stored already, ok is false if it wasn't stored
+func (fp *FeedProcessor) storeNewsletter(ctx context.Context, feed *domain.Feed, msg *newsletter.Message) (item *domain.Item, ok bool) {
+	exists, err := fp.itemManager.ItemExists(ctx, feed.ID, msg.ID)
+	if err != nil {
+		lgr.Printf("[WARN] failed to check newsletter %s existence: %v", msg.ID, err)
+		return nil, false
+	}
+	if exists {
+		return nil, false
+	}
+
+	item = &domain.Item{
+		FeedID:      feed.ID,
+		GUID:        msg.ID,
+		Title:       msg.Subject,
+		Link:        "mid:" + url.PathEscape(msg.ID), // RFC 2392 message URL, see domain.Item.IsNewsletter
+		Description: truncateText(msg.Text, newsletterDescriptionLen),
+		Content:     msg.HTML,
+		Author:      msg.Sender(),
+		Published:   msg.Date,
+	}
+	if item.Title == "" {
+		item.Title = "Newsletter from " + msg.Sender()
+	}
+	if item.Published.IsZero() {
+		item.Published = time.Now()
+	}
+
+	if err := fp.retryFunc(ctx, func() error { return fp.itemManager.CreateItem(ctx, item) }); err != nil {
+		lgr.Printf("[WARN] failed to store newsletter %s after retries: %v", msg.ID, err)
+		return nil, false
+	}
+	return item, true
+}
+
+// truncateText cuts text to at most n runes, marking the cut with an ellipsis
+func truncateText(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/llm"
+	"github.com/umputun/newscope/pkg/newsletter"
+	"github.com/umputun/newscope/pkg/scheduler/mocks"
+)
+
+func TestFeedProcessor_ImportNewsletters(t *testing.T) {
+	date := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	source := &mocks.NewsletterSourceMock{
+		FetchFunc: func(ctx context.Context, since time.Time) ([]newsletter.Message, error) {
+			return []newsletter.Message{
+				{ID: "1@weekly", From: "weekly@example.com", FromName: "Weekly", Subject: "Issue 1",
+					Date: date, HTML: "<p>Issue one</p>", Text: "Issue one"},
+				{ID: "2@weekly", From: "weekly@example.com", FromName: "Weekly", Subject: "Issue 2",
+					Date: date, HTML: "<p>Issue two</p>", Text: "Issue two"},
+				{ID: "old@weekly", From: "weekly@example.com", Subject: "Stored before"},
+				{ID: "1@daily", From: "daily@example.com", HTML: "<p>Daily</p>", Text: "Daily"},
+				{ID: "1@muted", From: "muted@example.com", Subject: "Muted", HTML: "<p>Muted</p>"},
+			}, errors.New("message 7: parse sender") // partial result
+		},
+	}
+
+	var createdFeeds []string
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedByURLFunc: func(ctx context.Context, url string) (*domain.Feed, error) {
+			switch url {
+			case "mailto:weekly@example.com":
+				return &domain.Feed{ID: 1, Type: domain.FeedTypeNewsletter, URL: url, Enabled: true}, nil
+			case "mailto:muted@example.com":
+				return &domain.Feed{ID: 3, Type: domain.FeedTypeNewsletter, URL: url, Enabled: false}, nil
+			}
+			return nil, nil
+		},
+		CreateFeedFunc: func(ctx context.Context, feed *domain.Feed) error {
+			createdFeeds = append(createdFeeds, feed.URL)
+			assert.Equal(t, domain.FeedTypeNewsletter, feed.Type)
+			assert.Equal(t, "daily@example.com", feed.Title, "address used without a display name")
+			assert.Equal(t, 15*time.Minute, feed.FetchInterval)
+			assert.True(t, feed.Enabled)
+			feed.ID = 2
+			return nil
+		},
+	}
+	var lastID int64
+	itemManager := &mocks.ItemManagerMock{
+		ItemExistsFunc: func(ctx context.Context, feedID int64, guid string) (bool, error) {
+			return guid == "old@weekly", nil
+		},
+		CreateItemFunc: func(ctx context.Context, item *domain.Item) error {
+			lastID++
+			item.ID = lastID
+			return nil
+		},
+	}
+	jobManager := newJobManager()
+
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager:        feedManager,
+		ItemManager:        itemManager,
+		JobManager:         jobManager,
+		Newsletters:        []NewsletterSource{source},
+		NewsletterInterval: 15 * time.Minute,
+		NewsletterLookback: 48 * time.Hour,
+		RetryFunc:          func(ctx context.Context, op func() error) error { return op() },
+	})
+	assert.True(t, fp.HasNewsletters())
+
+	fp.ImportNewsletters(context.Background())
+
+	require.Len(t, source.FetchCalls(), 1)
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), source.FetchCalls()[0].Since, time.Minute)
+	assert.Equal(t, []string{"mailto:daily@example.com"}, createdFeeds, "feed created once per new sender")
+	assert.Len(t, feedManager.GetFeedByURLCalls(), 3, "feeds looked up once per sender")
+
+	var items []domain.Item
+	for _, call := range itemManager.CreateItemCalls() {
+		items = append(items, *call.Item)
+	}
+	require.Len(t, items, 3, "stored and muted newsletters skipped")
+	require.Len(t, jobManager.EnqueueJobCalls(), 3, "new newsletters queued")
+	for i, call := range jobManager.EnqueueJobCalls() {
+		assert.Equal(t, int64(i+1), call.ItemID)
+		assert.Equal(t, domain.PriorityNormal, call.Priority)
+	}
+
+	assert.Equal(t, int64(1), items[0].FeedID)
+	assert.Equal(t, "1@weekly", items[0].GUID)
+	assert.Equal(t, "Issue 1", items[0].Title)
+	assert.Equal(t, "mid:1@weekly", items[0].Link)
+	assert.True(t, items[0].IsNewsletter())
+	assert.Equal(t, "<p>Issue one</p>", items[0].Content)
+	assert.Equal(t, "Issue one", items[0].Description)
+	assert.Equal(t, "Weekly", items[0].Author)
+	assert.Equal(t, date, items[0].Published)
+
+	assert.Equal(t, int64(2), items[2].FeedID)
+	assert.Equal(t, "Newsletter from daily@example.com", items[2].Title, "title for a message without subject")
+	assert.WithinDuration(t, time.Now(), items[2].Published, time.Minute, "undated message dated now")
+	assert.Len(t, itemManager.CreateItemCalls(), 3)
+}
+
+func TestFeedProcessor_ProcessItem_Newsletter(t *testing.T) {
+	itemManager := &mocks.ItemManagerMock{
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+	}
+	classifier := &mocks.ClassifierMock{
+		ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+			return []domain.Classification{{GUID: req.Articles[0].GUID, Score: 6}}, nil
+		},
+	}
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		JobManager:  newJobManager(),
+		FeedManager: &mocks.FeedManagerMock{},
+		ItemManager: itemManager,
+		ClassificationManager: &mocks.ClassificationManagerMock{
+			GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+				return nil, nil
+			},
+			GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+		},
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		Extractor:  &mocks.ExtractorMock{}, // must not be called
+		Classifier: classifier,
+		MaxWorkers: 1,
+		RetryFunc:  func(ctx context.Context, op func() error) error { return op() },
+	})
+
+	fp.ProcessItem(context.Background(), &domain.Item{ID: 5, GUID: "1@weekly", Title: "Issue 1",
+		Link: "mid:1@weekly", Content: "<h1>Issue</h1><p>All the news</p>"})
+
+	require.Len(t, classifier.ClassifyItemsCalls(), 1)
+	assert.Equal(t, "Issue All the news", classifier.ClassifyItemsCalls()[0].Req.Articles[0].Content)
+	require.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	extraction := itemManager.UpdateItemProcessedCalls()[0].Extraction
+	assert.Equal(t, "Issue All the news", extraction.PlainText)
+	assert.Equal(t, "<h1>Issue</h1><p>All the news</p>", extraction.RichHTML)
+}
+
+func TestFeedProcessor_UpdateFeedNow_Newsletter(t *testing.T) {
+	fp := NewFeedProcessor(FeedProcessorConfig{
+		FeedManager: &mocks.FeedManagerMock{
+			GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+				return &domain.Feed{ID: id, Type: domain.FeedTypeNewsletter, URL: "mailto:weekly@example.com"}, nil
+			},
+		},
+		Parser: &mocks.ParserMock{}, // must not be called
+	})
+
+	err := fp.UpdateFeedNow(context.Background(), 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "collects newsletters")
+}
+
+func TestTruncateText(t *testing.T) {
+	assert.Equal(t, "short", truncateText("short", 10))
+	assert.Equal(t, "héll…", truncateText("héllo world", 4))
+}
@@ -2,9 +2,14 @@
 //go:generate moq -out mocks/item_manager.go -pkg mocks -skip-ensure -fmt goimports . ItemManager
 //go:generate moq -out mocks/classification_manager.go -pkg mocks -skip-ensure -fmt goimports . ClassificationManager
 //go:generate moq -out mocks/setting_manager.go -pkg mocks -skip-ensure -fmt goimports . SettingManager
+//go:generate moq -out mocks/mute_rule_manager.go -pkg mocks -skip-ensure -fmt goimports . MuteRuleManager
+//go:generate moq -out mocks/job_manager.go -pkg mocks -skip-ensure -fmt goimports . JobManager
 //go:generate moq -out mocks/parser.go -pkg mocks -skip-ensure -fmt goimports . Parser
 //go:generate moq -out mocks/extractor.go -pkg mocks -skip-ensure -fmt goimports . Extractor
 //go:generate moq -out mocks/classifier.go -pkg mocks -skip-ensure -fmt goimports . Classifier
+//go:generate moq -out mocks/web_subscriber.go -pkg mocks -skip-ensure -fmt goimports . WebSubscriber
+//go:generate moq -out mocks/newsletter_source.go -pkg mocks -skip-ensure -fmt goimports . NewsletterSource
+//go:generate moq -out mocks/link_resolver.go -pkg mocks -skip-ensure -fmt goimports . LinkResolver
 
 package scheduler
 
@@ -20,6 +25,7 @@ import (
 	"github.com/umputun/newscope/pkg/content"
 	"github.com/umputun/newscope/pkg/domain"
 	"github.com/umputun/newscope/pkg/llm"
+	"github.com/umputun/newscope/pkg/newsletter"
 )
 
 // Scheduler manages periodic feed updates and content processing
@@ -29,6 +35,7 @@ type Scheduler struct {
 	itemManager       ItemManager
 
 	updateInterval     time.Duration
+	newsletterInterval time.Duration
 	cleanupAge         time.Duration
 	cleanupMinScore    float64
 	cleanupInterval    time.Duration
@@ -43,17 +50,25 @@ type Scheduler struct {
 	cancel context.CancelFunc
 }
 
-const (
-	defaultChannelBufferSize = 100
-	defaultUpdateFeedBuffer  = 10
-)
-
 // FeedManager handles feed operations for scheduler
 type FeedManager interface {
 	GetFeed(ctx context.Context, id int64) (*domain.Feed, error)
-	GetFeeds(ctx context.Context, enabledOnly bool) ([]domain.Feed, error)
+	GetFeedByURL(ctx context.Context, url string) (*domain.Feed, error)
+	CreateFeed(ctx context.Context, feed *domain.Feed) error
+	GetFeedsToFetch(ctx context.Context, limit int) ([]domain.Feed, error)
 	UpdateFeedFetched(ctx context.Context, feedID int64, nextFetch time.Time) error
+	UpdateFeedAdaptiveInterval(ctx context.Context, feedID int64, interval time.Duration) error
+	UpdateFeedCache(ctx context.Context, feedID int64, etag, lastModified string, size int64) error
+	AddFeedBytesSaved(ctx context.Context, feedID, bytes int64) error
 	UpdateFeedError(ctx context.Context, feedID int64, errMsg string) error
+	DisableFeed(ctx context.Context, feedID int64, reason string) error
+	UpdateFeedURL(ctx context.Context, feedID int64, url string) error
+	UpdateFeedMovedTo(ctx context.Context, feedID int64, url string) error
+	AddFeedEvent(ctx context.Context, event *domain.FeedEvent) error
+	AddFeedFetch(ctx context.Context, fetch *domain.FeedFetch) error
+	UpdateFeedWebSub(ctx context.Context, feedID int64, hubURL, topic, secret string) error
+	ConfirmFeedWebSub(ctx context.Context, feedID int64, expires time.Time) error
+	ClearFeedWebSub(ctx context.Context, feedID int64) error
 }
 
 // ItemManager handles item operations for scheduler
@@ -64,6 +79,10 @@ type ItemManager interface {
 	ItemExistsByTitleOrURL(ctx context.Context, title, url string) (bool, error)
 	UpdateItemProcessed(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error
 	UpdateItemExtraction(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error
+	UpdateItemFingerprint(ctx context.Context, itemID int64, fingerprint uint64) error
+	FindDuplicate(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error)
+	UpdateItemDuplicate(ctx context.Context, itemID, duplicateOf int64, extraction *domain.ExtractedContent) error
+	UpdateItemMuted(ctx context.Context, itemID int64, rule string) error
 	DeleteOldItems(ctx context.Context, age time.Duration, minScore float64) (int64, error)
 }
 
@@ -80,14 +99,32 @@ type SettingManager interface {
 	SetSetting(ctx context.Context, key, value string) error
 }
 
-// Parser interface for feed parsing
+// MuteRuleManager provides mute rules for scheduler
+type MuteRuleManager interface {
+	GetMuteRules(ctx context.Context) ([]domain.MuteRule, error)
+}
+
+// JobManager handles the persistent processing queue for scheduler
+type JobManager interface {
+	EnqueueJob(ctx context.Context, itemID int64, priority int) error
+	ClaimJob(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error)
+	ClaimItemJob(ctx context.Context, itemID int64, timeout time.Duration) (*domain.ProcessJob, error)
+	UpdateJobState(ctx context.Context, itemID int64, state domain.JobState, errMsg string) error
+	RetryJob(ctx context.Context, itemID int64, errMsg string, delay time.Duration) error
+	RecoverJobs(ctx context.Context) (int64, error)
+}
+
+// Parser interface for feed fetching, parsing, scraping and discovery
 type Parser interface {
-	Parse(ctx context.Context, url string) (*domain.ParsedFeed, error)
+	Fetch(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error)
+	ParseContent(content []byte) (*domain.ParsedFeed, error)
+	Scrape(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error)
+	Discover(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error)
 }
 
-// Extractor interface for content extraction
+// Extractor interface for content extraction, auth is empty for public feeds
 type Extractor interface {
-	Extract(ctx context.Context, url string) (*content.ExtractResult, error)
+	Extract(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error)
 }
 
 // Classifier interface for LLM classification
@@ -97,6 +134,22 @@ type Classifier interface {
 	UpdatePreferenceSummary(ctx context.Context, currentSummary string, newFeedback []domain.FeedbackExample) (string, error)
 }
 
+// WebSubscriber interface for websub hub subscriptions
+type WebSubscriber interface {
+	Subscribe(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error
+	Unsubscribe(ctx context.Context, hubURL, topic, callback string) error
+}
+
+// LinkResolver interface for resolving redirect wrappers of item links, e.g. feed proxies and link shorteners
+type LinkResolver interface {
+	Resolve(ctx context.Context, link string) string
+}
+
+// NewsletterSource interface for reading email newsletters
+type NewsletterSource interface {
+	Fetch(ctx context.Context, since time.Time) ([]newsletter.Message, error)
+}
+
 // Params groups all dependencies and configuration needed by the scheduler
 type Params struct {
 	// dependencies
@@ -104,17 +157,34 @@ type Params struct {
 	ItemManager           ItemManager
 	ClassificationManager ClassificationManager
 	SettingManager        SettingManager
+	MuteRuleManager       MuteRuleManager // optional, nil disables mute rules
+	JobManager            JobManager
 	Parser                Parser
 	Extractor             Extractor
 	Classifier            Classifier
+	WebSubscriber         WebSubscriber      // optional, nil disables websub push subscriptions
+	LinkResolver          LinkResolver       // optional, nil only cleans item links without resolving redirects
+	Newsletters           []NewsletterSource // optional, empty disables email newsletters
 
 	// configuration
-	UpdateInterval             time.Duration
-	MaxWorkers                 int
+	UpdateInterval             time.Duration // how often to check for feeds due for fetching
+	MinFetchInterval           time.Duration // lower bound for adaptive feed intervals
+	MaxFetchInterval           time.Duration // upper bound for adaptive feed intervals
+	MaxFeedErrors              int           // consecutive fetch errors before a feed is disabled, 0 or negative to never disable
+	WebSubCallbackURL          string        // public base URL of the server used for websub callbacks
+	WebSubLease                time.Duration // requested websub lease duration
+	WebSubSafetyInterval       time.Duration // polling interval for feeds with an active push subscription
+	NewsletterInterval         time.Duration // how often newsletter sources are read
+	NewsletterLookback         time.Duration // only newsletters received within this period are read
+	MaxWorkers                 int           // concurrent feed updates
+	MaxExtractions             int           // concurrent content extractions of queued items
+	MaxClassifications         int           // concurrent LLM classifications of queued items
 	PreferenceSummaryThreshold int
 	CleanupAge                 time.Duration
 	CleanupMinScore            float64
 	CleanupInterval            time.Duration
+	JobTimeout                 time.Duration // how long a claimed job may run before it's claimed again
+	MaxProcessAttempts         int           // attempts to process an item before its job fails
 	// retry configuration for database operations
 	RetryAttempts     int           // number of retry attempts (default: 5)
 	RetryInitialDelay time.Duration // initial retry delay (default: 100ms)
@@ -129,6 +199,7 @@ func NewScheduler(params Params) *Scheduler {
 	s := &Scheduler{
 		itemManager:        params.ItemManager,
 		updateInterval:     params.UpdateInterval,
+		newsletterInterval: params.NewsletterInterval,
 		cleanupAge:         params.CleanupAge,
 		cleanupMinScore:    params.CleanupMinScore,
 		cleanupInterval:    params.CleanupInterval,
@@ -150,10 +221,27 @@ func NewScheduler(params Params) *Scheduler {
 		ItemManager:           params.ItemManager,
 		ClassificationManager: params.ClassificationManager,
 		SettingManager:        params.SettingManager,
+		MuteRuleManager:       params.MuteRuleManager,
+		JobManager:            params.JobManager,
 		Parser:                params.Parser,
 		Extractor:             params.Extractor,
 		Classifier:            params.Classifier,
 		MaxWorkers:            params.MaxWorkers,
+		MaxExtractions:        params.MaxExtractions,
+		MaxClassifications:    params.MaxClassifications,
+		MinFetchInterval:      params.MinFetchInterval,
+		MaxFetchInterval:      params.MaxFetchInterval,
+		MaxFeedErrors:         params.MaxFeedErrors,
+		WebSubscriber:         params.WebSubscriber,
+		LinkResolver:          params.LinkResolver,
+		WebSubCallbackURL:     params.WebSubCallbackURL,
+		WebSubLease:           params.WebSubLease,
+		WebSubSafetyInterval:  params.WebSubSafetyInterval,
+		Newsletters:           params.Newsletters,
+		NewsletterInterval:    params.NewsletterInterval,
+		NewsletterLookback:    params.NewsletterLookback,
+		JobTimeout:            params.JobTimeout,
+		MaxProcessAttempts:    params.MaxProcessAttempts,
 		RetryFunc:             retryFunc,
 	})
 
@@ -173,19 +261,23 @@ func NewScheduler(params Params) *Scheduler {
 func (s *Scheduler) Start(ctx context.Context) {
 	ctx, s.cancel = context.WithCancel(ctx)
 
-	// channel for items to process
-	processCh := make(chan domain.Item, defaultChannelBufferSize)
-
-	// start processing worker
+	// start processing worker, it works through the persistent queue independently of feed updates
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		s.feedProcessor.ProcessingWorker(ctx, processCh)
+		s.feedProcessor.ProcessingWorker(ctx)
 	}()
 
 	// start feed update worker
 	s.wg.Add(1)
-	go s.feedUpdateWorker(ctx, processCh)
+	go s.feedUpdateWorker(ctx)
+
+	// start websub worker, it stores pushed items after the hubs got their response
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.feedProcessor.WebSubWorker(ctx)
+	}()
 
 	// start preference update worker
 	s.wg.Add(1)
@@ -219,23 +311,33 @@ func (s *Scheduler) Stop() {
 	lgr.Printf("[INFO] scheduler stopped")
 }
 
-// feedUpdateWorker periodically updates all enabled feeds
-func (s *Scheduler) feedUpdateWorker(ctx context.Context, processCh chan<- domain.Item) {
+// feedUpdateWorker periodically updates enabled feeds due for fetching and reads newsletters,
+// new items are stored in the processing queue
+func (s *Scheduler) feedUpdateWorker(ctx context.Context) {
 	defer s.wg.Done()
-	defer close(processCh)
 
 	ticker := time.NewTicker(s.updateInterval)
 	defer ticker.Stop()
 
+	var newsletterTick <-chan time.Time
+	if s.feedProcessor.HasNewsletters() && s.newsletterInterval > 0 {
+		newsletterTicker := time.NewTicker(s.newsletterInterval)
+		defer newsletterTicker.Stop()
+		newsletterTick = newsletterTicker.C
+		s.feedProcessor.ImportNewsletters(ctx)
+	}
+
 	// run immediately on start
-	s.feedProcessor.UpdateAllFeeds(ctx, processCh)
+	s.feedProcessor.UpdateDueFeeds(ctx)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			s.feedProcessor.UpdateAllFeeds(ctx, processCh)
+			s.feedProcessor.UpdateDueFeeds(ctx)
+		case <-newsletterTick:
+			s.feedProcessor.ImportNewsletters(ctx)
 		}
 	}
 }
@@ -245,11 +347,51 @@ func (s *Scheduler) UpdateFeedNow(ctx context.Context, feedID int64) error {
 	return s.feedProcessor.UpdateFeedNow(ctx, feedID)
 }
 
+// DiscoverFeeds finds working feeds for a website or feed URL
+func (s *Scheduler) DiscoverFeeds(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+	return s.feedProcessor.DiscoverFeeds(ctx, pageURL)
+}
+
+// PreviewFeed classifies a sample of the feed's latest items without storing anything
+func (s *Scheduler) PreviewFeed(ctx context.Context, feedURL string) (*domain.FeedPreview, error) {
+	return s.feedProcessor.PreviewFeed(ctx, feedURL)
+}
+
+// ScrapePage extracts items from a web page with the selectors without storing anything
+func (s *Scheduler) ScrapePage(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	return s.feedProcessor.ScrapePage(ctx, pageURL, sel)
+}
+
+// VerifyWebSub confirms or rejects a hub's intent verification request for a feed
+func (s *Scheduler) VerifyWebSub(ctx context.Context, feedID int64, mode, topic string, lease time.Duration) error {
+	return s.feedProcessor.VerifyWebSub(ctx, feedID, mode, topic, lease)
+}
+
+// UnsubscribeWebSub cancels the push subscription of a feed before it is deleted or disabled
+func (s *Scheduler) UnsubscribeWebSub(ctx context.Context, feedID int64) error {
+	return s.feedProcessor.UnsubscribeWebSub(ctx, feedID)
+}
+
+// HandleWebSubPush accepts content pushed by a hub, its new items are stored and queued for processing in the background
+func (s *Scheduler) HandleWebSubPush(ctx context.Context, feedID int64, body []byte, signature string) error {
+	return s.feedProcessor.HandleWebSubPush(ctx, feedID, body, signature)
+}
+
+// MuteRulesChanged makes items processed from now on matched against the current mute rules
+func (s *Scheduler) MuteRulesChanged() {
+	s.feedProcessor.MuteRulesChanged()
+}
+
 // ExtractContentNow triggers immediate content extraction for an item
 func (s *Scheduler) ExtractContentNow(ctx context.Context, itemID int64) error {
 	return s.feedProcessor.ExtractContentNow(ctx, itemID)
 }
 
+// PipelineStats returns the number of items running and waiting in each stage of the processing pipeline
+func (s *Scheduler) PipelineStats() domain.PipelineStats {
+	return s.feedProcessor.PipelineStats()
+}
+
 // TriggerPreferenceUpdate triggers a preference summary update via the worker
 func (s *Scheduler) TriggerPreferenceUpdate() {
 	// non-blocking send to buffered channel
@@ -17,7 +17,9 @@ import (
 
 func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 	// setup all mocks
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -66,8 +68,8 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 	nextItemID := int64(100)
 
 	// setup mocks
-	feedManager.GetFeedsFunc = func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
-		assert.True(t, enabledOnly)
+	feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
+		assert.Equal(t, dueFeedsBatchSize, limit)
 		return testFeeds, nil
 	}
 
@@ -80,8 +82,8 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 		return nil, assert.AnError
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
-		if pf, ok := parsedItems[url]; ok {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+		if pf, ok := parsedItems[f.URL]; ok {
 			return pf, nil
 		}
 		return nil, assert.AnError
@@ -134,7 +136,7 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 		return nil
 	}
 
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "Extracted content for " + url,
 			RichContent: "<p>Rich content for " + url + "</p>",
@@ -208,8 +210,31 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 		return 0, nil // no old items to delete
 	}
 
+	// in-memory processing queue
+	var queueMu sync.Mutex
+	var queue []int64
+	jobManager := newJobManager()
+	jobManager.EnqueueJobFunc = func(ctx context.Context, itemID int64, priority int) error {
+		queueMu.Lock()
+		defer queueMu.Unlock()
+		queue = append(queue, itemID)
+		return nil
+	}
+	jobManager.ClaimJobFunc = func(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error) {
+		queueMu.Lock()
+		defer queueMu.Unlock()
+		if len(queue) == 0 {
+			return nil, nil
+		}
+		job := &domain.ProcessJob{ItemID: queue[0], State: domain.JobExtracting, Attempts: 1}
+		queue = queue[1:]
+		return job, nil
+	}
+
 	// create scheduler with short intervals for testing
 	params := Params{
+		JobManager:                 jobManager,
+		MaxProcessAttempts:         3,
 		FeedManager:                feedManager,
 		ItemManager:                itemManager,
 		ClassificationManager:      classificationManager,
@@ -235,7 +260,7 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 
 	// verify feeds were fetched
-	assert.GreaterOrEqual(t, len(feedManager.GetFeedsCalls()), 1)
+	assert.GreaterOrEqual(t, len(feedManager.GetFeedsToFetchCalls()), 1)
 
 	// verify items were created
 	assert.GreaterOrEqual(t, len(itemManager.CreateItemCalls()), 2) // one for each feed
@@ -272,7 +297,9 @@ func TestScheduler_Integration_FullWorkflow(t *testing.T) {
 
 func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 	// test scheduler behavior with various errors
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -289,7 +316,7 @@ func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 		FetchInterval: time.Hour,
 	}
 
-	feedManager.GetFeedsFunc = func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
+	feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
 		return []domain.Feed{testFeed}, nil
 	}
 
@@ -297,7 +324,7 @@ func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 		return &testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return nil, assert.AnError // simulate parse error
 	}
 
@@ -324,7 +351,7 @@ func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 		return "", nil
 	}
 
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{}, nil
 	}
 
@@ -342,6 +369,7 @@ func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 
 	// create scheduler
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -366,9 +394,9 @@ func TestScheduler_Integration_ErrorHandling(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 
 	// verify error was handled gracefully
-	assert.GreaterOrEqual(t, len(parser.ParseCalls()), 1)
+	assert.GreaterOrEqual(t, len(parser.FetchCalls()), 1)
 	assert.GreaterOrEqual(t, len(feedManager.UpdateFeedErrorCalls()), 1)
 
 	// scheduler should continue running despite errors
-	assert.GreaterOrEqual(t, len(feedManager.GetFeedsCalls()), 1)
+	assert.GreaterOrEqual(t, len(feedManager.GetFeedsToFetchCalls()), 1)
 }
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ func TestNewScheduler(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -53,6 +55,7 @@ func TestNewScheduler_DefaultConfig(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -84,15 +87,19 @@ func TestNewScheduler_DefaultConfig(t *testing.T) {
 }
 
 func TestScheduler_UpdateFeedNow(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
 	parser := &mocks.ParserMock{}
 	extractor := &mocks.ExtractorMock{}
 	classifier := &mocks.ClassifierMock{}
+	jobManager := newJobManager()
 
 	params := Params{
+		JobManager:            jobManager,
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -131,8 +138,8 @@ func TestScheduler_UpdateFeedNow(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
-		assert.Equal(t, testFeed.URL, url)
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
+		assert.Equal(t, testFeed.URL, f.URL)
 		return testParsedFeed, nil
 	}
 
@@ -163,62 +170,29 @@ func TestScheduler_UpdateFeedNow(t *testing.T) {
 		return nil
 	}
 
-	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
-		return &content.ExtractResult{
-			Content:     "extracted content",
-			RichContent: "<p>rich content</p>",
-		}, nil
-	}
-
-	classificationManager.GetRecentFeedbackFunc = func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
-		return []domain.FeedbackExample{}, nil
-	}
-
-	classificationManager.GetTopicsFunc = func(ctx context.Context) ([]string, error) {
-		return []string{"tech"}, nil
-	}
-
-	settingManager.GetSettingFunc = func(ctx context.Context, key string) (string, error) {
-		return "", nil
-	}
-
-	classifier.ClassifyItemsFunc = func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
-		return []domain.Classification{{
-			GUID:        req.Articles[0].GUID,
-			Score:       7.5,
-			Explanation: "test classification",
-			Topics:      []string{"tech"},
-			Summary:     "test summary",
-		}}, nil
-	}
-
-	itemManager.UpdateItemProcessedFunc = func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, classification *domain.Classification) error {
-		return nil
-	}
-
-	itemManager.UpdateItemExtractionFunc = func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent) error {
-		return nil
-	}
-
 	// execute
 	err := scheduler.UpdateFeedNow(context.Background(), 1)
 
-	// wait for background processing to complete
-	time.Sleep(100 * time.Millisecond)
-
 	// verify
 	require.NoError(t, err)
 	assert.Len(t, feedManager.GetFeedCalls(), 1)
-	assert.Len(t, parser.ParseCalls(), 1)
+	assert.Len(t, parser.FetchCalls(), 1)
 	assert.Len(t, itemManager.ItemExistsCalls(), 1)
 	assert.Len(t, itemManager.ItemExistsByTitleOrURLCalls(), 1)
 	assert.Len(t, itemManager.CreateItemCalls(), 1)
 	assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
+	require.Len(t, jobManager.EnqueueJobCalls(), 1)
+	assert.Equal(t, int64(123), jobManager.EnqueueJobCalls()[0].ItemID)
+	assert.Equal(t, domain.PriorityManual, jobManager.EnqueueJobCalls()[0].Priority, "queued ahead of the backlog")
+	assert.Empty(t, extractor.ExtractCalls(), "processed by the processing worker")
 }
 
 func TestScheduler_ExtractContentNow(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+			return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+		},
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -227,6 +201,7 @@ func TestScheduler_ExtractContentNow(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -267,7 +242,7 @@ func TestScheduler_ExtractContentNow(t *testing.T) {
 		return testItem, nil
 	}
 
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		assert.Equal(t, testItem.Link, url)
 		return extractResult, nil
 	}
@@ -342,6 +317,7 @@ func TestScheduler_StartStop(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -355,8 +331,8 @@ func TestScheduler_StartStop(t *testing.T) {
 	scheduler := NewScheduler(params)
 
 	// setup minimal expectations for feed update
-	feedManager.GetFeedsFunc = func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
-		assert.True(t, enabledOnly)
+	feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
+		assert.Equal(t, dueFeedsBatchSize, limit)
 		return []domain.Feed{}, nil
 	}
 
@@ -378,11 +354,15 @@ func TestScheduler_StartStop(t *testing.T) {
 	scheduler.Stop()
 
 	// verify at least one call was made
-	assert.GreaterOrEqual(t, len(feedManager.GetFeedsCalls()), 1)
+	assert.GreaterOrEqual(t, len(feedManager.GetFeedsToFetchCalls()), 1)
 }
 
 func TestScheduler_ProcessItem_ExtractionError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+			return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+		},
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -391,6 +371,7 @@ func TestScheduler_ProcessItem_ExtractionError(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -412,7 +393,7 @@ func TestScheduler_ProcessItem_ExtractionError(t *testing.T) {
 	}
 
 	// setup extraction to fail
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return nil, assert.AnError
 	}
 
@@ -440,15 +421,22 @@ func TestScheduler_ProcessItem_ExtractionError(t *testing.T) {
 }
 
 func TestScheduler_ProcessItem_ClassificationError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+			return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+		},
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
 	parser := &mocks.ParserMock{}
 	extractor := &mocks.ExtractorMock{}
 	classifier := &mocks.ClassifierMock{}
+	jobManager := newJobManager()
 
 	params := Params{
+		JobManager:            jobManager,
+		MaxProcessAttempts:    3,
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -470,7 +458,7 @@ func TestScheduler_ProcessItem_ClassificationError(t *testing.T) {
 	}
 
 	// setup successful extraction
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "Extracted content",
 			RichContent: "<p>Rich content</p>",
@@ -507,18 +495,29 @@ func TestScheduler_ProcessItem_ClassificationError(t *testing.T) {
 	assert.Len(t, extractor.ExtractCalls(), 1)
 	assert.Len(t, classifier.ClassifyItemsCalls(), 1)
 	assert.Empty(t, itemManager.UpdateItemProcessedCalls()) // should not be called after classification error
+	require.Len(t, jobManager.RetryJobCalls(), 1, "job retried later")
+	assert.Equal(t, int64(1), jobManager.RetryJobCalls()[0].ItemID)
+	assert.Equal(t, "classify: "+assert.AnError.Error(), jobManager.RetryJobCalls()[0].ErrMsg)
+	assert.Equal(t, jobRetryDelay, jobManager.RetryJobCalls()[0].Delay)
 }
 
 func TestScheduler_ProcessItem_NoClassificationResults(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedFunc: func(ctx context.Context, id int64) (*domain.Feed, error) {
+			return &domain.Feed{ID: id}, nil // public feed, items extracted without credentials
+		},
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
 	parser := &mocks.ParserMock{}
 	extractor := &mocks.ExtractorMock{}
 	classifier := &mocks.ClassifierMock{}
+	jobManager := newJobManager()
 
 	params := Params{
+		JobManager:            jobManager,
+		MaxProcessAttempts:    3,
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -540,7 +539,7 @@ func TestScheduler_ProcessItem_NoClassificationResults(t *testing.T) {
 	}
 
 	// setup successful extraction
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "Extracted content",
 			RichContent: "<p>Rich content</p>",
@@ -577,10 +576,14 @@ func TestScheduler_ProcessItem_NoClassificationResults(t *testing.T) {
 	assert.Len(t, extractor.ExtractCalls(), 1)
 	assert.Len(t, classifier.ClassifyItemsCalls(), 1)
 	assert.Empty(t, itemManager.UpdateItemProcessedCalls()) // should not be called with empty results
+	require.Len(t, jobManager.RetryJobCalls(), 1)
+	assert.Equal(t, "no classification returned", jobManager.RetryJobCalls()[0].ErrMsg)
 }
 
 func TestScheduler_UpdateFeed_ParseError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -589,6 +592,7 @@ func TestScheduler_UpdateFeed_ParseError(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -614,7 +618,7 @@ func TestScheduler_UpdateFeed_ParseError(t *testing.T) {
 	}
 
 	// setup parser to fail
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return nil, assert.AnError
 	}
 
@@ -630,13 +634,15 @@ func TestScheduler_UpdateFeed_ParseError(t *testing.T) {
 
 	// verify - should not return error but should call UpdateFeedError
 	require.NoError(t, err)
-	assert.Len(t, parser.ParseCalls(), 1)
+	assert.Len(t, parser.FetchCalls(), 1)
 	assert.Len(t, feedManager.UpdateFeedErrorCalls(), 1)
 	assert.Empty(t, itemManager.CreateItemCalls()) // should not create items after parse error
 }
 
 func TestScheduler_UpdateFeed_DuplicateItems(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -645,6 +651,7 @@ func TestScheduler_UpdateFeed_DuplicateItems(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -685,7 +692,7 @@ func TestScheduler_UpdateFeed_DuplicateItems(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -713,7 +720,7 @@ func TestScheduler_UpdateFeed_DuplicateItems(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -761,7 +768,7 @@ func TestScheduler_UpdateFeed_DuplicateItems(t *testing.T) {
 	assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)      // updated feed timestamp
 }
 
-func TestScheduler_UpdateAllFeeds_GetFeedsError(t *testing.T) {
+func TestScheduler_UpdateDueFeeds_GetFeedsError(t *testing.T) {
 	feedManager := &mocks.FeedManagerMock{}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
@@ -771,6 +778,7 @@ func TestScheduler_UpdateAllFeeds_GetFeedsError(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -783,13 +791,13 @@ func TestScheduler_UpdateAllFeeds_GetFeedsError(t *testing.T) {
 	}
 	scheduler := NewScheduler(params)
 
-	// setup GetFeeds to fail
-	feedManager.GetFeedsFunc = func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
+	// setup GetFeedsToFetch to fail
+	feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
 		return nil, assert.AnError
 	}
 
 	// setup mocks for background processing (in case there are residual items from other tests)
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -834,13 +842,15 @@ func TestScheduler_UpdateAllFeeds_GetFeedsError(t *testing.T) {
 	cancel()
 	scheduler.Stop()
 
-	// verify - should call GetFeeds but not attempt to process any feeds
-	assert.GreaterOrEqual(t, len(feedManager.GetFeedsCalls()), 1)
-	assert.Empty(t, parser.ParseCalls()) // should not parse if GetFeeds fails
+	// verify - should call GetFeedsToFetch but not attempt to process any feeds
+	assert.GreaterOrEqual(t, len(feedManager.GetFeedsToFetchCalls()), 1)
+	assert.Empty(t, parser.FetchCalls()) // should not parse if GetFeedsToFetch fails
 }
 
-func TestScheduler_UpdateAllFeeds_MultipleFeeds(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+func TestScheduler_UpdateDueFeeds_MultipleFeeds(t *testing.T) {
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -849,6 +859,7 @@ func TestScheduler_UpdateAllFeeds_MultipleFeeds(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -866,14 +877,14 @@ func TestScheduler_UpdateAllFeeds_MultipleFeeds(t *testing.T) {
 		{ID: 2, URL: "https://example.com/feed2.xml", FetchInterval: 3600},
 	}
 
-	// setup GetFeeds to return multiple feeds
-	feedManager.GetFeedsFunc = func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
-		assert.True(t, enabledOnly)
+	// setup GetFeedsToFetch to return multiple due feeds
+	feedManager.GetFeedsToFetchFunc = func(ctx context.Context, limit int) ([]domain.Feed, error) {
+		assert.Equal(t, dueFeedsBatchSize, limit)
 		return testFeeds, nil
 	}
 
 	// setup parser to return empty feeds (no items)
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return &domain.ParsedFeed{Items: []domain.ParsedItem{}}, nil
 	}
 
@@ -884,7 +895,7 @@ func TestScheduler_UpdateAllFeeds_MultipleFeeds(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -930,13 +941,15 @@ func TestScheduler_UpdateAllFeeds_MultipleFeeds(t *testing.T) {
 	scheduler.Stop()
 
 	// verify - should process both feeds
-	assert.GreaterOrEqual(t, len(feedManager.GetFeedsCalls()), 1)
-	assert.GreaterOrEqual(t, len(parser.ParseCalls()), 2)                  // should parse both feeds
+	assert.GreaterOrEqual(t, len(feedManager.GetFeedsToFetchCalls()), 1)
+	assert.GreaterOrEqual(t, len(parser.FetchCalls()), 2)                  // should parse both feeds
 	assert.GreaterOrEqual(t, len(feedManager.UpdateFeedFetchedCalls()), 2) // should update both feeds
 }
 
 func TestScheduler_UpdateFeed_ItemCreationError(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -945,6 +958,7 @@ func TestScheduler_UpdateFeed_ItemCreationError(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -976,7 +990,7 @@ func TestScheduler_UpdateFeed_ItemCreationError(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -1000,7 +1014,7 @@ func TestScheduler_UpdateFeed_ItemCreationError(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
@@ -1041,7 +1055,9 @@ func TestScheduler_UpdateFeed_ItemCreationError(t *testing.T) {
 }
 
 func TestScheduler_UpdateFeed_EmptyTitle(t *testing.T) {
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -1050,6 +1066,7 @@ func TestScheduler_UpdateFeed_EmptyTitle(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -1080,7 +1097,7 @@ func TestScheduler_UpdateFeed_EmptyTitle(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -1094,7 +1111,7 @@ func TestScheduler_UpdateFeed_EmptyTitle(t *testing.T) {
 
 	// verify - should handle empty title gracefully
 	require.NoError(t, err)
-	assert.Len(t, parser.ParseCalls(), 1)
+	assert.Len(t, parser.FetchCalls(), 1)
 	assert.Len(t, feedManager.UpdateFeedFetchedCalls(), 1)
 }
 
@@ -1162,6 +1179,78 @@ func TestScheduler_PerformCleanup(t *testing.T) {
 	})
 }
 
+func TestScheduler_ProcessingQueue(t *testing.T) {
+	itemManager := &mocks.ItemManagerMock{
+		GetItemFunc: func(ctx context.Context, id int64) (*domain.Item, error) {
+			return &domain.Item{ID: id, GUID: "7", Title: "left from last run", Link: "https://example.com/7"}, nil
+		},
+		UpdateItemProcessedFunc: func(ctx context.Context, itemID int64, extraction *domain.ExtractedContent, class *domain.Classification) error {
+			return nil
+		},
+		UpdateItemFingerprintFunc: func(ctx context.Context, itemID int64, fingerprint uint64) error { return nil },
+		FindDuplicateFunc: func(ctx context.Context, itemID int64, fingerprint uint64, maxDistance int, window time.Duration) (int64, error) {
+			return 0, nil
+		},
+	}
+	feedManager := &mocks.FeedManagerMock{
+		GetFeedsToFetchFunc: func(ctx context.Context, limit int) ([]domain.Feed, error) { return nil, nil },
+		GetFeedFunc:         func(ctx context.Context, id int64) (*domain.Feed, error) { return &domain.Feed{ID: id}, nil },
+	}
+	classificationManager := &mocks.ClassificationManagerMock{
+		GetRecentFeedbackFunc: func(ctx context.Context, feedbackType string, limit int) ([]domain.FeedbackExample, error) {
+			return nil, nil
+		},
+		GetTopicsFunc: func(ctx context.Context) ([]string, error) { return nil, nil },
+	}
+	var claimed atomic.Bool
+	jobManager := newJobManager()
+	jobManager.RecoverJobsFunc = func(ctx context.Context) (int64, error) { return 1, nil }
+	jobManager.ClaimJobFunc = func(ctx context.Context, timeout time.Duration) (*domain.ProcessJob, error) {
+		assert.Equal(t, 10*time.Minute, timeout)
+		if claimed.Swap(true) {
+			return nil, nil
+		}
+		return &domain.ProcessJob{ID: 1, ItemID: 7, State: domain.JobExtracting, Attempts: 1}, nil
+	}
+	scheduler := NewScheduler(Params{
+		FeedManager:           feedManager,
+		ItemManager:           itemManager,
+		JobManager:            jobManager,
+		ClassificationManager: classificationManager,
+		SettingManager: &mocks.SettingManagerMock{
+			GetSettingFunc: func(ctx context.Context, key string) (string, error) { return "", nil },
+		},
+		Extractor: &mocks.ExtractorMock{
+			ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
+				return &content.ExtractResult{Content: "content"}, nil
+			},
+		},
+		Classifier: &mocks.ClassifierMock{
+			ClassifyItemsFunc: func(ctx context.Context, req llm.ClassifyRequest) ([]domain.Classification, error) {
+				return []domain.Classification{{GUID: "7", Score: 5}}, nil
+			},
+		},
+		MaxWorkers:         1,
+		UpdateInterval:     time.Hour,
+		JobTimeout:         10 * time.Minute,
+		MaxProcessAttempts: 3,
+		RetryAttempts:      1,
+	})
+
+	scheduler.Start(context.Background())
+	require.Eventually(t, func() bool { return len(jobManager.UpdateJobStateCalls()) == 2 },
+		time.Second, 10*time.Millisecond, "recovered job processed")
+	scheduler.Stop()
+
+	assert.Len(t, jobManager.RecoverJobsCalls(), 1)
+	require.Len(t, itemManager.UpdateItemProcessedCalls(), 1)
+	assert.Equal(t, int64(7), itemManager.UpdateItemProcessedCalls()[0].ItemID)
+	states := jobManager.UpdateJobStateCalls()
+	assert.Equal(t, domain.JobClassifying, states[0].State)
+	assert.Equal(t, domain.JobDone, states[1].State)
+	assert.Equal(t, int64(7), states[1].ItemID)
+}
+
 func TestScheduler_CleanupWorker(t *testing.T) {
 	itemManager := &mocks.ItemManagerMock{
 		DeleteOldItemsFunc: func(ctx context.Context, age time.Duration, minScore float64) (int64, error) {
@@ -1170,13 +1259,13 @@ func TestScheduler_CleanupWorker(t *testing.T) {
 	}
 
 	feedManager := &mocks.FeedManagerMock{
-		GetFeedsFunc: func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
+		GetFeedsToFetchFunc: func(ctx context.Context, limit int) ([]domain.Feed, error) {
 			return []domain.Feed{}, nil
 		},
 	}
 
 	extractor := &mocks.ExtractorMock{
-		ExtractFunc: func(ctx context.Context, url string) (*content.ExtractResult, error) {
+		ExtractFunc: func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 			return &content.ExtractResult{}, nil
 		},
 	}
@@ -1203,6 +1292,7 @@ func TestScheduler_CleanupWorker(t *testing.T) {
 	}
 
 	params := Params{
+		JobManager:            newJobManager(),
 		FeedManager:           feedManager,
 		ItemManager:           itemManager,
 		ClassificationManager: classificationManager,
@@ -1238,6 +1328,7 @@ func TestScheduler_CleanupWorker(t *testing.T) {
 func TestScheduler_CleanupConfig(t *testing.T) {
 	t.Run("custom config values", func(t *testing.T) {
 		params := Params{
+			JobManager:            newJobManager(),
 			FeedManager:           &mocks.FeedManagerMock{},
 			ItemManager:           &mocks.ItemManagerMock{},
 			ClassificationManager: &mocks.ClassificationManagerMock{},
@@ -1260,7 +1351,9 @@ func TestScheduler_CleanupConfig(t *testing.T) {
 
 func TestScheduler_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 	// setup dependencies
-	feedManager := &mocks.FeedManagerMock{}
+	feedManager := &mocks.FeedManagerMock{
+		AddFeedFetchFunc: func(ctx context.Context, fetch *domain.FeedFetch) error { return nil },
+	}
 	itemManager := &mocks.ItemManagerMock{}
 	classificationManager := &mocks.ClassificationManagerMock{}
 	settingManager := &mocks.SettingManagerMock{}
@@ -1269,6 +1362,7 @@ func TestScheduler_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 	classifier := &mocks.ClassifierMock{}
 
 	params := Params{
+		JobManager:                 newJobManager(),
 		FeedManager:                feedManager,
 		ItemManager:                itemManager,
 		ClassificationManager:      classificationManager,
@@ -1308,7 +1402,7 @@ func TestScheduler_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 		return testFeed, nil
 	}
 
-	parser.ParseFunc = func(ctx context.Context, url string) (*domain.ParsedFeed, error) {
+	parser.FetchFunc = func(ctx context.Context, f *domain.Feed) (*domain.ParsedFeed, error) {
 		return testParsedFeed, nil
 	}
 
@@ -1337,7 +1431,7 @@ func TestScheduler_UpdateFeed_ItemCreationWithLockError(t *testing.T) {
 	}
 
 	// setup mocks for background processing
-	extractor.ExtractFunc = func(ctx context.Context, url string) (*content.ExtractResult, error) {
+	extractor.ExtractFunc = func(ctx context.Context, url string, auth domain.FeedAuth) (*content.ExtractResult, error) {
 		return &content.ExtractResult{
 			Content:     "extracted content",
 			RichContent: "<p>rich content</p>",
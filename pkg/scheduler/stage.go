@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// stage limits the number of items processed concurrently by a step of the processing pipeline,
+// e.g. extraction or classification, and counts items running and waiting for a free slot
+type stage struct {
+	slots   chan struct{}
+	waiting atomic.Int64
+	running atomic.Int64
+}
+
+// newStage makes a stage processing up to limit items at once, at least one
+func newStage(limit int) *stage {
+	return &stage{slots: make(chan struct{}, max(limit, 1))}
+}
+
+// Acquire blocks until the stage has a free slot, the slot has to be released after the step is done.
+// returns the context's error if it is canceled while waiting.
+func (s *stage) Acquire(ctx context.Context) error {
+	s.waiting.Add(1)
+	defer s.waiting.Add(-1)
+	select {
+	case s.slots <- struct{}{}:
+		s.running.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot taken by Acquire
+func (s *stage) Release() {
+	s.running.Add(-1)
+	<-s.slots
+}
+
+// stats returns the current load of the stage
+func (s *stage) stats() domain.StageStats {
+	return domain.StageStats{Waiting: int(s.waiting.Load()), Running: int(s.running.Load()), Limit: cap(s.slots)}
+}
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+func TestStage(t *testing.T) {
+	s := newStage(1)
+	require.NoError(t, s.Acquire(context.Background()))
+	assert.Equal(t, domain.StageStats{Running: 1, Limit: 1}, s.stats())
+
+	acquired := make(chan struct{})
+	go func() {
+		assert.NoError(t, s.Acquire(context.Background()))
+		close(acquired)
+	}()
+	require.Eventually(t, func() bool { return s.stats().Waiting == 1 }, time.Second, 5*time.Millisecond)
+
+	s.Release()
+	<-acquired
+	assert.Equal(t, domain.StageStats{Running: 1, Limit: 1}, s.stats())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.Acquire(ctx), context.DeadlineExceeded)
+	assert.Equal(t, domain.StageStats{Running: 1, Limit: 1}, s.stats(), "canceled wait doesn't count")
+
+	assert.Equal(t, 1, newStage(0).stats().Limit, "at least one slot")
+}
@@ -0,0 +1,55 @@
+// Package simhash implements SimHash content fingerprints. Texts differing in a few words,
+// e.g. the same wire story with a reworded headline, get fingerprints differing in a few bits.
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// minWords is the number of words a text needs to get a fingerprint,
+// fingerprints of shorter texts match unrelated texts too easily
+const minWords = 50
+
+// Fingerprint returns the 64-bit SimHash of the text, features are pairs of adjacent words
+// with case and punctuation ignored. returns 0 for texts too short to fingerprint.
+func Fingerprint(text string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < minWords {
+		return 0
+	}
+
+	var weights [64]int
+	h := fnv.New64a()
+	for i := 1; i < len(words); i++ {
+		h.Reset()
+		_, _ = h.Write([]byte(words[i-1]))
+		_, _ = h.Write([]byte{' '})
+		_, _ = h.Write([]byte(words[i]))
+		sum := h.Sum64()
+		for b := range 64 {
+			if sum&(1<<b) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var res uint64
+	for b, w := range weights {
+		if w > 0 {
+			res |= 1 << b
+		}
+	}
+	return res
+}
+
+// Distance returns the number of bits differing between two fingerprints
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,42 @@
+package simhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const story = `The central bank raised its benchmark interest rate by a quarter of a percentage point on Wednesday,
+the third increase this year, saying inflation remained well above its target despite signs of a cooling labor market.
+Policymakers signaled that further increases were possible if price pressures did not ease in the coming months,
+and officials said they would continue to watch wage growth, energy costs and consumer spending closely.
+The decision was widely expected by economists, and markets reacted calmly, with stocks little changed in afternoon trading
+and bond yields edging slightly higher. The governor told reporters that the bank was prepared to act as needed,
+but added that the full effect of earlier increases had not yet been felt across the economy. Mortgage rates have already
+climbed to their highest level in more than a decade, weighing on home sales and construction, while business investment
+has slowed as borrowing costs rose. Some analysts warned that the bank risked tipping the economy into a recession.`
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint("Central bank raises rates again. " + story)
+	assert.NotZero(t, base)
+	assert.Equal(t, base, Fingerprint("CENTRAL bank raises rates, again! "+story), "case and punctuation ignored")
+
+	reworded := Fingerprint("Central bank lifts interest rate for third time this year. " + story)
+	assert.LessOrEqual(t, Distance(base, reworded), 6, "same story with another headline")
+	reposted := Fingerprint("Rates up again. " + story + " Reporting by John Smith; editing by Jane Doe. Read more at example news.")
+	assert.LessOrEqual(t, Distance(base, reposted), 8, "same story with another headline and a byline")
+
+	sameTopic := Fingerprint(`The central bank held its benchmark interest rate steady on Wednesday, pausing after three increases,
+		saying inflation had eased toward its target as the labor market cooled. Policymakers signaled that they could resume
+		raising rates if price pressures picked up again, and officials said they would keep watching wages and energy costs.`)
+	assert.Greater(t, Distance(base, sameTopic), 20, "another story on the same topic")
+
+	assert.Zero(t, Fingerprint("too short to fingerprint"))
+	assert.Zero(t, Fingerprint(""))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance(0xff, 0xff))
+	assert.Equal(t, 2, Distance(0b1010, 0b0110))
+	assert.Equal(t, 64, Distance(0, ^uint64(0)))
+}
@@ -0,0 +1,132 @@
+// Package websub implements the subscriber side of WebSub (formerly PubSubHubbub):
+// subscription requests to hubs and verification of signed content distribution requests.
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // sha1 signatures are part of the WebSub spec and still used by many hubs
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Subscriber sends subscription requests to WebSub hubs
+type Subscriber struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewSubscriber creates a new WebSub subscriber
+func NewSubscriber(timeout time.Duration, userAgent string) *Subscriber {
+	return &Subscriber{
+		client:    &http.Client{Timeout: timeout},
+		userAgent: userAgent,
+	}
+}
+
+// SetTransport sets the transport used for requests, e.g. one with a proxy or custom root CAs
+func (s *Subscriber) SetTransport(t http.RoundTripper) {
+	s.client.Transport = t
+}
+
+// Subscribe asks the hub to push updates of the topic to the callback URL. The hub confirms
+// the subscription asynchronously by calling the callback with a challenge (intent verification),
+// so a nil error means only that the request was accepted.
+func (s *Subscriber) Subscribe(ctx context.Context, hubURL, topic, callback, secret string, lease time.Duration) error {
+	form := url.Values{
+		"hub.mode":     {"subscribe"},
+		"hub.topic":    {topic},
+		"hub.callback": {callback},
+	}
+	if lease > 0 {
+		form.Set("hub.lease_seconds", strconv.Itoa(int(lease.Seconds())))
+	}
+	if secret != "" {
+		form.Set("hub.secret", secret)
+	}
+	return s.send(ctx, hubURL, form)
+}
+
+// Unsubscribe asks the hub to stop pushing updates of the topic to the callback URL.
+// As with Subscribe, the hub verifies the intent asynchronously with a call to the callback.
+func (s *Subscriber) Unsubscribe(ctx context.Context, hubURL, topic, callback string) error {
+	form := url.Values{
+		"hub.mode":     {"unsubscribe"},
+		"hub.topic":    {topic},
+		"hub.callback": {callback},
+	}
+	return s.send(ctx, hubURL, form)
+}
+
+// send posts the subscription request to the hub, any 2xx status means the request was accepted
+func (s *Subscriber) send(ctx context.Context, hubURL string, form url.Values) error {
+	mode := form.Get("hub.mode")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", mode, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send %s request: %w", mode, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("hub rejected %s, status %d: %s", mode, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// NewSecret generates a random secret for a subscription
+func NewSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// VerifySignature checks the X-Hub-Signature header value ("method=hexdigest") of pushed content
+// against the HMAC of the body with the subscription secret
+func VerifySignature(secret string, body []byte, signature string) bool {
+	method, digest, ok := strings.Cut(strings.TrimSpace(signature), "=")
+	if !ok || secret == "" {
+		return false
+	}
+
+	var h func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha384":
+		h = sha512.New384
+	case "sha512":
+		h = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,121 @@
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // test of sha1 signatures
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriber_Subscribe(t *testing.T) {
+	t.Run("accepted", func(t *testing.T) {
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "test-agent", r.UserAgent())
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "subscribe", r.PostForm.Get("hub.mode"))
+			assert.Equal(t, "https://example.com/feed.xml", r.PostForm.Get("hub.topic"))
+			assert.Equal(t, "https://newscope.example.com/websub/1", r.PostForm.Get("hub.callback"))
+			assert.Equal(t, "864000", r.PostForm.Get("hub.lease_seconds"))
+			assert.Equal(t, "s3cret", r.PostForm.Get("hub.secret"))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer hub.Close()
+
+		s := NewSubscriber(time.Second, "test-agent")
+		err := s.Subscribe(context.Background(), hub.URL, "https://example.com/feed.xml",
+			"https://newscope.example.com/websub/1", "s3cret", 240*time.Hour)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad topic", http.StatusBadRequest)
+		}))
+		defer hub.Close()
+
+		s := NewSubscriber(time.Second, "test-agent")
+		err := s.Subscribe(context.Background(), hub.URL, "t", "c", "", 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400: bad topic")
+	})
+}
+
+func TestSubscriber_Unsubscribe(t *testing.T) {
+	t.Run("accepted", func(t *testing.T) {
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "unsubscribe", r.PostForm.Get("hub.mode"))
+			assert.Equal(t, "https://example.com/feed.xml", r.PostForm.Get("hub.topic"))
+			assert.Equal(t, "https://newscope.example.com/websub/1", r.PostForm.Get("hub.callback"))
+			assert.Empty(t, r.PostForm.Get("hub.secret"))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer hub.Close()
+
+		s := NewSubscriber(time.Second, "test-agent")
+		err := s.Unsubscribe(context.Background(), hub.URL, "https://example.com/feed.xml", "https://newscope.example.com/websub/1")
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unknown subscription", http.StatusNotFound)
+		}))
+		defer hub.Close()
+
+		s := NewSubscriber(time.Second, "test-agent")
+		err := s.Unsubscribe(context.Background(), hub.URL, "t", "c")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "hub rejected unsubscribe, status 404: unknown subscription")
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`<feed>pushed</feed>`)
+	sign := func(h func() hash.Hash, secret string) string {
+		mac := hmac.New(h, []byte(secret))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		want      bool
+	}{
+		{"sha1", "secret", "sha1=" + sign(sha1.New, "secret"), true},
+		{"sha256", "secret", "sha256=" + sign(sha256.New, "secret"), true},
+		{"upper case method", "secret", "SHA256=" + sign(sha256.New, "secret"), true},
+		{"wrong secret", "secret", "sha256=" + sign(sha256.New, "other"), false},
+		{"unknown method", "secret", "md5=abcd", false},
+		{"not hex", "secret", "sha1=zzzz", false},
+		{"no method", "secret", sign(sha1.New, "secret"), false},
+		{"empty secret", "", "sha1=" + sign(sha1.New, ""), false},
+		{"empty signature", "secret", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifySignature(tt.secret, body, tt.signature))
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	s1, err := NewSecret()
+	require.NoError(t, err)
+	s2, err := NewSecret()
+	require.NoError(t, err)
+	assert.Len(t, s1, 40)
+	assert.NotEqual(t, s1, s2)
+}
@@ -1,12 +1,15 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"log"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +30,10 @@ const (
 	// view modes
 	viewModeExpanded  = "expanded"
 	viewModeCondensed = "condensed"
+
+	// feeds health view
+	healthFetchesLimit = 30                  // latest fetches of each feed shown on the health view
+	silentFeedAge      = 14 * 24 * time.Hour // enabled feeds without new items for longer are flagged as silent
 )
 
 var (
@@ -69,13 +76,14 @@ func getViewMode(r *http.Request) string {
 
 // articlesPageRequest holds data for rendering articles page
 type articlesPageRequest struct {
-	articles      []domain.ClassifiedItem
-	topics        []string
-	feeds         []string
-	selectedTopic string
-	selectedFeed  string
-	selectedSort  string
-	showLikedOnly bool
+	articles       []domain.ClassifiedItem
+	topics         []string
+	feeds          []string
+	selectedTopic  string
+	selectedFeed   string
+	selectedFolder string
+	selectedSort   string
+	showLikedOnly  bool
 	// pagination
 	currentPage int
 	totalPages  int
@@ -90,6 +98,129 @@ type articlesPageRequest struct {
 	searchQuery string
 }
 
+// feedGroup is a folder with its feeds on the feeds page, empty folder is the top level
+type feedGroup struct {
+	Folder string
+	Feeds  []domain.Feed
+}
+
+// groupFeedsByFolder groups feeds by folder, top-level feeds first and folders sorted by path.
+// feeds keep their order within a folder.
+func groupFeedsByFolder(feeds []domain.Feed) []feedGroup {
+	sorted := slices.Clone(feeds)
+	slices.SortStableFunc(sorted, func(a, b domain.Feed) int { return strings.Compare(a.Folder, b.Folder) })
+
+	groups := []feedGroup{}
+	for _, f := range sorted {
+		if len(groups) == 0 || groups[len(groups)-1].Folder != f.Folder {
+			groups = append(groups, feedGroup{Folder: f.Folder})
+		}
+		groups[len(groups)-1].Feeds = append(groups[len(groups)-1].Feeds, f)
+	}
+	return groups
+}
+
+// feedHealth is a feed with its fetch statistics on the feeds health view
+type feedHealth struct {
+	Feed         domain.Feed
+	Fetches      []domain.FeedFetch // latest fetches, oldest first
+	SuccessRate  float64            // share of successful fetches, 0 to 1
+	AvgDuration  time.Duration      // average fetch time
+	SinceNewItem time.Duration      // time since the last fetch with new items, negative if there was none
+	Silent       bool               // enabled feed without new items for silentFeedAge
+}
+
+// newFeedHealth calculates health statistics of a feed from its latest fetches
+func newFeedHealth(f domain.Feed, fetches []domain.FeedFetch, now time.Time) feedHealth {
+	h := feedHealth{Feed: f, Fetches: fetches, SinceNewItem: -1}
+	if len(fetches) > 0 {
+		var ok int
+		var total time.Duration
+		for _, fetch := range fetches {
+			if fetch.OK() {
+				ok++
+			}
+			total += fetch.Duration
+		}
+		h.SuccessRate = float64(ok) / float64(len(fetches))
+		h.AvgDuration = total / time.Duration(len(fetches))
+	}
+
+	// feeds that never had new items are silent once they are old enough
+	lastNew := f.CreatedAt
+	if f.LastNewItem != nil {
+		h.SinceNewItem = now.Sub(*f.LastNewItem)
+		lastNew = *f.LastNewItem
+	}
+	h.Silent = f.Enabled && now.Sub(lastNew) > silentFeedAge
+	return h
+}
+
+// feedsHealth returns health of fetched feeds, silent ones and ones with the lowest success rate first
+func feedsHealth(feeds []domain.Feed, fetches map[int64][]domain.FeedFetch, now time.Time) []feedHealth {
+	res := []feedHealth{}
+	for _, f := range feeds {
+		if f.IsNewsletter() {
+			continue // newsletters are not fetched
+		}
+		res = append(res, newFeedHealth(f, fetches[f.ID], now))
+	}
+	slices.SortStableFunc(res, func(a, b feedHealth) int {
+		if a.Silent != b.Silent {
+			if a.Silent {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.SuccessRate, b.SuccessRate)
+	})
+	return res
+}
+
+// sparkline renders fetches as an inline SVG bar chart, bar height is the fetch time
+// and color the result: successful, not modified or failed
+func sparkline(fetches []domain.FeedFetch) template.HTML {
+	const barWidth, height = 4, 20
+	var maxDuration time.Duration
+	for _, f := range fetches {
+		maxDuration = max(maxDuration, f.Duration)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg class="sparkline" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="recent fetches">`,
+		len(fetches)*barWidth, height, len(fetches)*barWidth, height)
+	for i, f := range fetches {
+		barHeight := height
+		if maxDuration > 0 && f.OK() {
+			barHeight = max(2, int(float64(height)*float64(f.Duration)/float64(maxDuration)))
+		}
+		class, result := "spark-ok", strconv.Itoa(f.StatusCode)
+		switch {
+		case !f.OK():
+			class, result = "spark-fail", f.Error
+		case f.StatusCode == http.StatusNotModified:
+			class = "spark-cached"
+		}
+		fmt.Fprintf(&sb, `<rect class="%s" x="%d" y="%d" width="%d" height="%d"><title>%s: %s, %s</title></rect>`,
+			class, i*barWidth, height-barHeight, barWidth-1, barHeight, f.FetchedAt.Local().Format("Jan 2, 15:04"),
+			template.HTMLEscapeString(result), f.Duration.Round(time.Millisecond))
+	}
+	sb.WriteString("</svg>")
+	return template.HTML(sb.String()) //nolint:gosec // built from numbers and escaped text
+}
+
+// humanDuration formats a duration as a short human-readable string, e.g. 5 min, 3 h or 12 days
+func humanDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%d min", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%d h", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+}
+
 // commonPageData contains fields common to all pages
 type commonPageData struct {
 	ActivePage   string
@@ -111,6 +242,7 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	topic := r.URL.Query().Get("topic")
 	feedName := r.URL.Query().Get("feed")
+	folder := r.URL.Query().Get("folder")
 	sortBy := r.URL.Query().Get("sort")
 	if sortBy == "" {
 		sortBy = "published" // default sort
@@ -131,6 +263,7 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 		MinScore:      minScore,
 		Topic:         topic,
 		FeedName:      feedName,
+		Folder:        folder,
 		SortBy:        sortBy,
 		Limit:         pageSize,
 		Page:          page,
@@ -161,8 +294,8 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 		topics = []string{} // continue with empty topics
 	}
 
-	// get active feed names
-	feeds, err := s.db.GetActiveFeedNames(ctx, minScore)
+	// get active feed names, limited to the selected folder
+	feeds, err := s.db.GetActiveFeedNames(ctx, minScore, folder)
 	if err != nil {
 		log.Printf("[WARN] failed to get feed names: %v", err)
 		feeds = []string{} // continue with empty feeds
@@ -171,13 +304,14 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 	// check if this is an HTMX request for partial update
 	if r.Header.Get("HX-Request") == "true" {
 		s.handleHTMXArticlesRequest(w, r, articlesPageRequest{
-			articles:      articles,
-			topics:        topics,
-			feeds:         feeds,
-			selectedTopic: topic,
-			selectedFeed:  feedName,
-			selectedSort:  sortBy,
-			showLikedOnly: showLikedOnly,
+			articles:       articles,
+			topics:         topics,
+			feeds:          feeds,
+			selectedTopic:  topic,
+			selectedFeed:   feedName,
+			selectedFolder: folder,
+			selectedSort:   sortBy,
+			showLikedOnly:  showLikedOnly,
 			// pagination
 			currentPage: page,
 			totalPages:  totalPages,
@@ -194,18 +328,27 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// folders are listed on full page render only, they don't depend on other filters
+	folders, err := s.db.GetFolders(ctx)
+	if err != nil {
+		log.Printf("[WARN] failed to get folders: %v", err)
+		folders = []string{} // continue with empty folders
+	}
+
 	// prepare template data for full page render
 	data := struct {
 		commonPageData
-		Articles      []domain.ClassifiedItem
-		ArticleCount  int
-		TotalCount    int
-		Topics        []string
-		Feeds         []string
-		MinScore      float64
-		SelectedTopic string
-		SelectedFeed  string
-		ShowLikedOnly bool
+		Articles       []domain.ClassifiedItem
+		ArticleCount   int
+		TotalCount     int
+		Topics         []string
+		Feeds          []string
+		Folders        []string
+		MinScore       float64
+		SelectedTopic  string
+		SelectedFeed   string
+		SelectedFolder string
+		ShowLikedOnly  bool
 		// pagination
 		CurrentPage int
 		TotalPages  int
@@ -221,15 +364,17 @@ func (s *Server) articlesHandler(w http.ResponseWriter, r *http.Request) {
 			SearchQuery:  "",
 			SelectedSort: sortBy,
 		},
-		Articles:      articles,
-		ArticleCount:  len(articles),
-		TotalCount:    totalCount,
-		Topics:        topics,
-		Feeds:         feeds,
-		MinScore:      minScore,
-		SelectedTopic: topic,
-		SelectedFeed:  feedName,
-		ShowLikedOnly: showLikedOnly,
+		Articles:       articles,
+		ArticleCount:   len(articles),
+		TotalCount:     totalCount,
+		Topics:         topics,
+		Feeds:          feeds,
+		Folders:        folders,
+		MinScore:       minScore,
+		SelectedTopic:  topic,
+		SelectedFeed:   feedName,
+		SelectedFolder: folder,
+		ShowLikedOnly:  showLikedOnly,
 		// pagination
 		CurrentPage: page,
 		TotalPages:  totalPages,
@@ -313,10 +458,75 @@ func (s *Server) feedsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// collect broken feeds for the filter
+	broken := []domain.Feed{}
+	for _, f := range feeds {
+		if f.IsBroken() {
+			broken = append(broken, f)
+		}
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "broken" {
+		feeds = broken
+	}
+
+	// folder filter keeps feeds of the folder and its subfolders
+	folder := r.URL.Query().Get("folder")
+	if folder != "" {
+		inFolder := []domain.Feed{}
+		for _, f := range feeds {
+			if f.InFolder(folder) {
+				inFolder = append(inFolder, f)
+			}
+		}
+		feeds = inFolder
+	}
+
+	folders, err := s.db.GetFolders(ctx)
+	if err != nil {
+		log.Printf("[WARN] failed to get folders: %v", err)
+		folders = []string{} // continue with empty folders
+	}
+
+	var health []feedHealth
+	var failed []domain.ClassifiedItem
+	var queue *domain.QueueStats
+	var pipeline domain.PipelineStats
+	if status == "health" {
+		fetches, err := s.db.GetFeedFetches(ctx, healthFetchesLimit)
+		if err != nil {
+			s.respondWithError(w, http.StatusInternalServerError, "Failed to load feed fetches", err)
+			return
+		}
+		health = feedsHealth(feeds, fetches, time.Now())
+
+		if failed, err = s.db.GetFailedItems(ctx, failedItemsLimit); err != nil {
+			log.Printf("[WARN] failed to get failed items: %v", err)
+			failed = []domain.ClassifiedItem{} // health of feeds is shown anyway
+		}
+
+		stats, err := s.db.GetQueueStats(ctx)
+		if err != nil {
+			log.Printf("[WARN] failed to get queue stats: %v", err)
+		} else {
+			queue = &stats
+		}
+		pipeline = s.scheduler.PipelineStats()
+	}
+
 	// prepare template data
 	data := struct {
 		commonPageData
-		Feeds []domain.Feed
+		Groups         []feedGroup
+		Health         []feedHealth
+		FailedItems    []domain.ClassifiedItem
+		Queue          *domain.QueueStats
+		Pipeline       domain.PipelineStats
+		Status         string
+		BrokenCount    int
+		Folders        []string
+		SelectedFolder string
 	}{
 		commonPageData: commonPageData{
 			ActivePage:   "feeds",
@@ -324,7 +534,15 @@ func (s *Server) feedsHandler(w http.ResponseWriter, r *http.Request) {
 			SearchQuery:  "",
 			SelectedSort: "",
 		},
-		Feeds: feeds,
+		Groups:         groupFeedsByFolder(feeds),
+		Health:         health,
+		FailedItems:    failed,
+		Queue:          queue,
+		Pipeline:       pipeline,
+		Status:         status,
+		BrokenCount:    len(broken),
+		Folders:        folders,
+		SelectedFolder: folder,
 	}
 
 	// render page with base template
@@ -580,37 +798,39 @@ func (s *Server) writeLikedButton(w http.ResponseWriter, showLikedOnly bool) {
 func (s *Server) writePaginationControls(w http.ResponseWriter, req articlesPageRequest) {
 	// create template data matching the structure used by full page render
 	paginationData := struct {
-		Articles      []domain.ClassifiedItem
-		TotalCount    int
-		MinScore      float64
-		SelectedTopic string
-		SelectedFeed  string
-		SelectedSort  string
-		ShowLikedOnly bool
-		CurrentPage   int
-		TotalPages    int
-		PageNumbers   []int
-		HasNext       bool
-		HasPrev       bool
-		IsHTMX        bool
-		IsSearch      bool
-		SearchQuery   string
+		Articles       []domain.ClassifiedItem
+		TotalCount     int
+		MinScore       float64
+		SelectedTopic  string
+		SelectedFeed   string
+		SelectedFolder string
+		SelectedSort   string
+		ShowLikedOnly  bool
+		CurrentPage    int
+		TotalPages     int
+		PageNumbers    []int
+		HasNext        bool
+		HasPrev        bool
+		IsHTMX         bool
+		IsSearch       bool
+		SearchQuery    string
 	}{
-		Articles:      req.articles,
-		TotalCount:    req.totalCount,
-		MinScore:      req.minScore,
-		SelectedTopic: req.selectedTopic,
-		SelectedFeed:  req.selectedFeed,
-		SelectedSort:  req.selectedSort,
-		ShowLikedOnly: req.showLikedOnly,
-		CurrentPage:   req.currentPage,
-		TotalPages:    req.totalPages,
-		PageNumbers:   req.pageNumbers,
-		HasNext:       req.hasNext,
-		HasPrev:       req.hasPrev,
-		IsHTMX:        true,
-		IsSearch:      req.isSearch,
-		SearchQuery:   req.searchQuery,
+		Articles:       req.articles,
+		TotalCount:     req.totalCount,
+		MinScore:       req.minScore,
+		SelectedTopic:  req.selectedTopic,
+		SelectedFeed:   req.selectedFeed,
+		SelectedFolder: req.selectedFolder,
+		SelectedSort:   req.selectedSort,
+		ShowLikedOnly:  req.showLikedOnly,
+		CurrentPage:    req.currentPage,
+		TotalPages:     req.totalPages,
+		PageNumbers:    req.pageNumbers,
+		HasNext:        req.hasNext,
+		HasPrev:        req.hasPrev,
+		IsHTMX:         true,
+		IsSearch:       req.isSearch,
+		SearchQuery:    req.searchQuery,
 	}
 
 	// execute the pagination template
@@ -834,6 +1054,7 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	topic := r.URL.Query().Get("topic")
 	feedName := r.URL.Query().Get("feed")
+	folder := r.URL.Query().Get("folder")
 	sortBy := r.URL.Query().Get("sort")
 	if sortBy == "" {
 		sortBy = "published" // default to date sort, same as articles page
@@ -854,6 +1075,7 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 		MinScore:      minScore,
 		Topic:         topic,
 		FeedName:      feedName,
+		Folder:        folder,
 		SortBy:        sortBy,
 		Limit:         pageSize,
 		Page:          page,
@@ -884,8 +1106,8 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 		topics = []string{} // continue with empty topics
 	}
 
-	// get active feed names
-	feeds, err := s.db.GetActiveFeedNames(ctx, minScore)
+	// get active feed names, limited to the selected folder
+	feeds, err := s.db.GetActiveFeedNames(ctx, minScore, folder)
 	if err != nil {
 		log.Printf("[WARN] failed to get feed names: %v", err)
 		feeds = []string{} // continue with empty feeds
@@ -894,13 +1116,14 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 	// check if this is an HTMX request for partial update
 	if r.Header.Get("HX-Request") == "true" {
 		s.handleHTMXArticlesRequest(w, r, articlesPageRequest{
-			articles:      articles,
-			topics:        topics,
-			feeds:         feeds,
-			selectedTopic: topic,
-			selectedFeed:  feedName,
-			selectedSort:  sortBy,
-			showLikedOnly: showLikedOnly,
+			articles:       articles,
+			topics:         topics,
+			feeds:          feeds,
+			selectedTopic:  topic,
+			selectedFeed:   feedName,
+			selectedFolder: folder,
+			selectedSort:   sortBy,
+			showLikedOnly:  showLikedOnly,
 			// pagination
 			currentPage: page,
 			totalPages:  totalPages,
@@ -917,18 +1140,27 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// folders are listed on full page render only, they don't depend on other filters
+	folders, err := s.db.GetFolders(ctx)
+	if err != nil {
+		log.Printf("[WARN] failed to get folders: %v", err)
+		folders = []string{} // continue with empty folders
+	}
+
 	// prepare template data for full page render
 	data := struct {
 		commonPageData
-		Articles      []domain.ClassifiedItem
-		ArticleCount  int
-		TotalCount    int
-		Topics        []string
-		Feeds         []string
-		MinScore      float64
-		SelectedTopic string
-		SelectedFeed  string
-		ShowLikedOnly bool
+		Articles       []domain.ClassifiedItem
+		ArticleCount   int
+		TotalCount     int
+		Topics         []string
+		Feeds          []string
+		Folders        []string
+		MinScore       float64
+		SelectedTopic  string
+		SelectedFeed   string
+		SelectedFolder string
+		ShowLikedOnly  bool
 		// pagination
 		CurrentPage int
 		TotalPages  int
@@ -944,15 +1176,17 @@ func (s *Server) searchHandler(w http.ResponseWriter, r *http.Request) {
 			SearchQuery:  searchQuery,
 			SelectedSort: sortBy,
 		},
-		Articles:      articles,
-		ArticleCount:  len(articles),
-		TotalCount:    totalCount,
-		Topics:        topics,
-		Feeds:         feeds,
-		MinScore:      minScore,
-		SelectedTopic: topic,
-		SelectedFeed:  feedName,
-		ShowLikedOnly: showLikedOnly,
+		Articles:       articles,
+		ArticleCount:   len(articles),
+		TotalCount:     totalCount,
+		Topics:         topics,
+		Feeds:          feeds,
+		Folders:        folders,
+		MinScore:       minScore,
+		SelectedTopic:  topic,
+		SelectedFeed:   feedName,
+		SelectedFolder: folder,
+		ShowLikedOnly:  showLikedOnly,
 		// pagination
 		CurrentPage: page,
 		TotalPages:  totalPages,
@@ -51,14 +51,25 @@ func TestServer_articlesHandler(t *testing.T) {
 			return []domain.ClassifiedItem{
 				{
 					Item: &domain.Item{
-						ID:          1,
-						GUID:        "guid-1",
-						Title:       "Test Article",
-						Link:        "https://example.com/article",
-						Description: "A test article",
-						Published:   now,
+						ID:            1,
+						GUID:          "guid-1",
+						Title:         "Test Article",
+						Link:          "https://t.co/article",
+						CanonicalLink: "https://example.com/article",
+						ResolvedLink:  "https://m.example.com/article?id=1",
+						Description:   "A test article",
+						Published:     now,
+						Image:         "https://example.com/thumb.jpg",
+						Enclosures: []domain.Enclosure{
+							{URL: "https://example.com/episode.mp3", Type: "audio/mpeg"},
+							{URL: "https://example.com/slides.pdf", Type: "application/pdf"},
+						},
 					},
 					FeedName: "Test Feed",
+					Duplicates: []domain.Duplicate{
+						{ItemID: 2, Link: "https://other.example.com/article", FeedName: "Other Feed"},
+						{ItemID: 3, Link: "https://third.example.com/article", FeedName: "Third Feed"},
+					},
 					Classification: &domain.Classification{
 						Score:        8.5,
 						Explanation:  "Very relevant",
@@ -71,9 +82,12 @@ func TestServer_articlesHandler(t *testing.T) {
 		GetClassifiedItemsCountFunc: func(ctx context.Context, req domain.ArticlesRequest) (int, error) {
 			return 1, nil // return count of 1 item for testing
 		},
-		GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64) ([]string, error) {
+		GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64, folder string) ([]string, error) {
 			return []string{"Test Feed", "Example Feed"}, nil
 		},
+		GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"Tech", "Tech/Go"}, nil
+		},
 		GetTopicsFunc: func(ctx context.Context) ([]string, error) {
 			return []string{"tech", "ai", "science"}, nil
 		},
@@ -103,6 +117,12 @@ func TestServer_articlesHandler(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Test Article")
 	assert.Contains(t, w.Body.String(), "Test Feed")
 	assert.Contains(t, w.Body.String(), "Score: 8.5/10")
+	assert.Contains(t, w.Body.String(), `<a href="https://m.example.com/article?id=1" target="_blank"`, "resolved link shown")
+	assert.NotContains(t, w.Body.String(), "https://t.co/")
+	assert.Contains(t, w.Body.String(), `<img class="article-thumbnail" src="https://example.com/thumb.jpg" loading="lazy" alt="">`)
+	assert.Contains(t, w.Body.String(), `<audio class="article-audio" controls preload="none" src="https://example.com/episode.mp3"></audio>`)
+	assert.NotContains(t, w.Body.String(), "slides.pdf", "only audio enclosures played")
+	assert.Contains(t, w.Body.String(), `Also covered by: <a href="https://other.example.com/article" target="_blank" rel="noopener">Other Feed</a>, <a href="https://third.example.com/article"`)
 	assert.Contains(t, w.Body.String(), "<html")                                                                    // should contain full HTML
 	assert.Contains(t, w.Body.String(), "Articles <span id=\"article-count\" class=\"article-count\">(1/1)</span>") // should show count
 
@@ -183,6 +203,20 @@ func TestServer_articlesHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w5.Code)
 	assert.Contains(t, w5.Body.String(), "Liked Article")
+
+	// test folder filter, passed to the articles and feed names queries
+	req6 := httptest.NewRequest("GET", "/articles?folder=Tech%2FGo&page=1", http.NoBody)
+	w6 := httptest.NewRecorder()
+
+	srv.articlesHandler(w6, req6)
+
+	assert.Equal(t, http.StatusOK, w6.Code)
+	calls := database.GetClassifiedItemsWithFiltersCalls()
+	assert.Equal(t, "Tech/Go", calls[len(calls)-1].Req.Folder)
+	feedCalls := database.GetActiveFeedNamesCalls()
+	assert.Equal(t, "Tech/Go", feedCalls[len(feedCalls)-1].Folder)
+	assert.Contains(t, w6.Body.String(), `<option value="Tech/Go" selected>Tech/Go</option>`)
+	assert.Contains(t, w6.Body.String(), `<option value="Tech" >Tech</option>`)
 }
 
 func TestServer_feedsHandler(t *testing.T) {
@@ -197,21 +231,24 @@ func TestServer_feedsHandler(t *testing.T) {
 		GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
 			return []domain.Feed{
 				{
-					ID:            1,
-					URL:           "https://example.com/feed.xml",
-					Title:         "Example Feed",
-					Description:   "A test feed",
-					LastFetched:   &now,
-					NextFetch:     &now,
-					FetchInterval: 3600,
-					ErrorCount:    0,
-					Enabled:       true,
+					ID:               1,
+					URL:              "https://example.com/feed.xml",
+					Title:            "Example Feed",
+					Description:      "A test feed",
+					LastFetched:      &now,
+					NextFetch:        &now,
+					FetchInterval:    time.Hour,
+					AdaptiveInterval: 2 * time.Hour,
+					BytesSaved:       3 * 1024 * 1024,
+					ErrorCount:       0,
+					Enabled:          true,
 				},
 				{
 					ID:            2,
 					URL:           "https://test.com/rss",
 					Title:         "Test RSS",
 					Description:   "Another feed",
+					Folder:        "Tech/Go",
 					FetchInterval: 1800,
 					ErrorCount:    2,
 					LastError:     "Connection timeout",
@@ -219,6 +256,9 @@ func TestServer_feedsHandler(t *testing.T) {
 				},
 			}, nil
 		},
+		GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"Tech", "Tech/Go"}, nil
+		},
 	}
 
 	scheduler := &mocks.SchedulerMock{
@@ -238,7 +278,205 @@ func TestServer_feedsHandler(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Example Feed")
 	assert.Contains(t, w.Body.String(), "https://example.com/feed.xml")
 	assert.Contains(t, w.Body.String(), "Test RSS")
+	assert.Contains(t, w.Body.String(), "Adaptive interval: 120 minutes")
+	assert.Contains(t, w.Body.String(), "Saved: 3.0 MB")
+
+	// broken feed has retry button, healthy one doesn't
+	assert.Contains(t, w.Body.String(), `hx-post="/api/v1/feeds/2/retry"`)
+	assert.NotContains(t, w.Body.String(), `hx-post="/api/v1/feeds/1/retry"`)
+	assert.Contains(t, w.Body.String(), "Broken feeds (1)")
+
+	// broken filter shows only failing feeds
+	req = httptest.NewRequest("GET", "/feeds?status=broken", http.NoBody)
+	w = httptest.NewRecorder()
+	srv.feedsHandler(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Test RSS")
+	assert.NotContains(t, w.Body.String(), "Example Feed")
 	assert.Contains(t, w.Body.String(), "Connection timeout")
+
+	// feeds are grouped by folder, top-level first
+	req = httptest.NewRequest("GET", "/feeds", http.NoBody)
+	w = httptest.NewRecorder()
+	srv.feedsHandler(w, req)
+	body := w.Body.String()
+	assert.Contains(t, body, `<h3 class="feed-folder">Tech/Go <span class="feed-folder-count">(1)</span></h3>`)
+	assert.Less(t, strings.Index(body, "Example Feed"), strings.Index(body, `class="feed-folder"`))
+	assert.Less(t, strings.Index(body, `class="feed-folder"`), strings.Index(body, "Test RSS"))
+	assert.Contains(t, body, `value="Tech/Go" list="folder-list"`, "folder editable")
+
+	// folder filter includes subfolders
+	req = httptest.NewRequest("GET", "/feeds?folder=Tech", http.NoBody)
+	w = httptest.NewRecorder()
+	srv.feedsHandler(w, req)
+	assert.Contains(t, w.Body.String(), "Test RSS")
+	assert.NotContains(t, w.Body.String(), "Example Feed")
+	assert.Contains(t, w.Body.String(), `<option value="Tech" selected>Tech</option>`)
+}
+
+func TestGroupFeedsByFolder(t *testing.T) {
+	feeds := []domain.Feed{{ID: 1, Folder: "News"}, {ID: 2}, {ID: 3, Folder: "Tech"}, {ID: 4, Folder: "News"}, {ID: 5}}
+	groups := groupFeedsByFolder(feeds)
+
+	require.Len(t, groups, 3)
+	folders := []string{}
+	ids := [][]int64{}
+	for _, g := range groups {
+		folders = append(folders, g.Folder)
+		var groupIDs []int64
+		for _, f := range g.Feeds {
+			groupIDs = append(groupIDs, f.ID)
+		}
+		ids = append(ids, groupIDs)
+	}
+	assert.Equal(t, []string{"", "News", "Tech"}, folders)
+	assert.Equal(t, [][]int64{{2, 5}, {1, 4}, {3}}, ids)
+	assert.Equal(t, int64(1), feeds[0].ID, "input not reordered")
+	assert.Empty(t, groupFeedsByFolder(nil))
+}
+
+func TestServer_feedsHandler_Health(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) {
+			return ":8080", 30 * time.Second
+		},
+	}
+
+	now := time.Now()
+	recent, old := now.Add(-3*time.Hour), now.Add(-20*24*time.Hour)
+	database := &mocks.DatabaseMock{
+		GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+			return []domain.Feed{
+				{ID: 1, URL: "https://example.com/feed.xml", Title: "Busy Feed", Enabled: true, CreatedAt: old, LastNewItem: &recent},
+				{ID: 2, URL: "https://test.com/rss", Title: "Quiet Feed", Folder: "Tech", Enabled: true, CreatedAt: old, LastNewItem: &old},
+				{ID: 3, Type: domain.FeedTypeNewsletter, URL: "mailto:weekly@example.com", Title: "Weekly", Enabled: true},
+			}, nil
+		},
+		GetFoldersFunc: func(ctx context.Context) ([]string, error) { return []string{"Tech"}, nil },
+		GetFeedFetchesFunc: func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+			assert.Equal(t, healthFetchesLimit, limit)
+			return map[int64][]domain.FeedFetch{
+				1: {
+					{FeedID: 1, FetchedAt: now.Add(-time.Hour), StatusCode: 200, Duration: time.Second},
+					{FeedID: 1, FetchedAt: now, StatusCode: 503, Duration: 2 * time.Second, Error: "unexpected status code: 503"},
+				},
+				2: {{FeedID: 2, FetchedAt: now, StatusCode: 304, Duration: 500 * time.Millisecond}},
+			}, nil
+		},
+		GetFailedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+			assert.Equal(t, failedItemsLimit, limit)
+			return []domain.ClassifiedItem{{Item: &domain.Item{ID: 42, Title: "Stuck article", Link: "https://example.com/stuck",
+				ProcessError: "classify: timeout", FailedAt: &recent}, FeedName: "Busy Feed"}}, nil
+		},
+		GetQueueStatsFunc: func(ctx context.Context) (domain.QueueStats, error) {
+			return domain.QueueStats{Pending: 12, InProgress: 2, Done: 40, Failed: 1}, nil
+		},
+	}
+	scheduler := &mocks.SchedulerMock{
+		PipelineStatsFunc: func() domain.PipelineStats {
+			return domain.PipelineStats{
+				Extraction:     domain.StageStats{Running: 5, Limit: 5, Waiting: 3},
+				Classification: domain.StageStats{Running: 1, Limit: 2},
+			}
+		},
+	}
+	srv := testServer(t, cfg, database, scheduler)
+
+	req := httptest.NewRequest("GET", "/feeds?status=health", http.NoBody)
+	w := httptest.NewRecorder()
+	srv.feedsHandler(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, `class="feeds-health-table"`)
+	assert.NotContains(t, body, `class="feed-card"`, "cards not shown on the health view")
+	assert.NotContains(t, body, "mailto:weekly@example.com", "newsletters are not fetched")
+	assert.Less(t, strings.Index(body, "Quiet Feed"), strings.Index(body, "Busy Feed"), "silent feeds first")
+	assert.Contains(t, body, "50% of 2")
+	assert.Contains(t, body, "1.5s")
+	assert.Contains(t, body, "3 h ago")
+	assert.Contains(t, body, "20 days ago")
+	assert.Equal(t, 1, strings.Count(body, ">Silent</span>"))
+	assert.Contains(t, body, "unexpected status code: 503")
+	assert.Contains(t, body, `class="spark-cached"`)
+	assert.Contains(t, body, "Failed articles")
+	assert.Contains(t, body, `<a href="https://example.com/stuck" target="_blank" rel="noopener">Stuck article</a>`)
+	assert.Contains(t, body, "classify: timeout")
+	assert.Contains(t, body, "Processing queue: 12 pending, 2 in progress, 1 failed")
+	assert.Contains(t, body, "Extraction: 5 of 5 running, 3 waiting")
+	assert.Contains(t, body, "Classification: 1 of 2 running, 0 waiting")
+	assert.Contains(t, body, `hx-post="/api/v1/articles/42/retry"`)
+
+	t.Run("failed items error", func(t *testing.T) {
+		database.GetFailedItemsFunc = func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+			return nil, errors.New("db error")
+		}
+		w := httptest.NewRecorder()
+		srv.feedsHandler(w, httptest.NewRequest("GET", "/feeds?status=health", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `class="feeds-health-table"`)
+		assert.NotContains(t, w.Body.String(), "Failed articles")
+	})
+
+	t.Run("fetches error", func(t *testing.T) {
+		database.GetFeedFetchesFunc = func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+			return nil, errors.New("db error")
+		}
+		w := httptest.NewRecorder()
+		srv.feedsHandler(w, httptest.NewRequest("GET", "/feeds?status=health", http.NoBody))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestNewFeedHealth(t *testing.T) {
+	now := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-30 * 24 * time.Hour)
+	lastNew := now.Add(-2 * time.Hour)
+
+	h := newFeedHealth(domain.Feed{ID: 1, Enabled: true, CreatedAt: created, LastNewItem: &lastNew}, []domain.FeedFetch{
+		{StatusCode: 200, Duration: time.Second},
+		{StatusCode: 304, Duration: 3 * time.Second},
+		{StatusCode: 0, Duration: 5 * time.Second, Error: "connection refused"},
+		{StatusCode: 200, Duration: 3 * time.Second},
+	}, now)
+	assert.InDelta(t, 0.75, h.SuccessRate, 0.001)
+	assert.Equal(t, 3*time.Second, h.AvgDuration)
+	assert.Equal(t, 2*time.Hour, h.SinceNewItem)
+	assert.False(t, h.Silent)
+
+	h = newFeedHealth(domain.Feed{ID: 2, Enabled: true, CreatedAt: created}, nil, now)
+	assert.Zero(t, h.SuccessRate)
+	assert.Equal(t, time.Duration(-1), h.SinceNewItem)
+	assert.True(t, h.Silent, "never had new items since created a month ago")
+
+	h = newFeedHealth(domain.Feed{ID: 3, Enabled: true, CreatedAt: now.Add(-time.Hour)}, nil, now)
+	assert.False(t, h.Silent, "new feed is not silent")
+
+	h = newFeedHealth(domain.Feed{ID: 4, Enabled: false, CreatedAt: created}, nil, now)
+	assert.False(t, h.Silent, "disabled feed is not silent")
+}
+
+func TestSparkline(t *testing.T) {
+	at := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	svg := string(sparkline([]domain.FeedFetch{
+		{FetchedAt: at, StatusCode: 200, Duration: 2 * time.Second},
+		{FetchedAt: at, StatusCode: 304, Duration: time.Second},
+		{FetchedAt: at, Duration: 100 * time.Millisecond, Error: `dial tcp: <lookup> "failed"`},
+	}))
+	assert.True(t, strings.HasPrefix(svg, `<svg class="sparkline" width="12" height="20"`))
+	assert.Contains(t, svg, `<rect class="spark-ok" x="0" y="0" width="3" height="20">`)
+	assert.Contains(t, svg, `<rect class="spark-cached" x="4" y="10" width="3" height="10">`)
+	assert.Contains(t, svg, `<rect class="spark-fail" x="8" y="0" width="3" height="20">`, "failures are full height")
+	assert.Contains(t, svg, "dial tcp: &lt;lookup&gt; &#34;failed&#34;, 100ms")
+	assert.Equal(t, `<svg class="sparkline" width="0" height="20" viewBox="0 0 0 20" role="img" aria-label="recent fetches"></svg>`,
+		string(sparkline(nil)))
+}
+
+func TestHumanDuration(t *testing.T) {
+	assert.Equal(t, "0 min", humanDuration(20*time.Second))
+	assert.Equal(t, "45 min", humanDuration(45*time.Minute))
+	assert.Equal(t, "47 h", humanDuration(47*time.Hour+30*time.Minute))
+	assert.Equal(t, "3 days", humanDuration(80*time.Hour))
 }
 
 func TestServer_SettingsHandler(t *testing.T) {
@@ -581,6 +819,33 @@ func TestServer_RenderFeedCard_TemplateError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Failed to render feed")
 }
 
+func TestServer_RenderNewsletterCards(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+	srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+
+	t.Run("feed card", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		srv.renderFeedCard(w, &domain.Feed{ID: 3, Type: domain.FeedTypeNewsletter, Title: "Weekly",
+			URL: "mailto:weekly@example.com", Enabled: true, FetchInterval: 15 * time.Minute})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Newsletter")
+		assert.NotContains(t, w.Body.String(), "Fetch Now", "newsletter feeds are not fetched")
+	})
+
+	t.Run("article card", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		srv.renderArticleCard(w, &domain.ClassifiedItem{
+			Item:           &domain.Item{ID: 1, Title: "Issue 1", Link: "mid:1@weekly"},
+			Classification: &domain.Classification{Score: 7},
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Issue 1")
+		assert.NotContains(t, w.Body.String(), "mid:", "message links are not rendered")
+	})
+}
+
 func TestServer_RenderArticleCard_TemplateError(t *testing.T) {
 	cfg := &mocks.ConfigProviderMock{
 		GetServerConfigFunc: func() (string, time.Duration) {
@@ -667,9 +932,12 @@ func TestServer_TemplateErrors(t *testing.T) {
 			GetTopicsFilteredFunc: func(ctx context.Context, minScore float64) ([]string, error) {
 				return []string{"tech"}, nil
 			},
-			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64) ([]string, error) {
+			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64, folder string) ([]string, error) {
 				return []string{"Test Feed"}, nil
 			},
+			GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+				return nil, nil
+			},
 		}
 
 		scheduler := &mocks.SchedulerMock{
@@ -710,6 +978,9 @@ func TestServer_TemplateErrors(t *testing.T) {
 					},
 				}, nil
 			},
+			GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+				return nil, nil
+			},
 		}
 
 		scheduler := &mocks.SchedulerMock{
@@ -26,9 +26,15 @@ import (
 //			GetClassifiedItemsCountFunc: func(ctx context.Context, filter *domain.ItemFilter) (int, error) {
 //				panic("mock out the GetClassifiedItemsCount method")
 //			},
+//			GetFailedItemsFunc: func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+//				panic("mock out the GetFailedItems method")
+//			},
 //			GetFeedbackCountFunc: func(ctx context.Context) (int64, error) {
 //				panic("mock out the GetFeedbackCount method")
 //			},
+//			GetMutedItemsFunc: func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+//				panic("mock out the GetMutedItems method")
+//			},
 //			GetSearchItemsCountFunc: func(ctx context.Context, searchQuery string, filter *domain.ItemFilter) (int, error) {
 //				panic("mock out the GetSearchItemsCount method")
 //			},
@@ -63,9 +69,15 @@ type ClassificationRepoMock struct {
 	// GetClassifiedItemsCountFunc mocks the GetClassifiedItemsCount method.
 	GetClassifiedItemsCountFunc func(ctx context.Context, filter *domain.ItemFilter) (int, error)
 
+	// GetFailedItemsFunc mocks the GetFailedItems method.
+	GetFailedItemsFunc func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error)
+
 	// GetFeedbackCountFunc mocks the GetFeedbackCount method.
 	GetFeedbackCountFunc func(ctx context.Context) (int64, error)
 
+	// GetMutedItemsFunc mocks the GetMutedItems method.
+	GetMutedItemsFunc func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error)
+
 	// GetSearchItemsCountFunc mocks the GetSearchItemsCount method.
 	GetSearchItemsCountFunc func(ctx context.Context, searchQuery string, filter *domain.ItemFilter) (int, error)
 
@@ -107,11 +119,25 @@ type ClassificationRepoMock struct {
 			// Filter is the filter argument value.
 			Filter *domain.ItemFilter
 		}
+		// GetFailedItems holds details about calls to the GetFailedItems method.
+		GetFailedItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetFeedbackCount holds details about calls to the GetFeedbackCount method.
 		GetFeedbackCount []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetMutedItems holds details about calls to the GetMutedItems method.
+		GetMutedItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetSearchItemsCount holds details about calls to the GetSearchItemsCount method.
 		GetSearchItemsCount []struct {
 			// Ctx is the ctx argument value.
@@ -164,7 +190,9 @@ type ClassificationRepoMock struct {
 	lockGetClassifiedItem       sync.RWMutex
 	lockGetClassifiedItems      sync.RWMutex
 	lockGetClassifiedItemsCount sync.RWMutex
+	lockGetFailedItems          sync.RWMutex
 	lockGetFeedbackCount        sync.RWMutex
+	lockGetMutedItems           sync.RWMutex
 	lockGetSearchItemsCount     sync.RWMutex
 	lockGetTopTopicsByScore     sync.RWMutex
 	lockGetTopics               sync.RWMutex
@@ -281,6 +309,42 @@ func (mock *ClassificationRepoMock) GetClassifiedItemsCountCalls() []struct {
 	return calls
 }
 
+// GetFailedItems calls GetFailedItemsFunc.
+func (mock *ClassificationRepoMock) GetFailedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+	if mock.GetFailedItemsFunc == nil {
+		panic("ClassificationRepoMock.GetFailedItemsFunc: method is nil but ClassificationRepo.GetFailedItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetFailedItems.Lock()
+	mock.calls.GetFailedItems = append(mock.calls.GetFailedItems, callInfo)
+	mock.lockGetFailedItems.Unlock()
+	return mock.GetFailedItemsFunc(ctx, limit)
+}
+
+// GetFailedItemsCalls gets all the calls that were made to GetFailedItems.
+// Check the length with:
+//
+//	len(mockedClassificationRepo.GetFailedItemsCalls())
+func (mock *ClassificationRepoMock) GetFailedItemsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetFailedItems.RLock()
+	calls = mock.calls.GetFailedItems
+	mock.lockGetFailedItems.RUnlock()
+	return calls
+}
+
 // GetFeedbackCount calls GetFeedbackCountFunc.
 func (mock *ClassificationRepoMock) GetFeedbackCount(ctx context.Context) (int64, error) {
 	if mock.GetFeedbackCountFunc == nil {
@@ -313,6 +377,42 @@ func (mock *ClassificationRepoMock) GetFeedbackCountCalls() []struct {
 	return calls
 }
 
+// GetMutedItems calls GetMutedItemsFunc.
+func (mock *ClassificationRepoMock) GetMutedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+	if mock.GetMutedItemsFunc == nil {
+		panic("ClassificationRepoMock.GetMutedItemsFunc: method is nil but ClassificationRepo.GetMutedItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetMutedItems.Lock()
+	mock.calls.GetMutedItems = append(mock.calls.GetMutedItems, callInfo)
+	mock.lockGetMutedItems.Unlock()
+	return mock.GetMutedItemsFunc(ctx, limit)
+}
+
+// GetMutedItemsCalls gets all the calls that were made to GetMutedItems.
+// Check the length with:
+//
+//	len(mockedClassificationRepo.GetMutedItemsCalls())
+func (mock *ClassificationRepoMock) GetMutedItemsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetMutedItems.RLock()
+	calls = mock.calls.GetMutedItems
+	mock.lockGetMutedItems.RUnlock()
+	return calls
+}
+
 // GetSearchItemsCount calls GetSearchItemsCountFunc.
 func (mock *ClassificationRepoMock) GetSearchItemsCount(ctx context.Context, searchQuery string, filter *domain.ItemFilter) (int, error) {
 	if mock.GetSearchItemsCountFunc == nil {
@@ -20,10 +20,16 @@ import (
 //			CreateFeedFunc: func(ctx context.Context, feed *domain.Feed) error {
 //				panic("mock out the CreateFeed method")
 //			},
+//			CreateMuteRuleFunc: func(ctx context.Context, rule *domain.MuteRule) error {
+//				panic("mock out the CreateMuteRule method")
+//			},
 //			DeleteFeedFunc: func(ctx context.Context, feedID int64) error {
 //				panic("mock out the DeleteFeed method")
 //			},
-//			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64) ([]string, error) {
+//			DeleteMuteRuleFunc: func(ctx context.Context, id int64) error {
+//				panic("mock out the DeleteMuteRule method")
+//			},
+//			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64, folder string) ([]string, error) {
 //				panic("mock out the GetActiveFeedNames method")
 //			},
 //			GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
@@ -41,12 +47,33 @@ import (
 //			GetClassifiedItemsWithFiltersFunc: func(ctx context.Context, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error) {
 //				panic("mock out the GetClassifiedItemsWithFilters method")
 //			},
+//			GetFailedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+//				panic("mock out the GetFailedItems method")
+//			},
+//			GetFeedEventsFunc: func(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+//				panic("mock out the GetFeedEvents method")
+//			},
+//			GetFeedFetchesFunc: func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+//				panic("mock out the GetFeedFetches method")
+//			},
 //			GetFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
 //				panic("mock out the GetFeeds method")
 //			},
+//			GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetFolders method")
+//			},
 //			GetItemsFunc: func(ctx context.Context, limit int, offset int) ([]domain.Item, error) {
 //				panic("mock out the GetItems method")
 //			},
+//			GetMuteRulesFunc: func(ctx context.Context) ([]domain.MuteRule, error) {
+//				panic("mock out the GetMuteRules method")
+//			},
+//			GetMutedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+//				panic("mock out the GetMutedItems method")
+//			},
+//			GetQueueStatsFunc: func(ctx context.Context) (domain.QueueStats, error) {
+//				panic("mock out the GetQueueStats method")
+//			},
 //			GetSearchItemsCountFunc: func(ctx context.Context, searchQuery string, req domain.ArticlesRequest) (int, error) {
 //				panic("mock out the GetSearchItemsCount method")
 //			},
@@ -62,6 +89,12 @@ import (
 //			GetTopicsFilteredFunc: func(ctx context.Context, minScore float64) ([]string, error) {
 //				panic("mock out the GetTopicsFiltered method")
 //			},
+//			RetryFailedItemFunc: func(ctx context.Context, itemID int64) error {
+//				panic("mock out the RetryFailedItem method")
+//			},
+//			RetryFeedFunc: func(ctx context.Context, feedID int64) error {
+//				panic("mock out the RetryFeed method")
+//			},
 //			SearchItemsFunc: func(ctx context.Context, searchQuery string, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error) {
 //				panic("mock out the SearchItems method")
 //			},
@@ -71,6 +104,18 @@ import (
 //			UpdateFeedFunc: func(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error {
 //				panic("mock out the UpdateFeed method")
 //			},
+//			UpdateFeedAuthFunc: func(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+//				panic("mock out the UpdateFeedAuth method")
+//			},
+//			UpdateFeedFolderFunc: func(ctx context.Context, feedID int64, folder string) error {
+//				panic("mock out the UpdateFeedFolder method")
+//			},
+//			UpdateFeedProxyFunc: func(ctx context.Context, feedID int64, proxy string) error {
+//				panic("mock out the UpdateFeedProxy method")
+//			},
+//			UpdateFeedSelectorsFunc: func(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+//				panic("mock out the UpdateFeedSelectors method")
+//			},
 //			UpdateFeedStatusFunc: func(ctx context.Context, feedID int64, enabled bool) error {
 //				panic("mock out the UpdateFeedStatus method")
 //			},
@@ -87,11 +132,17 @@ type DatabaseMock struct {
 	// CreateFeedFunc mocks the CreateFeed method.
 	CreateFeedFunc func(ctx context.Context, feed *domain.Feed) error
 
+	// CreateMuteRuleFunc mocks the CreateMuteRule method.
+	CreateMuteRuleFunc func(ctx context.Context, rule *domain.MuteRule) error
+
 	// DeleteFeedFunc mocks the DeleteFeed method.
 	DeleteFeedFunc func(ctx context.Context, feedID int64) error
 
+	// DeleteMuteRuleFunc mocks the DeleteMuteRule method.
+	DeleteMuteRuleFunc func(ctx context.Context, id int64) error
+
 	// GetActiveFeedNamesFunc mocks the GetActiveFeedNames method.
-	GetActiveFeedNamesFunc func(ctx context.Context, minScore float64) ([]string, error)
+	GetActiveFeedNamesFunc func(ctx context.Context, minScore float64, folder string) ([]string, error)
 
 	// GetAllFeedsFunc mocks the GetAllFeeds method.
 	GetAllFeedsFunc func(ctx context.Context) ([]domain.Feed, error)
@@ -108,12 +159,33 @@ type DatabaseMock struct {
 	// GetClassifiedItemsWithFiltersFunc mocks the GetClassifiedItemsWithFilters method.
 	GetClassifiedItemsWithFiltersFunc func(ctx context.Context, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error)
 
+	// GetFailedItemsFunc mocks the GetFailedItems method.
+	GetFailedItemsFunc func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error)
+
+	// GetFeedEventsFunc mocks the GetFeedEvents method.
+	GetFeedEventsFunc func(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error)
+
+	// GetFeedFetchesFunc mocks the GetFeedFetches method.
+	GetFeedFetchesFunc func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error)
+
 	// GetFeedsFunc mocks the GetFeeds method.
 	GetFeedsFunc func(ctx context.Context) ([]domain.Feed, error)
 
+	// GetFoldersFunc mocks the GetFolders method.
+	GetFoldersFunc func(ctx context.Context) ([]string, error)
+
 	// GetItemsFunc mocks the GetItems method.
 	GetItemsFunc func(ctx context.Context, limit int, offset int) ([]domain.Item, error)
 
+	// GetMuteRulesFunc mocks the GetMuteRules method.
+	GetMuteRulesFunc func(ctx context.Context) ([]domain.MuteRule, error)
+
+	// GetMutedItemsFunc mocks the GetMutedItems method.
+	GetMutedItemsFunc func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error)
+
+	// GetQueueStatsFunc mocks the GetQueueStats method.
+	GetQueueStatsFunc func(ctx context.Context) (domain.QueueStats, error)
+
 	// GetSearchItemsCountFunc mocks the GetSearchItemsCount method.
 	GetSearchItemsCountFunc func(ctx context.Context, searchQuery string, req domain.ArticlesRequest) (int, error)
 
@@ -129,6 +201,12 @@ type DatabaseMock struct {
 	// GetTopicsFilteredFunc mocks the GetTopicsFiltered method.
 	GetTopicsFilteredFunc func(ctx context.Context, minScore float64) ([]string, error)
 
+	// RetryFailedItemFunc mocks the RetryFailedItem method.
+	RetryFailedItemFunc func(ctx context.Context, itemID int64) error
+
+	// RetryFeedFunc mocks the RetryFeed method.
+	RetryFeedFunc func(ctx context.Context, feedID int64) error
+
 	// SearchItemsFunc mocks the SearchItems method.
 	SearchItemsFunc func(ctx context.Context, searchQuery string, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error)
 
@@ -138,6 +216,18 @@ type DatabaseMock struct {
 	// UpdateFeedFunc mocks the UpdateFeed method.
 	UpdateFeedFunc func(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error
 
+	// UpdateFeedAuthFunc mocks the UpdateFeedAuth method.
+	UpdateFeedAuthFunc func(ctx context.Context, feedID int64, auth domain.FeedAuth) error
+
+	// UpdateFeedFolderFunc mocks the UpdateFeedFolder method.
+	UpdateFeedFolderFunc func(ctx context.Context, feedID int64, folder string) error
+
+	// UpdateFeedProxyFunc mocks the UpdateFeedProxy method.
+	UpdateFeedProxyFunc func(ctx context.Context, feedID int64, proxy string) error
+
+	// UpdateFeedSelectorsFunc mocks the UpdateFeedSelectors method.
+	UpdateFeedSelectorsFunc func(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error
+
 	// UpdateFeedStatusFunc mocks the UpdateFeedStatus method.
 	UpdateFeedStatusFunc func(ctx context.Context, feedID int64, enabled bool) error
 
@@ -153,6 +243,13 @@ type DatabaseMock struct {
 			// Feed is the feed argument value.
 			Feed *domain.Feed
 		}
+		// CreateMuteRule holds details about calls to the CreateMuteRule method.
+		CreateMuteRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rule is the rule argument value.
+			Rule *domain.MuteRule
+		}
 		// DeleteFeed holds details about calls to the DeleteFeed method.
 		DeleteFeed []struct {
 			// Ctx is the ctx argument value.
@@ -160,12 +257,21 @@ type DatabaseMock struct {
 			// FeedID is the feedID argument value.
 			FeedID int64
 		}
+		// DeleteMuteRule holds details about calls to the DeleteMuteRule method.
+		DeleteMuteRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
 		// GetActiveFeedNames holds details about calls to the GetActiveFeedNames method.
 		GetActiveFeedNames []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// MinScore is the minScore argument value.
 			MinScore float64
+			// Folder is the folder argument value.
+			Folder string
 		}
 		// GetAllFeeds holds details about calls to the GetAllFeeds method.
 		GetAllFeeds []struct {
@@ -204,11 +310,39 @@ type DatabaseMock struct {
 			// Req is the req argument value.
 			Req domain.ArticlesRequest
 		}
+		// GetFailedItems holds details about calls to the GetFailedItems method.
+		GetFailedItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetFeedEvents holds details about calls to the GetFeedEvents method.
+		GetFeedEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetFeedFetches holds details about calls to the GetFeedFetches method.
+		GetFeedFetches []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetFeeds holds details about calls to the GetFeeds method.
 		GetFeeds []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetFolders holds details about calls to the GetFolders method.
+		GetFolders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetItems holds details about calls to the GetItems method.
 		GetItems []struct {
 			// Ctx is the ctx argument value.
@@ -218,6 +352,23 @@ type DatabaseMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetMuteRules holds details about calls to the GetMuteRules method.
+		GetMuteRules []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetMutedItems holds details about calls to the GetMutedItems method.
+		GetMutedItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetQueueStats holds details about calls to the GetQueueStats method.
+		GetQueueStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetSearchItemsCount holds details about calls to the GetSearchItemsCount method.
 		GetSearchItemsCount []struct {
 			// Ctx is the ctx argument value.
@@ -255,6 +406,20 @@ type DatabaseMock struct {
 			// MinScore is the minScore argument value.
 			MinScore float64
 		}
+		// RetryFailedItem holds details about calls to the RetryFailedItem method.
+		RetryFailedItem []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ItemID is the itemID argument value.
+			ItemID int64
+		}
+		// RetryFeed holds details about calls to the RetryFeed method.
+		RetryFeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+		}
 		// SearchItems holds details about calls to the SearchItems method.
 		SearchItems []struct {
 			// Ctx is the ctx argument value.
@@ -284,6 +449,42 @@ type DatabaseMock struct {
 			// FetchInterval is the fetchInterval argument value.
 			FetchInterval time.Duration
 		}
+		// UpdateFeedAuth holds details about calls to the UpdateFeedAuth method.
+		UpdateFeedAuth []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Auth is the auth argument value.
+			Auth domain.FeedAuth
+		}
+		// UpdateFeedFolder holds details about calls to the UpdateFeedFolder method.
+		UpdateFeedFolder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Folder is the folder argument value.
+			Folder string
+		}
+		// UpdateFeedProxy holds details about calls to the UpdateFeedProxy method.
+		UpdateFeedProxy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Proxy is the proxy argument value.
+			Proxy string
+		}
+		// UpdateFeedSelectors holds details about calls to the UpdateFeedSelectors method.
+		UpdateFeedSelectors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Selectors is the selectors argument value.
+			Selectors domain.ScrapeSelectors
+		}
 		// UpdateFeedStatus holds details about calls to the UpdateFeedStatus method.
 		UpdateFeedStatus []struct {
 			// Ctx is the ctx argument value.
@@ -304,23 +505,38 @@ type DatabaseMock struct {
 		}
 	}
 	lockCreateFeed                    sync.RWMutex
+	lockCreateMuteRule                sync.RWMutex
 	lockDeleteFeed                    sync.RWMutex
+	lockDeleteMuteRule                sync.RWMutex
 	lockGetActiveFeedNames            sync.RWMutex
 	lockGetAllFeeds                   sync.RWMutex
 	lockGetClassifiedItem             sync.RWMutex
 	lockGetClassifiedItems            sync.RWMutex
 	lockGetClassifiedItemsCount       sync.RWMutex
 	lockGetClassifiedItemsWithFilters sync.RWMutex
+	lockGetFailedItems                sync.RWMutex
+	lockGetFeedEvents                 sync.RWMutex
+	lockGetFeedFetches                sync.RWMutex
 	lockGetFeeds                      sync.RWMutex
+	lockGetFolders                    sync.RWMutex
 	lockGetItems                      sync.RWMutex
+	lockGetMuteRules                  sync.RWMutex
+	lockGetMutedItems                 sync.RWMutex
+	lockGetQueueStats                 sync.RWMutex
 	lockGetSearchItemsCount           sync.RWMutex
 	lockGetSetting                    sync.RWMutex
 	lockGetTopTopicsByScore           sync.RWMutex
 	lockGetTopics                     sync.RWMutex
 	lockGetTopicsFiltered             sync.RWMutex
+	lockRetryFailedItem               sync.RWMutex
+	lockRetryFeed                     sync.RWMutex
 	lockSearchItems                   sync.RWMutex
 	lockSetSetting                    sync.RWMutex
 	lockUpdateFeed                    sync.RWMutex
+	lockUpdateFeedAuth                sync.RWMutex
+	lockUpdateFeedFolder              sync.RWMutex
+	lockUpdateFeedProxy               sync.RWMutex
+	lockUpdateFeedSelectors           sync.RWMutex
 	lockUpdateFeedStatus              sync.RWMutex
 	lockUpdateItemFeedback            sync.RWMutex
 }
@@ -361,6 +577,42 @@ func (mock *DatabaseMock) CreateFeedCalls() []struct {
 	return calls
 }
 
+// CreateMuteRule calls CreateMuteRuleFunc.
+func (mock *DatabaseMock) CreateMuteRule(ctx context.Context, rule *domain.MuteRule) error {
+	if mock.CreateMuteRuleFunc == nil {
+		panic("DatabaseMock.CreateMuteRuleFunc: method is nil but Database.CreateMuteRule was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Rule *domain.MuteRule
+	}{
+		Ctx:  ctx,
+		Rule: rule,
+	}
+	mock.lockCreateMuteRule.Lock()
+	mock.calls.CreateMuteRule = append(mock.calls.CreateMuteRule, callInfo)
+	mock.lockCreateMuteRule.Unlock()
+	return mock.CreateMuteRuleFunc(ctx, rule)
+}
+
+// CreateMuteRuleCalls gets all the calls that were made to CreateMuteRule.
+// Check the length with:
+//
+//	len(mockedDatabase.CreateMuteRuleCalls())
+func (mock *DatabaseMock) CreateMuteRuleCalls() []struct {
+	Ctx  context.Context
+	Rule *domain.MuteRule
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Rule *domain.MuteRule
+	}
+	mock.lockCreateMuteRule.RLock()
+	calls = mock.calls.CreateMuteRule
+	mock.lockCreateMuteRule.RUnlock()
+	return calls
+}
+
 // DeleteFeed calls DeleteFeedFunc.
 func (mock *DatabaseMock) DeleteFeed(ctx context.Context, feedID int64) error {
 	if mock.DeleteFeedFunc == nil {
@@ -397,22 +649,60 @@ func (mock *DatabaseMock) DeleteFeedCalls() []struct {
 	return calls
 }
 
+// DeleteMuteRule calls DeleteMuteRuleFunc.
+func (mock *DatabaseMock) DeleteMuteRule(ctx context.Context, id int64) error {
+	if mock.DeleteMuteRuleFunc == nil {
+		panic("DatabaseMock.DeleteMuteRuleFunc: method is nil but Database.DeleteMuteRule was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteMuteRule.Lock()
+	mock.calls.DeleteMuteRule = append(mock.calls.DeleteMuteRule, callInfo)
+	mock.lockDeleteMuteRule.Unlock()
+	return mock.DeleteMuteRuleFunc(ctx, id)
+}
+
+// DeleteMuteRuleCalls gets all the calls that were made to DeleteMuteRule.
+// Check the length with:
+//
+//	len(mockedDatabase.DeleteMuteRuleCalls())
+func (mock *DatabaseMock) DeleteMuteRuleCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockDeleteMuteRule.RLock()
+	calls = mock.calls.DeleteMuteRule
+	mock.lockDeleteMuteRule.RUnlock()
+	return calls
+}
+
 // GetActiveFeedNames calls GetActiveFeedNamesFunc.
-func (mock *DatabaseMock) GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error) {
+func (mock *DatabaseMock) GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error) {
 	if mock.GetActiveFeedNamesFunc == nil {
 		panic("DatabaseMock.GetActiveFeedNamesFunc: method is nil but Database.GetActiveFeedNames was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
 		MinScore float64
+		Folder   string
 	}{
 		Ctx:      ctx,
 		MinScore: minScore,
+		Folder:   folder,
 	}
 	mock.lockGetActiveFeedNames.Lock()
 	mock.calls.GetActiveFeedNames = append(mock.calls.GetActiveFeedNames, callInfo)
 	mock.lockGetActiveFeedNames.Unlock()
-	return mock.GetActiveFeedNamesFunc(ctx, minScore)
+	return mock.GetActiveFeedNamesFunc(ctx, minScore, folder)
 }
 
 // GetActiveFeedNamesCalls gets all the calls that were made to GetActiveFeedNames.
@@ -422,10 +712,12 @@ func (mock *DatabaseMock) GetActiveFeedNames(ctx context.Context, minScore float
 func (mock *DatabaseMock) GetActiveFeedNamesCalls() []struct {
 	Ctx      context.Context
 	MinScore float64
+	Folder   string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		MinScore float64
+		Folder   string
 	}
 	mock.lockGetActiveFeedNames.RLock()
 	calls = mock.calls.GetActiveFeedNames
@@ -617,6 +909,118 @@ func (mock *DatabaseMock) GetClassifiedItemsWithFiltersCalls() []struct {
 	return calls
 }
 
+// GetFailedItems calls GetFailedItemsFunc.
+func (mock *DatabaseMock) GetFailedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+	if mock.GetFailedItemsFunc == nil {
+		panic("DatabaseMock.GetFailedItemsFunc: method is nil but Database.GetFailedItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetFailedItems.Lock()
+	mock.calls.GetFailedItems = append(mock.calls.GetFailedItems, callInfo)
+	mock.lockGetFailedItems.Unlock()
+	return mock.GetFailedItemsFunc(ctx, limit)
+}
+
+// GetFailedItemsCalls gets all the calls that were made to GetFailedItems.
+// Check the length with:
+//
+//	len(mockedDatabase.GetFailedItemsCalls())
+func (mock *DatabaseMock) GetFailedItemsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetFailedItems.RLock()
+	calls = mock.calls.GetFailedItems
+	mock.lockGetFailedItems.RUnlock()
+	return calls
+}
+
+// GetFeedEvents calls GetFeedEventsFunc.
+func (mock *DatabaseMock) GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+	if mock.GetFeedEventsFunc == nil {
+		panic("DatabaseMock.GetFeedEventsFunc: method is nil but Database.GetFeedEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Limit  int
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Limit:  limit,
+	}
+	mock.lockGetFeedEvents.Lock()
+	mock.calls.GetFeedEvents = append(mock.calls.GetFeedEvents, callInfo)
+	mock.lockGetFeedEvents.Unlock()
+	return mock.GetFeedEventsFunc(ctx, feedID, limit)
+}
+
+// GetFeedEventsCalls gets all the calls that were made to GetFeedEvents.
+// Check the length with:
+//
+//	len(mockedDatabase.GetFeedEventsCalls())
+func (mock *DatabaseMock) GetFeedEventsCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Limit  int
+	}
+	mock.lockGetFeedEvents.RLock()
+	calls = mock.calls.GetFeedEvents
+	mock.lockGetFeedEvents.RUnlock()
+	return calls
+}
+
+// GetFeedFetches calls GetFeedFetchesFunc.
+func (mock *DatabaseMock) GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+	if mock.GetFeedFetchesFunc == nil {
+		panic("DatabaseMock.GetFeedFetchesFunc: method is nil but Database.GetFeedFetches was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetFeedFetches.Lock()
+	mock.calls.GetFeedFetches = append(mock.calls.GetFeedFetches, callInfo)
+	mock.lockGetFeedFetches.Unlock()
+	return mock.GetFeedFetchesFunc(ctx, limit)
+}
+
+// GetFeedFetchesCalls gets all the calls that were made to GetFeedFetches.
+// Check the length with:
+//
+//	len(mockedDatabase.GetFeedFetchesCalls())
+func (mock *DatabaseMock) GetFeedFetchesCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetFeedFetches.RLock()
+	calls = mock.calls.GetFeedFetches
+	mock.lockGetFeedFetches.RUnlock()
+	return calls
+}
+
 // GetFeeds calls GetFeedsFunc.
 func (mock *DatabaseMock) GetFeeds(ctx context.Context) ([]domain.Feed, error) {
 	if mock.GetFeedsFunc == nil {
@@ -649,6 +1053,38 @@ func (mock *DatabaseMock) GetFeedsCalls() []struct {
 	return calls
 }
 
+// GetFolders calls GetFoldersFunc.
+func (mock *DatabaseMock) GetFolders(ctx context.Context) ([]string, error) {
+	if mock.GetFoldersFunc == nil {
+		panic("DatabaseMock.GetFoldersFunc: method is nil but Database.GetFolders was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetFolders.Lock()
+	mock.calls.GetFolders = append(mock.calls.GetFolders, callInfo)
+	mock.lockGetFolders.Unlock()
+	return mock.GetFoldersFunc(ctx)
+}
+
+// GetFoldersCalls gets all the calls that were made to GetFolders.
+// Check the length with:
+//
+//	len(mockedDatabase.GetFoldersCalls())
+func (mock *DatabaseMock) GetFoldersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetFolders.RLock()
+	calls = mock.calls.GetFolders
+	mock.lockGetFolders.RUnlock()
+	return calls
+}
+
 // GetItems calls GetItemsFunc.
 func (mock *DatabaseMock) GetItems(ctx context.Context, limit int, offset int) ([]domain.Item, error) {
 	if mock.GetItemsFunc == nil {
@@ -689,6 +1125,106 @@ func (mock *DatabaseMock) GetItemsCalls() []struct {
 	return calls
 }
 
+// GetMuteRules calls GetMuteRulesFunc.
+func (mock *DatabaseMock) GetMuteRules(ctx context.Context) ([]domain.MuteRule, error) {
+	if mock.GetMuteRulesFunc == nil {
+		panic("DatabaseMock.GetMuteRulesFunc: method is nil but Database.GetMuteRules was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetMuteRules.Lock()
+	mock.calls.GetMuteRules = append(mock.calls.GetMuteRules, callInfo)
+	mock.lockGetMuteRules.Unlock()
+	return mock.GetMuteRulesFunc(ctx)
+}
+
+// GetMuteRulesCalls gets all the calls that were made to GetMuteRules.
+// Check the length with:
+//
+//	len(mockedDatabase.GetMuteRulesCalls())
+func (mock *DatabaseMock) GetMuteRulesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetMuteRules.RLock()
+	calls = mock.calls.GetMuteRules
+	mock.lockGetMuteRules.RUnlock()
+	return calls
+}
+
+// GetMutedItems calls GetMutedItemsFunc.
+func (mock *DatabaseMock) GetMutedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+	if mock.GetMutedItemsFunc == nil {
+		panic("DatabaseMock.GetMutedItemsFunc: method is nil but Database.GetMutedItems was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetMutedItems.Lock()
+	mock.calls.GetMutedItems = append(mock.calls.GetMutedItems, callInfo)
+	mock.lockGetMutedItems.Unlock()
+	return mock.GetMutedItemsFunc(ctx, limit)
+}
+
+// GetMutedItemsCalls gets all the calls that were made to GetMutedItems.
+// Check the length with:
+//
+//	len(mockedDatabase.GetMutedItemsCalls())
+func (mock *DatabaseMock) GetMutedItemsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetMutedItems.RLock()
+	calls = mock.calls.GetMutedItems
+	mock.lockGetMutedItems.RUnlock()
+	return calls
+}
+
+// GetQueueStats calls GetQueueStatsFunc.
+func (mock *DatabaseMock) GetQueueStats(ctx context.Context) (domain.QueueStats, error) {
+	if mock.GetQueueStatsFunc == nil {
+		panic("DatabaseMock.GetQueueStatsFunc: method is nil but Database.GetQueueStats was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetQueueStats.Lock()
+	mock.calls.GetQueueStats = append(mock.calls.GetQueueStats, callInfo)
+	mock.lockGetQueueStats.Unlock()
+	return mock.GetQueueStatsFunc(ctx)
+}
+
+// GetQueueStatsCalls gets all the calls that were made to GetQueueStats.
+// Check the length with:
+//
+//	len(mockedDatabase.GetQueueStatsCalls())
+func (mock *DatabaseMock) GetQueueStatsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetQueueStats.RLock()
+	calls = mock.calls.GetQueueStats
+	mock.lockGetQueueStats.RUnlock()
+	return calls
+}
+
 // GetSearchItemsCount calls GetSearchItemsCountFunc.
 func (mock *DatabaseMock) GetSearchItemsCount(ctx context.Context, searchQuery string, req domain.ArticlesRequest) (int, error) {
 	if mock.GetSearchItemsCountFunc == nil {
@@ -873,6 +1409,78 @@ func (mock *DatabaseMock) GetTopicsFilteredCalls() []struct {
 	return calls
 }
 
+// RetryFailedItem calls RetryFailedItemFunc.
+func (mock *DatabaseMock) RetryFailedItem(ctx context.Context, itemID int64) error {
+	if mock.RetryFailedItemFunc == nil {
+		panic("DatabaseMock.RetryFailedItemFunc: method is nil but Database.RetryFailedItem was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ItemID int64
+	}{
+		Ctx:    ctx,
+		ItemID: itemID,
+	}
+	mock.lockRetryFailedItem.Lock()
+	mock.calls.RetryFailedItem = append(mock.calls.RetryFailedItem, callInfo)
+	mock.lockRetryFailedItem.Unlock()
+	return mock.RetryFailedItemFunc(ctx, itemID)
+}
+
+// RetryFailedItemCalls gets all the calls that were made to RetryFailedItem.
+// Check the length with:
+//
+//	len(mockedDatabase.RetryFailedItemCalls())
+func (mock *DatabaseMock) RetryFailedItemCalls() []struct {
+	Ctx    context.Context
+	ItemID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ItemID int64
+	}
+	mock.lockRetryFailedItem.RLock()
+	calls = mock.calls.RetryFailedItem
+	mock.lockRetryFailedItem.RUnlock()
+	return calls
+}
+
+// RetryFeed calls RetryFeedFunc.
+func (mock *DatabaseMock) RetryFeed(ctx context.Context, feedID int64) error {
+	if mock.RetryFeedFunc == nil {
+		panic("DatabaseMock.RetryFeedFunc: method is nil but Database.RetryFeed was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+	}
+	mock.lockRetryFeed.Lock()
+	mock.calls.RetryFeed = append(mock.calls.RetryFeed, callInfo)
+	mock.lockRetryFeed.Unlock()
+	return mock.RetryFeedFunc(ctx, feedID)
+}
+
+// RetryFeedCalls gets all the calls that were made to RetryFeed.
+// Check the length with:
+//
+//	len(mockedDatabase.RetryFeedCalls())
+func (mock *DatabaseMock) RetryFeedCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+	}
+	mock.lockRetryFeed.RLock()
+	calls = mock.calls.RetryFeed
+	mock.lockRetryFeed.RUnlock()
+	return calls
+}
+
 // SearchItems calls SearchItemsFunc.
 func (mock *DatabaseMock) SearchItems(ctx context.Context, searchQuery string, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error) {
 	if mock.SearchItemsFunc == nil {
@@ -997,6 +1605,166 @@ func (mock *DatabaseMock) UpdateFeedCalls() []struct {
 	return calls
 }
 
+// UpdateFeedAuth calls UpdateFeedAuthFunc.
+func (mock *DatabaseMock) UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+	if mock.UpdateFeedAuthFunc == nil {
+		panic("DatabaseMock.UpdateFeedAuthFunc: method is nil but Database.UpdateFeedAuth was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Auth   domain.FeedAuth
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Auth:   auth,
+	}
+	mock.lockUpdateFeedAuth.Lock()
+	mock.calls.UpdateFeedAuth = append(mock.calls.UpdateFeedAuth, callInfo)
+	mock.lockUpdateFeedAuth.Unlock()
+	return mock.UpdateFeedAuthFunc(ctx, feedID, auth)
+}
+
+// UpdateFeedAuthCalls gets all the calls that were made to UpdateFeedAuth.
+// Check the length with:
+//
+//	len(mockedDatabase.UpdateFeedAuthCalls())
+func (mock *DatabaseMock) UpdateFeedAuthCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Auth   domain.FeedAuth
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Auth   domain.FeedAuth
+	}
+	mock.lockUpdateFeedAuth.RLock()
+	calls = mock.calls.UpdateFeedAuth
+	mock.lockUpdateFeedAuth.RUnlock()
+	return calls
+}
+
+// UpdateFeedFolder calls UpdateFeedFolderFunc.
+func (mock *DatabaseMock) UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error {
+	if mock.UpdateFeedFolderFunc == nil {
+		panic("DatabaseMock.UpdateFeedFolderFunc: method is nil but Database.UpdateFeedFolder was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Folder string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Folder: folder,
+	}
+	mock.lockUpdateFeedFolder.Lock()
+	mock.calls.UpdateFeedFolder = append(mock.calls.UpdateFeedFolder, callInfo)
+	mock.lockUpdateFeedFolder.Unlock()
+	return mock.UpdateFeedFolderFunc(ctx, feedID, folder)
+}
+
+// UpdateFeedFolderCalls gets all the calls that were made to UpdateFeedFolder.
+// Check the length with:
+//
+//	len(mockedDatabase.UpdateFeedFolderCalls())
+func (mock *DatabaseMock) UpdateFeedFolderCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Folder string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Folder string
+	}
+	mock.lockUpdateFeedFolder.RLock()
+	calls = mock.calls.UpdateFeedFolder
+	mock.lockUpdateFeedFolder.RUnlock()
+	return calls
+}
+
+// UpdateFeedProxy calls UpdateFeedProxyFunc.
+func (mock *DatabaseMock) UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error {
+	if mock.UpdateFeedProxyFunc == nil {
+		panic("DatabaseMock.UpdateFeedProxyFunc: method is nil but Database.UpdateFeedProxy was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Proxy  string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Proxy:  proxy,
+	}
+	mock.lockUpdateFeedProxy.Lock()
+	mock.calls.UpdateFeedProxy = append(mock.calls.UpdateFeedProxy, callInfo)
+	mock.lockUpdateFeedProxy.Unlock()
+	return mock.UpdateFeedProxyFunc(ctx, feedID, proxy)
+}
+
+// UpdateFeedProxyCalls gets all the calls that were made to UpdateFeedProxy.
+// Check the length with:
+//
+//	len(mockedDatabase.UpdateFeedProxyCalls())
+func (mock *DatabaseMock) UpdateFeedProxyCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Proxy  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Proxy  string
+	}
+	mock.lockUpdateFeedProxy.RLock()
+	calls = mock.calls.UpdateFeedProxy
+	mock.lockUpdateFeedProxy.RUnlock()
+	return calls
+}
+
+// UpdateFeedSelectors calls UpdateFeedSelectorsFunc.
+func (mock *DatabaseMock) UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+	if mock.UpdateFeedSelectorsFunc == nil {
+		panic("DatabaseMock.UpdateFeedSelectorsFunc: method is nil but Database.UpdateFeedSelectors was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		FeedID    int64
+		Selectors domain.ScrapeSelectors
+	}{
+		Ctx:       ctx,
+		FeedID:    feedID,
+		Selectors: selectors,
+	}
+	mock.lockUpdateFeedSelectors.Lock()
+	mock.calls.UpdateFeedSelectors = append(mock.calls.UpdateFeedSelectors, callInfo)
+	mock.lockUpdateFeedSelectors.Unlock()
+	return mock.UpdateFeedSelectorsFunc(ctx, feedID, selectors)
+}
+
+// UpdateFeedSelectorsCalls gets all the calls that were made to UpdateFeedSelectors.
+// Check the length with:
+//
+//	len(mockedDatabase.UpdateFeedSelectorsCalls())
+func (mock *DatabaseMock) UpdateFeedSelectorsCalls() []struct {
+	Ctx       context.Context
+	FeedID    int64
+	Selectors domain.ScrapeSelectors
+} {
+	var calls []struct {
+		Ctx       context.Context
+		FeedID    int64
+		Selectors domain.ScrapeSelectors
+	}
+	mock.lockUpdateFeedSelectors.RLock()
+	calls = mock.calls.UpdateFeedSelectors
+	mock.lockUpdateFeedSelectors.RUnlock()
+	return calls
+}
+
 // UpdateFeedStatus calls UpdateFeedStatusFunc.
 func (mock *DatabaseMock) UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error {
 	if mock.UpdateFeedStatusFunc == nil {
@@ -23,15 +23,39 @@ import (
 //			DeleteFeedFunc: func(ctx context.Context, feedID int64) error {
 //				panic("mock out the DeleteFeed method")
 //			},
-//			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64) ([]string, error) {
+//			GetActiveFeedNamesFunc: func(ctx context.Context, minScore float64, folder string) ([]string, error) {
 //				panic("mock out the GetActiveFeedNames method")
 //			},
+//			GetFeedEventsFunc: func(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+//				panic("mock out the GetFeedEvents method")
+//			},
+//			GetFeedFetchesFunc: func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+//				panic("mock out the GetFeedFetches method")
+//			},
 //			GetFeedsFunc: func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
 //				panic("mock out the GetFeeds method")
 //			},
+//			GetFoldersFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetFolders method")
+//			},
+//			RetryFeedFunc: func(ctx context.Context, feedID int64) error {
+//				panic("mock out the RetryFeed method")
+//			},
 //			UpdateFeedFunc: func(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error {
 //				panic("mock out the UpdateFeed method")
 //			},
+//			UpdateFeedAuthFunc: func(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+//				panic("mock out the UpdateFeedAuth method")
+//			},
+//			UpdateFeedFolderFunc: func(ctx context.Context, feedID int64, folder string) error {
+//				panic("mock out the UpdateFeedFolder method")
+//			},
+//			UpdateFeedProxyFunc: func(ctx context.Context, feedID int64, proxy string) error {
+//				panic("mock out the UpdateFeedProxy method")
+//			},
+//			UpdateFeedSelectorsFunc: func(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+//				panic("mock out the UpdateFeedSelectors method")
+//			},
 //			UpdateFeedStatusFunc: func(ctx context.Context, feedID int64, enabled bool) error {
 //				panic("mock out the UpdateFeedStatus method")
 //			},
@@ -49,14 +73,38 @@ type FeedRepoMock struct {
 	DeleteFeedFunc func(ctx context.Context, feedID int64) error
 
 	// GetActiveFeedNamesFunc mocks the GetActiveFeedNames method.
-	GetActiveFeedNamesFunc func(ctx context.Context, minScore float64) ([]string, error)
+	GetActiveFeedNamesFunc func(ctx context.Context, minScore float64, folder string) ([]string, error)
+
+	// GetFeedEventsFunc mocks the GetFeedEvents method.
+	GetFeedEventsFunc func(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error)
+
+	// GetFeedFetchesFunc mocks the GetFeedFetches method.
+	GetFeedFetchesFunc func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error)
 
 	// GetFeedsFunc mocks the GetFeeds method.
 	GetFeedsFunc func(ctx context.Context, enabledOnly bool) ([]domain.Feed, error)
 
+	// GetFoldersFunc mocks the GetFolders method.
+	GetFoldersFunc func(ctx context.Context) ([]string, error)
+
+	// RetryFeedFunc mocks the RetryFeed method.
+	RetryFeedFunc func(ctx context.Context, feedID int64) error
+
 	// UpdateFeedFunc mocks the UpdateFeed method.
 	UpdateFeedFunc func(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error
 
+	// UpdateFeedAuthFunc mocks the UpdateFeedAuth method.
+	UpdateFeedAuthFunc func(ctx context.Context, feedID int64, auth domain.FeedAuth) error
+
+	// UpdateFeedFolderFunc mocks the UpdateFeedFolder method.
+	UpdateFeedFolderFunc func(ctx context.Context, feedID int64, folder string) error
+
+	// UpdateFeedProxyFunc mocks the UpdateFeedProxy method.
+	UpdateFeedProxyFunc func(ctx context.Context, feedID int64, proxy string) error
+
+	// UpdateFeedSelectorsFunc mocks the UpdateFeedSelectors method.
+	UpdateFeedSelectorsFunc func(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error
+
 	// UpdateFeedStatusFunc mocks the UpdateFeedStatus method.
 	UpdateFeedStatusFunc func(ctx context.Context, feedID int64, enabled bool) error
 
@@ -82,6 +130,24 @@ type FeedRepoMock struct {
 			Ctx context.Context
 			// MinScore is the minScore argument value.
 			MinScore float64
+			// Folder is the folder argument value.
+			Folder string
+		}
+		// GetFeedEvents holds details about calls to the GetFeedEvents method.
+		GetFeedEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetFeedFetches holds details about calls to the GetFeedFetches method.
+		GetFeedFetches []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
 		}
 		// GetFeeds holds details about calls to the GetFeeds method.
 		GetFeeds []struct {
@@ -90,6 +156,18 @@ type FeedRepoMock struct {
 			// EnabledOnly is the enabledOnly argument value.
 			EnabledOnly bool
 		}
+		// GetFolders holds details about calls to the GetFolders method.
+		GetFolders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RetryFeed holds details about calls to the RetryFeed method.
+		RetryFeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+		}
 		// UpdateFeed holds details about calls to the UpdateFeed method.
 		UpdateFeed []struct {
 			// Ctx is the ctx argument value.
@@ -101,6 +179,42 @@ type FeedRepoMock struct {
 			// FetchInterval is the fetchInterval argument value.
 			FetchInterval time.Duration
 		}
+		// UpdateFeedAuth holds details about calls to the UpdateFeedAuth method.
+		UpdateFeedAuth []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Auth is the auth argument value.
+			Auth domain.FeedAuth
+		}
+		// UpdateFeedFolder holds details about calls to the UpdateFeedFolder method.
+		UpdateFeedFolder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Folder is the folder argument value.
+			Folder string
+		}
+		// UpdateFeedProxy holds details about calls to the UpdateFeedProxy method.
+		UpdateFeedProxy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Proxy is the proxy argument value.
+			Proxy string
+		}
+		// UpdateFeedSelectors holds details about calls to the UpdateFeedSelectors method.
+		UpdateFeedSelectors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Selectors is the selectors argument value.
+			Selectors domain.ScrapeSelectors
+		}
 		// UpdateFeedStatus holds details about calls to the UpdateFeedStatus method.
 		UpdateFeedStatus []struct {
 			// Ctx is the ctx argument value.
@@ -111,12 +225,20 @@ type FeedRepoMock struct {
 			Enabled bool
 		}
 	}
-	lockCreateFeed         sync.RWMutex
-	lockDeleteFeed         sync.RWMutex
-	lockGetActiveFeedNames sync.RWMutex
-	lockGetFeeds           sync.RWMutex
-	lockUpdateFeed         sync.RWMutex
-	lockUpdateFeedStatus   sync.RWMutex
+	lockCreateFeed          sync.RWMutex
+	lockDeleteFeed          sync.RWMutex
+	lockGetActiveFeedNames  sync.RWMutex
+	lockGetFeedEvents       sync.RWMutex
+	lockGetFeedFetches      sync.RWMutex
+	lockGetFeeds            sync.RWMutex
+	lockGetFolders          sync.RWMutex
+	lockRetryFeed           sync.RWMutex
+	lockUpdateFeed          sync.RWMutex
+	lockUpdateFeedAuth      sync.RWMutex
+	lockUpdateFeedFolder    sync.RWMutex
+	lockUpdateFeedProxy     sync.RWMutex
+	lockUpdateFeedSelectors sync.RWMutex
+	lockUpdateFeedStatus    sync.RWMutex
 }
 
 // CreateFeed calls CreateFeedFunc.
@@ -192,21 +314,23 @@ func (mock *FeedRepoMock) DeleteFeedCalls() []struct {
 }
 
 // GetActiveFeedNames calls GetActiveFeedNamesFunc.
-func (mock *FeedRepoMock) GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error) {
+func (mock *FeedRepoMock) GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error) {
 	if mock.GetActiveFeedNamesFunc == nil {
 		panic("FeedRepoMock.GetActiveFeedNamesFunc: method is nil but FeedRepo.GetActiveFeedNames was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
 		MinScore float64
+		Folder   string
 	}{
 		Ctx:      ctx,
 		MinScore: minScore,
+		Folder:   folder,
 	}
 	mock.lockGetActiveFeedNames.Lock()
 	mock.calls.GetActiveFeedNames = append(mock.calls.GetActiveFeedNames, callInfo)
 	mock.lockGetActiveFeedNames.Unlock()
-	return mock.GetActiveFeedNamesFunc(ctx, minScore)
+	return mock.GetActiveFeedNamesFunc(ctx, minScore, folder)
 }
 
 // GetActiveFeedNamesCalls gets all the calls that were made to GetActiveFeedNames.
@@ -216,10 +340,12 @@ func (mock *FeedRepoMock) GetActiveFeedNames(ctx context.Context, minScore float
 func (mock *FeedRepoMock) GetActiveFeedNamesCalls() []struct {
 	Ctx      context.Context
 	MinScore float64
+	Folder   string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		MinScore float64
+		Folder   string
 	}
 	mock.lockGetActiveFeedNames.RLock()
 	calls = mock.calls.GetActiveFeedNames
@@ -227,6 +353,82 @@ func (mock *FeedRepoMock) GetActiveFeedNamesCalls() []struct {
 	return calls
 }
 
+// GetFeedEvents calls GetFeedEventsFunc.
+func (mock *FeedRepoMock) GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+	if mock.GetFeedEventsFunc == nil {
+		panic("FeedRepoMock.GetFeedEventsFunc: method is nil but FeedRepo.GetFeedEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Limit  int
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Limit:  limit,
+	}
+	mock.lockGetFeedEvents.Lock()
+	mock.calls.GetFeedEvents = append(mock.calls.GetFeedEvents, callInfo)
+	mock.lockGetFeedEvents.Unlock()
+	return mock.GetFeedEventsFunc(ctx, feedID, limit)
+}
+
+// GetFeedEventsCalls gets all the calls that were made to GetFeedEvents.
+// Check the length with:
+//
+//	len(mockedFeedRepo.GetFeedEventsCalls())
+func (mock *FeedRepoMock) GetFeedEventsCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Limit  int
+	}
+	mock.lockGetFeedEvents.RLock()
+	calls = mock.calls.GetFeedEvents
+	mock.lockGetFeedEvents.RUnlock()
+	return calls
+}
+
+// GetFeedFetches calls GetFeedFetchesFunc.
+func (mock *FeedRepoMock) GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+	if mock.GetFeedFetchesFunc == nil {
+		panic("FeedRepoMock.GetFeedFetchesFunc: method is nil but FeedRepo.GetFeedFetches was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetFeedFetches.Lock()
+	mock.calls.GetFeedFetches = append(mock.calls.GetFeedFetches, callInfo)
+	mock.lockGetFeedFetches.Unlock()
+	return mock.GetFeedFetchesFunc(ctx, limit)
+}
+
+// GetFeedFetchesCalls gets all the calls that were made to GetFeedFetches.
+// Check the length with:
+//
+//	len(mockedFeedRepo.GetFeedFetchesCalls())
+func (mock *FeedRepoMock) GetFeedFetchesCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetFeedFetches.RLock()
+	calls = mock.calls.GetFeedFetches
+	mock.lockGetFeedFetches.RUnlock()
+	return calls
+}
+
 // GetFeeds calls GetFeedsFunc.
 func (mock *FeedRepoMock) GetFeeds(ctx context.Context, enabledOnly bool) ([]domain.Feed, error) {
 	if mock.GetFeedsFunc == nil {
@@ -263,6 +465,74 @@ func (mock *FeedRepoMock) GetFeedsCalls() []struct {
 	return calls
 }
 
+// GetFolders calls GetFoldersFunc.
+func (mock *FeedRepoMock) GetFolders(ctx context.Context) ([]string, error) {
+	if mock.GetFoldersFunc == nil {
+		panic("FeedRepoMock.GetFoldersFunc: method is nil but FeedRepo.GetFolders was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetFolders.Lock()
+	mock.calls.GetFolders = append(mock.calls.GetFolders, callInfo)
+	mock.lockGetFolders.Unlock()
+	return mock.GetFoldersFunc(ctx)
+}
+
+// GetFoldersCalls gets all the calls that were made to GetFolders.
+// Check the length with:
+//
+//	len(mockedFeedRepo.GetFoldersCalls())
+func (mock *FeedRepoMock) GetFoldersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetFolders.RLock()
+	calls = mock.calls.GetFolders
+	mock.lockGetFolders.RUnlock()
+	return calls
+}
+
+// RetryFeed calls RetryFeedFunc.
+func (mock *FeedRepoMock) RetryFeed(ctx context.Context, feedID int64) error {
+	if mock.RetryFeedFunc == nil {
+		panic("FeedRepoMock.RetryFeedFunc: method is nil but FeedRepo.RetryFeed was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+	}
+	mock.lockRetryFeed.Lock()
+	mock.calls.RetryFeed = append(mock.calls.RetryFeed, callInfo)
+	mock.lockRetryFeed.Unlock()
+	return mock.RetryFeedFunc(ctx, feedID)
+}
+
+// RetryFeedCalls gets all the calls that were made to RetryFeed.
+// Check the length with:
+//
+//	len(mockedFeedRepo.RetryFeedCalls())
+func (mock *FeedRepoMock) RetryFeedCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+	}
+	mock.lockRetryFeed.RLock()
+	calls = mock.calls.RetryFeed
+	mock.lockRetryFeed.RUnlock()
+	return calls
+}
+
 // UpdateFeed calls UpdateFeedFunc.
 func (mock *FeedRepoMock) UpdateFeed(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error {
 	if mock.UpdateFeedFunc == nil {
@@ -307,6 +577,166 @@ func (mock *FeedRepoMock) UpdateFeedCalls() []struct {
 	return calls
 }
 
+// UpdateFeedAuth calls UpdateFeedAuthFunc.
+func (mock *FeedRepoMock) UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+	if mock.UpdateFeedAuthFunc == nil {
+		panic("FeedRepoMock.UpdateFeedAuthFunc: method is nil but FeedRepo.UpdateFeedAuth was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Auth   domain.FeedAuth
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Auth:   auth,
+	}
+	mock.lockUpdateFeedAuth.Lock()
+	mock.calls.UpdateFeedAuth = append(mock.calls.UpdateFeedAuth, callInfo)
+	mock.lockUpdateFeedAuth.Unlock()
+	return mock.UpdateFeedAuthFunc(ctx, feedID, auth)
+}
+
+// UpdateFeedAuthCalls gets all the calls that were made to UpdateFeedAuth.
+// Check the length with:
+//
+//	len(mockedFeedRepo.UpdateFeedAuthCalls())
+func (mock *FeedRepoMock) UpdateFeedAuthCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Auth   domain.FeedAuth
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Auth   domain.FeedAuth
+	}
+	mock.lockUpdateFeedAuth.RLock()
+	calls = mock.calls.UpdateFeedAuth
+	mock.lockUpdateFeedAuth.RUnlock()
+	return calls
+}
+
+// UpdateFeedFolder calls UpdateFeedFolderFunc.
+func (mock *FeedRepoMock) UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error {
+	if mock.UpdateFeedFolderFunc == nil {
+		panic("FeedRepoMock.UpdateFeedFolderFunc: method is nil but FeedRepo.UpdateFeedFolder was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Folder string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Folder: folder,
+	}
+	mock.lockUpdateFeedFolder.Lock()
+	mock.calls.UpdateFeedFolder = append(mock.calls.UpdateFeedFolder, callInfo)
+	mock.lockUpdateFeedFolder.Unlock()
+	return mock.UpdateFeedFolderFunc(ctx, feedID, folder)
+}
+
+// UpdateFeedFolderCalls gets all the calls that were made to UpdateFeedFolder.
+// Check the length with:
+//
+//	len(mockedFeedRepo.UpdateFeedFolderCalls())
+func (mock *FeedRepoMock) UpdateFeedFolderCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Folder string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Folder string
+	}
+	mock.lockUpdateFeedFolder.RLock()
+	calls = mock.calls.UpdateFeedFolder
+	mock.lockUpdateFeedFolder.RUnlock()
+	return calls
+}
+
+// UpdateFeedProxy calls UpdateFeedProxyFunc.
+func (mock *FeedRepoMock) UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error {
+	if mock.UpdateFeedProxyFunc == nil {
+		panic("FeedRepoMock.UpdateFeedProxyFunc: method is nil but FeedRepo.UpdateFeedProxy was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Proxy  string
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Proxy:  proxy,
+	}
+	mock.lockUpdateFeedProxy.Lock()
+	mock.calls.UpdateFeedProxy = append(mock.calls.UpdateFeedProxy, callInfo)
+	mock.lockUpdateFeedProxy.Unlock()
+	return mock.UpdateFeedProxyFunc(ctx, feedID, proxy)
+}
+
+// UpdateFeedProxyCalls gets all the calls that were made to UpdateFeedProxy.
+// Check the length with:
+//
+//	len(mockedFeedRepo.UpdateFeedProxyCalls())
+func (mock *FeedRepoMock) UpdateFeedProxyCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Proxy  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Proxy  string
+	}
+	mock.lockUpdateFeedProxy.RLock()
+	calls = mock.calls.UpdateFeedProxy
+	mock.lockUpdateFeedProxy.RUnlock()
+	return calls
+}
+
+// UpdateFeedSelectors calls UpdateFeedSelectorsFunc.
+func (mock *FeedRepoMock) UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+	if mock.UpdateFeedSelectorsFunc == nil {
+		panic("FeedRepoMock.UpdateFeedSelectorsFunc: method is nil but FeedRepo.UpdateFeedSelectors was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		FeedID    int64
+		Selectors domain.ScrapeSelectors
+	}{
+		Ctx:       ctx,
+		FeedID:    feedID,
+		Selectors: selectors,
+	}
+	mock.lockUpdateFeedSelectors.Lock()
+	mock.calls.UpdateFeedSelectors = append(mock.calls.UpdateFeedSelectors, callInfo)
+	mock.lockUpdateFeedSelectors.Unlock()
+	return mock.UpdateFeedSelectorsFunc(ctx, feedID, selectors)
+}
+
+// UpdateFeedSelectorsCalls gets all the calls that were made to UpdateFeedSelectors.
+// Check the length with:
+//
+//	len(mockedFeedRepo.UpdateFeedSelectorsCalls())
+func (mock *FeedRepoMock) UpdateFeedSelectorsCalls() []struct {
+	Ctx       context.Context
+	FeedID    int64
+	Selectors domain.ScrapeSelectors
+} {
+	var calls []struct {
+		Ctx       context.Context
+		FeedID    int64
+		Selectors domain.ScrapeSelectors
+	}
+	mock.lockUpdateFeedSelectors.RLock()
+	calls = mock.calls.UpdateFeedSelectors
+	mock.lockUpdateFeedSelectors.RUnlock()
+	return calls
+}
+
 // UpdateFeedStatus calls UpdateFeedStatusFunc.
 func (mock *FeedRepoMock) UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error {
 	if mock.UpdateFeedStatusFunc == nil {
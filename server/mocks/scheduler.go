@@ -6,6 +6,9 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
+
+	"github.com/umputun/newscope/pkg/domain"
 )
 
 // SchedulerMock is a mock implementation of server.Scheduler.
@@ -14,18 +17,42 @@ import (
 //
 //		// make and configure a mocked server.Scheduler
 //		mockedScheduler := &SchedulerMock{
+//			DiscoverFeedsFunc: func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+//				panic("mock out the DiscoverFeeds method")
+//			},
 //			ExtractContentNowFunc: func(ctx context.Context, itemID int64) error {
 //				panic("mock out the ExtractContentNow method")
 //			},
+//			HandleWebSubPushFunc: func(ctx context.Context, feedID int64, body []byte, signature string) error {
+//				panic("mock out the HandleWebSubPush method")
+//			},
+//			MuteRulesChangedFunc: func()  {
+//				panic("mock out the MuteRulesChanged method")
+//			},
+//			PipelineStatsFunc: func() domain.PipelineStats {
+//				panic("mock out the PipelineStats method")
+//			},
+//			PreviewFeedFunc: func(ctx context.Context, feedURL string) (*domain.FeedPreview, error) {
+//				panic("mock out the PreviewFeed method")
+//			},
+//			ScrapePageFunc: func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+//				panic("mock out the ScrapePage method")
+//			},
 //			TriggerPreferenceUpdateFunc: func()  {
 //				panic("mock out the TriggerPreferenceUpdate method")
 //			},
+//			UnsubscribeWebSubFunc: func(ctx context.Context, feedID int64) error {
+//				panic("mock out the UnsubscribeWebSub method")
+//			},
 //			UpdateFeedNowFunc: func(ctx context.Context, feedID int64) error {
 //				panic("mock out the UpdateFeedNow method")
 //			},
 //			UpdatePreferenceSummaryFunc: func(ctx context.Context) error {
 //				panic("mock out the UpdatePreferenceSummary method")
 //			},
+//			VerifyWebSubFunc: func(ctx context.Context, feedID int64, mode string, topic string, lease time.Duration) error {
+//				panic("mock out the VerifyWebSub method")
+//			},
 //		}
 //
 //		// use mockedScheduler in code that requires server.Scheduler
@@ -33,20 +60,51 @@ import (
 //
 //	}
 type SchedulerMock struct {
+	// DiscoverFeedsFunc mocks the DiscoverFeeds method.
+	DiscoverFeedsFunc func(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error)
+
 	// ExtractContentNowFunc mocks the ExtractContentNow method.
 	ExtractContentNowFunc func(ctx context.Context, itemID int64) error
 
+	// HandleWebSubPushFunc mocks the HandleWebSubPush method.
+	HandleWebSubPushFunc func(ctx context.Context, feedID int64, body []byte, signature string) error
+
+	// MuteRulesChangedFunc mocks the MuteRulesChanged method.
+	MuteRulesChangedFunc func()
+
+	// PipelineStatsFunc mocks the PipelineStats method.
+	PipelineStatsFunc func() domain.PipelineStats
+
+	// PreviewFeedFunc mocks the PreviewFeed method.
+	PreviewFeedFunc func(ctx context.Context, feedURL string) (*domain.FeedPreview, error)
+
+	// ScrapePageFunc mocks the ScrapePage method.
+	ScrapePageFunc func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error)
+
 	// TriggerPreferenceUpdateFunc mocks the TriggerPreferenceUpdate method.
 	TriggerPreferenceUpdateFunc func()
 
+	// UnsubscribeWebSubFunc mocks the UnsubscribeWebSub method.
+	UnsubscribeWebSubFunc func(ctx context.Context, feedID int64) error
+
 	// UpdateFeedNowFunc mocks the UpdateFeedNow method.
 	UpdateFeedNowFunc func(ctx context.Context, feedID int64) error
 
 	// UpdatePreferenceSummaryFunc mocks the UpdatePreferenceSummary method.
 	UpdatePreferenceSummaryFunc func(ctx context.Context) error
 
+	// VerifyWebSubFunc mocks the VerifyWebSub method.
+	VerifyWebSubFunc func(ctx context.Context, feedID int64, mode string, topic string, lease time.Duration) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// DiscoverFeeds holds details about calls to the DiscoverFeeds method.
+		DiscoverFeeds []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PageURL is the pageURL argument value.
+			PageURL string
+		}
 		// ExtractContentNow holds details about calls to the ExtractContentNow method.
 		ExtractContentNow []struct {
 			// Ctx is the ctx argument value.
@@ -54,9 +112,49 @@ type SchedulerMock struct {
 			// ItemID is the itemID argument value.
 			ItemID int64
 		}
+		// HandleWebSubPush holds details about calls to the HandleWebSubPush method.
+		HandleWebSubPush []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Body is the body argument value.
+			Body []byte
+			// Signature is the signature argument value.
+			Signature string
+		}
+		// MuteRulesChanged holds details about calls to the MuteRulesChanged method.
+		MuteRulesChanged []struct {
+		}
+		// PipelineStats holds details about calls to the PipelineStats method.
+		PipelineStats []struct {
+		}
+		// PreviewFeed holds details about calls to the PreviewFeed method.
+		PreviewFeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedURL is the feedURL argument value.
+			FeedURL string
+		}
+		// ScrapePage holds details about calls to the ScrapePage method.
+		ScrapePage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PageURL is the pageURL argument value.
+			PageURL string
+			// Sel is the sel argument value.
+			Sel domain.ScrapeSelectors
+		}
 		// TriggerPreferenceUpdate holds details about calls to the TriggerPreferenceUpdate method.
 		TriggerPreferenceUpdate []struct {
 		}
+		// UnsubscribeWebSub holds details about calls to the UnsubscribeWebSub method.
+		UnsubscribeWebSub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+		}
 		// UpdateFeedNow holds details about calls to the UpdateFeedNow method.
 		UpdateFeedNow []struct {
 			// Ctx is the ctx argument value.
@@ -69,11 +167,68 @@ type SchedulerMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// VerifyWebSub holds details about calls to the VerifyWebSub method.
+		VerifyWebSub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FeedID is the feedID argument value.
+			FeedID int64
+			// Mode is the mode argument value.
+			Mode string
+			// Topic is the topic argument value.
+			Topic string
+			// Lease is the lease argument value.
+			Lease time.Duration
+		}
 	}
+	lockDiscoverFeeds           sync.RWMutex
 	lockExtractContentNow       sync.RWMutex
+	lockHandleWebSubPush        sync.RWMutex
+	lockMuteRulesChanged        sync.RWMutex
+	lockPipelineStats           sync.RWMutex
+	lockPreviewFeed             sync.RWMutex
+	lockScrapePage              sync.RWMutex
 	lockTriggerPreferenceUpdate sync.RWMutex
+	lockUnsubscribeWebSub       sync.RWMutex
 	lockUpdateFeedNow           sync.RWMutex
 	lockUpdatePreferenceSummary sync.RWMutex
+	lockVerifyWebSub            sync.RWMutex
+}
+
+// DiscoverFeeds calls DiscoverFeedsFunc.
+func (mock *SchedulerMock) DiscoverFeeds(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error) {
+	if mock.DiscoverFeedsFunc == nil {
+		panic("SchedulerMock.DiscoverFeedsFunc: method is nil but Scheduler.DiscoverFeeds was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		PageURL string
+	}{
+		Ctx:     ctx,
+		PageURL: pageURL,
+	}
+	mock.lockDiscoverFeeds.Lock()
+	mock.calls.DiscoverFeeds = append(mock.calls.DiscoverFeeds, callInfo)
+	mock.lockDiscoverFeeds.Unlock()
+	return mock.DiscoverFeedsFunc(ctx, pageURL)
+}
+
+// DiscoverFeedsCalls gets all the calls that were made to DiscoverFeeds.
+// Check the length with:
+//
+//	len(mockedScheduler.DiscoverFeedsCalls())
+func (mock *SchedulerMock) DiscoverFeedsCalls() []struct {
+	Ctx     context.Context
+	PageURL string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		PageURL string
+	}
+	mock.lockDiscoverFeeds.RLock()
+	calls = mock.calls.DiscoverFeeds
+	mock.lockDiscoverFeeds.RUnlock()
+	return calls
 }
 
 // ExtractContentNow calls ExtractContentNowFunc.
@@ -112,6 +267,180 @@ func (mock *SchedulerMock) ExtractContentNowCalls() []struct {
 	return calls
 }
 
+// HandleWebSubPush calls HandleWebSubPushFunc.
+func (mock *SchedulerMock) HandleWebSubPush(ctx context.Context, feedID int64, body []byte, signature string) error {
+	if mock.HandleWebSubPushFunc == nil {
+		panic("SchedulerMock.HandleWebSubPushFunc: method is nil but Scheduler.HandleWebSubPush was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		FeedID    int64
+		Body      []byte
+		Signature string
+	}{
+		Ctx:       ctx,
+		FeedID:    feedID,
+		Body:      body,
+		Signature: signature,
+	}
+	mock.lockHandleWebSubPush.Lock()
+	mock.calls.HandleWebSubPush = append(mock.calls.HandleWebSubPush, callInfo)
+	mock.lockHandleWebSubPush.Unlock()
+	return mock.HandleWebSubPushFunc(ctx, feedID, body, signature)
+}
+
+// HandleWebSubPushCalls gets all the calls that were made to HandleWebSubPush.
+// Check the length with:
+//
+//	len(mockedScheduler.HandleWebSubPushCalls())
+func (mock *SchedulerMock) HandleWebSubPushCalls() []struct {
+	Ctx       context.Context
+	FeedID    int64
+	Body      []byte
+	Signature string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		FeedID    int64
+		Body      []byte
+		Signature string
+	}
+	mock.lockHandleWebSubPush.RLock()
+	calls = mock.calls.HandleWebSubPush
+	mock.lockHandleWebSubPush.RUnlock()
+	return calls
+}
+
+// MuteRulesChanged calls MuteRulesChangedFunc.
+func (mock *SchedulerMock) MuteRulesChanged() {
+	if mock.MuteRulesChangedFunc == nil {
+		panic("SchedulerMock.MuteRulesChangedFunc: method is nil but Scheduler.MuteRulesChanged was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockMuteRulesChanged.Lock()
+	mock.calls.MuteRulesChanged = append(mock.calls.MuteRulesChanged, callInfo)
+	mock.lockMuteRulesChanged.Unlock()
+	mock.MuteRulesChangedFunc()
+}
+
+// MuteRulesChangedCalls gets all the calls that were made to MuteRulesChanged.
+// Check the length with:
+//
+//	len(mockedScheduler.MuteRulesChangedCalls())
+func (mock *SchedulerMock) MuteRulesChangedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMuteRulesChanged.RLock()
+	calls = mock.calls.MuteRulesChanged
+	mock.lockMuteRulesChanged.RUnlock()
+	return calls
+}
+
+// PipelineStats calls PipelineStatsFunc.
+func (mock *SchedulerMock) PipelineStats() domain.PipelineStats {
+	if mock.PipelineStatsFunc == nil {
+		panic("SchedulerMock.PipelineStatsFunc: method is nil but Scheduler.PipelineStats was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockPipelineStats.Lock()
+	mock.calls.PipelineStats = append(mock.calls.PipelineStats, callInfo)
+	mock.lockPipelineStats.Unlock()
+	return mock.PipelineStatsFunc()
+}
+
+// PipelineStatsCalls gets all the calls that were made to PipelineStats.
+// Check the length with:
+//
+//	len(mockedScheduler.PipelineStatsCalls())
+func (mock *SchedulerMock) PipelineStatsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPipelineStats.RLock()
+	calls = mock.calls.PipelineStats
+	mock.lockPipelineStats.RUnlock()
+	return calls
+}
+
+// PreviewFeed calls PreviewFeedFunc.
+func (mock *SchedulerMock) PreviewFeed(ctx context.Context, feedURL string) (*domain.FeedPreview, error) {
+	if mock.PreviewFeedFunc == nil {
+		panic("SchedulerMock.PreviewFeedFunc: method is nil but Scheduler.PreviewFeed was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		FeedURL string
+	}{
+		Ctx:     ctx,
+		FeedURL: feedURL,
+	}
+	mock.lockPreviewFeed.Lock()
+	mock.calls.PreviewFeed = append(mock.calls.PreviewFeed, callInfo)
+	mock.lockPreviewFeed.Unlock()
+	return mock.PreviewFeedFunc(ctx, feedURL)
+}
+
+// PreviewFeedCalls gets all the calls that were made to PreviewFeed.
+// Check the length with:
+//
+//	len(mockedScheduler.PreviewFeedCalls())
+func (mock *SchedulerMock) PreviewFeedCalls() []struct {
+	Ctx     context.Context
+	FeedURL string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		FeedURL string
+	}
+	mock.lockPreviewFeed.RLock()
+	calls = mock.calls.PreviewFeed
+	mock.lockPreviewFeed.RUnlock()
+	return calls
+}
+
+// ScrapePage calls ScrapePageFunc.
+func (mock *SchedulerMock) ScrapePage(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+	if mock.ScrapePageFunc == nil {
+		panic("SchedulerMock.ScrapePageFunc: method is nil but Scheduler.ScrapePage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		PageURL string
+		Sel     domain.ScrapeSelectors
+	}{
+		Ctx:     ctx,
+		PageURL: pageURL,
+		Sel:     sel,
+	}
+	mock.lockScrapePage.Lock()
+	mock.calls.ScrapePage = append(mock.calls.ScrapePage, callInfo)
+	mock.lockScrapePage.Unlock()
+	return mock.ScrapePageFunc(ctx, pageURL, sel)
+}
+
+// ScrapePageCalls gets all the calls that were made to ScrapePage.
+// Check the length with:
+//
+//	len(mockedScheduler.ScrapePageCalls())
+func (mock *SchedulerMock) ScrapePageCalls() []struct {
+	Ctx     context.Context
+	PageURL string
+	Sel     domain.ScrapeSelectors
+} {
+	var calls []struct {
+		Ctx     context.Context
+		PageURL string
+		Sel     domain.ScrapeSelectors
+	}
+	mock.lockScrapePage.RLock()
+	calls = mock.calls.ScrapePage
+	mock.lockScrapePage.RUnlock()
+	return calls
+}
+
 // TriggerPreferenceUpdate calls TriggerPreferenceUpdateFunc.
 func (mock *SchedulerMock) TriggerPreferenceUpdate() {
 	if mock.TriggerPreferenceUpdateFunc == nil {
@@ -139,6 +468,42 @@ func (mock *SchedulerMock) TriggerPreferenceUpdateCalls() []struct {
 	return calls
 }
 
+// UnsubscribeWebSub calls UnsubscribeWebSubFunc.
+func (mock *SchedulerMock) UnsubscribeWebSub(ctx context.Context, feedID int64) error {
+	if mock.UnsubscribeWebSubFunc == nil {
+		panic("SchedulerMock.UnsubscribeWebSubFunc: method is nil but Scheduler.UnsubscribeWebSub was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+	}
+	mock.lockUnsubscribeWebSub.Lock()
+	mock.calls.UnsubscribeWebSub = append(mock.calls.UnsubscribeWebSub, callInfo)
+	mock.lockUnsubscribeWebSub.Unlock()
+	return mock.UnsubscribeWebSubFunc(ctx, feedID)
+}
+
+// UnsubscribeWebSubCalls gets all the calls that were made to UnsubscribeWebSub.
+// Check the length with:
+//
+//	len(mockedScheduler.UnsubscribeWebSubCalls())
+func (mock *SchedulerMock) UnsubscribeWebSubCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+	}
+	mock.lockUnsubscribeWebSub.RLock()
+	calls = mock.calls.UnsubscribeWebSub
+	mock.lockUnsubscribeWebSub.RUnlock()
+	return calls
+}
+
 // UpdateFeedNow calls UpdateFeedNowFunc.
 func (mock *SchedulerMock) UpdateFeedNow(ctx context.Context, feedID int64) error {
 	if mock.UpdateFeedNowFunc == nil {
@@ -206,3 +571,51 @@ func (mock *SchedulerMock) UpdatePreferenceSummaryCalls() []struct {
 	mock.lockUpdatePreferenceSummary.RUnlock()
 	return calls
 }
+
+// VerifyWebSub calls VerifyWebSubFunc.
+func (mock *SchedulerMock) VerifyWebSub(ctx context.Context, feedID int64, mode string, topic string, lease time.Duration) error {
+	if mock.VerifyWebSubFunc == nil {
+		panic("SchedulerMock.VerifyWebSubFunc: method is nil but Scheduler.VerifyWebSub was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		FeedID int64
+		Mode   string
+		Topic  string
+		Lease  time.Duration
+	}{
+		Ctx:    ctx,
+		FeedID: feedID,
+		Mode:   mode,
+		Topic:  topic,
+		Lease:  lease,
+	}
+	mock.lockVerifyWebSub.Lock()
+	mock.calls.VerifyWebSub = append(mock.calls.VerifyWebSub, callInfo)
+	mock.lockVerifyWebSub.Unlock()
+	return mock.VerifyWebSubFunc(ctx, feedID, mode, topic, lease)
+}
+
+// VerifyWebSubCalls gets all the calls that were made to VerifyWebSub.
+// Check the length with:
+//
+//	len(mockedScheduler.VerifyWebSubCalls())
+func (mock *SchedulerMock) VerifyWebSubCalls() []struct {
+	Ctx    context.Context
+	FeedID int64
+	Mode   string
+	Topic  string
+	Lease  time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		FeedID int64
+		Mode   string
+		Topic  string
+		Lease  time.Duration
+	}
+	mock.lockVerifyWebSub.RLock()
+	calls = mock.calls.VerifyWebSub
+	mock.lockVerifyWebSub.RUnlock()
+	return calls
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// muteRulesHandler renders the mute rules section of the settings page
+func (s *Server) muteRulesHandler(w http.ResponseWriter, r *http.Request) {
+	s.renderMuteRules(w, r, "")
+}
+
+// createMuteRuleHandler adds a mute rule from the form, an invalid rule is reported in the rendered section
+func (s *Server) createMuteRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, fmt.Errorf("invalid form data"), http.StatusBadRequest)
+		return
+	}
+
+	rule := &domain.MuteRule{
+		Kind:    domain.MuteKind(r.FormValue("kind")),
+		Pattern: strings.TrimSpace(r.FormValue("pattern")),
+	}
+	if feedID := r.FormValue("feed_id"); feedID != "" {
+		id, err := strconv.ParseInt(feedID, 10, 64)
+		if err != nil {
+			renderError(w, r, fmt.Errorf("invalid feed ID"), http.StatusBadRequest)
+			return
+		}
+		rule.FeedID = id
+	}
+	if err := rule.Validate(); err != nil {
+		s.renderMuteRules(w, r, err.Error())
+		return
+	}
+
+	if err := s.db.CreateMuteRule(r.Context(), rule); err != nil {
+		log.Printf("[ERROR] failed to create mute rule: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	s.scheduler.MuteRulesChanged()
+	s.renderMuteRules(w, r, "")
+}
+
+// deleteMuteRuleHandler removes a mute rule, items it muted stay muted
+func (s *Server) deleteMuteRuleHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		renderError(w, r, fmt.Errorf("invalid mute rule ID"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.db.DeleteMuteRule(r.Context(), id); err != nil {
+		log.Printf("[ERROR] failed to delete mute rule: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	s.scheduler.MuteRulesChanged()
+	s.renderMuteRules(w, r, "")
+}
+
+// mutedItemsHandler renders recently muted items with the rules that muted them
+func (s *Server) mutedItemsHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := s.db.GetMutedItems(r.Context(), mutedItemsLimit)
+	if err != nil {
+		log.Printf("[ERROR] failed to get muted items: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "muted-items", items); err != nil {
+		log.Printf("[ERROR] failed to render muted items: %v", err)
+	}
+}
+
+// renderMuteRules renders the mute rules with the form adding a new one, errMsg is shown above the form
+func (s *Server) renderMuteRules(w http.ResponseWriter, r *http.Request, errMsg string) {
+	ctx := r.Context()
+	rules, err := s.db.GetMuteRules(ctx)
+	if err != nil {
+		log.Printf("[ERROR] failed to get mute rules: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	feeds, err := s.db.GetAllFeeds(ctx)
+	if err != nil {
+		log.Printf("[WARN] failed to get feeds: %v", err)
+		feeds = []domain.Feed{} // rules can still be added for all feeds
+	}
+
+	data := struct {
+		Rules []domain.MuteRule
+		Feeds []domain.Feed
+		Kinds []domain.MuteKind
+		Error string
+	}{Rules: rules, Feeds: feeds, Kinds: domain.MuteKinds, Error: errMsg}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "mute-rules.html", data); err != nil {
+		log.Printf("[ERROR] failed to render mute rules: %v", err)
+	}
+}
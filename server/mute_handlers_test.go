@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/server/mocks"
+)
+
+func TestServer_muteRuleHandlers(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) {
+			return ":8080", 30 * time.Second
+		},
+	}
+	var rules []domain.MuteRule
+	database := &mocks.DatabaseMock{
+		GetMuteRulesFunc: func(ctx context.Context) ([]domain.MuteRule, error) { return rules, nil },
+		CreateMuteRuleFunc: func(ctx context.Context, rule *domain.MuteRule) error {
+			rule.ID = int64(len(rules) + 1)
+			if rule.FeedID == 2 {
+				rule.FeedName = "Sports Daily"
+			}
+			rules = append(rules, *rule)
+			return nil
+		},
+		DeleteMuteRuleFunc: func(ctx context.Context, id int64) error {
+			rules = rules[:0]
+			return nil
+		},
+		GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+			return []domain.Feed{{ID: 2, Title: "Sports Daily"}, {ID: 3, URL: "https://example.com/feed"}}, nil
+		},
+	}
+	scheduler := &mocks.SchedulerMock{MuteRulesChangedFunc: func() {}}
+	srv := New(cfg, database, scheduler, "1.0.0", false)
+
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/mute-rules", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		srv.createMuteRuleHandler(w, req)
+		return w
+	}
+
+	t.Run("list without rules", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		srv.muteRulesHandler(w, httptest.NewRequest("GET", "/api/v1/mute-rules", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "No mute rules")
+		assert.Contains(t, w.Body.String(), `<option value="regex">regex</option>`)
+		assert.Contains(t, w.Body.String(), `<option value="2">Sports Daily</option>`)
+		assert.Contains(t, w.Body.String(), `<option value="3">https://example.com/feed</option>`, "feed without title")
+	})
+
+	t.Run("add rule", func(t *testing.T) {
+		w := post(url.Values{"kind": {"topic"}, "pattern": {" football "}, "feed_id": {"2"}})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, database.CreateMuteRuleCalls(), 1)
+		rule := database.CreateMuteRuleCalls()[0].Rule
+		assert.Equal(t, domain.MuteTopic, rule.Kind)
+		assert.Equal(t, "football", rule.Pattern)
+		assert.Equal(t, int64(2), rule.FeedID)
+		assert.Contains(t, w.Body.String(), "football")
+		assert.Contains(t, w.Body.String(), "in Sports Daily")
+		assert.Contains(t, w.Body.String(), `hx-delete="/api/v1/mute-rules/1"`)
+		assert.Len(t, scheduler.MuteRulesChangedCalls(), 1, "processing uses the new rule")
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		w := post(url.Values{"kind": {"regex"}, "pattern": {"(unclosed"}})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<p class="mute-rule-error">invalid regex`)
+		assert.Len(t, database.CreateMuteRuleCalls(), 1, "invalid rule not stored")
+		assert.Len(t, scheduler.MuteRulesChangedCalls(), 1)
+
+		w = post(url.Values{"kind": {"sender"}, "pattern": {"x"}})
+		assert.Contains(t, w.Body.String(), "unknown mute rule kind")
+
+		w = post(url.Values{"kind": {"keyword"}, "pattern": {"ad"}, "feed_id": {"abc"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("delete rule", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/api/v1/mute-rules/1", http.NoBody)
+		req.SetPathValue("id", "1")
+		w := httptest.NewRecorder()
+		srv.deleteMuteRuleHandler(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, database.DeleteMuteRuleCalls(), 1)
+		assert.Equal(t, int64(1), database.DeleteMuteRuleCalls()[0].ID)
+		assert.Contains(t, w.Body.String(), "No mute rules")
+		assert.Len(t, scheduler.MuteRulesChangedCalls(), 2, "processing drops the deleted rule")
+
+		req = httptest.NewRequest("DELETE", "/api/v1/mute-rules/x", http.NoBody)
+		req.SetPathValue("id", "x")
+		w = httptest.NewRecorder()
+		srv.deleteMuteRuleHandler(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServer_mutedItemsHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) {
+			return ":8080", 30 * time.Second
+		},
+	}
+
+	t.Run("renders muted items", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetMutedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+				assert.Equal(t, mutedItemsLimit, limit)
+				return []domain.ClassifiedItem{{Item: &domain.Item{ID: 1, Title: "Sponsored: gadget",
+					Link: "https://example.com/ad", MutedBy: `keyword "sponsored"`,
+					CreatedAt: time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC)}, FeedName: "Tech News"}}, nil
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.mutedItemsHandler(w, httptest.NewRequest("GET", "/api/v1/mute-rules/muted", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<a href="https://example.com/ad" target="_blank" rel="noopener">Sponsored: gadget</a>`)
+		assert.Contains(t, w.Body.String(), "Tech News")
+		assert.Contains(t, w.Body.String(), "muted by keyword &#34;sponsored&#34;")
+	})
+
+	t.Run("nothing muted", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetMutedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) { return nil, nil },
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.mutedItemsHandler(w, httptest.NewRequest("GET", "/api/v1/mute-rules/muted", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "No muted articles")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetMutedItemsFunc: func(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.mutedItemsHandler(w, httptest.NewRequest("GET", "/api/v1/mute-rules/muted", http.NoBody))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/feed"
+)
+
+// opmlImportReport summarizes the result of an OPML import
+type opmlImportReport struct {
+	Added      []domain.Feed
+	Duplicates []feed.OPMLEntry
+	Failed     []opmlImportFailure
+}
+
+// opmlImportFailure is an OPML entry which was not imported, with the reason
+type opmlImportFailure struct {
+	Entry  feed.OPMLEntry
+	Reason string
+}
+
+// opmlExportHandler serves all enabled feeds as OPML document
+func (s *Server) opmlExportHandler(w http.ResponseWriter, r *http.Request) {
+	feeds, err := s.db.GetAllFeeds(r.Context())
+	if err != nil {
+		log.Printf("[ERROR] failed to get feeds for OPML: %v", err)
+		http.Error(w, "Failed to generate OPML", http.StatusInternalServerError)
+		return
+	}
+
+	opml, err := feed.NewGenerator(s.config.GetFullConfig().Server.BaseURL).GenerateOPML(feeds)
+	if err != nil {
+		log.Printf("[ERROR] failed to generate OPML: %v", err)
+		http.Error(w, "Failed to generate OPML", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="newscope-feeds.opml"`)
+	if _, err := w.Write([]byte(opml)); err != nil {
+		log.Printf("[ERROR] failed to write OPML response: %v", err)
+	}
+}
+
+// opmlImportHandler creates feeds from an uploaded OPML file and renders the import report.
+// feeds already subscribed to are skipped, added feeds are fetched on the next update cycle.
+func (s *Server) opmlImportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	file, _, err := r.FormFile("opml")
+	if err != nil {
+		renderError(w, r, fmt.Errorf("OPML file is required"), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	entries, err := feed.ParseOPML(file)
+	if err != nil {
+		renderError(w, r, fmt.Errorf("invalid OPML file: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	existing, err := s.db.GetAllFeeds(ctx)
+	if err != nil {
+		log.Printf("[ERROR] failed to get feeds for OPML import: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	known := make(map[string]bool, len(existing)+len(entries))
+	for _, f := range existing {
+		known[f.URL] = true
+	}
+
+	report := opmlImportReport{}
+	for _, entry := range entries {
+		if known[entry.URL] {
+			report.Duplicates = append(report.Duplicates, entry)
+			continue
+		}
+
+		f, err := opmlEntryToFeed(entry)
+		if err != nil {
+			report.Failed = append(report.Failed, opmlImportFailure{Entry: entry, Reason: err.Error()})
+			continue
+		}
+
+		if err := s.db.CreateFeed(ctx, f); err != nil {
+			log.Printf("[WARN] failed to create feed %s from OPML: %v", entry.URL, err)
+			report.Failed = append(report.Failed, opmlImportFailure{Entry: entry, Reason: "failed to save feed"})
+			continue
+		}
+		known[entry.URL] = true
+		report.Added = append(report.Added, *f)
+	}
+
+	log.Printf("[INFO] OPML import: %d added, %d duplicates, %d failed",
+		len(report.Added), len(report.Duplicates), len(report.Failed))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "opml-import-report.html", report); err != nil {
+		log.Printf("[ERROR] failed to render OPML import report: %v", err)
+	}
+}
+
+// opmlEntryToFeed validates an OPML entry and converts it to a new enabled feed
+func opmlEntryToFeed(entry feed.OPMLEntry) (*domain.Feed, error) {
+	u, err := url.Parse(entry.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("invalid feed URL")
+	}
+
+	fetchInterval := 30 * time.Minute // same default as for manually added feeds
+	if entry.Interval != "" {
+		minutes, err := strconv.Atoi(entry.Interval)
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid fetch interval %q", entry.Interval)
+		}
+		fetchInterval = time.Duration(minutes) * time.Minute
+	}
+
+	return &domain.Feed{
+		URL:           entry.URL,
+		Title:         entry.Title,
+		Folder:        entry.Folder,
+		FetchInterval: fetchInterval,
+		Enabled:       true,
+	}, nil
+}
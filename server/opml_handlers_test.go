@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/config"
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/feed"
+	"github.com/umputun/newscope/server/mocks"
+)
+
+func TestServer_OPMLExportHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+		GetFullConfigFunc: func() *config.Config {
+			c := &config.Config{}
+			c.Server.BaseURL = "http://localhost:8080"
+			return c
+		},
+	}
+
+	t.Run("exports feeds with folders", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+				return []domain.Feed{
+					{ID: 1, Title: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech/Go", FetchInterval: time.Hour, Enabled: true},
+					{ID: 2, Title: "Off", URL: "https://example.com/off.xml", Enabled: false},
+				}, nil
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+
+		req := httptest.NewRequest("GET", "/api/v1/feeds/opml", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/x-opml; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "newscope-feeds.opml")
+
+		entries, err := feed.ParseOPML(strings.NewReader(w.Body.String()))
+		require.NoError(t, err)
+		assert.Equal(t, []feed.OPMLEntry{
+			{Title: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech/Go", Interval: "60"},
+		}, entries)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+
+		w := httptest.NewRecorder()
+		srv.opmlExportHandler(w, httptest.NewRequest("GET", "/api/v1/feeds/opml", http.NoBody))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestServer_OPMLImportHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+
+	const opml = `<?xml version="1.0"?>
+<opml version="2.0"><head><title>export</title></head><body>
+  <outline text="Existing" xmlUrl="https://example.com/existing.xml"/>
+  <outline text="News">
+    <outline text="World">
+      <outline text="World News" xmlUrl="https://example.com/world.xml" fetchInterval="90"/>
+    </outline>
+    <outline text="Local" xmlUrl="https://example.com/local.xml"/>
+  </outline>
+  <outline text="Local again" xmlUrl="https://example.com/local.xml"/>
+  <outline text="Bad URL" xmlUrl="ftp://example.com/feed"/>
+  <outline text="Bad interval" xmlUrl="https://example.com/bad-interval.xml" fetchInterval="soon"/>
+  <outline text="Save fails" xmlUrl="https://example.com/fail.xml"/>
+</body></opml>`
+
+	upload := func(t *testing.T, content string) *http.Request {
+		t.Helper()
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		if content != "" {
+			fw, err := mw.CreateFormFile("opml", "subs.opml")
+			require.NoError(t, err)
+			_, err = fw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, mw.Close())
+		req := httptest.NewRequest("POST", "/api/v1/feeds/opml", body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		return req
+	}
+
+	t.Run("imports feeds and reports results", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+				return []domain.Feed{{ID: 1, URL: "https://example.com/existing.xml", Enabled: true}}, nil
+			},
+			CreateFeedFunc: func(ctx context.Context, f *domain.Feed) error {
+				if f.URL == "https://example.com/fail.xml" {
+					return errors.New("db locked")
+				}
+				f.ID = 100
+				return nil
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+
+		w := httptest.NewRecorder()
+		srv.opmlImportHandler(w, upload(t, opml))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		created := database.CreateFeedCalls()
+		require.Len(t, created, 3)
+		assert.Equal(t, domain.Feed{ID: 100, Title: "World News", URL: "https://example.com/world.xml",
+			Folder: "News/World", FetchInterval: 90 * time.Minute, Enabled: true}, *created[0].Feed)
+		assert.Equal(t, domain.Feed{ID: 100, Title: "Local", URL: "https://example.com/local.xml",
+			Folder: "News", FetchInterval: 30 * time.Minute, Enabled: true}, *created[1].Feed)
+		assert.Equal(t, "https://example.com/fail.xml", created[2].Feed.URL)
+
+		body := w.Body.String()
+		assert.Contains(t, body, "Imported 2 feeds, skipped 2 duplicates, 3 failed.")
+		assert.Contains(t, body, "World News")
+		assert.Contains(t, body, "News/World")
+		assert.Contains(t, body, "Local again - https://example.com/local.xml")
+		assert.Contains(t, body, "ftp://example.com/feed: invalid feed URL")
+		assert.Contains(t, body, "invalid fetch interval &#34;soon&#34;")
+		assert.Contains(t, body, "https://example.com/fail.xml: failed to save feed")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.opmlImportHandler(w, upload(t, ""))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid opml", func(t *testing.T) {
+		srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.opmlImportHandler(w, upload(t, "<html><body>not opml</body></html>"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid OPML file")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := httptest.NewRecorder()
+		srv.opmlImportHandler(w, upload(t, opml))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, database.CreateFeedCalls())
+	})
+}
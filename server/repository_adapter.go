@@ -14,6 +14,8 @@ import (
 //go:generate moq -out mocks/item_repo.go -pkg mocks -skip-ensure -fmt goimports . ItemRepo
 //go:generate moq -out mocks/classification_repo.go -pkg mocks -skip-ensure -fmt goimports . ClassificationRepo
 //go:generate moq -out mocks/setting_repo.go -pkg mocks -skip-ensure -fmt goimports . SettingRepo
+//go:generate moq -out mocks/mute_rule_repo.go -pkg mocks -skip-ensure -fmt goimports . MuteRuleRepo
+//go:generate moq -out mocks/job_repo.go -pkg mocks -skip-ensure -fmt goimports . JobRepo
 
 // RepositoryAdapter adapts repositories to server.Database interface
 type RepositoryAdapter struct {
@@ -21,6 +23,8 @@ type RepositoryAdapter struct {
 	itemRepo           ItemRepo
 	classificationRepo ClassificationRepo
 	settingRepo        SettingRepo
+	muteRuleRepo       MuteRuleRepo
+	jobRepo            JobRepo
 }
 
 // FeedRepo defines the feed repository interface used by the adapter
@@ -28,9 +32,17 @@ type FeedRepo interface {
 	GetFeeds(ctx context.Context, enabledOnly bool) ([]domain.Feed, error)
 	CreateFeed(ctx context.Context, feed *domain.Feed) error
 	UpdateFeed(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error
+	UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error
+	UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error
+	UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error
+	UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error
 	UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error
+	RetryFeed(ctx context.Context, feedID int64) error
 	DeleteFeed(ctx context.Context, feedID int64) error
-	GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error)
+	GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error)
+	GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error)
+	GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error)
+	GetFolders(ctx context.Context) ([]string, error)
 }
 
 // ItemRepo defines the item repository interface used by the adapter
@@ -50,6 +62,8 @@ type ClassificationRepo interface {
 	GetFeedbackCount(ctx context.Context) (int64, error)
 	SearchItems(ctx context.Context, searchQuery string, filter *domain.ItemFilter) ([]*domain.ClassifiedItem, error)
 	GetSearchItemsCount(ctx context.Context, searchQuery string, filter *domain.ItemFilter) (int, error)
+	GetMutedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error)
+	GetFailedItems(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error)
 }
 
 // SettingRepo defines the setting repository interface used by the adapter
@@ -58,6 +72,19 @@ type SettingRepo interface {
 	SetSetting(ctx context.Context, key, value string) error
 }
 
+// MuteRuleRepo defines the mute rule repository interface used by the adapter
+type MuteRuleRepo interface {
+	CreateMuteRule(ctx context.Context, rule *domain.MuteRule) error
+	GetMuteRules(ctx context.Context) ([]domain.MuteRule, error)
+	DeleteMuteRule(ctx context.Context, id int64) error
+}
+
+// JobRepo defines the processing queue interface used by the adapter
+type JobRepo interface {
+	EnqueueJob(ctx context.Context, itemID int64, priority int) error
+	GetQueueStats(ctx context.Context) (domain.QueueStats, error)
+}
+
 // NewRepositoryAdapter creates a new repository adapter from concrete repositories
 func NewRepositoryAdapter(repos *repository.Repositories) *RepositoryAdapter {
 	return &RepositoryAdapter{
@@ -65,16 +92,21 @@ func NewRepositoryAdapter(repos *repository.Repositories) *RepositoryAdapter {
 		itemRepo:           repos.Item,
 		classificationRepo: repos.Classification,
 		settingRepo:        repos.Setting,
+		muteRuleRepo:       repos.MuteRule,
+		jobRepo:            repos.Job,
 	}
 }
 
 // NewRepositoryAdapterWithInterfaces creates a new repository adapter with interface dependencies for testing
-func NewRepositoryAdapterWithInterfaces(feedRepo FeedRepo, itemRepo ItemRepo, classificationRepo ClassificationRepo, settingRepo SettingRepo) *RepositoryAdapter {
+func NewRepositoryAdapterWithInterfaces(feedRepo FeedRepo, itemRepo ItemRepo, classificationRepo ClassificationRepo,
+	settingRepo SettingRepo, muteRuleRepo MuteRuleRepo, jobRepo JobRepo) *RepositoryAdapter {
 	return &RepositoryAdapter{
 		feedRepo:           feedRepo,
 		itemRepo:           itemRepo,
 		classificationRepo: classificationRepo,
 		settingRepo:        settingRepo,
+		muteRuleRepo:       muteRuleRepo,
+		jobRepo:            jobRepo,
 	}
 }
 
@@ -124,6 +156,7 @@ func (r *RepositoryAdapter) GetClassifiedItemsWithFilters(ctx context.Context, r
 		MinScore:      req.MinScore,
 		Topic:         req.Topic,
 		FeedName:      req.FeedName,
+		Folder:        req.Folder,
 		SortBy:        req.SortBy,
 		Limit:         req.Limit,
 		Offset:        offset,
@@ -139,9 +172,8 @@ func (r *RepositoryAdapter) GetClassifiedItemsWithFilters(ctx context.Context, r
 	// convert to domain.ClassifiedItem and handle feed name
 	result := make([]domain.ClassifiedItem, 0, len(items))
 	for _, item := range items {
-		classified := *item
-		classified.FeedName = getFeedDisplayName(item.FeedName, item.FeedURL)
-		result = append(result, classified)
+		setFeedDisplayNames(item)
+		result = append(result, *item)
 	}
 
 	return result, nil
@@ -153,6 +185,7 @@ func (r *RepositoryAdapter) GetClassifiedItemsCount(ctx context.Context, req dom
 		MinScore:      req.MinScore,
 		Topic:         req.Topic,
 		FeedName:      req.FeedName,
+		Folder:        req.Folder,
 		SortBy:        req.SortBy,
 		Limit:         req.Limit,
 		ShowLikedOnly: req.ShowLikedOnly,
@@ -177,8 +210,7 @@ func (r *RepositoryAdapter) GetClassifiedItem(ctx context.Context, itemID int64)
 		return nil, err
 	}
 
-	// handle feed name
-	item.FeedName = getFeedDisplayName(item.FeedName, item.FeedURL)
+	setFeedDisplayNames(item)
 	return item, nil
 }
 
@@ -236,19 +268,59 @@ func (r *RepositoryAdapter) UpdateFeed(ctx context.Context, feedID int64, title
 	return r.feedRepo.UpdateFeed(ctx, feedID, title, fetchInterval)
 }
 
+// UpdateFeedSelectors updates scraping selectors of a web page feed
+func (r *RepositoryAdapter) UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+	return r.feedRepo.UpdateFeedSelectors(ctx, feedID, selectors)
+}
+
+// UpdateFeedFolder moves a feed to the folder
+func (r *RepositoryAdapter) UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error {
+	return r.feedRepo.UpdateFeedFolder(ctx, feedID, folder)
+}
+
+// UpdateFeedAuth replaces credentials of a private feed
+func (r *RepositoryAdapter) UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+	return r.feedRepo.UpdateFeedAuth(ctx, feedID, auth)
+}
+
+// UpdateFeedProxy sets the proxy used to fetch a feed
+func (r *RepositoryAdapter) UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error {
+	return r.feedRepo.UpdateFeedProxy(ctx, feedID, proxy)
+}
+
 // UpdateFeedStatus enables or disables a feed
 func (r *RepositoryAdapter) UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error {
 	return r.feedRepo.UpdateFeedStatus(ctx, feedID, enabled)
 }
 
+// RetryFeed re-enables a broken feed and resets its errors
+func (r *RepositoryAdapter) RetryFeed(ctx context.Context, feedID int64) error {
+	return r.feedRepo.RetryFeed(ctx, feedID)
+}
+
 // DeleteFeed removes a feed
 func (r *RepositoryAdapter) DeleteFeed(ctx context.Context, feedID int64) error {
 	return r.feedRepo.DeleteFeed(ctx, feedID)
 }
 
-// GetActiveFeedNames returns names of feeds that have classified articles
-func (r *RepositoryAdapter) GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error) {
-	return r.feedRepo.GetActiveFeedNames(ctx, minScore)
+// GetFeedEvents returns the latest events of a feed, newest first
+func (r *RepositoryAdapter) GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+	return r.feedRepo.GetFeedEvents(ctx, feedID, limit)
+}
+
+// GetFeedFetches returns the latest fetch attempts of each feed, oldest first, keyed by feed ID
+func (r *RepositoryAdapter) GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+	return r.feedRepo.GetFeedFetches(ctx, limit)
+}
+
+// GetActiveFeedNames returns names of feeds that have classified articles, limited to the folder if set
+func (r *RepositoryAdapter) GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error) {
+	return r.feedRepo.GetActiveFeedNames(ctx, minScore, folder)
+}
+
+// GetFolders returns all folder paths in use
+func (r *RepositoryAdapter) GetFolders(ctx context.Context) ([]string, error) {
+	return r.feedRepo.GetFolders(ctx)
 }
 
 // GetSetting retrieves a setting value by key
@@ -261,6 +333,61 @@ func (r *RepositoryAdapter) SetSetting(ctx context.Context, key, value string) e
 	return r.settingRepo.SetSetting(ctx, key, value)
 }
 
+// GetMutedItems returns the most recently stored muted items
+func (r *RepositoryAdapter) GetMutedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+	items, err := r.classificationRepo.GetMutedItems(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ClassifiedItem, len(items))
+	for i, item := range items {
+		setFeedDisplayNames(item)
+		result[i] = *item
+	}
+	return result, nil
+}
+
+// GetFailedItems returns the most recently failed items parked after all processing attempts
+func (r *RepositoryAdapter) GetFailedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error) {
+	items, err := r.classificationRepo.GetFailedItems(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ClassifiedItem, len(items))
+	for i, item := range items {
+		setFeedDisplayNames(item)
+		result[i] = *item
+	}
+	return result, nil
+}
+
+// RetryFailedItem queues the failed item again ahead of the scheduled items, its attempts start over
+func (r *RepositoryAdapter) RetryFailedItem(ctx context.Context, itemID int64) error {
+	return r.jobRepo.EnqueueJob(ctx, itemID, domain.PriorityManual)
+}
+
+// GetQueueStats returns the number of jobs in the processing queue by state
+func (r *RepositoryAdapter) GetQueueStats(ctx context.Context) (domain.QueueStats, error) {
+	return r.jobRepo.GetQueueStats(ctx)
+}
+
+// CreateMuteRule stores a new mute rule
+func (r *RepositoryAdapter) CreateMuteRule(ctx context.Context, rule *domain.MuteRule) error {
+	return r.muteRuleRepo.CreateMuteRule(ctx, rule)
+}
+
+// GetMuteRules returns all mute rules
+func (r *RepositoryAdapter) GetMuteRules(ctx context.Context) ([]domain.MuteRule, error) {
+	return r.muteRuleRepo.GetMuteRules(ctx)
+}
+
+// DeleteMuteRule removes a mute rule
+func (r *RepositoryAdapter) DeleteMuteRule(ctx context.Context, id int64) error {
+	return r.muteRuleRepo.DeleteMuteRule(ctx, id)
+}
+
 // SearchItems searches for items using full-text search
 func (r *RepositoryAdapter) SearchItems(ctx context.Context, searchQuery string, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error) {
 	// calculate offset from page number
@@ -273,6 +400,7 @@ func (r *RepositoryAdapter) SearchItems(ctx context.Context, searchQuery string,
 		MinScore:      req.MinScore,
 		Topic:         req.Topic,
 		FeedName:      req.FeedName,
+		Folder:        req.Folder,
 		SortBy:        req.SortBy,
 		Limit:         req.Limit,
 		Offset:        offset,
@@ -288,9 +416,8 @@ func (r *RepositoryAdapter) SearchItems(ctx context.Context, searchQuery string,
 	// convert to domain.ClassifiedItem and handle feed name
 	result := make([]domain.ClassifiedItem, 0, len(items))
 	for _, item := range items {
-		classified := *item
-		classified.FeedName = getFeedDisplayName(item.FeedName, item.FeedURL)
-		result = append(result, classified)
+		setFeedDisplayNames(item)
+		result = append(result, *item)
 	}
 
 	return result, nil
@@ -302,6 +429,7 @@ func (r *RepositoryAdapter) GetSearchItemsCount(ctx context.Context, searchQuery
 		MinScore:      req.MinScore,
 		Topic:         req.Topic,
 		FeedName:      req.FeedName,
+		Folder:        req.Folder,
 		SortBy:        req.SortBy,
 		Limit:         req.Limit,
 		ShowLikedOnly: req.ShowLikedOnly,
@@ -310,6 +438,14 @@ func (r *RepositoryAdapter) GetSearchItemsCount(ctx context.Context, searchQuery
 	return r.classificationRepo.GetSearchItemsCount(ctx, searchQuery, filter)
 }
 
+// setFeedDisplayNames sets display names of the item's feed and feeds of its near-duplicates
+func setFeedDisplayNames(item *domain.ClassifiedItem) {
+	item.FeedName = getFeedDisplayName(item.FeedName, item.FeedURL)
+	for i := range item.Duplicates {
+		item.Duplicates[i].FeedName = getFeedDisplayName(item.Duplicates[i].FeedName, item.Duplicates[i].FeedURL)
+	}
+}
+
 // getFeedDisplayName returns the feed title if available, otherwise extracts hostname from URL
 func getFeedDisplayName(title, feedURL string) string {
 	if title != "" {
@@ -19,14 +19,18 @@ func TestNewRepositoryAdapterWithInterfaces(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 	settingRepo := &mocks.SettingRepoMock{}
+	muteRuleRepo := &mocks.MuteRuleRepoMock{}
+	jobRepo := &mocks.JobRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, settingRepo)
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, settingRepo, muteRuleRepo, jobRepo)
 
 	assert.NotNil(t, adapter)
 	assert.Equal(t, feedRepo, adapter.feedRepo)
 	assert.Equal(t, itemRepo, adapter.itemRepo)
 	assert.Equal(t, classificationRepo, adapter.classificationRepo)
 	assert.Equal(t, settingRepo, adapter.settingRepo)
+	assert.Equal(t, muteRuleRepo, adapter.muteRuleRepo)
+	assert.Equal(t, jobRepo, adapter.jobRepo)
 }
 
 func TestRepositoryAdapter_GetClassifiedItemsWithFilters_Pagination(t *testing.T) {
@@ -34,7 +38,7 @@ func TestRepositoryAdapter_GetClassifiedItemsWithFilters_Pagination(t *testing.T
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	now := time.Now()
 	classifiedAt := now.Add(-1 * time.Hour)
@@ -144,7 +148,7 @@ func TestRepositoryAdapter_GetClassifiedItemsWithFilters_PassThrough(t *testing.
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	now := time.Now()
 	classifiedAt := now.Add(-1 * time.Hour)
@@ -302,7 +306,7 @@ func TestRepositoryAdapter_GetClassifiedItemsCount(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	t.Run("successful count", func(t *testing.T) {
 		classificationRepo.GetClassifiedItemsCountFunc = func(ctx context.Context, filter *domain.ItemFilter) (int, error) {
@@ -342,7 +346,7 @@ func TestRepositoryAdapter_UpdateItemFeedback(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	t.Run("successful feedback update", func(t *testing.T) {
 		classificationRepo.UpdateItemFeedbackFunc = func(ctx context.Context, itemID int64, feedback *domain.Feedback) error {
@@ -386,7 +390,7 @@ func TestRepositoryAdapter_GetClassifiedItem(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	now := time.Now()
 	classifiedAt := now.Add(-2 * time.Hour)
@@ -403,6 +407,10 @@ func TestRepositoryAdapter_GetClassifiedItem(t *testing.T) {
 			},
 			FeedName: "",
 			FeedURL:  "https://example.com/feed",
+			Duplicates: []domain.Duplicate{
+				{ItemID: 790, Link: "https://other.com/single", FeedName: "Other", FeedURL: "https://other.com/feed"},
+				{ItemID: 791, Link: "https://www.third.com/single", FeedURL: "https://www.third.com/rss"},
+			},
 			Classification: &domain.Classification{
 				Score:        9.0,
 				Explanation:  "Highly relevant",
@@ -421,6 +429,9 @@ func TestRepositoryAdapter_GetClassifiedItem(t *testing.T) {
 		require.NotNil(t, item)
 		assert.Equal(t, int64(789), item.ID)
 		assert.Equal(t, "example.com", item.FeedName) // URL hostname extraction
+		require.Len(t, item.Duplicates, 2)
+		assert.Equal(t, "Other", item.Duplicates[0].FeedName)
+		assert.Equal(t, "third.com", item.Duplicates[1].FeedName)
 		assert.InDelta(t, 9.0, item.GetRelevanceScore(), 0.01)
 		assert.Equal(t, &classifiedAt, item.GetClassifiedAt())
 	})
@@ -443,7 +454,7 @@ func TestRepositoryAdapter_ErrorPropagation(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	testError := errors.New("repository error")
 
@@ -504,7 +515,7 @@ func TestRepositoryAdapter_FeedOperations(t *testing.T) {
 	itemRepo := &mocks.ItemRepoMock{}
 	classificationRepo := &mocks.ClassificationRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{})
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, &mocks.SettingRepoMock{}, nil, nil)
 
 	t.Run("GetAllFeeds", func(t *testing.T) {
 		expectedFeeds := []domain.Feed{
@@ -568,15 +579,80 @@ func TestRepositoryAdapter_FeedOperations(t *testing.T) {
 	t.Run("GetActiveFeedNames", func(t *testing.T) {
 		expectedNames := []string{"Active Feed 1", "Active Feed 2"}
 
-		feedRepo.GetActiveFeedNamesFunc = func(ctx context.Context, minScore float64) ([]string, error) {
+		feedRepo.GetActiveFeedNamesFunc = func(ctx context.Context, minScore float64, folder string) ([]string, error) {
+			assert.Equal(t, "Tech", folder)
 			return expectedNames, nil
 		}
 
-		names, err := adapter.GetActiveFeedNames(context.Background(), 5.0)
+		names, err := adapter.GetActiveFeedNames(context.Background(), 5.0, "Tech")
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedNames, names)
 	})
+
+	t.Run("Folders", func(t *testing.T) {
+		feedRepo.GetFoldersFunc = func(ctx context.Context) ([]string, error) {
+			return []string{"Tech", "Tech/Go"}, nil
+		}
+		feedRepo.UpdateFeedFolderFunc = func(ctx context.Context, feedID int64, folder string) error {
+			return nil
+		}
+
+		folders, err := adapter.GetFolders(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tech", "Tech/Go"}, folders)
+
+		require.NoError(t, adapter.UpdateFeedFolder(context.Background(), 7, "Tech/Go"))
+		require.Len(t, feedRepo.UpdateFeedFolderCalls(), 1)
+		assert.Equal(t, int64(7), feedRepo.UpdateFeedFolderCalls()[0].FeedID)
+		assert.Equal(t, "Tech/Go", feedRepo.UpdateFeedFolderCalls()[0].Folder)
+	})
+
+	t.Run("UpdateFeedAuth", func(t *testing.T) {
+		feedRepo.UpdateFeedAuthFunc = func(ctx context.Context, feedID int64, auth domain.FeedAuth) error {
+			return nil
+		}
+
+		auth := domain.FeedAuth{Token: "secret"}
+		require.NoError(t, adapter.UpdateFeedAuth(context.Background(), 7, auth))
+		require.Len(t, feedRepo.UpdateFeedAuthCalls(), 1)
+		assert.Equal(t, int64(7), feedRepo.UpdateFeedAuthCalls()[0].FeedID)
+		assert.Equal(t, auth, feedRepo.UpdateFeedAuthCalls()[0].Auth)
+	})
+
+	t.Run("UpdateFeedProxy", func(t *testing.T) {
+		feedRepo.UpdateFeedProxyFunc = func(ctx context.Context, feedID int64, proxy string) error {
+			return nil
+		}
+
+		require.NoError(t, adapter.UpdateFeedProxy(context.Background(), 7, "direct"))
+		require.Len(t, feedRepo.UpdateFeedProxyCalls(), 1)
+		assert.Equal(t, "direct", feedRepo.UpdateFeedProxyCalls()[0].Proxy)
+	})
+
+	t.Run("GetFeedEvents", func(t *testing.T) {
+		feedRepo.GetFeedEventsFunc = func(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error) {
+			return []domain.FeedEvent{{ID: 1, FeedID: feedID, Type: domain.FeedEventMoved}}, nil
+		}
+
+		events, err := adapter.GetFeedEvents(context.Background(), 7, 20)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.FeedEventMoved, events[0].Type)
+		assert.Equal(t, 20, feedRepo.GetFeedEventsCalls()[0].Limit)
+	})
+
+	t.Run("GetFeedFetches", func(t *testing.T) {
+		feedRepo.GetFeedFetchesFunc = func(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error) {
+			return map[int64][]domain.FeedFetch{7: {{FeedID: 7, StatusCode: 200}}}, nil
+		}
+
+		fetches, err := adapter.GetFeedFetches(context.Background(), 30)
+		require.NoError(t, err)
+		require.Len(t, fetches[7], 1)
+		assert.Equal(t, 200, fetches[7][0].StatusCode)
+		assert.Equal(t, 30, feedRepo.GetFeedFetchesCalls()[0].Limit)
+	})
 }
 
 func TestRepositoryAdapter_Settings(t *testing.T) {
@@ -585,7 +661,7 @@ func TestRepositoryAdapter_Settings(t *testing.T) {
 	classificationRepo := &mocks.ClassificationRepoMock{}
 	settingRepo := &mocks.SettingRepoMock{}
 
-	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, settingRepo)
+	adapter := NewRepositoryAdapterWithInterfaces(feedRepo, itemRepo, classificationRepo, settingRepo, nil, nil)
 
 	t.Run("GetSetting", func(t *testing.T) {
 		settingRepo.GetSettingFunc = func(ctx context.Context, key string) (string, error) {
@@ -658,7 +734,7 @@ func TestRepositoryAdapter_GetClassifiedItems(t *testing.T) {
 		},
 	}
 
-	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil)
+	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil, nil, nil)
 
 	items, err := adapter.GetClassifiedItems(context.Background(), 7.5, "technology", 50)
 	require.NoError(t, err)
@@ -668,7 +744,7 @@ func TestRepositoryAdapter_GetClassifiedItems(t *testing.T) {
 
 func TestRepositoryAdapter_GetTopTopicsByScore(t *testing.T) {
 	classificationRepo := &mocks.ClassificationRepoMock{}
-	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil)
+	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil, nil, nil)
 
 	t.Run("successful get top topics", func(t *testing.T) {
 		repoTopics := []repository.TopicWithScore{
@@ -728,7 +804,7 @@ func TestRepositoryAdapter_GetTopTopicsByScore(t *testing.T) {
 
 func TestRepositoryAdapter_GetFeedbackCount(t *testing.T) {
 	classificationRepo := &mocks.ClassificationRepoMock{}
-	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil)
+	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil, nil, nil)
 
 	t.Run("successful get feedback count", func(t *testing.T) {
 		classificationRepo.GetFeedbackCountFunc = func(ctx context.Context) (int64, error) {
@@ -763,3 +839,68 @@ func TestRepositoryAdapter_GetFeedbackCount(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 }
+
+func TestRepositoryAdapter_MuteRules(t *testing.T) {
+	classificationRepo := &mocks.ClassificationRepoMock{
+		GetMutedItemsFunc: func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+			return []*domain.ClassifiedItem{{Item: &domain.Item{ID: 1, MutedBy: `keyword "ad"`}, FeedURL: "https://www.example.com/feed"}}, nil
+		},
+	}
+	muteRuleRepo := &mocks.MuteRuleRepoMock{
+		CreateMuteRuleFunc: func(ctx context.Context, rule *domain.MuteRule) error { rule.ID = 3; return nil },
+		GetMuteRulesFunc: func(ctx context.Context) ([]domain.MuteRule, error) {
+			return []domain.MuteRule{{ID: 3, Kind: domain.MuteAuthor, Pattern: "Ad Team"}}, nil
+		},
+		DeleteMuteRuleFunc: func(ctx context.Context, id int64) error { return nil },
+	}
+	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil, muteRuleRepo, nil)
+	ctx := context.Background()
+
+	items, err := adapter.GetMutedItems(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "example.com", items[0].FeedName, "feed name from the URL")
+	assert.Equal(t, 10, classificationRepo.GetMutedItemsCalls()[0].Limit)
+
+	rule := &domain.MuteRule{Kind: domain.MuteAuthor, Pattern: "Ad Team"}
+	require.NoError(t, adapter.CreateMuteRule(ctx, rule))
+	assert.Equal(t, int64(3), rule.ID)
+
+	rules, err := adapter.GetMuteRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+
+	require.NoError(t, adapter.DeleteMuteRule(ctx, 3))
+	assert.Equal(t, int64(3), muteRuleRepo.DeleteMuteRuleCalls()[0].ID)
+}
+
+func TestRepositoryAdapter_FailedItems(t *testing.T) {
+	classificationRepo := &mocks.ClassificationRepoMock{
+		GetFailedItemsFunc: func(ctx context.Context, limit int) ([]*domain.ClassifiedItem, error) {
+			return []*domain.ClassifiedItem{{Item: &domain.Item{ID: 1, ProcessError: "gave up"}, FeedURL: "https://www.example.com/feed"}}, nil
+		},
+	}
+	jobRepo := &mocks.JobRepoMock{
+		EnqueueJobFunc: func(ctx context.Context, itemID int64, priority int) error { return nil },
+		GetQueueStatsFunc: func(ctx context.Context) (domain.QueueStats, error) {
+			return domain.QueueStats{Pending: 3, Failed: 1}, nil
+		},
+	}
+	adapter := NewRepositoryAdapterWithInterfaces(nil, nil, classificationRepo, nil, nil, jobRepo)
+	ctx := context.Background()
+
+	items, err := adapter.GetFailedItems(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "example.com", items[0].FeedName, "feed name from the URL")
+	assert.Equal(t, 10, classificationRepo.GetFailedItemsCalls()[0].Limit)
+
+	require.NoError(t, adapter.RetryFailedItem(ctx, 1))
+	require.Len(t, jobRepo.EnqueueJobCalls(), 1)
+	assert.Equal(t, int64(1), jobRepo.EnqueueJobCalls()[0].ItemID)
+	assert.Equal(t, domain.PriorityManual, jobRepo.EnqueueJobCalls()[0].Priority, "retry jumps the queue")
+
+	stats, err := adapter.GetQueueStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QueueStats{Pending: 3, Failed: 1}, stats)
+}
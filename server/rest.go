@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/httpclient"
 )
 
 // statusHandler returns server status
@@ -112,6 +113,23 @@ func (s *Server) extractHandler(w http.ResponseWriter, r *http.Request) {
 	s.renderArticleCard(w, article)
 }
 
+// retryItemHandler returns an item parked as failed to processing with its attempts reset.
+// the item is queued ahead of the scheduled items, the response removes it from the failed list.
+func (s *Server) retryItemHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		renderError(w, r, fmt.Errorf("invalid item ID"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.db.RetryFailedItem(r.Context(), id); err != nil {
+		log.Printf("[ERROR] failed to retry item %d: %v", id, err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
 // createFeedHandler handles feed creation
 func (s *Server) createFeedHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -140,10 +158,26 @@ func (s *Server) createFeedHandler(w http.ResponseWriter, r *http.Request) {
 	feed := &domain.Feed{
 		URL:           url,
 		Title:         r.FormValue("title"),
+		Folder:        domain.CleanFolder(r.FormValue("folder")),
 		FetchInterval: fetchInterval,
 		Enabled:       true,
 	}
 
+	switch domain.FeedType(r.FormValue("feed_type")) {
+	case "", domain.FeedTypeRSS:
+	case domain.FeedTypeHTML: // web page without a feed, scraped with CSS selectors
+		if feed.Selectors, err = selectorsFromForm(r); err != nil {
+			renderError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		feed.Type = domain.FeedTypeHTML
+	case domain.FeedTypeSitemap:
+		feed.Type = domain.FeedTypeSitemap
+	default:
+		renderError(w, r, fmt.Errorf("unknown feed type %q", r.FormValue("feed_type")), http.StatusBadRequest)
+		return
+	}
+
 	// create feed in database
 	if err := s.db.CreateFeed(ctx, feed); err != nil {
 		log.Printf("[ERROR] failed to create feed: %v", err)
@@ -162,7 +196,84 @@ func (s *Server) createFeedHandler(w http.ResponseWriter, r *http.Request) {
 	s.renderFeedCard(w, feed)
 }
 
-// updateFeedHandler updates feed title and interval
+// discoverFeedsHandler finds feeds for the URL entered in the add feed form and renders
+// the candidates to pick from. nothing is created here, the chosen candidate is posted to createFeedHandler.
+func (s *Server) discoverFeedsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, fmt.Errorf("invalid form data"), http.StatusBadRequest)
+		return
+	}
+
+	pageURL := strings.TrimSpace(r.FormValue("url"))
+	if pageURL == "" {
+		renderError(w, r, fmt.Errorf("feed URL is required"), http.StatusBadRequest)
+		return
+	}
+
+	data := struct {
+		URL           string
+		Title         string
+		FetchInterval string
+		Folder        string
+		Candidates    []domain.FeedCandidate
+		Error         string
+	}{
+		URL:           pageURL,
+		Title:         r.FormValue("title"),
+		FetchInterval: r.FormValue("fetch_interval"),
+		Folder:        r.FormValue("folder"),
+	}
+
+	candidates, err := s.scheduler.DiscoverFeeds(r.Context(), pageURL)
+	if err != nil {
+		log.Printf("[WARN] failed to discover feeds: %v", err)
+		data.Error = err.Error()
+	}
+	data.Candidates = candidates
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "feed-candidates.html", data); err != nil {
+		log.Printf("[ERROR] failed to render feed candidates: %v", err)
+	}
+}
+
+// previewFeedHandler renders the latest items of a feed with projected scores, nothing is stored
+func (s *Server) previewFeedHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, fmt.Errorf("invalid form data"), http.StatusBadRequest)
+		return
+	}
+
+	feedURL := strings.TrimSpace(r.FormValue("url"))
+	if feedURL == "" {
+		renderError(w, r, fmt.Errorf("feed URL is required"), http.StatusBadRequest)
+		return
+	}
+
+	data := struct {
+		URL       string
+		Preview   *domain.FeedPreview
+		Threshold float64
+		Error     string
+	}{
+		URL:       feedURL,
+		Threshold: defaultMinScore, // same default threshold as RSS output
+	}
+
+	preview, err := s.scheduler.PreviewFeed(r.Context(), feedURL)
+	if err != nil {
+		log.Printf("[WARN] failed to preview feed: %v", err)
+		data.Error = err.Error()
+	}
+	data.Preview = preview
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "feed-preview.html", data); err != nil {
+		log.Printf("[ERROR] failed to render feed preview: %v", err)
+	}
+}
+
+// updateFeedHandler updates feed title, interval, folder and, for web page feeds, selectors
 func (s *Server) updateFeedHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -190,12 +301,80 @@ func (s *Server) updateFeedHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// selectors are submitted for web page feeds only
+	var selectors *domain.ScrapeSelectors
+	if r.Form.Has("selector_item") {
+		sel, err := selectorsFromForm(r)
+		if err != nil {
+			renderError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		selectors = &sel
+	}
+
+	// credentials and proxy are submitted by the edit form of feeds fetched over http,
+	// secrets in them are not sent to the browser and are merged with the stored ones
+	var auth *domain.FeedAuth
+	var proxy *string
+	if r.Form.Has("auth_username") || r.Form.Has("proxy") {
+		stored, err := s.storedFeed(ctx, id)
+		if err != nil {
+			log.Printf("[ERROR] failed to get feed: %v", err)
+			renderError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		if r.Form.Has("auth_username") {
+			a, err := authFromForm(r, stored.Auth)
+			if err != nil {
+				renderError(w, r, err, http.StatusBadRequest)
+				return
+			}
+			auth = &a
+		}
+		if r.Form.Has("proxy") {
+			p, err := proxyFromForm(r, stored.Proxy)
+			if err != nil {
+				renderError(w, r, err, http.StatusBadRequest)
+				return
+			}
+			proxy = &p
+		}
+	}
+
 	// update feed
 	if err := s.db.UpdateFeed(ctx, id, title, fetchInterval); err != nil {
 		log.Printf("[ERROR] failed to update feed: %v", err)
 		renderError(w, r, err, http.StatusInternalServerError)
 		return
 	}
+	if selectors != nil {
+		if err := s.db.UpdateFeedSelectors(ctx, id, *selectors); err != nil {
+			log.Printf("[ERROR] failed to update feed selectors: %v", err)
+			renderError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	if r.Form.Has("folder") {
+		if err := s.db.UpdateFeedFolder(ctx, id, domain.CleanFolder(r.FormValue("folder"))); err != nil {
+			log.Printf("[ERROR] failed to update feed folder: %v", err)
+			renderError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	if auth != nil {
+		if err := s.db.UpdateFeedAuth(ctx, id, *auth); err != nil {
+			log.Printf("[ERROR] failed to update feed credentials: %v", err)
+			renderError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
+	if proxy != nil {
+		if err := s.db.UpdateFeedProxy(ctx, id, *proxy); err != nil {
+			log.Printf("[ERROR] failed to update feed proxy: %v", err)
+			renderError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+	}
 
 	// get updated feed
 	feeds, err := s.db.GetAllFeeds(ctx)
@@ -215,6 +394,82 @@ func (s *Server) updateFeedHandler(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Feed not found", http.StatusNotFound)
 }
 
+// storedFeed returns the feed as stored, before the update
+func (s *Server) storedFeed(ctx context.Context, feedID int64) (*domain.Feed, error) {
+	feeds, err := s.db.GetAllFeeds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get feeds: %w", err)
+	}
+	for i := range feeds {
+		if feeds[i].ID == feedID {
+			return &feeds[i], nil
+		}
+	}
+	return nil, fmt.Errorf("feed %d not found", feedID)
+}
+
+// proxyFromForm reads and checks the feed proxy from the submitted form.
+// the proxy is shown with its password redacted, so the redacted stored proxy keeps it unchanged.
+func proxyFromForm(r *http.Request, stored string) (string, error) {
+	proxy := strings.TrimSpace(r.FormValue("proxy"))
+	switch {
+	case proxy == "" || proxy == httpclient.Direct:
+		return proxy, nil
+	case proxy == redactURL(stored):
+		return stored, nil
+	}
+	if _, err := httpclient.ParseProxy(proxy); err != nil {
+		return "", err
+	}
+	return proxy, nil
+}
+
+// authFromForm reads feed credentials from the submitted form. secrets are never sent to the browser,
+// so blank password, token and cookies keep the stored values. headers are "Name: value" lines,
+// the masked value keeps the stored one. the clear checkbox removes all credentials.
+func authFromForm(r *http.Request, stored domain.FeedAuth) (domain.FeedAuth, error) {
+	if r.FormValue("auth_clear") != "" {
+		return domain.FeedAuth{}, nil
+	}
+	keep := func(value, storedValue string) string {
+		if value == "" {
+			return storedValue
+		}
+		return value
+	}
+
+	auth := domain.FeedAuth{
+		Username: strings.TrimSpace(r.FormValue("auth_username")),
+		Token:    keep(strings.TrimSpace(r.FormValue("auth_token")), stored.Token),
+		Cookies:  keep(strings.TrimSpace(r.FormValue("auth_cookies")), stored.Cookies),
+	}
+	if auth.Username != "" {
+		auth.Password = keep(r.FormValue("auth_password"), stored.Password)
+	}
+
+	for _, line := range strings.Split(r.FormValue("auth_headers"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			// the line may hold a secret, so it is not quoted in the error
+			return domain.FeedAuth{}, fmt.Errorf("invalid header line, expected \"Name: value\"")
+		}
+		name = http.CanonicalHeaderKey(name)
+		if value == domain.SecretMask {
+			value = stored.Headers[name]
+		}
+		if auth.Headers == nil {
+			auth.Headers = make(map[string]string)
+		}
+		auth.Headers[name] = value
+	}
+	return auth, nil
+}
+
 // enableFeedHandler enables a feed
 func (s *Server) enableFeedHandler(w http.ResponseWriter, r *http.Request) {
 	s.updateFeedStatus(w, r, true)
@@ -243,6 +498,13 @@ func (s *Server) updateFeedStatus(w http.ResponseWriter, r *http.Request, enable
 		return
 	}
 
+	// a disabled feed doesn't need pushes, enabling subscribes again on the next fetch
+	if !enabled {
+		if err := s.scheduler.UnsubscribeWebSub(ctx, id); err != nil {
+			log.Printf("[WARN] failed to unsubscribe disabled feed %d: %v", id, err)
+		}
+	}
+
 	// get updated feed
 	feeds, err := s.db.GetAllFeeds(ctx)
 	if err != nil {
@@ -298,6 +560,48 @@ func (s *Server) fetchFeedHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// retryFeedHandler re-enables a broken feed, resets its errors and fetches it immediately.
+// a failed fetch starts the error count and backoff over.
+func (s *Server) retryFeedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		renderError(w, r, fmt.Errorf("invalid feed ID"), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.db.RetryFeed(ctx, id); err != nil {
+		log.Printf("[ERROR] failed to reset feed for retry: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	// trigger fetch with background context to avoid cancellation when HTTP request completes
+	if err := s.scheduler.UpdateFeedNow(context.Background(), id); err != nil {
+		log.Printf("[ERROR] failed to retry feed: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	// get updated feed to show the retry result
+	feeds, err := s.db.GetAllFeeds(ctx)
+	if err != nil {
+		http.Error(w, "Failed to reload feed", http.StatusInternalServerError)
+		return
+	}
+
+	for _, feed := range feeds {
+		if feed.ID == id {
+			s.renderFeedCard(w, &feed)
+			return
+		}
+	}
+
+	http.Error(w, "Feed not found", http.StatusNotFound)
+}
+
 // deleteFeedHandler deletes a feed
 func (s *Server) deleteFeedHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -309,6 +613,11 @@ func (s *Server) deleteFeedHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the subscription state is gone with the feed, so the hub is told first
+	if err := s.scheduler.UnsubscribeWebSub(ctx, id); err != nil {
+		log.Printf("[WARN] failed to unsubscribe deleted feed %d: %v", id, err)
+	}
+
 	// delete feed
 	if err := s.db.DeleteFeed(ctx, id); err != nil {
 		log.Printf("[ERROR] failed to delete feed: %v", err)
@@ -320,6 +629,28 @@ func (s *Server) deleteFeedHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// feedEventsHandler renders the history of feed events, such as moves and disabling of gone feeds
+func (s *Server) feedEventsHandler(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		renderError(w, r, fmt.Errorf("invalid feed ID"), http.StatusBadRequest)
+		return
+	}
+
+	events, err := s.db.GetFeedEvents(r.Context(), id, feedEventsLimit)
+	if err != nil {
+		log.Printf("[ERROR] failed to get feed events: %v", err)
+		renderError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "feed-events.html", events); err != nil {
+		log.Printf("[ERROR] failed to render feed events: %v", err)
+	}
+}
+
 // rssBuilderHandler handles HTMX requests for RSS URL building
 func (s *Server) rssBuilderHandler(w http.ResponseWriter, r *http.Request) {
 	topic := r.URL.Query().Get("topic")
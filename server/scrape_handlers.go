@@ -0,0 +1,61 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/pkg/feed"
+)
+
+// selectorsFromForm reads and validates scraping selectors of a web page feed from the submitted form
+func selectorsFromForm(r *http.Request) (domain.ScrapeSelectors, error) {
+	sel := domain.ScrapeSelectors{
+		Item:    strings.TrimSpace(r.FormValue("selector_item")),
+		Title:   strings.TrimSpace(r.FormValue("selector_title")),
+		Link:    strings.TrimSpace(r.FormValue("selector_link")),
+		Date:    strings.TrimSpace(r.FormValue("selector_date")),
+		Summary: strings.TrimSpace(r.FormValue("selector_summary")),
+	}
+	if err := feed.ValidateSelectors(sel); err != nil {
+		return domain.ScrapeSelectors{}, err
+	}
+	return sel, nil
+}
+
+// scrapePageHandler tries the selectors from the web page feed form against the live page
+// and renders the items found, nothing is stored
+func (s *Server) scrapePageHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		renderError(w, r, fmt.Errorf("invalid form data"), http.StatusBadRequest)
+		return
+	}
+
+	pageURL := strings.TrimSpace(r.FormValue("url"))
+	if pageURL == "" {
+		renderError(w, r, fmt.Errorf("page URL is required"), http.StatusBadRequest)
+		return
+	}
+
+	data := struct {
+		URL   string
+		Feed  *domain.ParsedFeed
+		Error string
+	}{URL: pageURL}
+
+	sel, err := selectorsFromForm(r)
+	if err == nil {
+		data.Feed, err = s.scheduler.ScrapePage(r.Context(), pageURL, sel)
+	}
+	if err != nil {
+		log.Printf("[WARN] failed to scrape page: %v", err)
+		data.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates.ExecuteTemplate(w, "scrape-result.html", data); err != nil {
+		log.Printf("[ERROR] failed to render scrape result: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/server/mocks"
+)
+
+func TestServer_ScrapePageHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+	scrape := func(srv *Server, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/feeds/scrape", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		return w
+	}
+	form := url.Values{
+		"url":            {"https://example.com/news"},
+		"selector_item":  {"article"},
+		"selector_title": {" h2 "},
+		"selector_date":  {"time"},
+	}
+
+	t.Run("renders matched items", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			ScrapePageFunc: func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+				assert.Equal(t, "https://example.com/news", pageURL)
+				assert.Equal(t, domain.ScrapeSelectors{Item: "article", Title: "h2", Date: "time"}, sel)
+				return &domain.ParsedFeed{Title: "Example News", Items: []domain.ParsedItem{
+					{Title: "First post", Link: "https://example.com/1", Published: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+					{Title: "Second post", Description: "short summary"},
+				}}, nil
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		w := scrape(srv, form)
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, "Selectors match 2 items on &ldquo;Example News&rdquo;")
+		assert.Contains(t, body, `href="https://example.com/1"`)
+		assert.Contains(t, body, "Mar 1, 2024 10:00")
+		assert.Contains(t, body, "(no link)")
+		assert.Contains(t, body, "no date")
+		assert.Contains(t, body, "short summary")
+	})
+
+	t.Run("scrape error", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			ScrapePageFunc: func(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error) {
+				return nil, errors.New(`no items found with selector "article"`)
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		w := scrape(srv, form)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "no items found with selector &#34;article&#34;")
+	})
+
+	t.Run("invalid selectors are not scraped", func(t *testing.T) {
+		srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := scrape(srv, url.Values{"url": {"https://example.com/news"}, "selector_item": {"article"}})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "title selector is required")
+	})
+
+	t.Run("missing url", func(t *testing.T) {
+		srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+		w := scrape(srv, url.Values{"selector_item": {"article"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServer_CreateFeedHandler_WebPage(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+
+	t.Run("creates scraped feed", func(t *testing.T) {
+		database := &mocks.DatabaseMock{
+			CreateFeedFunc: func(ctx context.Context, feed *domain.Feed) error {
+				feed.ID = 5
+				return nil
+			},
+		}
+		updated := make(chan int64, 1)
+		scheduler := &mocks.SchedulerMock{
+			UpdateFeedNowFunc: func(ctx context.Context, feedID int64) error {
+				updated <- feedID
+				return nil
+			},
+		}
+		srv := New(cfg, database, scheduler, "1.0.0", false)
+
+		form := url.Values{"url": {"https://example.com/news"}, "title": {"News"}, "fetch_interval": {"60"},
+			"feed_type": {"html"}, "selector_item": {"article"}, "selector_title": {"h2"}, "selector_summary": {"p"}}
+		req := httptest.NewRequest("POST", "/api/v1/feeds", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		srv.createFeedHandler(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, database.CreateFeedCalls(), 1)
+		feed := database.CreateFeedCalls()[0].Feed
+		assert.Equal(t, domain.FeedTypeHTML, feed.Type)
+		assert.Equal(t, domain.ScrapeSelectors{Item: "article", Title: "h2", Summary: "p"}, feed.Selectors)
+		assert.Equal(t, time.Hour, feed.FetchInterval)
+		assert.Contains(t, w.Body.String(), "Web page")
+		assert.Equal(t, int64(5), <-updated)
+	})
+
+	t.Run("invalid selectors", func(t *testing.T) {
+		database := &mocks.DatabaseMock{} // nothing must be created
+		srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+
+		form := url.Values{"url": {"https://example.com/news"}, "feed_type": {"html"},
+			"selector_item": {"div["}, "selector_title": {"h2"}}
+		req := httptest.NewRequest("POST", "/api/v1/feeds", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		srv.createFeedHandler(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid item selector")
+	})
+}
+
+func TestServer_UpdateFeedHandler_Selectors(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+	sel := domain.ScrapeSelectors{Item: ".post", Title: ".post-title", Link: "a.more"}
+	database := &mocks.DatabaseMock{
+		UpdateFeedFunc: func(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error {
+			return nil
+		},
+		UpdateFeedSelectorsFunc: func(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error {
+			return nil
+		},
+		GetAllFeedsFunc: func(ctx context.Context) ([]domain.Feed, error) {
+			return []domain.Feed{{ID: 3, Type: domain.FeedTypeHTML, URL: "https://example.com/news", Title: "News",
+				FetchInterval: time.Hour, Enabled: true, Selectors: sel}}, nil
+		},
+	}
+	srv := New(cfg, database, &mocks.SchedulerMock{}, "1.0.0", false)
+
+	form := url.Values{"title": {"News"}, "fetch_interval": {"60"}, "url": {"https://example.com/news"},
+		"selector_item": {".post"}, "selector_title": {".post-title"}, "selector_link": {"a.more"}}
+	req := httptest.NewRequest("PUT", "/api/v1/feeds/3", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	srv.updateFeedHandler(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, database.UpdateFeedSelectorsCalls(), 1)
+	assert.Equal(t, int64(3), database.UpdateFeedSelectorsCalls()[0].FeedID)
+	assert.Equal(t, sel, database.UpdateFeedSelectorsCalls()[0].Selectors)
+	assert.Contains(t, w.Body.String(), `name="selector_link" value="a.more"`)
+	assert.Contains(t, w.Body.String(), `hx-target="#scrape-result-3"`)
+}
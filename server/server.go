@@ -9,6 +9,7 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -32,6 +33,15 @@ const (
 	defaultMinScore = 5.0
 	defaultRSSLimit = 100
 	defaultBaseURL  = "http://localhost:8080"
+
+	// feedEventsLimit is the number of feed events shown in the feed history
+	feedEventsLimit = 20
+
+	// mutedItemsLimit is the number of recently muted items shown with the mute rules
+	mutedItemsLimit = 50
+
+	// failedItemsLimit is the number of failed items shown on the feeds health view
+	failedItemsLimit = 50
 )
 
 //go:generate moq -out mocks/config.go -pkg mocks -skip-ensure -fmt goimports . ConfigProvider
@@ -68,22 +78,45 @@ type Database interface {
 	GetTopics(ctx context.Context) ([]string, error)
 	GetTopicsFiltered(ctx context.Context, minScore float64) ([]string, error)
 	GetTopTopicsByScore(ctx context.Context, minScore float64, limit int) ([]domain.TopicWithScore, error)
-	GetActiveFeedNames(ctx context.Context, minScore float64) ([]string, error)
+	GetActiveFeedNames(ctx context.Context, minScore float64, folder string) ([]string, error)
+	GetFolders(ctx context.Context) ([]string, error)
 	GetAllFeeds(ctx context.Context) ([]domain.Feed, error)
 	CreateFeed(ctx context.Context, feed *domain.Feed) error
 	UpdateFeed(ctx context.Context, feedID int64, title string, fetchInterval time.Duration) error
+	UpdateFeedSelectors(ctx context.Context, feedID int64, selectors domain.ScrapeSelectors) error
+	UpdateFeedFolder(ctx context.Context, feedID int64, folder string) error
+	UpdateFeedAuth(ctx context.Context, feedID int64, auth domain.FeedAuth) error
+	UpdateFeedProxy(ctx context.Context, feedID int64, proxy string) error
 	UpdateFeedStatus(ctx context.Context, feedID int64, enabled bool) error
+	RetryFeed(ctx context.Context, feedID int64) error
 	DeleteFeed(ctx context.Context, feedID int64) error
+	GetFeedEvents(ctx context.Context, feedID int64, limit int) ([]domain.FeedEvent, error)
+	GetFeedFetches(ctx context.Context, limit int) (map[int64][]domain.FeedFetch, error)
 	GetSetting(ctx context.Context, key string) (string, error)
 	SetSetting(ctx context.Context, key, value string) error
 	SearchItems(ctx context.Context, searchQuery string, req domain.ArticlesRequest) ([]domain.ClassifiedItem, error)
 	GetSearchItemsCount(ctx context.Context, searchQuery string, req domain.ArticlesRequest) (int, error)
+	GetMutedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error)
+	GetFailedItems(ctx context.Context, limit int) ([]domain.ClassifiedItem, error)
+	RetryFailedItem(ctx context.Context, itemID int64) error
+	GetQueueStats(ctx context.Context) (domain.QueueStats, error)
+	CreateMuteRule(ctx context.Context, rule *domain.MuteRule) error
+	GetMuteRules(ctx context.Context) ([]domain.MuteRule, error)
+	DeleteMuteRule(ctx context.Context, id int64) error
 }
 
 // Scheduler interface for on-demand operations
 type Scheduler interface {
 	UpdateFeedNow(ctx context.Context, feedID int64) error
+	DiscoverFeeds(ctx context.Context, pageURL string) ([]domain.FeedCandidate, error)
+	PreviewFeed(ctx context.Context, feedURL string) (*domain.FeedPreview, error)
+	ScrapePage(ctx context.Context, pageURL string, sel domain.ScrapeSelectors) (*domain.ParsedFeed, error)
+	VerifyWebSub(ctx context.Context, feedID int64, mode, topic string, lease time.Duration) error
+	UnsubscribeWebSub(ctx context.Context, feedID int64) error
+	HandleWebSubPush(ctx context.Context, feedID int64, body []byte, signature string) error
 	ExtractContentNow(ctx context.Context, itemID int64) error
+	MuteRulesChanged()
+	PipelineStats() domain.PipelineStats
 	UpdatePreferenceSummary(ctx context.Context) error
 	TriggerPreferenceUpdate()
 }
@@ -132,6 +165,29 @@ func generatePageNumbers(currentPage, totalPages int) []int {
 	return pages
 }
 
+// humanBytes formats a byte count as a short human-readable string, e.g. 1.5 MB
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// redactURL replaces the password of a URL with "xxxxx", other strings are returned as is
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	return u.Redacted()
+}
+
 // New initializes a new server instance
 func New(cfg ConfigProvider, database Database, scheduler Scheduler, version string, debug bool) *Server {
 	// create bluemonday policy for HTML sanitization
@@ -164,8 +220,12 @@ func New(cfg ConfigProvider, database Database, scheduler Scheduler, version str
 		"durationMinutes": func(d time.Duration) int {
 			return int(d.Minutes())
 		},
-		"printf":       fmt.Sprintf,
-		"unescapeHTML": html.UnescapeString,
+		"humanBytes":    humanBytes,
+		"humanDuration": humanDuration,
+		"sparkline":     sparkline,
+		"redactURL":     redactURL,
+		"printf":        fmt.Sprintf,
+		"unescapeHTML":  html.UnescapeString,
 		"safeHTML": func(s string) template.HTML {
 			// fix common content extraction issues before sanitization
 
@@ -210,7 +270,13 @@ func New(cfg ConfigProvider, database Database, scheduler Scheduler, version str
 		"templates/topic-tags.html",
 		"templates/topic-dropdowns.html",
 		"templates/controls.html",
-		"templates/preference-summary.html")
+		"templates/preference-summary.html",
+		"templates/opml-import-report.html",
+		"templates/feed-candidates.html",
+		"templates/feed-preview.html",
+		"templates/scrape-result.html",
+		"templates/feed-events.html",
+		"templates/mute-rules.html")
 	if err != nil {
 		log.Printf("[WARN] failed to parse templates: %v", err)
 	}
@@ -331,19 +397,33 @@ func (s *Server) setupRoutes() {
 		r.HandleFunc("POST /extract/{id}", s.extractHandler)
 		r.HandleFunc("GET /articles/{id}/content", s.articleContentHandler)
 		r.HandleFunc("GET /articles/{id}/hide", s.hideContentHandler)
+		r.HandleFunc("POST /articles/{id}/retry", s.retryItemHandler)
 
 		// feed management
 		r.HandleFunc("POST /feeds", s.createFeedHandler)
+		r.HandleFunc("POST /feeds/discover", s.discoverFeedsHandler)
+		r.HandleFunc("POST /feeds/preview", s.previewFeedHandler)
+		r.HandleFunc("POST /feeds/scrape", s.scrapePageHandler)
+		r.HandleFunc("GET /feeds/opml", s.opmlExportHandler)
+		r.HandleFunc("POST /feeds/opml", s.opmlImportHandler)
 		r.HandleFunc("PUT /feeds/{id}", s.updateFeedHandler)
 		r.HandleFunc("POST /feeds/{id}/enable", s.enableFeedHandler)
 		r.HandleFunc("POST /feeds/{id}/disable", s.disableFeedHandler)
 		r.HandleFunc("POST /feeds/{id}/fetch", s.fetchFeedHandler)
+		r.HandleFunc("POST /feeds/{id}/retry", s.retryFeedHandler)
+		r.HandleFunc("GET /feeds/{id}/events", s.feedEventsHandler)
 		r.HandleFunc("DELETE /feeds/{id}", s.deleteFeedHandler)
 
 		// topic preferences management
 		r.HandleFunc("POST /topics", s.addTopicHandler)
 		r.HandleFunc("DELETE /topics/{topic}", s.deleteTopicHandler)
 
+		// mute rules
+		r.HandleFunc("GET /mute-rules", s.muteRulesHandler)
+		r.HandleFunc("POST /mute-rules", s.createMuteRuleHandler)
+		r.HandleFunc("DELETE /mute-rules/{id}", s.deleteMuteRuleHandler)
+		r.HandleFunc("GET /mute-rules/muted", s.mutedItemsHandler)
+
 		// preference summary management (JSON API)
 		r.HandleFunc("GET /preferences", s.getPreferencesHandler)
 		r.HandleFunc("PUT /preferences", s.updatePreferencesHandler)
@@ -360,4 +440,8 @@ func (s *Server) setupRoutes() {
 	// RSS routes
 	s.router.HandleFunc("GET /rss/{topic}", s.rssHandler)
 	s.router.HandleFunc("GET /rss", s.rssHandler)
+
+	// WebSub callbacks, called by hubs
+	s.router.HandleFunc("GET /websub/{id}", s.websubVerifyHandler)
+	s.router.HandleFunc("POST /websub/{id}", s.websubPushHandler)
 }
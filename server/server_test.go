@@ -164,6 +164,23 @@ func TestGeneratePageNumbers(t *testing.T) {
 	}
 }
 
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, humanBytes(tt.in))
+	}
+}
+
 func TestServer_GetPageSize(t *testing.T) {
 	cfg := &mocks.ConfigProviderMock{
 		GetFullConfigFunc: func() *config.Config {
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/umputun/newscope/pkg/domain"
+)
+
+// websubVerifyHandler answers intent verification requests sent by a hub to the feed's callback.
+// the challenge is echoed back only if the subscription was requested by us, otherwise 404 is returned.
+func (s *Server) websubVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	mode, topic, challenge := query.Get("hub.mode"), query.Get("hub.topic"), query.Get("hub.challenge")
+	if mode == "denied" {
+		log.Printf("[WARN] hub denied subscription to %s: %s", topic, query.Get("hub.reason"))
+	}
+
+	var lease time.Duration
+	if secs, err := strconv.Atoi(query.Get("hub.lease_seconds")); err == nil && secs > 0 {
+		lease = time.Duration(secs) * time.Second
+	}
+
+	if err := s.scheduler.VerifyWebSub(r.Context(), id, mode, topic, lease); err != nil {
+		log.Printf("[WARN] rejected websub verification for feed %d: %v", id, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(challenge)); err != nil {
+		log.Printf("[WARN] failed to write websub challenge: %v", err)
+	}
+}
+
+// websubPushHandler receives feed content pushed by a hub, its new items are stored in the background
+func (s *Server) websubPushHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "failed to read pushed content", err)
+		return
+	}
+
+	err = s.scheduler.HandleWebSubPush(r.Context(), id, body, r.Header.Get("X-Hub-Signature"))
+	if errors.Is(err, domain.ErrWebSubBusy) {
+		// hubs retry failed deliveries
+		s.respondWithError(w, http.StatusServiceUnavailable, "too many pushes, try later", err)
+		return
+	}
+	if err != nil {
+		s.respondWithError(w, http.StatusBadRequest, "failed to process pushed content", err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/umputun/newscope/pkg/domain"
+	"github.com/umputun/newscope/server/mocks"
+)
+
+func TestServer_WebSubVerifyHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+
+	t.Run("confirms requested subscription", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			VerifyWebSubFunc: func(ctx context.Context, feedID int64, mode, topic string, lease time.Duration) error {
+				return nil
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		req := httptest.NewRequest("GET", "/websub/7?hub.mode=subscribe&hub.topic=https%3A%2F%2Fexample.com%2Ffeed.xml"+
+			"&hub.challenge=abc123&hub.lease_seconds=86400", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "abc123", w.Body.String())
+		require.Len(t, scheduler.VerifyWebSubCalls(), 1)
+		call := scheduler.VerifyWebSubCalls()[0]
+		assert.Equal(t, int64(7), call.FeedID)
+		assert.Equal(t, "subscribe", call.Mode)
+		assert.Equal(t, "https://example.com/feed.xml", call.Topic)
+		assert.Equal(t, 24*time.Hour, call.Lease)
+	})
+
+	t.Run("rejects unknown subscription", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			VerifyWebSubFunc: func(ctx context.Context, feedID int64, mode, topic string, lease time.Duration) error {
+				return errors.New("no pending subscription")
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		req := httptest.NewRequest("GET", "/websub/7?hub.mode=subscribe&hub.topic=t&hub.challenge=abc123", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NotContains(t, w.Body.String(), "abc123")
+		assert.Zero(t, scheduler.VerifyWebSubCalls()[0].Lease)
+	})
+
+	t.Run("invalid feed id", func(t *testing.T) {
+		srv := New(cfg, &mocks.DatabaseMock{}, &mocks.SchedulerMock{}, "1.0.0", false)
+		req := httptest.NewRequest("GET", "/websub/abc?hub.mode=subscribe", http.NoBody)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServer_WebSubPushHandler(t *testing.T) {
+	cfg := &mocks.ConfigProviderMock{
+		GetServerConfigFunc: func() (string, time.Duration) { return ":8080", 30 * time.Second },
+	}
+	const content = `<feed xmlns="http://www.w3.org/2005/Atom"><entry><id>1</id></entry></feed>`
+
+	t.Run("accepts pushed content", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			HandleWebSubPushFunc: func(ctx context.Context, feedID int64, body []byte, signature string) error {
+				return nil
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		req := httptest.NewRequest("POST", "/websub/7", strings.NewReader(content))
+		req.Header.Set("Content-Type", "application/atom+xml")
+		req.Header.Set("X-Hub-Signature", "sha1=abcdef")
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		require.Len(t, scheduler.HandleWebSubPushCalls(), 1)
+		call := scheduler.HandleWebSubPushCalls()[0]
+		assert.Equal(t, int64(7), call.FeedID)
+		assert.Equal(t, content, string(call.Body))
+		assert.Equal(t, "sha1=abcdef", call.Signature)
+	})
+
+	t.Run("processing error", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			HandleWebSubPushFunc: func(ctx context.Context, feedID int64, body []byte, signature string) error {
+				return errors.New("parse pushed content: not a feed")
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		req := httptest.NewRequest("POST", "/websub/7", strings.NewReader("junk"))
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("too many waiting pushes", func(t *testing.T) {
+		scheduler := &mocks.SchedulerMock{
+			HandleWebSubPushFunc: func(ctx context.Context, feedID int64, body []byte, signature string) error {
+				return fmt.Errorf("push for feed 7: %w", domain.ErrWebSubBusy)
+			},
+		}
+		srv := New(cfg, &mocks.DatabaseMock{}, scheduler, "1.0.0", false)
+
+		req := httptest.NewRequest("POST", "/websub/7", strings.NewReader(content))
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, "hub retries later")
+	})
+}